@@ -36,11 +36,13 @@ const (
 )
 
 // CART ITEM
+// sku is the SKU of the catalog item, the chosen variant of a product
 type CartItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
 	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -96,6 +98,13 @@ func (x *CartItem) GetPrice() float64 {
 	return 0
 }
 
+func (x *CartItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
 // CART
 type Cart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -735,11 +744,12 @@ var File_proto_cart_cart_proto protoreflect.FileDescriptor
 
 const file_proto_cart_cart_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/cart/cart.proto\x12\x04cart\"U\n" +
+	"\x15proto/cart/cart.proto\x12\x04cart\"g\n" +
 	"\bCartItem\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\rR\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"H\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\"H\n" +
 	"\x04Cart\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12$\n" +
 	"\x05items\x18\x02 \x03(\v2\x0e.cart.CartItemR\x05items\"_\n" +
@@ -35,13 +35,471 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LISTING CATALOG
+// Order of the listed items, ties are broken by item ID
+type CatalogSort int32
+
+const (
+	CatalogSort_NAME       CatalogSort = 0
+	CatalogSort_PRICE_ASC  CatalogSort = 1
+	CatalogSort_PRICE_DESC CatalogSort = 2
+	CatalogSort_NEWEST     CatalogSort = 3
+	CatalogSort_RATING     CatalogSort = 4
+)
+
+// Enum value maps for CatalogSort.
+var (
+	CatalogSort_name = map[int32]string{
+		0: "NAME",
+		1: "PRICE_ASC",
+		2: "PRICE_DESC",
+		3: "NEWEST",
+		4: "RATING",
+	}
+	CatalogSort_value = map[string]int32{
+		"NAME":       0,
+		"PRICE_ASC":  1,
+		"PRICE_DESC": 2,
+		"NEWEST":     3,
+		"RATING":     4,
+	}
+)
+
+func (x CatalogSort) Enum() *CatalogSort {
+	p := new(CatalogSort)
+	*p = x
+	return p
+}
+
+func (x CatalogSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CatalogSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[0].Descriptor()
+}
+
+func (CatalogSort) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[0]
+}
+
+func (x CatalogSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CatalogSort.Descriptor instead.
+func (CatalogSort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+// How the quantity reserved is taken from the warehouses
+type AllocationStrategy int32
+
+const (
+	AllocationStrategy_ALLOCATE_FEWEST_SPLITS AllocationStrategy = 0
+	AllocationStrategy_ALLOCATE_NEAREST       AllocationStrategy = 1
+)
+
+// Enum value maps for AllocationStrategy.
+var (
+	AllocationStrategy_name = map[int32]string{
+		0: "ALLOCATE_FEWEST_SPLITS",
+		1: "ALLOCATE_NEAREST",
+	}
+	AllocationStrategy_value = map[string]int32{
+		"ALLOCATE_FEWEST_SPLITS": 0,
+		"ALLOCATE_NEAREST":       1,
+	}
+)
+
+func (x AllocationStrategy) Enum() *AllocationStrategy {
+	p := new(AllocationStrategy)
+	*p = x
+	return p
+}
+
+func (x AllocationStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllocationStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[1].Descriptor()
+}
+
+func (AllocationStrategy) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[1]
+}
+
+func (x AllocationStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AllocationStrategy.Descriptor instead.
+func (AllocationStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+// BULK IMPORT AND EXPORT OF THE CATALOG
+type CatalogFileFormat int32
+
+const (
+	CatalogFileFormat_CSV  CatalogFileFormat = 0
+	CatalogFileFormat_JSON CatalogFileFormat = 1
+)
+
+// Enum value maps for CatalogFileFormat.
+var (
+	CatalogFileFormat_name = map[int32]string{
+		0: "CSV",
+		1: "JSON",
+	}
+	CatalogFileFormat_value = map[string]int32{
+		"CSV":  0,
+		"JSON": 1,
+	}
+)
+
+func (x CatalogFileFormat) Enum() *CatalogFileFormat {
+	p := new(CatalogFileFormat)
+	*p = x
+	return p
+}
+
+func (x CatalogFileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CatalogFileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[2].Descriptor()
+}
+
+func (CatalogFileFormat) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[2]
+}
+
+func (x CatalogFileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CatalogFileFormat.Descriptor instead.
+func (CatalogFileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+// WATCH THE CHANGES OF THE CATALOG
+type CatalogEventType int32
+
+const (
+	CatalogEventType_ITEM_ADDED         CatalogEventType = 0
+	CatalogEventType_ITEM_REMOVED       CatalogEventType = 1
+	CatalogEventType_ITEM_UPDATED       CatalogEventType = 2
+	CatalogEventType_PRICE_CHANGED      CatalogEventType = 3
+	CatalogEventType_STOCK_CHANGED      CatalogEventType = 4
+	CatalogEventType_CATEGORIES_CHANGED CatalogEventType = 5
+	CatalogEventType_LOW_STOCK          CatalogEventType = 6
+)
+
+// Enum value maps for CatalogEventType.
+var (
+	CatalogEventType_name = map[int32]string{
+		0: "ITEM_ADDED",
+		1: "ITEM_REMOVED",
+		2: "ITEM_UPDATED",
+		3: "PRICE_CHANGED",
+		4: "STOCK_CHANGED",
+		5: "CATEGORIES_CHANGED",
+		6: "LOW_STOCK",
+	}
+	CatalogEventType_value = map[string]int32{
+		"ITEM_ADDED":         0,
+		"ITEM_REMOVED":       1,
+		"ITEM_UPDATED":       2,
+		"PRICE_CHANGED":      3,
+		"STOCK_CHANGED":      4,
+		"CATEGORIES_CHANGED": 5,
+		"LOW_STOCK":          6,
+	}
+)
+
+func (x CatalogEventType) Enum() *CatalogEventType {
+	p := new(CatalogEventType)
+	*p = x
+	return p
+}
+
+func (x CatalogEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CatalogEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[3].Descriptor()
+}
+
+func (CatalogEventType) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[3]
+}
+
+func (x CatalogEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CatalogEventType.Descriptor instead.
+func (CatalogEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+// PRICE HISTORY OF AN ITEM, THE MOST RECENT CHANGE FIRST
+type PriceChangeReason int32
+
+const (
+	PriceChangeReason_PRICE_UPDATED   PriceChangeReason = 0
+	PriceChangeReason_PRICE_INITIAL   PriceChangeReason = 1
+	PriceChangeReason_PRICE_SCHEDULED PriceChangeReason = 2
+	PriceChangeReason_PRICE_RESTORED  PriceChangeReason = 3
+)
+
+// Enum value maps for PriceChangeReason.
+var (
+	PriceChangeReason_name = map[int32]string{
+		0: "PRICE_UPDATED",
+		1: "PRICE_INITIAL",
+		2: "PRICE_SCHEDULED",
+		3: "PRICE_RESTORED",
+	}
+	PriceChangeReason_value = map[string]int32{
+		"PRICE_UPDATED":   0,
+		"PRICE_INITIAL":   1,
+		"PRICE_SCHEDULED": 2,
+		"PRICE_RESTORED":  3,
+	}
+)
+
+func (x PriceChangeReason) Enum() *PriceChangeReason {
+	p := new(PriceChangeReason)
+	*p = x
+	return p
+}
+
+func (x PriceChangeReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceChangeReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[4].Descriptor()
+}
+
+func (PriceChangeReason) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[4]
+}
+
+func (x PriceChangeReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceChangeReason.Descriptor instead.
+func (PriceChangeReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+// SCHEDULED PRICES
+// A scheduled price is applied at starts_at, a sale has an ends_at when the previous price comes back.
+// ends_at is 0 for a permanent change.
+type ScheduledPriceStatus int32
+
+const (
+	ScheduledPriceStatus_SCHEDULE_PENDING  ScheduledPriceStatus = 0
+	ScheduledPriceStatus_SCHEDULE_ACTIVE   ScheduledPriceStatus = 1
+	ScheduledPriceStatus_SCHEDULE_DONE     ScheduledPriceStatus = 2
+	ScheduledPriceStatus_SCHEDULE_CANCELED ScheduledPriceStatus = 3
+)
+
+// Enum value maps for ScheduledPriceStatus.
+var (
+	ScheduledPriceStatus_name = map[int32]string{
+		0: "SCHEDULE_PENDING",
+		1: "SCHEDULE_ACTIVE",
+		2: "SCHEDULE_DONE",
+		3: "SCHEDULE_CANCELED",
+	}
+	ScheduledPriceStatus_value = map[string]int32{
+		"SCHEDULE_PENDING":  0,
+		"SCHEDULE_ACTIVE":   1,
+		"SCHEDULE_DONE":     2,
+		"SCHEDULE_CANCELED": 3,
+	}
+)
+
+func (x ScheduledPriceStatus) Enum() *ScheduledPriceStatus {
+	p := new(ScheduledPriceStatus)
+	*p = x
+	return p
+}
+
+func (x ScheduledPriceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduledPriceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[5].Descriptor()
+}
+
+func (ScheduledPriceStatus) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[5]
+}
+
+func (x ScheduledPriceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledPriceStatus.Descriptor instead.
+func (ScheduledPriceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+// INVENTORY LEDGER, EVERY CHANGE OF THE STOCK OF AN ITEM IS A MOVEMENT
+// Products with variants have no movements, their stock is the one of the variants.
+type StockMovementReason int32
+
+const (
+	StockMovementReason_MOVEMENT_ADJUSTMENT   StockMovementReason = 0
+	StockMovementReason_MOVEMENT_INITIAL      StockMovementReason = 1
+	StockMovementReason_MOVEMENT_RESTOCK      StockMovementReason = 2
+	StockMovementReason_MOVEMENT_SALE         StockMovementReason = 3
+	StockMovementReason_MOVEMENT_RELEASE      StockMovementReason = 4
+	StockMovementReason_MOVEMENT_EXPIRATION   StockMovementReason = 5
+	StockMovementReason_MOVEMENT_CANCELLATION StockMovementReason = 6
+	StockMovementReason_MOVEMENT_TRANSFER     StockMovementReason = 7
+)
+
+// Enum value maps for StockMovementReason.
+var (
+	StockMovementReason_name = map[int32]string{
+		0: "MOVEMENT_ADJUSTMENT",
+		1: "MOVEMENT_INITIAL",
+		2: "MOVEMENT_RESTOCK",
+		3: "MOVEMENT_SALE",
+		4: "MOVEMENT_RELEASE",
+		5: "MOVEMENT_EXPIRATION",
+		6: "MOVEMENT_CANCELLATION",
+		7: "MOVEMENT_TRANSFER",
+	}
+	StockMovementReason_value = map[string]int32{
+		"MOVEMENT_ADJUSTMENT":   0,
+		"MOVEMENT_INITIAL":      1,
+		"MOVEMENT_RESTOCK":      2,
+		"MOVEMENT_SALE":         3,
+		"MOVEMENT_RELEASE":      4,
+		"MOVEMENT_EXPIRATION":   5,
+		"MOVEMENT_CANCELLATION": 6,
+		"MOVEMENT_TRANSFER":     7,
+	}
+)
+
+func (x StockMovementReason) Enum() *StockMovementReason {
+	p := new(StockMovementReason)
+	*p = x
+	return p
+}
+
+func (x StockMovementReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockMovementReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[6].Descriptor()
+}
+
+func (StockMovementReason) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[6]
+}
+
+func (x StockMovementReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockMovementReason.Descriptor instead.
+func (StockMovementReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+// REVIEWS OF THE ITEMS
+// A review is written by a customer who bought the item, or one of its variants for a product.
+// It is shown and counted in the rating of the item once approved by an admin.
+type ReviewStatus int32
+
+const (
+	ReviewStatus_REVIEW_PENDING  ReviewStatus = 0
+	ReviewStatus_REVIEW_APPROVED ReviewStatus = 1
+	ReviewStatus_REVIEW_REJECTED ReviewStatus = 2
+)
+
+// Enum value maps for ReviewStatus.
+var (
+	ReviewStatus_name = map[int32]string{
+		0: "REVIEW_PENDING",
+		1: "REVIEW_APPROVED",
+		2: "REVIEW_REJECTED",
+	}
+	ReviewStatus_value = map[string]int32{
+		"REVIEW_PENDING":  0,
+		"REVIEW_APPROVED": 1,
+		"REVIEW_REJECTED": 2,
+	}
+)
+
+func (x ReviewStatus) Enum() *ReviewStatus {
+	p := new(ReviewStatus)
+	*p = x
+	return p
+}
+
+func (x ReviewStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_catalog_catalog_proto_enumTypes[7].Descriptor()
+}
+
+func (ReviewStatus) Type() protoreflect.EnumType {
+	return &file_proto_catalog_catalog_proto_enumTypes[7]
+}
+
+func (x ReviewStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewStatus.Descriptor instead.
+func (ReviewStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{7}
+}
+
 // CATALOG ITEM
+// item_id is generated by the catalog and never changes, name, sku and slug can be edited
+// category_id is empty for an item not categorized.
+// A variant (e.g. an edition or a format) is an item with the product_id of its parent and its own attributes,
+// the variants of a product are listed in variants, the product has the lowest price and the total quantity of them.
+// version is incremented by every change of the item, updates given an expected_version are rejected if it changed.
 type CatalogItem struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	ItemId            string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
 	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	QuantityAvailable uint32                 `protobuf:"varint,3,opt,name=quantity_available,json=quantityAvailable,proto3" json:"quantity_available,omitempty"`
 	Price             float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Name              string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Sku               string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
+	Slug              string                 `protobuf:"bytes,7,opt,name=slug,proto3" json:"slug,omitempty"`
+	CategoryId        string                 `protobuf:"bytes,8,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tags              []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	ProductId         string                 `protobuf:"bytes,10,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Attributes        map[string]string      `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variants          []*CatalogItem         `protobuf:"bytes,12,rep,name=variants,proto3" json:"variants,omitempty"`
+	Version           uint64                 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	LocationsInStock  uint32                 `protobuf:"varint,14,opt,name=locations_in_stock,json=locationsInStock,proto3" json:"locations_in_stock,omitempty"`
+	ReorderThreshold  uint32                 `protobuf:"varint,15,opt,name=reorder_threshold,json=reorderThreshold,proto3" json:"reorder_threshold,omitempty"`
+	Images            []*ItemImage           `protobuf:"bytes,16,rep,name=images,proto3" json:"images,omitempty"`
+	RatingAverage     float64                `protobuf:"fixed64,17,opt,name=rating_average,json=ratingAverage,proto3" json:"rating_average,omitempty"`
+	RatingCount       uint32                 `protobuf:"varint,18,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -104,6 +562,104 @@ func (x *CatalogItem) GetPrice() float64 {
 	return 0
 }
 
+func (x *CatalogItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CatalogItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CatalogItem) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CatalogItem) GetVariants() []*CatalogItem {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CatalogItem) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetLocationsInStock() uint32 {
+	if x != nil {
+		return x.LocationsInStock
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetReorderThreshold() uint32 {
+	if x != nil {
+		return x.ReorderThreshold
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetImages() []*ItemImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *CatalogItem) GetRatingAverage() float64 {
+	if x != nil {
+		return x.RatingAverage
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetRatingCount() uint32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
 // ADD ITEM TO CATALOG
 type AddCatalogItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -152,6 +708,7 @@ func (x *AddCatalogItemRequest) GetItem() *CatalogItem {
 type AddCatalogItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,36 +743,5606 @@ func (*AddCatalogItemResponse) Descriptor() ([]byte, []int) {
 	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *AddCatalogItemResponse) GetErrorMessage() string {
+func (x *AddCatalogItemResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *AddCatalogItemResponse) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+// REMOVE ITEM FROM CATALOG
+type RemoveCatalogItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCatalogItemRequest) Reset() {
+	*x = RemoveCatalogItemRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCatalogItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCatalogItemRequest) ProtoMessage() {}
+
+func (x *RemoveCatalogItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCatalogItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCatalogItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveCatalogItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type RemoveCatalogItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCatalogItemResponse) Reset() {
+	*x = RemoveCatalogItemResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCatalogItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCatalogItemResponse) ProtoMessage() {}
+
+func (x *RemoveCatalogItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCatalogItemResponse.ProtoReflect.Descriptor instead.
+func (*RemoveCatalogItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoveCatalogItemResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// GET ITEM FROM CATALOG, BY ONE OF ITS ID, SLUG OR SKU
+type GetCatalogItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogItemRequest) Reset() {
+	*x = GetCatalogItemRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogItemRequest) ProtoMessage() {}
+
+func (x *GetCatalogItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogItemRequest.ProtoReflect.Descriptor instead.
+func (*GetCatalogItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCatalogItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *GetCatalogItemRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetCatalogItemRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type GetCatalogItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *CatalogItem           `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogItemResponse) Reset() {
+	*x = GetCatalogItemResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogItemResponse) ProtoMessage() {}
+
+func (x *GetCatalogItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogItemResponse.ProtoReflect.Descriptor instead.
+func (*GetCatalogItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCatalogItemResponse) GetItem() *CatalogItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *GetCatalogItemResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// GET SEVERAL ITEMS FROM CATALOG, THE ITEMS NOT FOUND ARE LEFT OUT
+type GetCatalogItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemIds       []string               `protobuf:"bytes,1,rep,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogItemsRequest) Reset() {
+	*x = GetCatalogItemsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogItemsRequest) ProtoMessage() {}
+
+func (x *GetCatalogItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetCatalogItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCatalogItemsRequest) GetItemIds() []string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+type GetCatalogItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogItemsResponse) Reset() {
+	*x = GetCatalogItemsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogItemsResponse) ProtoMessage() {}
+
+func (x *GetCatalogItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetCatalogItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCatalogItemsResponse) GetItems() []*CatalogItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetCatalogItemsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// UPDATE ITEM DETAILS, EMPTY FIELDS ARE LEFT UNCHANGED
+// attributes replace the ones of a variant
+// expected_version, when not zero, must be the current version of the item or the update is aborted
+type UpdateCatalogItemRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Sku             string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	Slug            string                 `protobuf:"bytes,5,opt,name=slug,proto3" json:"slug,omitempty"`
+	Attributes      map[string]string      `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpectedVersion uint64                 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateCatalogItemRequest) Reset() {
+	*x = UpdateCatalogItemRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatalogItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatalogItemRequest) ProtoMessage() {}
+
+func (x *UpdateCatalogItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatalogItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCatalogItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCatalogItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *UpdateCatalogItemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCatalogItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCatalogItemRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateCatalogItemRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateCatalogItemRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *UpdateCatalogItemRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateCatalogItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatalogItemResponse) Reset() {
+	*x = UpdateCatalogItemResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatalogItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatalogItemResponse) ProtoMessage() {}
+
+func (x *UpdateCatalogItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatalogItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCatalogItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateCatalogItemResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// UPDATE ITEM QUANTITY
+// expected_version as in UpdateCatalogItemRequest
+// reason of the change recorded in the ledger, MOVEMENT_ADJUSTMENT or MOVEMENT_RESTOCK
+// quantity is the one at warehouse_id, the default warehouse if empty
+type UpdateQuantityAvailableRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity        uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExpectedVersion uint64                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Reason          StockMovementReason    `protobuf:"varint,4,opt,name=reason,proto3,enum=catalog.StockMovementReason" json:"reason,omitempty"`
+	WarehouseId     string                 `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateQuantityAvailableRequest) Reset() {
+	*x = UpdateQuantityAvailableRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuantityAvailableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuantityAvailableRequest) ProtoMessage() {}
+
+func (x *UpdateQuantityAvailableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuantityAvailableRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuantityAvailableRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateQuantityAvailableRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *UpdateQuantityAvailableRequest) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *UpdateQuantityAvailableRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateQuantityAvailableRequest) GetReason() StockMovementReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StockMovementReason_MOVEMENT_ADJUSTMENT
+}
+
+func (x *UpdateQuantityAvailableRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+type UpdateQuantityAvailableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateQuantityAvailableResponse) Reset() {
+	*x = UpdateQuantityAvailableResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuantityAvailableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuantityAvailableResponse) ProtoMessage() {}
+
+func (x *UpdateQuantityAvailableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuantityAvailableResponse.ProtoReflect.Descriptor instead.
+func (*UpdateQuantityAvailableResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateQuantityAvailableResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// UPDATE ITEM PRICE
+// expected_version as in UpdateCatalogItemRequest
+type UpdatePriceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Price           float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	ExpectedVersion uint64                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdatePriceRequest) Reset() {
+	*x = UpdatePriceRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceRequest) ProtoMessage() {}
+
+func (x *UpdatePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePriceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdatePriceRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *UpdatePriceRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdatePriceRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdatePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePriceResponse) Reset() {
+	*x = UpdatePriceResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceResponse) ProtoMessage() {}
+
+func (x *UpdatePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePriceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdatePriceResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// page_size 0 uses the default size, max_price 0 means no upper bound.
+// category_id includes the items of its subcategories, tag keeps the items having it.
+// The page_token of a response is valid only with the same filters and sort.
+type ListCatalogItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	MinPrice      float64                `protobuf:"fixed64,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      float64                `protobuf:"fixed64,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	InStockOnly   bool                   `protobuf:"varint,5,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"`
+	Sort          CatalogSort            `protobuf:"varint,6,opt,name=sort,proto3,enum=catalog.CatalogSort" json:"sort,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatalogItemsRequest) Reset() {
+	*x = ListCatalogItemsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatalogItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatalogItemsRequest) ProtoMessage() {}
+
+func (x *ListCatalogItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatalogItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListCatalogItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListCatalogItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCatalogItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCatalogItemsRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListCatalogItemsRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListCatalogItemsRequest) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
+func (x *ListCatalogItemsRequest) GetSort() CatalogSort {
+	if x != nil {
+		return x.Sort
+	}
+	return CatalogSort_NAME
+}
+
+func (x *ListCatalogItemsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListCatalogItemsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type ListCatalogItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatalogItemsResponse) Reset() {
+	*x = ListCatalogItemsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatalogItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatalogItemsResponse) ProtoMessage() {}
+
+func (x *ListCatalogItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatalogItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListCatalogItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListCatalogItemsResponse) GetItems() []*CatalogItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListCatalogItemsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ListCatalogItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ITEM QUANTITY OF A RESERVATION
+type StockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StockItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockItem) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Quantity of an item taken from a warehouse
+type StockAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAllocation) Reset() {
+	*x = StockAllocation{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAllocation) ProtoMessage() {}
+
+func (x *StockAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAllocation.ProtoReflect.Descriptor instead.
+func (*StockAllocation) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StockAllocation) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockAllocation) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *StockAllocation) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// RESERVE STOCK OF SEVERAL ITEMS
+// ALLOCATE_NEAREST needs the destination, without it the fewest splits are used
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	Strategy      AllocationStrategy     `protobuf:"varint,3,opt,name=strategy,proto3,enum=catalog.AllocationStrategy" json:"strategy,omitempty"`
+	Destination   *Location              `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReserveStockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetStrategy() AllocationStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return AllocationStrategy_ALLOCATE_FEWEST_SPLITS
+}
+
+func (x *ReserveStockRequest) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Allocations   []*StockAllocation     `protobuf:"bytes,4,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReserveStockResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ReserveStockResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetAllocations() []*StockAllocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+// COMMIT A RESERVATION
+// order_id is recorded in the movements of the stock reserved
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CommitReservationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// RELEASE A RESERVATION
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReleaseReservationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// GIVE BACK STOCK OF SEVERAL ITEMS (E.G. CANCELED ORDER)
+type RestockItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestockId     string                 `protobuf:"bytes,1,opt,name=restock_id,json=restockId,proto3" json:"restock_id,omitempty"`
+	Items         []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItemsRequest) Reset() {
+	*x = RestockItemsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItemsRequest) ProtoMessage() {}
+
+func (x *RestockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItemsRequest.ProtoReflect.Descriptor instead.
+func (*RestockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestockItemsRequest) GetRestockId() string {
+	if x != nil {
+		return x.RestockId
+	}
+	return ""
+}
+
+func (x *RestockItemsRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RestockItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RestockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItemsResponse) Reset() {
+	*x = RestockItemsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItemsResponse) ProtoMessage() {}
+
+func (x *RestockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItemsResponse.ProtoReflect.Descriptor instead.
+func (*RestockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RestockItemsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// FULL-TEXT SEARCH OF THE CATALOG
+type SearchCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCatalogRequest) Reset() {
+	*x = SearchCatalogRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCatalogRequest) ProtoMessage() {}
+
+func (x *SearchCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCatalogRequest.ProtoReflect.Descriptor instead.
+func (*SearchCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchCatalogRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCatalogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Byte range [start, end) of a matched word in the snippet
+type Highlight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Highlight) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Highlight) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *CatalogItem           `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Highlights    []*Highlight           `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SearchHit) GetItem() *CatalogItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchHit) GetHighlights() []*Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type SearchCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCatalogResponse) Reset() {
+	*x = SearchCatalogResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCatalogResponse) ProtoMessage() {}
+
+func (x *SearchCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCatalogResponse.ProtoReflect.Descriptor instead.
+func (*SearchCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SearchCatalogResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchCatalogResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// NEW IDS OF THE ITEMS IDENTIFIED BY THEIR TITLE BEFORE THE GENERATED IDS
+type ResolveLegacyItemIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LegacyIds     []string               `protobuf:"bytes,1,rep,name=legacy_ids,json=legacyIds,proto3" json:"legacy_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveLegacyItemIDsRequest) Reset() {
+	*x = ResolveLegacyItemIDsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveLegacyItemIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveLegacyItemIDsRequest) ProtoMessage() {}
+
+func (x *ResolveLegacyItemIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveLegacyItemIDsRequest.ProtoReflect.Descriptor instead.
+func (*ResolveLegacyItemIDsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResolveLegacyItemIDsRequest) GetLegacyIds() []string {
+	if x != nil {
+		return x.LegacyIds
+	}
+	return nil
+}
+
+type ResolveLegacyItemIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemIds       map[string]string      `protobuf:"bytes,1,rep,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveLegacyItemIDsResponse) Reset() {
+	*x = ResolveLegacyItemIDsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveLegacyItemIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveLegacyItemIDsResponse) ProtoMessage() {}
+
+func (x *ResolveLegacyItemIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveLegacyItemIDsResponse.ProtoReflect.Descriptor instead.
+func (*ResolveLegacyItemIDsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResolveLegacyItemIDsResponse) GetItemIds() map[string]string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+func (x *ResolveLegacyItemIDsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// CATEGORY OF THE CATALOG, A ROOT CATEGORY HAS AN EMPTY parent_id
+// item_count includes the items of the subcategories
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	ParentId      string                 `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ItemCount     uint32                 `protobuf:"varint,5,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Category) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Category) GetItemCount() uint32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+// CREATE A CATEGORY, THE SLUG IS DERIVED FROM THE NAME UNLESS GIVEN
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateCategoryResponse) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CreateCategoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// RENAME A CATEGORY, EMPTY FIELDS ARE LEFT UNCHANGED
+type UpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateCategoryRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type UpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryResponse) Reset() {
+	*x = UpdateCategoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryResponse) ProtoMessage() {}
+
+func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateCategoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// MOVE A CATEGORY UNDER ANOTHER ONE, OR TO THE ROOT WITH AN EMPTY parent_id
+type MoveCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryRequest) Reset() {
+	*x = MoveCategoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryRequest) ProtoMessage() {}
+
+func (x *MoveCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MoveCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MoveCategoryRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *MoveCategoryRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type MoveCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryResponse) Reset() {
+	*x = MoveCategoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryResponse) ProtoMessage() {}
+
+func (x *MoveCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryResponse.ProtoReflect.Descriptor instead.
+func (*MoveCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *MoveCategoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// DELETE A CATEGORY WITHOUT SUBCATEGORIES NOR ITEMS
+type DeleteCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteCategoryRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteCategoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// LIST ALL THE CATEGORIES, THE TREE IS GIVEN BY THEIR parent_id
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{42}
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListCategoriesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// SET THE CATEGORY OF AN ITEM, AN EMPTY category_id REMOVES IT
+type SetItemCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemCategoryRequest) Reset() {
+	*x = SetItemCategoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemCategoryRequest) ProtoMessage() {}
+
+func (x *SetItemCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemCategoryRequest.ProtoReflect.Descriptor instead.
+func (*SetItemCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetItemCategoryRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetItemCategoryRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type SetItemCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemCategoryResponse) Reset() {
+	*x = SetItemCategoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemCategoryResponse) ProtoMessage() {}
+
+func (x *SetItemCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemCategoryResponse.ProtoReflect.Descriptor instead.
+func (*SetItemCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SetItemCategoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// REPLACE THE TAGS OF AN ITEM
+type SetItemTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemTagsRequest) Reset() {
+	*x = SetItemTagsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemTagsRequest) ProtoMessage() {}
+
+func (x *SetItemTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemTagsRequest.ProtoReflect.Descriptor instead.
+func (*SetItemTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SetItemTagsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetItemTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SetItemTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemTagsResponse) Reset() {
+	*x = SetItemTagsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemTagsResponse) ProtoMessage() {}
+
+func (x *SetItemTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemTagsResponse.ProtoReflect.Descriptor instead.
+func (*SetItemTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetItemTagsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// LIST THE TAGS IN USE, WITH THE NUMBER OF ITEMS HAVING THEM
+type TagCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	ItemCount     uint32                 `protobuf:"varint,2,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TagCount) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagCount) GetItemCount() uint32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{49}
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagCount            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTagsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// The file is streamed in chunks, format and dry_run are read from the first message.
+// Rows are added, or update the item with the same item_id or sku.
+type ImportCatalogItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        CatalogFileFormat      `protobuf:"varint,1,opt,name=format,proto3,enum=catalog.CatalogFileFormat" json:"format,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogItemsRequest) Reset() {
+	*x = ImportCatalogItemsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogItemsRequest) ProtoMessage() {}
+
+func (x *ImportCatalogItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogItemsRequest.ProtoReflect.Descriptor instead.
+func (*ImportCatalogItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ImportCatalogItemsRequest) GetFormat() CatalogFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return CatalogFileFormat_CSV
+}
+
+func (x *ImportCatalogItemsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportCatalogItemsRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// A row of the file which was not imported, rows are numbered from 1 without the CSV header
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           uint32                 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportRowError) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportCatalogItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       uint32                 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       uint32                 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        uint32                 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogItemsResponse) Reset() {
+	*x = ImportCatalogItemsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogItemsResponse) ProtoMessage() {}
+
+func (x *ImportCatalogItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogItemsResponse.ProtoReflect.Descriptor instead.
+func (*ImportCatalogItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportCatalogItemsResponse) GetCreated() uint32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportCatalogItemsResponse) GetUpdated() uint32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportCatalogItemsResponse) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportCatalogItemsResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportCatalogItemsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportCatalogItemsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ExportCatalogItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        CatalogFileFormat      `protobuf:"varint,1,opt,name=format,proto3,enum=catalog.CatalogFileFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogItemsRequest) Reset() {
+	*x = ExportCatalogItemsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogItemsRequest) ProtoMessage() {}
+
+func (x *ExportCatalogItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogItemsRequest.ProtoReflect.Descriptor instead.
+func (*ExportCatalogItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExportCatalogItemsRequest) GetFormat() CatalogFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return CatalogFileFormat_CSV
+}
+
+// The file is streamed in chunks, products come before their variants
+type ExportCatalogItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogItemsResponse) Reset() {
+	*x = ExportCatalogItemsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogItemsResponse) ProtoMessage() {}
+
+func (x *ExportCatalogItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogItemsResponse.ProtoReflect.Descriptor instead.
+func (*ExportCatalogItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExportCatalogItemsResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// A change of an item, item is its state after the change or before its removal.
+// Events are numbered in the order they happened, since the catalog service started.
+// A LOW_STOCK event carries the alert raised for the item.
+type CatalogEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          CatalogEventType       `protobuf:"varint,1,opt,name=type,proto3,enum=catalog.CatalogEventType" json:"type,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Item          *CatalogItem           `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Alert         *StockAlert            `protobuf:"bytes,6,opt,name=alert,proto3" json:"alert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogEvent) Reset() {
+	*x = CatalogEvent{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogEvent) ProtoMessage() {}
+
+func (x *CatalogEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogEvent.ProtoReflect.Descriptor instead.
+func (*CatalogEvent) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CatalogEvent) GetType() CatalogEventType {
+	if x != nil {
+		return x.Type
+	}
+	return CatalogEventType_ITEM_ADDED
+}
+
+func (x *CatalogEvent) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CatalogEvent) GetItem() *CatalogItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *CatalogEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CatalogEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *CatalogEvent) GetAlert() *StockAlert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+type WatchCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCatalogRequest) Reset() {
+	*x = WatchCatalogRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCatalogRequest) ProtoMessage() {}
+
+func (x *WatchCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCatalogRequest.ProtoReflect.Descriptor instead.
+func (*WatchCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{57}
+}
+
+// schedule_id is the scheduled price started or ended by the change, if any
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	ChangedAt     int64                  `protobuf:"varint,2,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Reason        PriceChangeReason      `protobuf:"varint,3,opt,name=reason,proto3,enum=catalog.PriceChangeReason" json:"reason,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,4,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PriceChange) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceChange) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *PriceChange) GetReason() PriceChangeReason {
+	if x != nil {
+		return x.Reason
+	}
+	return PriceChangeReason_PRICE_UPDATED
+}
+
+func (x *PriceChange) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type GetPriceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetPriceHistoryRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PriceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetPriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ScheduledPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,4,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,5,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Status        ScheduledPriceStatus   `protobuf:"varint,6,opt,name=status,proto3,enum=catalog.ScheduledPriceStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledPrice) Reset() {
+	*x = ScheduledPrice{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPrice) ProtoMessage() {}
+
+func (x *ScheduledPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPrice.ProtoReflect.Descriptor instead.
+func (*ScheduledPrice) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ScheduledPrice) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ScheduledPrice) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ScheduledPrice) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *ScheduledPrice) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *ScheduledPrice) GetStatus() ScheduledPriceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScheduledPriceStatus_SCHEDULE_PENDING
+}
+
+type SchedulePriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,4,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeRequest) Reset() {
+	*x = SchedulePriceChangeRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeRequest) ProtoMessage() {}
+
+func (x *SchedulePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SchedulePriceChangeRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SchedulePriceChangeRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeRequest) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeRequest) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+type SchedulePriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeResponse) Reset() {
+	*x = SchedulePriceChangeResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeResponse) ProtoMessage() {}
+
+func (x *SchedulePriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SchedulePriceChangeResponse) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *SchedulePriceChangeResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// The scheduled prices not applied yet and the sales in progress of an item
+type ListScheduledPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledPricesRequest) Reset() {
+	*x = ListScheduledPricesRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledPricesRequest) ProtoMessage() {}
+
+func (x *ListScheduledPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledPricesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledPricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListScheduledPricesRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type ListScheduledPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*ScheduledPrice      `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledPricesResponse) Reset() {
+	*x = ListScheduledPricesResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledPricesResponse) ProtoMessage() {}
+
+func (x *ListScheduledPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledPricesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledPricesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListScheduledPricesResponse) GetSchedules() []*ScheduledPrice {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+func (x *ListScheduledPricesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// A sale in progress is ended, its previous price comes back
+type CancelScheduledPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledPriceRequest) Reset() {
+	*x = CancelScheduledPriceRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledPriceRequest) ProtoMessage() {}
+
+func (x *CancelScheduledPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledPriceRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPriceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CancelScheduledPriceRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type CancelScheduledPriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledPriceResponse) Reset() {
+	*x = CancelScheduledPriceResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledPriceResponse) ProtoMessage() {}
+
+func (x *CancelScheduledPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledPriceResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledPriceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CancelScheduledPriceResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// delta is the quantity added or taken at warehouse_id,
+// quantity_after the quantity available of the item in all the warehouses after the movement
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovementId    uint64                 `protobuf:"varint,1,opt,name=movement_id,json=movementId,proto3" json:"movement_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Delta         int64                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	QuantityAfter uint32                 `protobuf:"varint,4,opt,name=quantity_after,json=quantityAfter,proto3" json:"quantity_after,omitempty"`
+	Reason        StockMovementReason    `protobuf:"varint,5,opt,name=reason,proto3,enum=catalog.StockMovementReason" json:"reason,omitempty"`
+	ReservationId string                 `protobuf:"bytes,6,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,7,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,10,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *StockMovement) GetMovementId() uint64 {
+	if x != nil {
+		return x.MovementId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetQuantityAfter() uint32 {
+	if x != nil {
+		return x.QuantityAfter
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() StockMovementReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StockMovementReason_MOVEMENT_ADJUSTMENT
+}
+
+func (x *StockMovement) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StockMovement) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+// The movements of an item or of an order, the most recent first
+type ListStockMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListStockMovementsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStockMovementsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListStockMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListStockMovementsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListStockMovementsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// An item whose quantity available is not the sum of its movements,
+// or whose stock at warehouse_id is not the sum of its movements there
+type StockDiscrepancy struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ItemId            string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	QuantityAvailable uint32                 `protobuf:"varint,3,opt,name=quantity_available,json=quantityAvailable,proto3" json:"quantity_available,omitempty"`
+	LedgerQuantity    int64                  `protobuf:"varint,4,opt,name=ledger_quantity,json=ledgerQuantity,proto3" json:"ledger_quantity,omitempty"`
+	WarehouseId       string                 `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StockDiscrepancy) Reset() {
+	*x = StockDiscrepancy{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDiscrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDiscrepancy) ProtoMessage() {}
+
+func (x *StockDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDiscrepancy.ProtoReflect.Descriptor instead.
+func (*StockDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *StockDiscrepancy) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockDiscrepancy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StockDiscrepancy) GetQuantityAvailable() uint32 {
+	if x != nil {
+		return x.QuantityAvailable
+	}
+	return 0
+}
+
+func (x *StockDiscrepancy) GetLedgerQuantity() int64 {
+	if x != nil {
+		return x.LedgerQuantity
+	}
+	return 0
+}
+
+func (x *StockDiscrepancy) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+// RECONCILE THE STOCK WITH THE LEDGER
+// With apply the stock of the discrepancies is set to the one of the ledger.
+type ReconcileStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Apply         bool                   `protobuf:"varint,1,opt,name=apply,proto3" json:"apply,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileStockRequest) Reset() {
+	*x = ReconcileStockRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStockRequest) ProtoMessage() {}
+
+func (x *ReconcileStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStockRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReconcileStockRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+type ReconcileStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Discrepancies []*StockDiscrepancy    `protobuf:"bytes,1,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	ItemsChecked  uint32                 `protobuf:"varint,2,opt,name=items_checked,json=itemsChecked,proto3" json:"items_checked,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileStockResponse) Reset() {
+	*x = ReconcileStockResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStockResponse) ProtoMessage() {}
+
+func (x *ReconcileStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStockResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ReconcileStockResponse) GetDiscrepancies() []*StockDiscrepancy {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
+func (x *ReconcileStockResponse) GetItemsChecked() uint32 {
+	if x != nil {
+		return x.ItemsChecked
+	}
+	return 0
+}
+
+func (x *ReconcileStockResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// WAREHOUSES, THE QUANTITY AVAILABLE OF AN ITEM IS THE TOTAL OF ITS STOCK IN THE WAREHOUSES
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *Warehouse) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Quantity of an item in stock at a warehouse
+type WarehouseStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarehouseStock) Reset() {
+	*x = WarehouseStock{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarehouseStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarehouseStock) ProtoMessage() {}
+
+func (x *WarehouseStock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarehouseStock.ProtoReflect.Descriptor instead.
+func (*WarehouseStock) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *WarehouseStock) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *WarehouseStock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WarehouseStock) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// CREATE A WAREHOUSE
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type CreateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseResponse) Reset() {
+	*x = CreateWarehouseResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseResponse) ProtoMessage() {}
+
+func (x *CreateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CreateWarehouseResponse) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *CreateWarehouseResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// LIST THE WAREHOUSES, BY NAME
+type ListWarehousesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{79}
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+func (x *ListWarehousesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// STOCK OF AN ITEM IN THE WAREHOUSES HAVING IT, THE ONE OF ITS VARIANTS FOR A PRODUCT
+type GetItemStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemStockRequest) Reset() {
+	*x = GetItemStockRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemStockRequest) ProtoMessage() {}
+
+func (x *GetItemStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemStockRequest.ProtoReflect.Descriptor instead.
+func (*GetItemStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetItemStockRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type GetItemStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         []*WarehouseStock      `protobuf:"bytes,1,rep,name=stock,proto3" json:"stock,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemStockResponse) Reset() {
+	*x = GetItemStockResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemStockResponse) ProtoMessage() {}
+
+func (x *GetItemStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemStockResponse.ProtoReflect.Descriptor instead.
+func (*GetItemStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetItemStockResponse) GetStock() []*WarehouseStock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *GetItemStockResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// MOVE STOCK OF AN ITEM BETWEEN WAREHOUSES, THE QUANTITY AVAILABLE DOES NOT CHANGE
+type TransferStockRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	FromWarehouseId string                 `protobuf:"bytes,2,opt,name=from_warehouse_id,json=fromWarehouseId,proto3" json:"from_warehouse_id,omitempty"`
+	ToWarehouseId   string                 `protobuf:"bytes,3,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty"`
+	Quantity        uint32                 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferStockRequest) Reset() {
+	*x = TransferStockRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockRequest) ProtoMessage() {}
+
+func (x *TransferStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockRequest.ProtoReflect.Descriptor instead.
+func (*TransferStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *TransferStockRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetFromWarehouseId() string {
+	if x != nil {
+		return x.FromWarehouseId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetToWarehouseId() string {
+	if x != nil {
+		return x.ToWarehouseId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type TransferStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStockResponse) Reset() {
+	*x = TransferStockResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockResponse) ProtoMessage() {}
+
+func (x *TransferStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockResponse.ProtoReflect.Descriptor instead.
+func (*TransferStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *TransferStockResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// LOW-STOCK ALERTS
+// An alert is raised when the quantity available of an item falls below its reorder threshold,
+// and resolved when the item is stocked up to the threshold again. resolved_at is 0 while the alert is open.
+type StockAlert struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AlertId           uint64                 `protobuf:"varint,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	ItemId            string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	QuantityAvailable uint32                 `protobuf:"varint,4,opt,name=quantity_available,json=quantityAvailable,proto3" json:"quantity_available,omitempty"`
+	Threshold         uint32                 `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	RaisedAt          int64                  `protobuf:"varint,6,opt,name=raised_at,json=raisedAt,proto3" json:"raised_at,omitempty"`
+	ResolvedAt        int64                  `protobuf:"varint,7,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StockAlert) Reset() {
+	*x = StockAlert{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAlert) ProtoMessage() {}
+
+func (x *StockAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAlert.ProtoReflect.Descriptor instead.
+func (*StockAlert) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *StockAlert) GetAlertId() uint64 {
+	if x != nil {
+		return x.AlertId
+	}
+	return 0
+}
+
+func (x *StockAlert) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockAlert) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StockAlert) GetQuantityAvailable() uint32 {
+	if x != nil {
+		return x.QuantityAvailable
+	}
+	return 0
+}
+
+func (x *StockAlert) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *StockAlert) GetRaisedAt() int64 {
+	if x != nil {
+		return x.RaisedAt
+	}
+	return 0
+}
+
+func (x *StockAlert) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+// SET THE REORDER THRESHOLD OF AN ITEM, 0 FOR NO ALERTS
+type SetReorderThresholdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Threshold     uint32                 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderThresholdRequest) Reset() {
+	*x = SetReorderThresholdRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderThresholdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderThresholdRequest) ProtoMessage() {}
+
+func (x *SetReorderThresholdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderThresholdRequest.ProtoReflect.Descriptor instead.
+func (*SetReorderThresholdRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *SetReorderThresholdRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetReorderThresholdRequest) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type SetReorderThresholdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderThresholdResponse) Reset() {
+	*x = SetReorderThresholdResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderThresholdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderThresholdResponse) ProtoMessage() {}
+
+func (x *SetReorderThresholdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderThresholdResponse.ProtoReflect.Descriptor instead.
+func (*SetReorderThresholdResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *SetReorderThresholdResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// LIST THE ALERTS, THE MOST RECENT FIRST
+type ListStockAlertsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeResolved bool                   `protobuf:"varint,1,opt,name=include_resolved,json=includeResolved,proto3" json:"include_resolved,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListStockAlertsRequest) Reset() {
+	*x = ListStockAlertsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockAlertsRequest) ProtoMessage() {}
+
+func (x *ListStockAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListStockAlertsRequest) GetIncludeResolved() bool {
+	if x != nil {
+		return x.IncludeResolved
+	}
+	return false
+}
+
+type ListStockAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*StockAlert          `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockAlertsResponse) Reset() {
+	*x = ListStockAlertsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockAlertsResponse) ProtoMessage() {}
+
+func (x *ListStockAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListStockAlertsResponse) GetAlerts() []*StockAlert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *ListStockAlertsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// REORDER SUGGESTIONS FROM THE SALES OF THE LAST window_days, ENOUGH STOCK FOR cover_days ABOVE THE THRESHOLD
+// daily_sales are the units sold per day in the window, days_of_stock how long the stock lasts at that pace (0 without sales).
+type ReorderSuggestion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ItemId            string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	QuantityAvailable uint32                 `protobuf:"varint,3,opt,name=quantity_available,json=quantityAvailable,proto3" json:"quantity_available,omitempty"`
+	Threshold         uint32                 `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	UnitsSold         uint32                 `protobuf:"varint,5,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	DailySales        float64                `protobuf:"fixed64,6,opt,name=daily_sales,json=dailySales,proto3" json:"daily_sales,omitempty"`
+	DaysOfStock       float64                `protobuf:"fixed64,7,opt,name=days_of_stock,json=daysOfStock,proto3" json:"days_of_stock,omitempty"`
+	SuggestedQuantity uint32                 `protobuf:"varint,8,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReorderSuggestion) Reset() {
+	*x = ReorderSuggestion{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderSuggestion) ProtoMessage() {}
+
+func (x *ReorderSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderSuggestion.ProtoReflect.Descriptor instead.
+func (*ReorderSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ReorderSuggestion) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReorderSuggestion) GetQuantityAvailable() uint32 {
+	if x != nil {
+		return x.QuantityAvailable
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetUnitsSold() uint32 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDailySales() float64 {
+	if x != nil {
+		return x.DailySales
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetDaysOfStock() float64 {
+	if x != nil {
+		return x.DaysOfStock
+	}
+	return 0
+}
+
+func (x *ReorderSuggestion) GetSuggestedQuantity() uint32 {
+	if x != nil {
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+type GetReorderSuggestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowDays    uint32                 `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	CoverDays     uint32                 `protobuf:"varint,2,opt,name=cover_days,json=coverDays,proto3" json:"cover_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReorderSuggestionsRequest) Reset() {
+	*x = GetReorderSuggestionsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReorderSuggestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReorderSuggestionsRequest) ProtoMessage() {}
+
+func (x *GetReorderSuggestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReorderSuggestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetReorderSuggestionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetReorderSuggestionsRequest) GetWindowDays() uint32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *GetReorderSuggestionsRequest) GetCoverDays() uint32 {
+	if x != nil {
+		return x.CoverDays
+	}
+	return 0
+}
+
+type GetReorderSuggestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*ReorderSuggestion   `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReorderSuggestionsResponse) Reset() {
+	*x = GetReorderSuggestionsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReorderSuggestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReorderSuggestionsResponse) ProtoMessage() {}
+
+func (x *GetReorderSuggestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReorderSuggestionsResponse.ProtoReflect.Descriptor instead.
+func (*GetReorderSuggestionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetReorderSuggestionsResponse) GetSuggestions() []*ReorderSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *GetReorderSuggestionsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// IMAGES OF THE ITEMS
+// The images of an item are in the order they are shown, the first one is the cover of the item.
+// Each image has a thumbnail, both are served by GetImage.
+type ItemImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageId       string                 `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Width         uint32                 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        uint32                 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemImage) Reset() {
+	*x = ItemImage{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemImage) ProtoMessage() {}
+
+func (x *ItemImage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemImage.ProtoReflect.Descriptor instead.
+func (*ItemImage) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ItemImage) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *ItemImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ItemImage) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ItemImage) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// ADD AN IMAGE TO AN ITEM, AFTER ITS OTHERS
+// data is a JPEG, PNG or GIF image, its thumbnail is generated by the catalog
+type AddItemImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemImageRequest) Reset() {
+	*x = AddItemImageRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemImageRequest) ProtoMessage() {}
+
+func (x *AddItemImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemImageRequest.ProtoReflect.Descriptor instead.
+func (*AddItemImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *AddItemImageRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AddItemImageRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AddItemImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         *ItemImage             `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemImageResponse) Reset() {
+	*x = AddItemImageResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemImageResponse) ProtoMessage() {}
+
+func (x *AddItemImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemImageResponse.ProtoReflect.Descriptor instead.
+func (*AddItemImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *AddItemImageResponse) GetImage() *ItemImage {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *AddItemImageResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// REMOVE AN IMAGE OF AN ITEM
+type RemoveItemImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemImageRequest) Reset() {
+	*x = RemoveItemImageRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemImageRequest) ProtoMessage() {}
+
+func (x *RemoveItemImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemImageRequest.ProtoReflect.Descriptor instead.
+func (*RemoveItemImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *RemoveItemImageRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *RemoveItemImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+type RemoveItemImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemImageResponse) Reset() {
+	*x = RemoveItemImageResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemImageResponse) ProtoMessage() {}
+
+func (x *RemoveItemImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemImageResponse.ProtoReflect.Descriptor instead.
+func (*RemoveItemImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *RemoveItemImageResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// ORDER THE IMAGES OF AN ITEM, image_ids ARE ALL THE IMAGES OF THE ITEM IN THEIR NEW ORDER
+type ReorderItemImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ImageIds      []string               `protobuf:"bytes,2,rep,name=image_ids,json=imageIds,proto3" json:"image_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderItemImagesRequest) Reset() {
+	*x = ReorderItemImagesRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderItemImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderItemImagesRequest) ProtoMessage() {}
+
+func (x *ReorderItemImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderItemImagesRequest.ProtoReflect.Descriptor instead.
+func (*ReorderItemImagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ReorderItemImagesRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReorderItemImagesRequest) GetImageIds() []string {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
+
+type ReorderItemImagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderItemImagesResponse) Reset() {
+	*x = ReorderItemImagesResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderItemImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderItemImagesResponse) ProtoMessage() {}
+
+func (x *ReorderItemImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderItemImagesResponse.ProtoReflect.Descriptor instead.
+func (*ReorderItemImagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ReorderItemImagesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// CONTENT OF AN IMAGE, OR OF ITS THUMBNAIL
+type GetImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageId       string                 `protobuf:"bytes,1,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Thumbnail     bool                   `protobuf:"varint,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageRequest) Reset() {
+	*x = GetImageRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageRequest) ProtoMessage() {}
+
+func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageRequest.ProtoReflect.Descriptor instead.
+func (*GetImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *GetImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *GetImageRequest) GetThumbnail() bool {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return false
+}
+
+type GetImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageResponse) Reset() {
+	*x = GetImageResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageResponse) ProtoMessage() {}
+
+func (x *GetImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageResponse.ProtoReflect.Descriptor instead.
+func (*GetImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *GetImageResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetImageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetImageResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-// REMOVE ITEM FROM CATALOG
-type RemoveCatalogItemRequest struct {
+type Review struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ReviewId      string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Rating        uint32                 `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Status        ReviewStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=catalog.ReviewStatus" json:"status,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveCatalogItemRequest) Reset() {
-	*x = RemoveCatalogItemRequest{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[3]
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveCatalogItemRequest) String() string {
+func (x *Review) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveCatalogItemRequest) ProtoMessage() {}
+func (*Review) ProtoMessage() {}
 
-func (x *RemoveCatalogItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[3]
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,85 +6353,86 @@ func (x *RemoveCatalogItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveCatalogItemRequest.ProtoReflect.Descriptor instead.
-func (*RemoveCatalogItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *RemoveCatalogItemRequest) GetItemId() string {
+func (x *Review) GetReviewId() string {
 	if x != nil {
-		return x.ItemId
+		return x.ReviewId
 	}
 	return ""
 }
 
-type RemoveCatalogItemResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Review) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
 }
 
-func (x *RemoveCatalogItemResponse) Reset() {
-	*x = RemoveCatalogItemResponse{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Review) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func (x *RemoveCatalogItemResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Review) GetRating() uint32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
 }
 
-func (*RemoveCatalogItemResponse) ProtoMessage() {}
-
-func (x *RemoveCatalogItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[4]
+func (x *Review) GetText() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Text
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RemoveCatalogItemResponse.ProtoReflect.Descriptor instead.
-func (*RemoveCatalogItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{4}
+func (x *Review) GetStatus() ReviewStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReviewStatus_REVIEW_PENDING
 }
 
-func (x *RemoveCatalogItemResponse) GetErrorMessage() string {
+func (x *Review) GetCreatedAt() int64 {
 	if x != nil {
-		return x.ErrorMessage
+		return x.CreatedAt
 	}
-	return ""
+	return 0
 }
 
-// GET ITEM FROM CATALOG
-type GetCatalogItemRequest struct {
+// REVIEW AN ITEM AS THE CALLER, A NEW REVIEW OF THE SAME ITEM REPLACES THE PREVIOUS ONE
+// rating goes from 1 to 5 stars, the review of a variant is the one of its product
+type SubmitReviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Rating        uint32                 `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCatalogItemRequest) Reset() {
-	*x = GetCatalogItemRequest{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[5]
+func (x *SubmitReviewRequest) Reset() {
+	*x = SubmitReviewRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCatalogItemRequest) String() string {
+func (x *SubmitReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCatalogItemRequest) ProtoMessage() {}
+func (*SubmitReviewRequest) ProtoMessage() {}
 
-func (x *GetCatalogItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[5]
+func (x *SubmitReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,41 +6443,55 @@ func (x *GetCatalogItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCatalogItemRequest.ProtoReflect.Descriptor instead.
-func (*GetCatalogItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SubmitReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *GetCatalogItemRequest) GetItemId() string {
+func (x *SubmitReviewRequest) GetItemId() string {
 	if x != nil {
 		return x.ItemId
 	}
 	return ""
 }
 
-type GetCatalogItemResponse struct {
+func (x *SubmitReviewRequest) GetRating() uint32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *SubmitReviewRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SubmitReviewResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *CatalogItem           `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
 	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCatalogItemResponse) Reset() {
-	*x = GetCatalogItemResponse{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[6]
+func (x *SubmitReviewResponse) Reset() {
+	*x = SubmitReviewResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCatalogItemResponse) String() string {
+func (x *SubmitReviewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCatalogItemResponse) ProtoMessage() {}
+func (*SubmitReviewResponse) ProtoMessage() {}
 
-func (x *GetCatalogItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[6]
+func (x *SubmitReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,49 +6502,48 @@ func (x *GetCatalogItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCatalogItemResponse.ProtoReflect.Descriptor instead.
-func (*GetCatalogItemResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SubmitReviewResponse.ProtoReflect.Descriptor instead.
+func (*SubmitReviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *GetCatalogItemResponse) GetItem() *CatalogItem {
+func (x *SubmitReviewResponse) GetReview() *Review {
 	if x != nil {
-		return x.Item
+		return x.Review
 	}
 	return nil
 }
 
-func (x *GetCatalogItemResponse) GetErrorMessage() string {
+func (x *SubmitReviewResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-// UPDATE ITEM QUANTITY
-type UpdateQuantityAvailableRequest struct {
+// APPROVED REVIEWS OF AN ITEM, THE MOST RECENT FIRST
+type ListReviewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateQuantityAvailableRequest) Reset() {
-	*x = UpdateQuantityAvailableRequest{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[7]
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateQuantityAvailableRequest) String() string {
+func (x *ListReviewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateQuantityAvailableRequest) ProtoMessage() {}
+func (*ListReviewsRequest) ProtoMessage() {}
 
-func (x *UpdateQuantityAvailableRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[7]
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,47 +6554,41 @@ func (x *UpdateQuantityAvailableRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateQuantityAvailableRequest.ProtoReflect.Descriptor instead.
-func (*UpdateQuantityAvailableRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *UpdateQuantityAvailableRequest) GetItemId() string {
+func (x *ListReviewsRequest) GetItemId() string {
 	if x != nil {
 		return x.ItemId
 	}
 	return ""
 }
 
-func (x *UpdateQuantityAvailableRequest) GetQuantity() uint32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type UpdateQuantityAvailableResponse struct {
+type ListReviewsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateQuantityAvailableResponse) Reset() {
-	*x = UpdateQuantityAvailableResponse{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[8]
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateQuantityAvailableResponse) String() string {
+func (x *ListReviewsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateQuantityAvailableResponse) ProtoMessage() {}
+func (*ListReviewsResponse) ProtoMessage() {}
 
-func (x *UpdateQuantityAvailableResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[8]
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,42 +6599,47 @@ func (x *UpdateQuantityAvailableResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateQuantityAvailableResponse.ProtoReflect.Descriptor instead.
-func (*UpdateQuantityAvailableResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *UpdateQuantityAvailableResponse) GetErrorMessage() string {
+func (x *ListReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-// UPDATE ITEM PRICE
-type UpdatePriceRequest struct {
+// REVIEWS WAITING FOR MODERATION, THE OLDEST FIRST
+type ListPendingReviewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdatePriceRequest) Reset() {
-	*x = UpdatePriceRequest{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[9]
+func (x *ListPendingReviewsRequest) Reset() {
+	*x = ListPendingReviewsRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePriceRequest) String() string {
+func (x *ListPendingReviewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePriceRequest) ProtoMessage() {}
+func (*ListPendingReviewsRequest) ProtoMessage() {}
 
-func (x *UpdatePriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[9]
+func (x *ListPendingReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,47 +6650,34 @@ func (x *UpdatePriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePriceRequest.ProtoReflect.Descriptor instead.
-func (*UpdatePriceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *UpdatePriceRequest) GetItemId() string {
-	if x != nil {
-		return x.ItemId
-	}
-	return ""
-}
-
-func (x *UpdatePriceRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
+// Deprecated: Use ListPendingReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{107}
 }
 
-type UpdatePriceResponse struct {
+type ListPendingReviewsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdatePriceResponse) Reset() {
-	*x = UpdatePriceResponse{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[10]
+func (x *ListPendingReviewsResponse) Reset() {
+	*x = ListPendingReviewsResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePriceResponse) String() string {
+func (x *ListPendingReviewsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePriceResponse) ProtoMessage() {}
+func (*ListPendingReviewsResponse) ProtoMessage() {}
 
-func (x *UpdatePriceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[10]
+func (x *ListPendingReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,40 +6688,49 @@ func (x *UpdatePriceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePriceResponse.ProtoReflect.Descriptor instead.
-func (*UpdatePriceResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListPendingReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *UpdatePriceResponse) GetErrorMessage() string {
+func (x *ListPendingReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListPendingReviewsResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-// LISTING CATALOG
-type ListCatalogItemsRequest struct {
+// APPROVE OR REJECT A REVIEW
+type ModerateReviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCatalogItemsRequest) Reset() {
-	*x = ListCatalogItemsRequest{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[11]
+func (x *ModerateReviewRequest) Reset() {
+	*x = ModerateReviewRequest{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCatalogItemsRequest) String() string {
+func (x *ModerateReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCatalogItemsRequest) ProtoMessage() {}
+func (*ModerateReviewRequest) ProtoMessage() {}
 
-func (x *ListCatalogItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[11]
+func (x *ModerateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,34 +6741,47 @@ func (x *ListCatalogItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCatalogItemsRequest.ProtoReflect.Descriptor instead.
-func (*ListCatalogItemsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ModerateReviewRequest.ProtoReflect.Descriptor instead.
+func (*ModerateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{109}
 }
 
-type ListCatalogItemsResponse struct {
+func (x *ModerateReviewRequest) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+func (x *ModerateReviewRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+type ModerateReviewResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCatalogItemsResponse) Reset() {
-	*x = ListCatalogItemsResponse{}
-	mi := &file_proto_catalog_catalog_proto_msgTypes[12]
+func (x *ModerateReviewResponse) Reset() {
+	*x = ModerateReviewResponse{}
+	mi := &file_proto_catalog_catalog_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCatalogItemsResponse) String() string {
+func (x *ModerateReviewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCatalogItemsResponse) ProtoMessage() {}
+func (*ModerateReviewResponse) ProtoMessage() {}
 
-func (x *ListCatalogItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_catalog_catalog_proto_msgTypes[12]
+func (x *ModerateReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_catalog_catalog_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,19 +6792,12 @@ func (x *ListCatalogItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCatalogItemsResponse.ProtoReflect.Descriptor instead.
-func (*ListCatalogItemsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *ListCatalogItemsResponse) GetItems() []*CatalogItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
+// Deprecated: Use ModerateReviewResponse.ProtoReflect.Descriptor instead.
+func (*ModerateReviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_catalog_catalog_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *ListCatalogItemsResponse) GetErrorMessage() string {
+func (x *ModerateReviewResponse) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
@@ -666,46 +6808,545 @@ var File_proto_catalog_catalog_proto protoreflect.FileDescriptor
 
 const file_proto_catalog_catalog_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/catalog/catalog.proto\x12\acatalog\"\x8d\x01\n" +
+	"\x1bproto/catalog/catalog.proto\x12\acatalog\"\xbd\x05\n" +
 	"\vCatalogItem\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12-\n" +
 	"\x12quantity_available\x18\x03 \x01(\rR\x11quantityAvailable\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\"A\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x06 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04slug\x18\a \x01(\tR\x04slug\x12\x1f\n" +
+	"\vcategory_id\x18\b \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\n" +
+	" \x01(\tR\tproductId\x12D\n" +
+	"\n" +
+	"attributes\x18\v \x03(\v2$.catalog.CatalogItem.AttributesEntryR\n" +
+	"attributes\x120\n" +
+	"\bvariants\x18\f \x03(\v2\x14.catalog.CatalogItemR\bvariants\x12\x18\n" +
+	"\aversion\x18\r \x01(\x04R\aversion\x12,\n" +
+	"\x12locations_in_stock\x18\x0e \x01(\rR\x10locationsInStock\x12+\n" +
+	"\x11reorder_threshold\x18\x0f \x01(\rR\x10reorderThreshold\x12*\n" +
+	"\x06images\x18\x10 \x03(\v2\x12.catalog.ItemImageR\x06images\x12%\n" +
+	"\x0erating_average\x18\x11 \x01(\x01R\rratingAverage\x12!\n" +
+	"\frating_count\x18\x12 \x01(\rR\vratingCount\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
 	"\x15AddCatalogItemRequest\x12(\n" +
-	"\x04item\x18\x01 \x01(\v2\x14.catalog.CatalogItemR\x04item\"=\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.catalog.CatalogItemR\x04item\"V\n" +
 	"\x16AddCatalogItemResponse\x12#\n" +
-	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"3\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"3\n" +
 	"\x18RemoveCatalogItemRequest\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"@\n" +
 	"\x19RemoveCatalogItemResponse\x12#\n" +
-	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"0\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"V\n" +
 	"\x15GetCatalogItemRequest\x12\x17\n" +
-	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"g\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\"g\n" +
 	"\x16GetCatalogItemResponse\x12(\n" +
 	"\x04item\x18\x01 \x01(\v2\x14.catalog.CatalogItemR\x04item\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"U\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"3\n" +
+	"\x16GetCatalogItemsRequest\x12\x19\n" +
+	"\bitem_ids\x18\x01 \x03(\tR\aitemIds\"j\n" +
+	"\x17GetCatalogItemsResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.catalog.CatalogItemR\x05items\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xcc\x02\n" +
+	"\x18UpdateCatalogItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04slug\x18\x05 \x01(\tR\x04slug\x12Q\n" +
+	"\n" +
+	"attributes\x18\x06 \x03(\v21.catalog.UpdateCatalogItemRequest.AttributesEntryR\n" +
+	"attributes\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x04R\x0fexpectedVersion\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x19UpdateCatalogItemResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\xd9\x01\n" +
 	"\x1eUpdateQuantityAvailableRequest\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\rR\bquantity\"F\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x04R\x0fexpectedVersion\x124\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x1c.catalog.StockMovementReasonR\x06reason\x12!\n" +
+	"\fwarehouse_id\x18\x05 \x01(\tR\vwarehouseId\"F\n" +
 	"\x1fUpdateQuantityAvailableResponse\x12#\n" +
-	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"C\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"n\n" +
 	"\x12UpdatePriceRequest\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x14\n" +
-	"\x05price\x18\x02 \x01(\x01R\x05price\":\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x04R\x0fexpectedVersion\":\n" +
 	"\x13UpdatePriceResponse\x12#\n" +
-	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\x19\n" +
-	"\x17ListCatalogItemsRequest\"k\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\x90\x02\n" +
+	"\x17ListCatalogItemsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x01R\bmaxPrice\x12\"\n" +
+	"\rin_stock_only\x18\x05 \x01(\bR\vinStockOnly\x12(\n" +
+	"\x04sort\x18\x06 \x01(\x0e2\x14.catalog.CatalogSortR\x04sort\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\tR\n" +
+	"categoryId\x12\x10\n" +
+	"\x03tag\x18\b \x01(\tR\x03tag\"\x93\x01\n" +
 	"\x18ListCatalogItemsResponse\x12*\n" +
 	"\x05items\x18\x01 \x03(\v2\x14.catalog.CatalogItemR\x05items\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage2\xa3\x04\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"@\n" +
+	"\tStockItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\"i\n" +
+	"\x0fStockAllocation\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"\xce\x01\n" +
+	"\x13ReserveStockRequest\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.catalog.StockItemR\x05items\x12\x1f\n" +
+	"\vttl_seconds\x18\x02 \x01(\x03R\n" +
+	"ttlSeconds\x127\n" +
+	"\bstrategy\x18\x03 \x01(\x0e2\x1b.catalog.AllocationStrategyR\bstrategy\x123\n" +
+	"\vdestination\x18\x04 \x01(\v2\x11.catalog.LocationR\vdestination\"\xbd\x01\n" +
+	"\x14ReserveStockResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12:\n" +
+	"\vallocations\x18\x04 \x03(\v2\x18.catalog.StockAllocationR\vallocations\"\\\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"@\n" +
+	"\x19CommitReservationResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"B\n" +
+	"\x19ReleaseReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"A\n" +
+	"\x1aReleaseReservationResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"y\n" +
+	"\x13RestockItemsRequest\x12\x1d\n" +
+	"\n" +
+	"restock_id\x18\x01 \x01(\tR\trestockId\x12(\n" +
+	"\x05items\x18\x02 \x03(\v2\x12.catalog.StockItemR\x05items\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\";\n" +
+	"\x14RestockItemsResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"B\n" +
+	"\x14SearchCatalogRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"3\n" +
+	"\tHighlight\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\x99\x01\n" +
+	"\tSearchHit\x12(\n" +
+	"\x04item\x18\x01 \x01(\v2\x14.catalog.CatalogItemR\x04item\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\x122\n" +
+	"\n" +
+	"highlights\x18\x04 \x03(\v2\x12.catalog.HighlightR\n" +
+	"highlights\"d\n" +
+	"\x15SearchCatalogResponse\x12&\n" +
+	"\x04hits\x18\x01 \x03(\v2\x12.catalog.SearchHitR\x04hits\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"<\n" +
+	"\x1bResolveLegacyItemIDsRequest\x12\x1d\n" +
+	"\n" +
+	"legacy_ids\x18\x01 \x03(\tR\tlegacyIds\"\xce\x01\n" +
+	"\x1cResolveLegacyItemIDsResponse\x12M\n" +
+	"\bitem_ids\x18\x01 \x03(\v22.catalog.ResolveLegacyItemIDsResponse.ItemIdsEntryR\aitemIds\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x1a:\n" +
+	"\fItemIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8f\x01\n" +
+	"\bCategory\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tparent_id\x18\x04 \x01(\tR\bparentId\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x05 \x01(\rR\titemCount\"\\\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\"^\n" +
+	"\x16CreateCategoryResponse\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"`\n" +
+	"\x15UpdateCategoryRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\"=\n" +
+	"\x16UpdateCategoryResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"S\n" +
+	"\x13MoveCategoryRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\";\n" +
+	"\x14MoveCategoryResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"8\n" +
+	"\x15DeleteCategoryRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\"=\n" +
+	"\x16DeleteCategoryResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\x17\n" +
+	"\x15ListCategoriesRequest\"p\n" +
+	"\x16ListCategoriesResponse\x121\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x11.catalog.CategoryR\n" +
+	"categories\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"R\n" +
+	"\x16SetItemCategoryRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\">\n" +
+	"\x17SetItemCategoryResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"A\n" +
+	"\x12SetItemTagsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\":\n" +
+	"\x13SetItemTagsResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\";\n" +
+	"\bTagCount\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x02 \x01(\rR\titemCount\"\x11\n" +
+	"\x0fListTagsRequest\"^\n" +
+	"\x10ListTagsResponse\x12%\n" +
+	"\x04tags\x18\x01 \x03(\v2\x11.catalog.TagCountR\x04tags\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"~\n" +
+	"\x19ImportCatalogItemsRequest\x122\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1a.catalog.CatalogFileFormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"Z\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\rR\x03row\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xd7\x01\n" +
+	"\x1aImportCatalogItemsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\rR\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\rR\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\rR\x06failed\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.catalog.ImportRowErrorR\x06errors\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"O\n" +
+	"\x19ExportCatalogItemsRequest\x122\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1a.catalog.CatalogFileFormatR\x06format\"2\n" +
+	"\x1aExportCatalogItemsResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"\xe5\x01\n" +
+	"\fCatalogEvent\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.catalog.CatalogEventTypeR\x04type\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12(\n" +
+	"\x04item\x18\x03 \x01(\v2\x14.catalog.CatalogItemR\x04item\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12)\n" +
+	"\x05alert\x18\x06 \x01(\v2\x13.catalog.StockAlertR\x05alert\"\x15\n" +
+	"\x13WatchCatalogRequest\"\x97\x01\n" +
+	"\vPriceChange\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x02 \x01(\x03R\tchangedAt\x122\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1a.catalog.PriceChangeReasonR\x06reason\x12\x1f\n" +
+	"\vschedule_id\x18\x04 \x01(\tR\n" +
+	"scheduleId\"1\n" +
+	"\x16GetPriceHistoryRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"n\n" +
+	"\x17GetPriceHistoryResponse\x12.\n" +
+	"\achanges\x18\x01 \x03(\v2\x14.catalog.PriceChangeR\achanges\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xcd\x01\n" +
+	"\x0eScheduledPrice\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1b\n" +
+	"\tstarts_at\x18\x04 \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\x05 \x01(\x03R\x06endsAt\x125\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1d.catalog.ScheduledPriceStatusR\x06status\"\x81\x01\n" +
+	"\x1aSchedulePriceChangeRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1b\n" +
+	"\tstarts_at\x18\x03 \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\x04 \x01(\x03R\x06endsAt\"c\n" +
+	"\x1bSchedulePriceChangeResponse\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"5\n" +
+	"\x1aListScheduledPricesRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"y\n" +
+	"\x1bListScheduledPricesResponse\x125\n" +
+	"\tschedules\x18\x01 \x03(\v2\x17.catalog.ScheduledPriceR\tschedules\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\">\n" +
+	"\x1bCancelScheduledPriceRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"C\n" +
+	"\x1cCancelScheduledPriceResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\xd6\x02\n" +
+	"\rStockMovement\x12\x1f\n" +
+	"\vmovement_id\x18\x01 \x01(\x04R\n" +
+	"movementId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\x12%\n" +
+	"\x0equantity_after\x18\x04 \x01(\rR\rquantityAfter\x124\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1c.catalog.StockMovementReasonR\x06reason\x12%\n" +
+	"\x0ereservation_id\x18\x06 \x01(\tR\rreservationId\x12\x19\n" +
+	"\border_id\x18\a \x01(\tR\aorderId\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fwarehouse_id\x18\n" +
+	" \x01(\tR\vwarehouseId\"\x8b\x01\n" +
+	"\x19ListStockMovementsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x9f\x01\n" +
+	"\x1aListStockMovementsResponse\x124\n" +
+	"\tmovements\x18\x01 \x03(\v2\x16.catalog.StockMovementR\tmovements\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\xba\x01\n" +
+	"\x10StockDiscrepancy\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x12quantity_available\x18\x03 \x01(\rR\x11quantityAvailable\x12'\n" +
+	"\x0fledger_quantity\x18\x04 \x01(\x03R\x0eledgerQuantity\x12!\n" +
+	"\fwarehouse_id\x18\x05 \x01(\tR\vwarehouseId\"-\n" +
+	"\x15ReconcileStockRequest\x12\x14\n" +
+	"\x05apply\x18\x01 \x01(\bR\x05apply\"\xa3\x01\n" +
+	"\x16ReconcileStockResponse\x12?\n" +
+	"\rdiscrepancies\x18\x01 \x03(\v2\x19.catalog.StockDiscrepancyR\rdiscrepancies\x12#\n" +
+	"\ritems_checked\x18\x02 \x01(\rR\fitemsChecked\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"D\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"q\n" +
+	"\tWarehouse\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\blocation\x18\x03 \x01(\v2\x11.catalog.LocationR\blocation\"c\n" +
+	"\x0eWarehouseStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"J\n" +
+	"\x16CreateWarehouseRequest\x120\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x12.catalog.WarehouseR\twarehouse\"a\n" +
+	"\x17CreateWarehouseResponse\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x17\n" +
+	"\x15ListWarehousesRequest\"q\n" +
+	"\x16ListWarehousesResponse\x122\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x12.catalog.WarehouseR\n" +
+	"warehouses\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\".\n" +
+	"\x13GetItemStockRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"j\n" +
+	"\x14GetItemStockResponse\x12-\n" +
+	"\x05stock\x18\x01 \x03(\v2\x17.catalog.WarehouseStockR\x05stock\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x9f\x01\n" +
+	"\x14TransferStockRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12*\n" +
+	"\x11from_warehouse_id\x18\x02 \x01(\tR\x0ffromWarehouseId\x12&\n" +
+	"\x0fto_warehouse_id\x18\x03 \x01(\tR\rtoWarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\rR\bquantity\"<\n" +
+	"\x15TransferStockResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"\xdf\x01\n" +
+	"\n" +
+	"StockAlert\x12\x19\n" +
+	"\balert_id\x18\x01 \x01(\x04R\aalertId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12-\n" +
+	"\x12quantity_available\x18\x04 \x01(\rR\x11quantityAvailable\x12\x1c\n" +
+	"\tthreshold\x18\x05 \x01(\rR\tthreshold\x12\x1b\n" +
+	"\traised_at\x18\x06 \x01(\x03R\braisedAt\x12\x1f\n" +
+	"\vresolved_at\x18\a \x01(\x03R\n" +
+	"resolvedAt\"S\n" +
+	"\x1aSetReorderThresholdRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\rR\tthreshold\"B\n" +
+	"\x1bSetReorderThresholdResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"C\n" +
+	"\x16ListStockAlertsRequest\x12)\n" +
+	"\x10include_resolved\x18\x01 \x01(\bR\x0fincludeResolved\"k\n" +
+	"\x17ListStockAlertsResponse\x12+\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x13.catalog.StockAlertR\x06alerts\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xa0\x02\n" +
+	"\x11ReorderSuggestion\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x12quantity_available\x18\x03 \x01(\rR\x11quantityAvailable\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\rR\tthreshold\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x05 \x01(\rR\tunitsSold\x12\x1f\n" +
+	"\vdaily_sales\x18\x06 \x01(\x01R\n" +
+	"dailySales\x12\"\n" +
+	"\rdays_of_stock\x18\a \x01(\x01R\vdaysOfStock\x12-\n" +
+	"\x12suggested_quantity\x18\b \x01(\rR\x11suggestedQuantity\"^\n" +
+	"\x1cGetReorderSuggestionsRequest\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\rR\n" +
+	"windowDays\x12\x1d\n" +
+	"\n" +
+	"cover_days\x18\x02 \x01(\rR\tcoverDays\"\x82\x01\n" +
+	"\x1dGetReorderSuggestionsResponse\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.catalog.ReorderSuggestionR\vsuggestions\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"w\n" +
+	"\tItemImage\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\tR\aimageId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\rR\x06height\"B\n" +
+	"\x13AddItemImageRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"e\n" +
+	"\x14AddItemImageResponse\x12(\n" +
+	"\x05image\x18\x01 \x01(\v2\x12.catalog.ItemImageR\x05image\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"L\n" +
+	"\x16RemoveItemImageRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\tR\aimageId\">\n" +
+	"\x17RemoveItemImageResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"P\n" +
+	"\x18ReorderItemImagesRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1b\n" +
+	"\timage_ids\x18\x02 \x03(\tR\bimageIds\"@\n" +
+	"\x19ReorderItemImagesResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"J\n" +
+	"\x0fGetImageRequest\x12\x19\n" +
+	"\bimage_id\x18\x01 \x01(\tR\aimageId\x12\x1c\n" +
+	"\tthumbnail\x18\x02 \x01(\bR\tthumbnail\"n\n" +
+	"\x10GetImageResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\xd4\x01\n" +
+	"\x06Review\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\rR\x06rating\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12-\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x15.catalog.ReviewStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"Z\n" +
+	"\x13SubmitReviewRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\rR\x06rating\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"d\n" +
+	"\x14SubmitReviewResponse\x12'\n" +
+	"\x06review\x18\x01 \x01(\v2\x0f.catalog.ReviewR\x06review\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"-\n" +
+	"\x12ListReviewsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\"e\n" +
+	"\x13ListReviewsResponse\x12)\n" +
+	"\areviews\x18\x01 \x03(\v2\x0f.catalog.ReviewR\areviews\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x1b\n" +
+	"\x19ListPendingReviewsRequest\"l\n" +
+	"\x1aListPendingReviewsResponse\x12)\n" +
+	"\areviews\x18\x01 \x03(\v2\x0f.catalog.ReviewR\areviews\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"N\n" +
+	"\x15ModerateReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\"=\n" +
+	"\x16ModerateReviewResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage*N\n" +
+	"\vCatalogSort\x12\b\n" +
+	"\x04NAME\x10\x00\x12\r\n" +
+	"\tPRICE_ASC\x10\x01\x12\x0e\n" +
+	"\n" +
+	"PRICE_DESC\x10\x02\x12\n" +
+	"\n" +
+	"\x06NEWEST\x10\x03\x12\n" +
+	"\n" +
+	"\x06RATING\x10\x04*F\n" +
+	"\x12AllocationStrategy\x12\x1a\n" +
+	"\x16ALLOCATE_FEWEST_SPLITS\x10\x00\x12\x14\n" +
+	"\x10ALLOCATE_NEAREST\x10\x01*&\n" +
+	"\x11CatalogFileFormat\x12\a\n" +
+	"\x03CSV\x10\x00\x12\b\n" +
+	"\x04JSON\x10\x01*\x93\x01\n" +
+	"\x10CatalogEventType\x12\x0e\n" +
+	"\n" +
+	"ITEM_ADDED\x10\x00\x12\x10\n" +
+	"\fITEM_REMOVED\x10\x01\x12\x10\n" +
+	"\fITEM_UPDATED\x10\x02\x12\x11\n" +
+	"\rPRICE_CHANGED\x10\x03\x12\x11\n" +
+	"\rSTOCK_CHANGED\x10\x04\x12\x16\n" +
+	"\x12CATEGORIES_CHANGED\x10\x05\x12\r\n" +
+	"\tLOW_STOCK\x10\x06*b\n" +
+	"\x11PriceChangeReason\x12\x11\n" +
+	"\rPRICE_UPDATED\x10\x00\x12\x11\n" +
+	"\rPRICE_INITIAL\x10\x01\x12\x13\n" +
+	"\x0fPRICE_SCHEDULED\x10\x02\x12\x12\n" +
+	"\x0ePRICE_RESTORED\x10\x03*k\n" +
+	"\x14ScheduledPriceStatus\x12\x14\n" +
+	"\x10SCHEDULE_PENDING\x10\x00\x12\x13\n" +
+	"\x0fSCHEDULE_ACTIVE\x10\x01\x12\x11\n" +
+	"\rSCHEDULE_DONE\x10\x02\x12\x15\n" +
+	"\x11SCHEDULE_CANCELED\x10\x03*\xce\x01\n" +
+	"\x13StockMovementReason\x12\x17\n" +
+	"\x13MOVEMENT_ADJUSTMENT\x10\x00\x12\x14\n" +
+	"\x10MOVEMENT_INITIAL\x10\x01\x12\x14\n" +
+	"\x10MOVEMENT_RESTOCK\x10\x02\x12\x11\n" +
+	"\rMOVEMENT_SALE\x10\x03\x12\x14\n" +
+	"\x10MOVEMENT_RELEASE\x10\x04\x12\x17\n" +
+	"\x13MOVEMENT_EXPIRATION\x10\x05\x12\x19\n" +
+	"\x15MOVEMENT_CANCELLATION\x10\x06\x12\x15\n" +
+	"\x11MOVEMENT_TRANSFER\x10\a*L\n" +
+	"\fReviewStatus\x12\x12\n" +
+	"\x0eREVIEW_PENDING\x10\x00\x12\x13\n" +
+	"\x0fREVIEW_APPROVED\x10\x01\x12\x13\n" +
+	"\x0fREVIEW_REJECTED\x10\x022\x82\x1f\n" +
 	"\x0eCatalogService\x12Q\n" +
 	"\x0eAddCatalogItem\x12\x1e.catalog.AddCatalogItemRequest\x1a\x1f.catalog.AddCatalogItemResponse\x12Z\n" +
 	"\x11RemoveCatalogItem\x12!.catalog.RemoveCatalogItemRequest\x1a\".catalog.RemoveCatalogItemResponse\x12Q\n" +
 	"\x0eGetCatalogItem\x12\x1e.catalog.GetCatalogItemRequest\x1a\x1f.catalog.GetCatalogItemResponse\x12l\n" +
 	"\x17UpdateQuantityAvailable\x12'.catalog.UpdateQuantityAvailableRequest\x1a(.catalog.UpdateQuantityAvailableResponse\x12H\n" +
 	"\vUpdatePrice\x12\x1b.catalog.UpdatePriceRequest\x1a\x1c.catalog.UpdatePriceResponse\x12W\n" +
-	"\x10ListCatalogItems\x12 .catalog.ListCatalogItemsRequest\x1a!.catalog.ListCatalogItemsResponseB^Z\\github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog;catalogb\x06proto3"
+	"\x10ListCatalogItems\x12 .catalog.ListCatalogItemsRequest\x1a!.catalog.ListCatalogItemsResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.catalog.ReserveStockRequest\x1a\x1d.catalog.ReserveStockResponse\x12Z\n" +
+	"\x11CommitReservation\x12!.catalog.CommitReservationRequest\x1a\".catalog.CommitReservationResponse\x12]\n" +
+	"\x12ReleaseReservation\x12\".catalog.ReleaseReservationRequest\x1a#.catalog.ReleaseReservationResponse\x12K\n" +
+	"\fRestockItems\x12\x1c.catalog.RestockItemsRequest\x1a\x1d.catalog.RestockItemsResponse\x12N\n" +
+	"\rSearchCatalog\x12\x1d.catalog.SearchCatalogRequest\x1a\x1e.catalog.SearchCatalogResponse\x12T\n" +
+	"\x0fGetCatalogItems\x12\x1f.catalog.GetCatalogItemsRequest\x1a .catalog.GetCatalogItemsResponse\x12Z\n" +
+	"\x11UpdateCatalogItem\x12!.catalog.UpdateCatalogItemRequest\x1a\".catalog.UpdateCatalogItemResponse\x12c\n" +
+	"\x14ResolveLegacyItemIDs\x12$.catalog.ResolveLegacyItemIDsRequest\x1a%.catalog.ResolveLegacyItemIDsResponse\x12Q\n" +
+	"\x0eCreateCategory\x12\x1e.catalog.CreateCategoryRequest\x1a\x1f.catalog.CreateCategoryResponse\x12Q\n" +
+	"\x0eUpdateCategory\x12\x1e.catalog.UpdateCategoryRequest\x1a\x1f.catalog.UpdateCategoryResponse\x12K\n" +
+	"\fMoveCategory\x12\x1c.catalog.MoveCategoryRequest\x1a\x1d.catalog.MoveCategoryResponse\x12Q\n" +
+	"\x0eDeleteCategory\x12\x1e.catalog.DeleteCategoryRequest\x1a\x1f.catalog.DeleteCategoryResponse\x12Q\n" +
+	"\x0eListCategories\x12\x1e.catalog.ListCategoriesRequest\x1a\x1f.catalog.ListCategoriesResponse\x12T\n" +
+	"\x0fSetItemCategory\x12\x1f.catalog.SetItemCategoryRequest\x1a .catalog.SetItemCategoryResponse\x12H\n" +
+	"\vSetItemTags\x12\x1b.catalog.SetItemTagsRequest\x1a\x1c.catalog.SetItemTagsResponse\x12?\n" +
+	"\bListTags\x12\x18.catalog.ListTagsRequest\x1a\x19.catalog.ListTagsResponse\x12_\n" +
+	"\x12ImportCatalogItems\x12\".catalog.ImportCatalogItemsRequest\x1a#.catalog.ImportCatalogItemsResponse(\x01\x12_\n" +
+	"\x12ExportCatalogItems\x12\".catalog.ExportCatalogItemsRequest\x1a#.catalog.ExportCatalogItemsResponse0\x01\x12E\n" +
+	"\fWatchCatalog\x12\x1c.catalog.WatchCatalogRequest\x1a\x15.catalog.CatalogEvent0\x01\x12T\n" +
+	"\x0fGetPriceHistory\x12\x1f.catalog.GetPriceHistoryRequest\x1a .catalog.GetPriceHistoryResponse\x12`\n" +
+	"\x13SchedulePriceChange\x12#.catalog.SchedulePriceChangeRequest\x1a$.catalog.SchedulePriceChangeResponse\x12`\n" +
+	"\x13ListScheduledPrices\x12#.catalog.ListScheduledPricesRequest\x1a$.catalog.ListScheduledPricesResponse\x12c\n" +
+	"\x14CancelScheduledPrice\x12$.catalog.CancelScheduledPriceRequest\x1a%.catalog.CancelScheduledPriceResponse\x12]\n" +
+	"\x12ListStockMovements\x12\".catalog.ListStockMovementsRequest\x1a#.catalog.ListStockMovementsResponse\x12Q\n" +
+	"\x0eReconcileStock\x12\x1e.catalog.ReconcileStockRequest\x1a\x1f.catalog.ReconcileStockResponse\x12T\n" +
+	"\x0fCreateWarehouse\x12\x1f.catalog.CreateWarehouseRequest\x1a .catalog.CreateWarehouseResponse\x12Q\n" +
+	"\x0eListWarehouses\x12\x1e.catalog.ListWarehousesRequest\x1a\x1f.catalog.ListWarehousesResponse\x12K\n" +
+	"\fGetItemStock\x12\x1c.catalog.GetItemStockRequest\x1a\x1d.catalog.GetItemStockResponse\x12N\n" +
+	"\rTransferStock\x12\x1d.catalog.TransferStockRequest\x1a\x1e.catalog.TransferStockResponse\x12`\n" +
+	"\x13SetReorderThreshold\x12#.catalog.SetReorderThresholdRequest\x1a$.catalog.SetReorderThresholdResponse\x12T\n" +
+	"\x0fListStockAlerts\x12\x1f.catalog.ListStockAlertsRequest\x1a .catalog.ListStockAlertsResponse\x12f\n" +
+	"\x15GetReorderSuggestions\x12%.catalog.GetReorderSuggestionsRequest\x1a&.catalog.GetReorderSuggestionsResponse\x12K\n" +
+	"\fAddItemImage\x12\x1c.catalog.AddItemImageRequest\x1a\x1d.catalog.AddItemImageResponse\x12T\n" +
+	"\x0fRemoveItemImage\x12\x1f.catalog.RemoveItemImageRequest\x1a .catalog.RemoveItemImageResponse\x12Z\n" +
+	"\x11ReorderItemImages\x12!.catalog.ReorderItemImagesRequest\x1a\".catalog.ReorderItemImagesResponse\x12?\n" +
+	"\bGetImage\x12\x18.catalog.GetImageRequest\x1a\x19.catalog.GetImageResponse\x12K\n" +
+	"\fSubmitReview\x12\x1c.catalog.SubmitReviewRequest\x1a\x1d.catalog.SubmitReviewResponse\x12H\n" +
+	"\vListReviews\x12\x1b.catalog.ListReviewsRequest\x1a\x1c.catalog.ListReviewsResponse\x12]\n" +
+	"\x12ListPendingReviews\x12\".catalog.ListPendingReviewsRequest\x1a#.catalog.ListPendingReviewsResponse\x12Q\n" +
+	"\x0eModerateReview\x12\x1e.catalog.ModerateReviewRequest\x1a\x1f.catalog.ModerateReviewResponseB^Z\\github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog;catalogb\x06proto3"
 
 var (
 	file_proto_catalog_catalog_proto_rawDescOnce sync.Once
@@ -719,43 +7360,275 @@ func file_proto_catalog_catalog_proto_rawDescGZIP() []byte {
 	return file_proto_catalog_catalog_proto_rawDescData
 }
 
-var file_proto_catalog_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_catalog_catalog_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_proto_catalog_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 114)
 var file_proto_catalog_catalog_proto_goTypes = []any{
-	(*CatalogItem)(nil),                     // 0: catalog.CatalogItem
-	(*AddCatalogItemRequest)(nil),           // 1: catalog.AddCatalogItemRequest
-	(*AddCatalogItemResponse)(nil),          // 2: catalog.AddCatalogItemResponse
-	(*RemoveCatalogItemRequest)(nil),        // 3: catalog.RemoveCatalogItemRequest
-	(*RemoveCatalogItemResponse)(nil),       // 4: catalog.RemoveCatalogItemResponse
-	(*GetCatalogItemRequest)(nil),           // 5: catalog.GetCatalogItemRequest
-	(*GetCatalogItemResponse)(nil),          // 6: catalog.GetCatalogItemResponse
-	(*UpdateQuantityAvailableRequest)(nil),  // 7: catalog.UpdateQuantityAvailableRequest
-	(*UpdateQuantityAvailableResponse)(nil), // 8: catalog.UpdateQuantityAvailableResponse
-	(*UpdatePriceRequest)(nil),              // 9: catalog.UpdatePriceRequest
-	(*UpdatePriceResponse)(nil),             // 10: catalog.UpdatePriceResponse
-	(*ListCatalogItemsRequest)(nil),         // 11: catalog.ListCatalogItemsRequest
-	(*ListCatalogItemsResponse)(nil),        // 12: catalog.ListCatalogItemsResponse
+	(CatalogSort)(0),                        // 0: catalog.CatalogSort
+	(AllocationStrategy)(0),                 // 1: catalog.AllocationStrategy
+	(CatalogFileFormat)(0),                  // 2: catalog.CatalogFileFormat
+	(CatalogEventType)(0),                   // 3: catalog.CatalogEventType
+	(PriceChangeReason)(0),                  // 4: catalog.PriceChangeReason
+	(ScheduledPriceStatus)(0),               // 5: catalog.ScheduledPriceStatus
+	(StockMovementReason)(0),                // 6: catalog.StockMovementReason
+	(ReviewStatus)(0),                       // 7: catalog.ReviewStatus
+	(*CatalogItem)(nil),                     // 8: catalog.CatalogItem
+	(*AddCatalogItemRequest)(nil),           // 9: catalog.AddCatalogItemRequest
+	(*AddCatalogItemResponse)(nil),          // 10: catalog.AddCatalogItemResponse
+	(*RemoveCatalogItemRequest)(nil),        // 11: catalog.RemoveCatalogItemRequest
+	(*RemoveCatalogItemResponse)(nil),       // 12: catalog.RemoveCatalogItemResponse
+	(*GetCatalogItemRequest)(nil),           // 13: catalog.GetCatalogItemRequest
+	(*GetCatalogItemResponse)(nil),          // 14: catalog.GetCatalogItemResponse
+	(*GetCatalogItemsRequest)(nil),          // 15: catalog.GetCatalogItemsRequest
+	(*GetCatalogItemsResponse)(nil),         // 16: catalog.GetCatalogItemsResponse
+	(*UpdateCatalogItemRequest)(nil),        // 17: catalog.UpdateCatalogItemRequest
+	(*UpdateCatalogItemResponse)(nil),       // 18: catalog.UpdateCatalogItemResponse
+	(*UpdateQuantityAvailableRequest)(nil),  // 19: catalog.UpdateQuantityAvailableRequest
+	(*UpdateQuantityAvailableResponse)(nil), // 20: catalog.UpdateQuantityAvailableResponse
+	(*UpdatePriceRequest)(nil),              // 21: catalog.UpdatePriceRequest
+	(*UpdatePriceResponse)(nil),             // 22: catalog.UpdatePriceResponse
+	(*ListCatalogItemsRequest)(nil),         // 23: catalog.ListCatalogItemsRequest
+	(*ListCatalogItemsResponse)(nil),        // 24: catalog.ListCatalogItemsResponse
+	(*StockItem)(nil),                       // 25: catalog.StockItem
+	(*StockAllocation)(nil),                 // 26: catalog.StockAllocation
+	(*ReserveStockRequest)(nil),             // 27: catalog.ReserveStockRequest
+	(*ReserveStockResponse)(nil),            // 28: catalog.ReserveStockResponse
+	(*CommitReservationRequest)(nil),        // 29: catalog.CommitReservationRequest
+	(*CommitReservationResponse)(nil),       // 30: catalog.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),       // 31: catalog.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),      // 32: catalog.ReleaseReservationResponse
+	(*RestockItemsRequest)(nil),             // 33: catalog.RestockItemsRequest
+	(*RestockItemsResponse)(nil),            // 34: catalog.RestockItemsResponse
+	(*SearchCatalogRequest)(nil),            // 35: catalog.SearchCatalogRequest
+	(*Highlight)(nil),                       // 36: catalog.Highlight
+	(*SearchHit)(nil),                       // 37: catalog.SearchHit
+	(*SearchCatalogResponse)(nil),           // 38: catalog.SearchCatalogResponse
+	(*ResolveLegacyItemIDsRequest)(nil),     // 39: catalog.ResolveLegacyItemIDsRequest
+	(*ResolveLegacyItemIDsResponse)(nil),    // 40: catalog.ResolveLegacyItemIDsResponse
+	(*Category)(nil),                        // 41: catalog.Category
+	(*CreateCategoryRequest)(nil),           // 42: catalog.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),          // 43: catalog.CreateCategoryResponse
+	(*UpdateCategoryRequest)(nil),           // 44: catalog.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),          // 45: catalog.UpdateCategoryResponse
+	(*MoveCategoryRequest)(nil),             // 46: catalog.MoveCategoryRequest
+	(*MoveCategoryResponse)(nil),            // 47: catalog.MoveCategoryResponse
+	(*DeleteCategoryRequest)(nil),           // 48: catalog.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),          // 49: catalog.DeleteCategoryResponse
+	(*ListCategoriesRequest)(nil),           // 50: catalog.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),          // 51: catalog.ListCategoriesResponse
+	(*SetItemCategoryRequest)(nil),          // 52: catalog.SetItemCategoryRequest
+	(*SetItemCategoryResponse)(nil),         // 53: catalog.SetItemCategoryResponse
+	(*SetItemTagsRequest)(nil),              // 54: catalog.SetItemTagsRequest
+	(*SetItemTagsResponse)(nil),             // 55: catalog.SetItemTagsResponse
+	(*TagCount)(nil),                        // 56: catalog.TagCount
+	(*ListTagsRequest)(nil),                 // 57: catalog.ListTagsRequest
+	(*ListTagsResponse)(nil),                // 58: catalog.ListTagsResponse
+	(*ImportCatalogItemsRequest)(nil),       // 59: catalog.ImportCatalogItemsRequest
+	(*ImportRowError)(nil),                  // 60: catalog.ImportRowError
+	(*ImportCatalogItemsResponse)(nil),      // 61: catalog.ImportCatalogItemsResponse
+	(*ExportCatalogItemsRequest)(nil),       // 62: catalog.ExportCatalogItemsRequest
+	(*ExportCatalogItemsResponse)(nil),      // 63: catalog.ExportCatalogItemsResponse
+	(*CatalogEvent)(nil),                    // 64: catalog.CatalogEvent
+	(*WatchCatalogRequest)(nil),             // 65: catalog.WatchCatalogRequest
+	(*PriceChange)(nil),                     // 66: catalog.PriceChange
+	(*GetPriceHistoryRequest)(nil),          // 67: catalog.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),         // 68: catalog.GetPriceHistoryResponse
+	(*ScheduledPrice)(nil),                  // 69: catalog.ScheduledPrice
+	(*SchedulePriceChangeRequest)(nil),      // 70: catalog.SchedulePriceChangeRequest
+	(*SchedulePriceChangeResponse)(nil),     // 71: catalog.SchedulePriceChangeResponse
+	(*ListScheduledPricesRequest)(nil),      // 72: catalog.ListScheduledPricesRequest
+	(*ListScheduledPricesResponse)(nil),     // 73: catalog.ListScheduledPricesResponse
+	(*CancelScheduledPriceRequest)(nil),     // 74: catalog.CancelScheduledPriceRequest
+	(*CancelScheduledPriceResponse)(nil),    // 75: catalog.CancelScheduledPriceResponse
+	(*StockMovement)(nil),                   // 76: catalog.StockMovement
+	(*ListStockMovementsRequest)(nil),       // 77: catalog.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil),      // 78: catalog.ListStockMovementsResponse
+	(*StockDiscrepancy)(nil),                // 79: catalog.StockDiscrepancy
+	(*ReconcileStockRequest)(nil),           // 80: catalog.ReconcileStockRequest
+	(*ReconcileStockResponse)(nil),          // 81: catalog.ReconcileStockResponse
+	(*Location)(nil),                        // 82: catalog.Location
+	(*Warehouse)(nil),                       // 83: catalog.Warehouse
+	(*WarehouseStock)(nil),                  // 84: catalog.WarehouseStock
+	(*CreateWarehouseRequest)(nil),          // 85: catalog.CreateWarehouseRequest
+	(*CreateWarehouseResponse)(nil),         // 86: catalog.CreateWarehouseResponse
+	(*ListWarehousesRequest)(nil),           // 87: catalog.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),          // 88: catalog.ListWarehousesResponse
+	(*GetItemStockRequest)(nil),             // 89: catalog.GetItemStockRequest
+	(*GetItemStockResponse)(nil),            // 90: catalog.GetItemStockResponse
+	(*TransferStockRequest)(nil),            // 91: catalog.TransferStockRequest
+	(*TransferStockResponse)(nil),           // 92: catalog.TransferStockResponse
+	(*StockAlert)(nil),                      // 93: catalog.StockAlert
+	(*SetReorderThresholdRequest)(nil),      // 94: catalog.SetReorderThresholdRequest
+	(*SetReorderThresholdResponse)(nil),     // 95: catalog.SetReorderThresholdResponse
+	(*ListStockAlertsRequest)(nil),          // 96: catalog.ListStockAlertsRequest
+	(*ListStockAlertsResponse)(nil),         // 97: catalog.ListStockAlertsResponse
+	(*ReorderSuggestion)(nil),               // 98: catalog.ReorderSuggestion
+	(*GetReorderSuggestionsRequest)(nil),    // 99: catalog.GetReorderSuggestionsRequest
+	(*GetReorderSuggestionsResponse)(nil),   // 100: catalog.GetReorderSuggestionsResponse
+	(*ItemImage)(nil),                       // 101: catalog.ItemImage
+	(*AddItemImageRequest)(nil),             // 102: catalog.AddItemImageRequest
+	(*AddItemImageResponse)(nil),            // 103: catalog.AddItemImageResponse
+	(*RemoveItemImageRequest)(nil),          // 104: catalog.RemoveItemImageRequest
+	(*RemoveItemImageResponse)(nil),         // 105: catalog.RemoveItemImageResponse
+	(*ReorderItemImagesRequest)(nil),        // 106: catalog.ReorderItemImagesRequest
+	(*ReorderItemImagesResponse)(nil),       // 107: catalog.ReorderItemImagesResponse
+	(*GetImageRequest)(nil),                 // 108: catalog.GetImageRequest
+	(*GetImageResponse)(nil),                // 109: catalog.GetImageResponse
+	(*Review)(nil),                          // 110: catalog.Review
+	(*SubmitReviewRequest)(nil),             // 111: catalog.SubmitReviewRequest
+	(*SubmitReviewResponse)(nil),            // 112: catalog.SubmitReviewResponse
+	(*ListReviewsRequest)(nil),              // 113: catalog.ListReviewsRequest
+	(*ListReviewsResponse)(nil),             // 114: catalog.ListReviewsResponse
+	(*ListPendingReviewsRequest)(nil),       // 115: catalog.ListPendingReviewsRequest
+	(*ListPendingReviewsResponse)(nil),      // 116: catalog.ListPendingReviewsResponse
+	(*ModerateReviewRequest)(nil),           // 117: catalog.ModerateReviewRequest
+	(*ModerateReviewResponse)(nil),          // 118: catalog.ModerateReviewResponse
+	nil,                                     // 119: catalog.CatalogItem.AttributesEntry
+	nil,                                     // 120: catalog.UpdateCatalogItemRequest.AttributesEntry
+	nil,                                     // 121: catalog.ResolveLegacyItemIDsResponse.ItemIdsEntry
 }
 var file_proto_catalog_catalog_proto_depIdxs = []int32{
-	0,  // 0: catalog.AddCatalogItemRequest.item:type_name -> catalog.CatalogItem
-	0,  // 1: catalog.GetCatalogItemResponse.item:type_name -> catalog.CatalogItem
-	0,  // 2: catalog.ListCatalogItemsResponse.items:type_name -> catalog.CatalogItem
-	1,  // 3: catalog.CatalogService.AddCatalogItem:input_type -> catalog.AddCatalogItemRequest
-	3,  // 4: catalog.CatalogService.RemoveCatalogItem:input_type -> catalog.RemoveCatalogItemRequest
-	5,  // 5: catalog.CatalogService.GetCatalogItem:input_type -> catalog.GetCatalogItemRequest
-	7,  // 6: catalog.CatalogService.UpdateQuantityAvailable:input_type -> catalog.UpdateQuantityAvailableRequest
-	9,  // 7: catalog.CatalogService.UpdatePrice:input_type -> catalog.UpdatePriceRequest
-	11, // 8: catalog.CatalogService.ListCatalogItems:input_type -> catalog.ListCatalogItemsRequest
-	2,  // 9: catalog.CatalogService.AddCatalogItem:output_type -> catalog.AddCatalogItemResponse
-	4,  // 10: catalog.CatalogService.RemoveCatalogItem:output_type -> catalog.RemoveCatalogItemResponse
-	6,  // 11: catalog.CatalogService.GetCatalogItem:output_type -> catalog.GetCatalogItemResponse
-	8,  // 12: catalog.CatalogService.UpdateQuantityAvailable:output_type -> catalog.UpdateQuantityAvailableResponse
-	10, // 13: catalog.CatalogService.UpdatePrice:output_type -> catalog.UpdatePriceResponse
-	12, // 14: catalog.CatalogService.ListCatalogItems:output_type -> catalog.ListCatalogItemsResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	119, // 0: catalog.CatalogItem.attributes:type_name -> catalog.CatalogItem.AttributesEntry
+	8,   // 1: catalog.CatalogItem.variants:type_name -> catalog.CatalogItem
+	101, // 2: catalog.CatalogItem.images:type_name -> catalog.ItemImage
+	8,   // 3: catalog.AddCatalogItemRequest.item:type_name -> catalog.CatalogItem
+	8,   // 4: catalog.GetCatalogItemResponse.item:type_name -> catalog.CatalogItem
+	8,   // 5: catalog.GetCatalogItemsResponse.items:type_name -> catalog.CatalogItem
+	120, // 6: catalog.UpdateCatalogItemRequest.attributes:type_name -> catalog.UpdateCatalogItemRequest.AttributesEntry
+	6,   // 7: catalog.UpdateQuantityAvailableRequest.reason:type_name -> catalog.StockMovementReason
+	0,   // 8: catalog.ListCatalogItemsRequest.sort:type_name -> catalog.CatalogSort
+	8,   // 9: catalog.ListCatalogItemsResponse.items:type_name -> catalog.CatalogItem
+	25,  // 10: catalog.ReserveStockRequest.items:type_name -> catalog.StockItem
+	1,   // 11: catalog.ReserveStockRequest.strategy:type_name -> catalog.AllocationStrategy
+	82,  // 12: catalog.ReserveStockRequest.destination:type_name -> catalog.Location
+	26,  // 13: catalog.ReserveStockResponse.allocations:type_name -> catalog.StockAllocation
+	25,  // 14: catalog.RestockItemsRequest.items:type_name -> catalog.StockItem
+	8,   // 15: catalog.SearchHit.item:type_name -> catalog.CatalogItem
+	36,  // 16: catalog.SearchHit.highlights:type_name -> catalog.Highlight
+	37,  // 17: catalog.SearchCatalogResponse.hits:type_name -> catalog.SearchHit
+	121, // 18: catalog.ResolveLegacyItemIDsResponse.item_ids:type_name -> catalog.ResolveLegacyItemIDsResponse.ItemIdsEntry
+	41,  // 19: catalog.ListCategoriesResponse.categories:type_name -> catalog.Category
+	56,  // 20: catalog.ListTagsResponse.tags:type_name -> catalog.TagCount
+	2,   // 21: catalog.ImportCatalogItemsRequest.format:type_name -> catalog.CatalogFileFormat
+	60,  // 22: catalog.ImportCatalogItemsResponse.errors:type_name -> catalog.ImportRowError
+	2,   // 23: catalog.ExportCatalogItemsRequest.format:type_name -> catalog.CatalogFileFormat
+	3,   // 24: catalog.CatalogEvent.type:type_name -> catalog.CatalogEventType
+	8,   // 25: catalog.CatalogEvent.item:type_name -> catalog.CatalogItem
+	93,  // 26: catalog.CatalogEvent.alert:type_name -> catalog.StockAlert
+	4,   // 27: catalog.PriceChange.reason:type_name -> catalog.PriceChangeReason
+	66,  // 28: catalog.GetPriceHistoryResponse.changes:type_name -> catalog.PriceChange
+	5,   // 29: catalog.ScheduledPrice.status:type_name -> catalog.ScheduledPriceStatus
+	69,  // 30: catalog.ListScheduledPricesResponse.schedules:type_name -> catalog.ScheduledPrice
+	6,   // 31: catalog.StockMovement.reason:type_name -> catalog.StockMovementReason
+	76,  // 32: catalog.ListStockMovementsResponse.movements:type_name -> catalog.StockMovement
+	79,  // 33: catalog.ReconcileStockResponse.discrepancies:type_name -> catalog.StockDiscrepancy
+	82,  // 34: catalog.Warehouse.location:type_name -> catalog.Location
+	83,  // 35: catalog.CreateWarehouseRequest.warehouse:type_name -> catalog.Warehouse
+	83,  // 36: catalog.ListWarehousesResponse.warehouses:type_name -> catalog.Warehouse
+	84,  // 37: catalog.GetItemStockResponse.stock:type_name -> catalog.WarehouseStock
+	93,  // 38: catalog.ListStockAlertsResponse.alerts:type_name -> catalog.StockAlert
+	98,  // 39: catalog.GetReorderSuggestionsResponse.suggestions:type_name -> catalog.ReorderSuggestion
+	101, // 40: catalog.AddItemImageResponse.image:type_name -> catalog.ItemImage
+	7,   // 41: catalog.Review.status:type_name -> catalog.ReviewStatus
+	110, // 42: catalog.SubmitReviewResponse.review:type_name -> catalog.Review
+	110, // 43: catalog.ListReviewsResponse.reviews:type_name -> catalog.Review
+	110, // 44: catalog.ListPendingReviewsResponse.reviews:type_name -> catalog.Review
+	9,   // 45: catalog.CatalogService.AddCatalogItem:input_type -> catalog.AddCatalogItemRequest
+	11,  // 46: catalog.CatalogService.RemoveCatalogItem:input_type -> catalog.RemoveCatalogItemRequest
+	13,  // 47: catalog.CatalogService.GetCatalogItem:input_type -> catalog.GetCatalogItemRequest
+	19,  // 48: catalog.CatalogService.UpdateQuantityAvailable:input_type -> catalog.UpdateQuantityAvailableRequest
+	21,  // 49: catalog.CatalogService.UpdatePrice:input_type -> catalog.UpdatePriceRequest
+	23,  // 50: catalog.CatalogService.ListCatalogItems:input_type -> catalog.ListCatalogItemsRequest
+	27,  // 51: catalog.CatalogService.ReserveStock:input_type -> catalog.ReserveStockRequest
+	29,  // 52: catalog.CatalogService.CommitReservation:input_type -> catalog.CommitReservationRequest
+	31,  // 53: catalog.CatalogService.ReleaseReservation:input_type -> catalog.ReleaseReservationRequest
+	33,  // 54: catalog.CatalogService.RestockItems:input_type -> catalog.RestockItemsRequest
+	35,  // 55: catalog.CatalogService.SearchCatalog:input_type -> catalog.SearchCatalogRequest
+	15,  // 56: catalog.CatalogService.GetCatalogItems:input_type -> catalog.GetCatalogItemsRequest
+	17,  // 57: catalog.CatalogService.UpdateCatalogItem:input_type -> catalog.UpdateCatalogItemRequest
+	39,  // 58: catalog.CatalogService.ResolveLegacyItemIDs:input_type -> catalog.ResolveLegacyItemIDsRequest
+	42,  // 59: catalog.CatalogService.CreateCategory:input_type -> catalog.CreateCategoryRequest
+	44,  // 60: catalog.CatalogService.UpdateCategory:input_type -> catalog.UpdateCategoryRequest
+	46,  // 61: catalog.CatalogService.MoveCategory:input_type -> catalog.MoveCategoryRequest
+	48,  // 62: catalog.CatalogService.DeleteCategory:input_type -> catalog.DeleteCategoryRequest
+	50,  // 63: catalog.CatalogService.ListCategories:input_type -> catalog.ListCategoriesRequest
+	52,  // 64: catalog.CatalogService.SetItemCategory:input_type -> catalog.SetItemCategoryRequest
+	54,  // 65: catalog.CatalogService.SetItemTags:input_type -> catalog.SetItemTagsRequest
+	57,  // 66: catalog.CatalogService.ListTags:input_type -> catalog.ListTagsRequest
+	59,  // 67: catalog.CatalogService.ImportCatalogItems:input_type -> catalog.ImportCatalogItemsRequest
+	62,  // 68: catalog.CatalogService.ExportCatalogItems:input_type -> catalog.ExportCatalogItemsRequest
+	65,  // 69: catalog.CatalogService.WatchCatalog:input_type -> catalog.WatchCatalogRequest
+	67,  // 70: catalog.CatalogService.GetPriceHistory:input_type -> catalog.GetPriceHistoryRequest
+	70,  // 71: catalog.CatalogService.SchedulePriceChange:input_type -> catalog.SchedulePriceChangeRequest
+	72,  // 72: catalog.CatalogService.ListScheduledPrices:input_type -> catalog.ListScheduledPricesRequest
+	74,  // 73: catalog.CatalogService.CancelScheduledPrice:input_type -> catalog.CancelScheduledPriceRequest
+	77,  // 74: catalog.CatalogService.ListStockMovements:input_type -> catalog.ListStockMovementsRequest
+	80,  // 75: catalog.CatalogService.ReconcileStock:input_type -> catalog.ReconcileStockRequest
+	85,  // 76: catalog.CatalogService.CreateWarehouse:input_type -> catalog.CreateWarehouseRequest
+	87,  // 77: catalog.CatalogService.ListWarehouses:input_type -> catalog.ListWarehousesRequest
+	89,  // 78: catalog.CatalogService.GetItemStock:input_type -> catalog.GetItemStockRequest
+	91,  // 79: catalog.CatalogService.TransferStock:input_type -> catalog.TransferStockRequest
+	94,  // 80: catalog.CatalogService.SetReorderThreshold:input_type -> catalog.SetReorderThresholdRequest
+	96,  // 81: catalog.CatalogService.ListStockAlerts:input_type -> catalog.ListStockAlertsRequest
+	99,  // 82: catalog.CatalogService.GetReorderSuggestions:input_type -> catalog.GetReorderSuggestionsRequest
+	102, // 83: catalog.CatalogService.AddItemImage:input_type -> catalog.AddItemImageRequest
+	104, // 84: catalog.CatalogService.RemoveItemImage:input_type -> catalog.RemoveItemImageRequest
+	106, // 85: catalog.CatalogService.ReorderItemImages:input_type -> catalog.ReorderItemImagesRequest
+	108, // 86: catalog.CatalogService.GetImage:input_type -> catalog.GetImageRequest
+	111, // 87: catalog.CatalogService.SubmitReview:input_type -> catalog.SubmitReviewRequest
+	113, // 88: catalog.CatalogService.ListReviews:input_type -> catalog.ListReviewsRequest
+	115, // 89: catalog.CatalogService.ListPendingReviews:input_type -> catalog.ListPendingReviewsRequest
+	117, // 90: catalog.CatalogService.ModerateReview:input_type -> catalog.ModerateReviewRequest
+	10,  // 91: catalog.CatalogService.AddCatalogItem:output_type -> catalog.AddCatalogItemResponse
+	12,  // 92: catalog.CatalogService.RemoveCatalogItem:output_type -> catalog.RemoveCatalogItemResponse
+	14,  // 93: catalog.CatalogService.GetCatalogItem:output_type -> catalog.GetCatalogItemResponse
+	20,  // 94: catalog.CatalogService.UpdateQuantityAvailable:output_type -> catalog.UpdateQuantityAvailableResponse
+	22,  // 95: catalog.CatalogService.UpdatePrice:output_type -> catalog.UpdatePriceResponse
+	24,  // 96: catalog.CatalogService.ListCatalogItems:output_type -> catalog.ListCatalogItemsResponse
+	28,  // 97: catalog.CatalogService.ReserveStock:output_type -> catalog.ReserveStockResponse
+	30,  // 98: catalog.CatalogService.CommitReservation:output_type -> catalog.CommitReservationResponse
+	32,  // 99: catalog.CatalogService.ReleaseReservation:output_type -> catalog.ReleaseReservationResponse
+	34,  // 100: catalog.CatalogService.RestockItems:output_type -> catalog.RestockItemsResponse
+	38,  // 101: catalog.CatalogService.SearchCatalog:output_type -> catalog.SearchCatalogResponse
+	16,  // 102: catalog.CatalogService.GetCatalogItems:output_type -> catalog.GetCatalogItemsResponse
+	18,  // 103: catalog.CatalogService.UpdateCatalogItem:output_type -> catalog.UpdateCatalogItemResponse
+	40,  // 104: catalog.CatalogService.ResolveLegacyItemIDs:output_type -> catalog.ResolveLegacyItemIDsResponse
+	43,  // 105: catalog.CatalogService.CreateCategory:output_type -> catalog.CreateCategoryResponse
+	45,  // 106: catalog.CatalogService.UpdateCategory:output_type -> catalog.UpdateCategoryResponse
+	47,  // 107: catalog.CatalogService.MoveCategory:output_type -> catalog.MoveCategoryResponse
+	49,  // 108: catalog.CatalogService.DeleteCategory:output_type -> catalog.DeleteCategoryResponse
+	51,  // 109: catalog.CatalogService.ListCategories:output_type -> catalog.ListCategoriesResponse
+	53,  // 110: catalog.CatalogService.SetItemCategory:output_type -> catalog.SetItemCategoryResponse
+	55,  // 111: catalog.CatalogService.SetItemTags:output_type -> catalog.SetItemTagsResponse
+	58,  // 112: catalog.CatalogService.ListTags:output_type -> catalog.ListTagsResponse
+	61,  // 113: catalog.CatalogService.ImportCatalogItems:output_type -> catalog.ImportCatalogItemsResponse
+	63,  // 114: catalog.CatalogService.ExportCatalogItems:output_type -> catalog.ExportCatalogItemsResponse
+	64,  // 115: catalog.CatalogService.WatchCatalog:output_type -> catalog.CatalogEvent
+	68,  // 116: catalog.CatalogService.GetPriceHistory:output_type -> catalog.GetPriceHistoryResponse
+	71,  // 117: catalog.CatalogService.SchedulePriceChange:output_type -> catalog.SchedulePriceChangeResponse
+	73,  // 118: catalog.CatalogService.ListScheduledPrices:output_type -> catalog.ListScheduledPricesResponse
+	75,  // 119: catalog.CatalogService.CancelScheduledPrice:output_type -> catalog.CancelScheduledPriceResponse
+	78,  // 120: catalog.CatalogService.ListStockMovements:output_type -> catalog.ListStockMovementsResponse
+	81,  // 121: catalog.CatalogService.ReconcileStock:output_type -> catalog.ReconcileStockResponse
+	86,  // 122: catalog.CatalogService.CreateWarehouse:output_type -> catalog.CreateWarehouseResponse
+	88,  // 123: catalog.CatalogService.ListWarehouses:output_type -> catalog.ListWarehousesResponse
+	90,  // 124: catalog.CatalogService.GetItemStock:output_type -> catalog.GetItemStockResponse
+	92,  // 125: catalog.CatalogService.TransferStock:output_type -> catalog.TransferStockResponse
+	95,  // 126: catalog.CatalogService.SetReorderThreshold:output_type -> catalog.SetReorderThresholdResponse
+	97,  // 127: catalog.CatalogService.ListStockAlerts:output_type -> catalog.ListStockAlertsResponse
+	100, // 128: catalog.CatalogService.GetReorderSuggestions:output_type -> catalog.GetReorderSuggestionsResponse
+	103, // 129: catalog.CatalogService.AddItemImage:output_type -> catalog.AddItemImageResponse
+	105, // 130: catalog.CatalogService.RemoveItemImage:output_type -> catalog.RemoveItemImageResponse
+	107, // 131: catalog.CatalogService.ReorderItemImages:output_type -> catalog.ReorderItemImagesResponse
+	109, // 132: catalog.CatalogService.GetImage:output_type -> catalog.GetImageResponse
+	112, // 133: catalog.CatalogService.SubmitReview:output_type -> catalog.SubmitReviewResponse
+	114, // 134: catalog.CatalogService.ListReviews:output_type -> catalog.ListReviewsResponse
+	116, // 135: catalog.CatalogService.ListPendingReviews:output_type -> catalog.ListPendingReviewsResponse
+	118, // 136: catalog.CatalogService.ModerateReview:output_type -> catalog.ModerateReviewResponse
+	91,  // [91:137] is the sub-list for method output_type
+	45,  // [45:91] is the sub-list for method input_type
+	45,  // [45:45] is the sub-list for extension type_name
+	45,  // [45:45] is the sub-list for extension extendee
+	0,   // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_proto_catalog_catalog_proto_init() }
@@ -768,13 +7641,14 @@ func file_proto_catalog_catalog_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_catalog_catalog_proto_rawDesc), len(file_proto_catalog_catalog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      8,
+			NumMessages:   114,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_catalog_catalog_proto_goTypes,
 		DependencyIndexes: file_proto_catalog_catalog_proto_depIdxs,
+		EnumInfos:         file_proto_catalog_catalog_proto_enumTypes,
 		MessageInfos:      file_proto_catalog_catalog_proto_msgTypes,
 	}.Build()
 	File_proto_catalog_catalog_proto = out.File
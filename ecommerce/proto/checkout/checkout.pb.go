@@ -0,0 +1,501 @@
+// Copyright 2015 gRPC authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: proto/checkout/checkout.proto
+
+package checkout
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CheckoutStatus int32
+
+const (
+	CheckoutStatus_RUNNING      CheckoutStatus = 0
+	CheckoutStatus_COMPENSATING CheckoutStatus = 1
+	CheckoutStatus_COMPLETED    CheckoutStatus = 2
+	CheckoutStatus_FAILED       CheckoutStatus = 3
+)
+
+// Enum value maps for CheckoutStatus.
+var (
+	CheckoutStatus_name = map[int32]string{
+		0: "RUNNING",
+		1: "COMPENSATING",
+		2: "COMPLETED",
+		3: "FAILED",
+	}
+	CheckoutStatus_value = map[string]int32{
+		"RUNNING":      0,
+		"COMPENSATING": 1,
+		"COMPLETED":    2,
+		"FAILED":       3,
+	}
+)
+
+func (x CheckoutStatus) Enum() *CheckoutStatus {
+	p := new(CheckoutStatus)
+	*p = x
+	return p
+}
+
+func (x CheckoutStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckoutStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_checkout_checkout_proto_enumTypes[0].Descriptor()
+}
+
+func (CheckoutStatus) Type() protoreflect.EnumType {
+	return &file_proto_checkout_checkout_proto_enumTypes[0]
+}
+
+func (x CheckoutStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckoutStatus.Descriptor instead.
+func (CheckoutStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{0}
+}
+
+// Where the order is shipped, the stock is taken from the nearest warehouses
+type Destination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Destination) Reset() {
+	*x = Destination{}
+	mi := &file_proto_checkout_checkout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Destination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Destination) ProtoMessage() {}
+
+func (x *Destination) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_checkout_checkout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Destination.ProtoReflect.Descriptor instead.
+func (*Destination) Descriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Destination) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Destination) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// START CHECKOUT OF THE CART
+// Without a destination the stock is taken from the fewest warehouses
+type CheckoutRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Amount         float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CardNumber     string                 `protobuf:"bytes,3,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Destination    *Destination           `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_proto_checkout_checkout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_checkout_checkout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckoutRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CheckoutRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetDestination() *Destination {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_proto_checkout_checkout_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_checkout_checkout_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckoutResponse) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// POLL THE RESULT OF A CHECKOUT
+type GetCheckoutStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutStatusRequest) Reset() {
+	*x = GetCheckoutStatusRequest{}
+	mi := &file_proto_checkout_checkout_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutStatusRequest) ProtoMessage() {}
+
+func (x *GetCheckoutStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_checkout_checkout_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCheckoutStatusRequest) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+type GetCheckoutStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	Status        CheckoutStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=checkout.CheckoutStatus" json:"status,omitempty"`
+	CurrentStep   string                 `protobuf:"bytes,3,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	FailureReason string                 `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutStatusResponse) Reset() {
+	*x = GetCheckoutStatusResponse{}
+	mi := &file_proto_checkout_checkout_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutStatusResponse) ProtoMessage() {}
+
+func (x *GetCheckoutStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_checkout_checkout_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_checkout_checkout_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCheckoutStatusResponse) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+func (x *GetCheckoutStatusResponse) GetStatus() CheckoutStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CheckoutStatus_RUNNING
+}
+
+func (x *GetCheckoutStatusResponse) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+func (x *GetCheckoutStatusResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetCheckoutStatusResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *GetCheckoutStatusResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+var File_proto_checkout_checkout_proto protoreflect.FileDescriptor
+
+const file_proto_checkout_checkout_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/checkout/checkout.proto\x12\bcheckout\"G\n" +
+	"\vDestination\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xc8\x01\n" +
+	"\x0fCheckoutRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1f\n" +
+	"\vcard_number\x18\x03 \x01(\tR\n" +
+	"cardNumber\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x127\n" +
+	"\vdestination\x18\x05 \x01(\v2\x15.checkout.DestinationR\vdestination\"X\n" +
+	"\x10CheckoutResponse\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\tR\n" +
+	"checkoutId\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\";\n" +
+	"\x18GetCheckoutStatusRequest\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\tR\n" +
+	"checkoutId\"\xf8\x01\n" +
+	"\x19GetCheckoutStatusResponse\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\tR\n" +
+	"checkoutId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.checkout.CheckoutStatusR\x06status\x12!\n" +
+	"\fcurrent_step\x18\x03 \x01(\tR\vcurrentStep\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12%\n" +
+	"\x0efailure_reason\x18\x05 \x01(\tR\rfailureReason\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage*J\n" +
+	"\x0eCheckoutStatus\x12\v\n" +
+	"\aRUNNING\x10\x00\x12\x10\n" +
+	"\fCOMPENSATING\x10\x01\x12\r\n" +
+	"\tCOMPLETED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x032\xb2\x01\n" +
+	"\x0fCheckoutService\x12A\n" +
+	"\bCheckout\x12\x19.checkout.CheckoutRequest\x1a\x1a.checkout.CheckoutResponse\x12\\\n" +
+	"\x11GetCheckoutStatus\x12\".checkout.GetCheckoutStatusRequest\x1a#.checkout.GetCheckoutStatusResponseB`Z^github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout;checkoutb\x06proto3"
+
+var (
+	file_proto_checkout_checkout_proto_rawDescOnce sync.Once
+	file_proto_checkout_checkout_proto_rawDescData []byte
+)
+
+func file_proto_checkout_checkout_proto_rawDescGZIP() []byte {
+	file_proto_checkout_checkout_proto_rawDescOnce.Do(func() {
+		file_proto_checkout_checkout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_checkout_checkout_proto_rawDesc), len(file_proto_checkout_checkout_proto_rawDesc)))
+	})
+	return file_proto_checkout_checkout_proto_rawDescData
+}
+
+var file_proto_checkout_checkout_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_checkout_checkout_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_checkout_checkout_proto_goTypes = []any{
+	(CheckoutStatus)(0),               // 0: checkout.CheckoutStatus
+	(*Destination)(nil),               // 1: checkout.Destination
+	(*CheckoutRequest)(nil),           // 2: checkout.CheckoutRequest
+	(*CheckoutResponse)(nil),          // 3: checkout.CheckoutResponse
+	(*GetCheckoutStatusRequest)(nil),  // 4: checkout.GetCheckoutStatusRequest
+	(*GetCheckoutStatusResponse)(nil), // 5: checkout.GetCheckoutStatusResponse
+}
+var file_proto_checkout_checkout_proto_depIdxs = []int32{
+	1, // 0: checkout.CheckoutRequest.destination:type_name -> checkout.Destination
+	0, // 1: checkout.GetCheckoutStatusResponse.status:type_name -> checkout.CheckoutStatus
+	2, // 2: checkout.CheckoutService.Checkout:input_type -> checkout.CheckoutRequest
+	4, // 3: checkout.CheckoutService.GetCheckoutStatus:input_type -> checkout.GetCheckoutStatusRequest
+	3, // 4: checkout.CheckoutService.Checkout:output_type -> checkout.CheckoutResponse
+	5, // 5: checkout.CheckoutService.GetCheckoutStatus:output_type -> checkout.GetCheckoutStatusResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_checkout_checkout_proto_init() }
+func file_proto_checkout_checkout_proto_init() {
+	if File_proto_checkout_checkout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_checkout_checkout_proto_rawDesc), len(file_proto_checkout_checkout_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_checkout_checkout_proto_goTypes,
+		DependencyIndexes: file_proto_checkout_checkout_proto_depIdxs,
+		EnumInfos:         file_proto_checkout_checkout_proto_enumTypes,
+		MessageInfos:      file_proto_checkout_checkout_proto_msgTypes,
+	}.Build()
+	File_proto_checkout_checkout_proto = out.File
+	file_proto_checkout_checkout_proto_goTypes = nil
+	file_proto_checkout_checkout_proto_depIdxs = nil
+}
@@ -91,11 +91,13 @@ func (OrderStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 // ORDER ITEM
+// sku is the SKU of the catalog item, the chosen variant of a product
 type OrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
 	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -151,6 +153,13 @@ func (x *OrderItem) GetPrice() float64 {
 	return 0
 }
 
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
 // ORDER
 type Order struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -222,11 +231,12 @@ func (x *Order) GetStatus() OrderStatus {
 
 // CREATE ORDER
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	OrderItems    []*OrderItem           `protobuf:"bytes,2,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderItems     []*OrderItem           `protobuf:"bytes,2,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
@@ -273,6 +283,13 @@ func (x *CreateOrderRequest) GetOrderItems() []*OrderItem {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -713,24 +730,587 @@ func (x *ListOrdersByUserResponse) GetErrorMessage() string {
 	return ""
 }
 
+// STATUS HISTORY OF AN ORDER
+type OrderStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        OrderStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
+	ChangedAt     int64                  `protobuf:"varint,2,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusChange) Reset() {
+	*x = OrderStatusChange{}
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChange) ProtoMessage() {}
+
+func (x *OrderStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChange.ProtoReflect.Descriptor instead.
+func (*OrderStatusChange) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderStatusChange) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *OrderStatusChange) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *OrderStatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderHistoryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*OrderStatusChange   `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetOrderHistoryResponse) GetHistory() []*OrderStatusChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *GetOrderHistoryResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// CANCEL ORDER
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelOrderResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// UNITS OF EACH ITEM SOLD BY THE ORDERS CREATED SINCE A TIME, CANCELED ORDERS EXCLUDED
+type ItemSales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemSales) Reset() {
+	*x = ItemSales{}
+	mi := &file_proto_order_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemSales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemSales) ProtoMessage() {}
+
+func (x *ItemSales) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemSales.ProtoReflect.Descriptor instead.
+func (*ItemSales) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ItemSales) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemSales) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetItemSalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         int64                  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemSalesRequest) Reset() {
+	*x = GetItemSalesRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemSalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemSalesRequest) ProtoMessage() {}
+
+func (x *GetItemSalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemSalesRequest.ProtoReflect.Descriptor instead.
+func (*GetItemSalesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetItemSalesRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type GetItemSalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sales         []*ItemSales           `protobuf:"bytes,1,rep,name=sales,proto3" json:"sales,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemSalesResponse) Reset() {
+	*x = GetItemSalesResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemSalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemSalesResponse) ProtoMessage() {}
+
+func (x *GetItemSalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemSalesResponse.ProtoReflect.Descriptor instead.
+func (*GetItemSalesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetItemSalesResponse) GetSales() []*ItemSales {
+	if x != nil {
+		return x.Sales
+	}
+	return nil
+}
+
+func (x *GetItemSalesResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// ITEMS BOUGHT TOGETHER WITH AN ITEM, OR WITH THE ITEMS BOUGHT BY A USER, CANCELED ORDERS EXCLUDED
+// orders is the number of orders in which the item was bought together with them
+type Recommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Orders        uint32                 `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_proto_order_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Recommendation) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *Recommendation) GetOrders() uint32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type GetRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRecommendationsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	ErrorMessage    string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsResponse) Reset() {
+	*x = GetRecommendationsResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *GetRecommendationsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 var File_proto_order_order_proto protoreflect.FileDescriptor
 
 const file_proto_order_order_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/order/order.proto\x12\x05order\"V\n" +
+	"\x17proto/order/order.proto\x12\x05order\"h\n" +
 	"\tOrderItem\x12\x17\n" +
 	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\rR\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"\x8f\x01\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\"\x8f\x01\n" +
 	"\x05Order\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
 	"\x05items\x18\x03 \x03(\v2\x10.order.OrderItemR\x05items\x12*\n" +
-	"\x06status\x18\x04 \x01(\x0e2\x12.order.OrderStatusR\x06status\"`\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x12.order.OrderStatusR\x06status\"\x89\x01\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
 	"\vorder_items\x18\x02 \x03(\v2\x10.order.OrderItemR\n" +
-	"orderItems\"U\n" +
+	"orderItems\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"U\n" +
 	"\x13CreateOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12#\n" +
 	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"a\n" +
@@ -754,6 +1334,38 @@ const file_proto_order_order_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"e\n" +
 	"\x18ListOrdersByUserResponse\x12$\n" +
 	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"t\n" +
+	"\x11OrderStatusChange\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.order.OrderStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x02 \x01(\x03R\tchangedAt\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"3\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"r\n" +
+	"\x17GetOrderHistoryResponse\x122\n" +
+	"\ahistory\x18\x01 \x03(\v2\x18.order.OrderStatusChangeR\ahistory\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"/\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\":\n" +
+	"\x13CancelOrderResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"@\n" +
+	"\tItemSales\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\"+\n" +
+	"\x13GetItemSalesRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\"c\n" +
+	"\x14GetItemSalesResponse\x12&\n" +
+	"\x05sales\x18\x01 \x03(\v2\x10.order.ItemSalesR\x05sales\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"A\n" +
+	"\x0eRecommendation\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\rR\x06orders\"c\n" +
+	"\x19GetRecommendationsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"\x82\x01\n" +
+	"\x1aGetRecommendationsResponse\x12?\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x15.order.RecommendationR\x0frecommendations\x12#\n" +
 	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage*T\n" +
 	"\vOrderStatus\x12\v\n" +
 	"\aPENDING\x10\x00\x12\x0e\n" +
@@ -761,13 +1373,17 @@ const file_proto_order_order_proto_rawDesc = "" +
 	"PROCESSING\x10\x01\x12\v\n" +
 	"\aSHIPPED\x10\x02\x12\r\n" +
 	"\tDELIVERED\x10\x03\x12\f\n" +
-	"\bCANCELED\x10\x042\x8a\x03\n" +
+	"\bCANCELED\x10\x042\xc6\x05\n" +
 	"\fOrderService\x12D\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12V\n" +
 	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a .order.UpdateOrderStatusResponse\x12;\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x17.order.GetOrderResponse\x12J\n" +
 	"\rGetOrderPrice\x12\x1b.order.GetOrderPriceRequest\x1a\x1c.order.GetOrderPriceResponse\x12S\n" +
-	"\x10ListOrdersByUser\x12\x1e.order.ListOrdersByUserRequest\x1a\x1f.order.ListOrdersByUserResponseBZZXgithub.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order;orderb\x06proto3"
+	"\x10ListOrdersByUser\x12\x1e.order.ListOrdersByUserRequest\x1a\x1f.order.ListOrdersByUserResponse\x12P\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\x12D\n" +
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x1a.order.CancelOrderResponse\x12G\n" +
+	"\fGetItemSales\x12\x1a.order.GetItemSalesRequest\x1a\x1b.order.GetItemSalesResponse\x12Y\n" +
+	"\x12GetRecommendations\x12 .order.GetRecommendationsRequest\x1a!.order.GetRecommendationsResponseBZZXgithub.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order;orderb\x06proto3"
 
 var (
 	file_proto_order_order_proto_rawDescOnce sync.Once
@@ -782,21 +1398,32 @@ func file_proto_order_order_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_order_order_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_proto_order_order_proto_goTypes = []any{
-	(OrderStatus)(0),                  // 0: order.OrderStatus
-	(*OrderItem)(nil),                 // 1: order.OrderItem
-	(*Order)(nil),                     // 2: order.Order
-	(*CreateOrderRequest)(nil),        // 3: order.CreateOrderRequest
-	(*CreateOrderResponse)(nil),       // 4: order.CreateOrderResponse
-	(*UpdateOrderStatusRequest)(nil),  // 5: order.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 6: order.UpdateOrderStatusResponse
-	(*GetOrderRequest)(nil),           // 7: order.GetOrderRequest
-	(*GetOrderResponse)(nil),          // 8: order.GetOrderResponse
-	(*GetOrderPriceRequest)(nil),      // 9: order.GetOrderPriceRequest
-	(*GetOrderPriceResponse)(nil),     // 10: order.GetOrderPriceResponse
-	(*ListOrdersByUserRequest)(nil),   // 11: order.ListOrdersByUserRequest
-	(*ListOrdersByUserResponse)(nil),  // 12: order.ListOrdersByUserResponse
+	(OrderStatus)(0),                   // 0: order.OrderStatus
+	(*OrderItem)(nil),                  // 1: order.OrderItem
+	(*Order)(nil),                      // 2: order.Order
+	(*CreateOrderRequest)(nil),         // 3: order.CreateOrderRequest
+	(*CreateOrderResponse)(nil),        // 4: order.CreateOrderResponse
+	(*UpdateOrderStatusRequest)(nil),   // 5: order.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),  // 6: order.UpdateOrderStatusResponse
+	(*GetOrderRequest)(nil),            // 7: order.GetOrderRequest
+	(*GetOrderResponse)(nil),           // 8: order.GetOrderResponse
+	(*GetOrderPriceRequest)(nil),       // 9: order.GetOrderPriceRequest
+	(*GetOrderPriceResponse)(nil),      // 10: order.GetOrderPriceResponse
+	(*ListOrdersByUserRequest)(nil),    // 11: order.ListOrdersByUserRequest
+	(*ListOrdersByUserResponse)(nil),   // 12: order.ListOrdersByUserResponse
+	(*OrderStatusChange)(nil),          // 13: order.OrderStatusChange
+	(*GetOrderHistoryRequest)(nil),     // 14: order.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),    // 15: order.GetOrderHistoryResponse
+	(*CancelOrderRequest)(nil),         // 16: order.CancelOrderRequest
+	(*CancelOrderResponse)(nil),        // 17: order.CancelOrderResponse
+	(*ItemSales)(nil),                  // 18: order.ItemSales
+	(*GetItemSalesRequest)(nil),        // 19: order.GetItemSalesRequest
+	(*GetItemSalesResponse)(nil),       // 20: order.GetItemSalesResponse
+	(*Recommendation)(nil),             // 21: order.Recommendation
+	(*GetRecommendationsRequest)(nil),  // 22: order.GetRecommendationsRequest
+	(*GetRecommendationsResponse)(nil), // 23: order.GetRecommendationsResponse
 }
 var file_proto_order_order_proto_depIdxs = []int32{
 	1,  // 0: order.Order.items:type_name -> order.OrderItem
@@ -805,21 +1432,33 @@ var file_proto_order_order_proto_depIdxs = []int32{
 	0,  // 3: order.UpdateOrderStatusRequest.status:type_name -> order.OrderStatus
 	2,  // 4: order.GetOrderResponse.order:type_name -> order.Order
 	2,  // 5: order.ListOrdersByUserResponse.orders:type_name -> order.Order
-	3,  // 6: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	5,  // 7: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
-	7,  // 8: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	9,  // 9: order.OrderService.GetOrderPrice:input_type -> order.GetOrderPriceRequest
-	11, // 10: order.OrderService.ListOrdersByUser:input_type -> order.ListOrdersByUserRequest
-	4,  // 11: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
-	6,  // 12: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
-	8,  // 13: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
-	10, // 14: order.OrderService.GetOrderPrice:output_type -> order.GetOrderPriceResponse
-	12, // 15: order.OrderService.ListOrdersByUser:output_type -> order.ListOrdersByUserResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	0,  // 6: order.OrderStatusChange.status:type_name -> order.OrderStatus
+	13, // 7: order.GetOrderHistoryResponse.history:type_name -> order.OrderStatusChange
+	18, // 8: order.GetItemSalesResponse.sales:type_name -> order.ItemSales
+	21, // 9: order.GetRecommendationsResponse.recommendations:type_name -> order.Recommendation
+	3,  // 10: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	5,  // 11: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	7,  // 12: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	9,  // 13: order.OrderService.GetOrderPrice:input_type -> order.GetOrderPriceRequest
+	11, // 14: order.OrderService.ListOrdersByUser:input_type -> order.ListOrdersByUserRequest
+	14, // 15: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	16, // 16: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	19, // 17: order.OrderService.GetItemSales:input_type -> order.GetItemSalesRequest
+	22, // 18: order.OrderService.GetRecommendations:input_type -> order.GetRecommendationsRequest
+	4,  // 19: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
+	6,  // 20: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
+	8,  // 21: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
+	10, // 22: order.OrderService.GetOrderPrice:output_type -> order.GetOrderPriceResponse
+	12, // 23: order.OrderService.ListOrdersByUser:output_type -> order.ListOrdersByUserResponse
+	15, // 24: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	17, // 25: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
+	20, // 26: order.OrderService.GetItemSales:output_type -> order.GetItemSalesResponse
+	23, // 27: order.OrderService.GetRecommendations:output_type -> order.GetRecommendationsResponse
+	19, // [19:28] is the sub-list for method output_type
+	10, // [10:19] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_order_proto_init() }
@@ -833,7 +1472,7 @@ func file_proto_order_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_order_proto_rawDesc), len(file_proto_order_order_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
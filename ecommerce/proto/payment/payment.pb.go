@@ -38,9 +38,11 @@ const (
 type PaymentStatus int32
 
 const (
-	PaymentStatus_PENDING_PAYMENT PaymentStatus = 0
-	PaymentStatus_PAID            PaymentStatus = 1
-	PaymentStatus_PAYMENT_FAILED  PaymentStatus = 2
+	PaymentStatus_PENDING_PAYMENT    PaymentStatus = 0
+	PaymentStatus_PAID               PaymentStatus = 1
+	PaymentStatus_PAYMENT_FAILED     PaymentStatus = 2
+	PaymentStatus_REFUNDED           PaymentStatus = 3
+	PaymentStatus_PARTIALLY_REFUNDED PaymentStatus = 4
 )
 
 // Enum value maps for PaymentStatus.
@@ -49,11 +51,15 @@ var (
 		0: "PENDING_PAYMENT",
 		1: "PAID",
 		2: "PAYMENT_FAILED",
+		3: "REFUNDED",
+		4: "PARTIALLY_REFUNDED",
 	}
 	PaymentStatus_value = map[string]int32{
-		"PENDING_PAYMENT": 0,
-		"PAID":            1,
-		"PAYMENT_FAILED":  2,
+		"PENDING_PAYMENT":    0,
+		"PAID":               1,
+		"PAYMENT_FAILED":     2,
+		"REFUNDED":           3,
+		"PARTIALLY_REFUNDED": 4,
 	}
 )
 
@@ -84,6 +90,157 @@ func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
 }
 
+// Kind of movement recorded for a payment
+type TransactionType int32
+
+const (
+	TransactionType_ATTEMPT TransactionType = 0
+	TransactionType_CAPTURE TransactionType = 1
+	TransactionType_REFUND  TransactionType = 2
+)
+
+// Enum value maps for TransactionType.
+var (
+	TransactionType_name = map[int32]string{
+		0: "ATTEMPT",
+		1: "CAPTURE",
+		2: "REFUND",
+	}
+	TransactionType_value = map[string]int32{
+		"ATTEMPT": 0,
+		"CAPTURE": 1,
+		"REFUND":  2,
+	}
+)
+
+func (x TransactionType) Enum() *TransactionType {
+	p := new(TransactionType)
+	*p = x
+	return p
+}
+
+func (x TransactionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_payment_proto_enumTypes[1].Descriptor()
+}
+
+func (TransactionType) Type() protoreflect.EnumType {
+	return &file_proto_payment_payment_proto_enumTypes[1]
+}
+
+func (x TransactionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionType.Descriptor instead.
+func (TransactionType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
+}
+
+type PaymentTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Type          TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=payment.TransactionType" json:"type,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Succeeded     bool                   `protobuf:"varint,5,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	FailureReason string                 `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Refund asked to the payment provider, whose outcome is not known yet
+	Pending       bool `protobuf:"varint,8,opt,name=pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentTransaction) Reset() {
+	*x = PaymentTransaction{}
+	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentTransaction) ProtoMessage() {}
+
+func (x *PaymentTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentTransaction.ProtoReflect.Descriptor instead.
+func (*PaymentTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PaymentTransaction) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_ATTEMPT
+}
+
+func (x *PaymentTransaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentTransaction) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *PaymentTransaction) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PaymentTransaction) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
 type Payment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -95,7 +252,7 @@ type Payment struct {
 
 func (x *Payment) Reset() {
 	*x = Payment{}
-	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	mi := &file_proto_payment_payment_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -107,7 +264,7 @@ func (x *Payment) String() string {
 func (*Payment) ProtoMessage() {}
 
 func (x *Payment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[0]
+	mi := &file_proto_payment_payment_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -120,7 +277,7 @@ func (x *Payment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Payment.ProtoReflect.Descriptor instead.
 func (*Payment) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Payment) GetOrderId() string {
@@ -146,16 +303,17 @@ func (x *Payment) GetStatus() PaymentStatus {
 
 // CREATE PAYMENT
 type CreatePaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreatePaymentRequest) Reset() {
 	*x = CreatePaymentRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[1]
+	mi := &file_proto_payment_payment_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -167,7 +325,7 @@ func (x *CreatePaymentRequest) String() string {
 func (*CreatePaymentRequest) ProtoMessage() {}
 
 func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[1]
+	mi := &file_proto_payment_payment_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -180,7 +338,7 @@ func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentRequest.ProtoReflect.Descriptor instead.
 func (*CreatePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreatePaymentRequest) GetOrderId() string {
@@ -197,6 +355,13 @@ func (x *CreatePaymentRequest) GetAmount() float64 {
 	return 0
 }
 
+func (x *CreatePaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreatePaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
@@ -206,7 +371,7 @@ type CreatePaymentResponse struct {
 
 func (x *CreatePaymentResponse) Reset() {
 	*x = CreatePaymentResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[2]
+	mi := &file_proto_payment_payment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +383,7 @@ func (x *CreatePaymentResponse) String() string {
 func (*CreatePaymentResponse) ProtoMessage() {}
 
 func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[2]
+	mi := &file_proto_payment_payment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +396,7 @@ func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentResponse.ProtoReflect.Descriptor instead.
 func (*CreatePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{2}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreatePaymentResponse) GetErrorMessage() string {
@@ -241,18 +406,118 @@ func (x *CreatePaymentResponse) GetErrorMessage() string {
 	return ""
 }
 
-// PROCESS PAYMENT
-type ProcessPaymentRequest struct {
+// TOKENIZE CARD
+// The card is stored by the payment provider, the token charges it without knowing its number
+type TokenizeCardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *TokenizeCardRequest) Reset() {
+	*x = TokenizeCardRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenizeCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenizeCardRequest) ProtoMessage() {}
+
+func (x *TokenizeCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenizeCardRequest.ProtoReflect.Descriptor instead.
+func (*TokenizeCardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TokenizeCardRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+type TokenizeCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardToken     string                 `protobuf:"bytes,1,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenizeCardResponse) Reset() {
+	*x = TokenizeCardResponse{}
+	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenizeCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenizeCardResponse) ProtoMessage() {}
+
+func (x *TokenizeCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenizeCardResponse.ProtoReflect.Descriptor instead.
+func (*TokenizeCardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TokenizeCardResponse) GetCardToken() string {
+	if x != nil {
+		return x.CardToken
+	}
+	return ""
+}
+
+func (x *TokenizeCardResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// PROCESS PAYMENT
+type ProcessPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	CardToken      string                 `protobuf:"bytes,5,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *ProcessPaymentRequest) Reset() {
 	*x = ProcessPaymentRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[3]
+	mi := &file_proto_payment_payment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -264,7 +529,7 @@ func (x *ProcessPaymentRequest) String() string {
 func (*ProcessPaymentRequest) ProtoMessage() {}
 
 func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[3]
+	mi := &file_proto_payment_payment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,7 +542,7 @@ func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{3}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ProcessPaymentRequest) GetOrderId() string {
@@ -294,6 +559,20 @@ func (x *ProcessPaymentRequest) GetAmount() float64 {
 	return 0
 }
 
+func (x *ProcessPaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ProcessPaymentRequest) GetCardToken() string {
+	if x != nil {
+		return x.CardToken
+	}
+	return ""
+}
+
 type ProcessPaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
@@ -303,7 +582,7 @@ type ProcessPaymentResponse struct {
 
 func (x *ProcessPaymentResponse) Reset() {
 	*x = ProcessPaymentResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	mi := &file_proto_payment_payment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +594,7 @@ func (x *ProcessPaymentResponse) String() string {
 func (*ProcessPaymentResponse) ProtoMessage() {}
 
 func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[4]
+	mi := &file_proto_payment_payment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +607,7 @@ func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{4}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ProcessPaymentResponse) GetErrorMessage() string {
@@ -348,7 +627,7 @@ type GetPaymentStatusRequest struct {
 
 func (x *GetPaymentStatusRequest) Reset() {
 	*x = GetPaymentStatusRequest{}
-	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_proto_payment_payment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +639,7 @@ func (x *GetPaymentStatusRequest) String() string {
 func (*GetPaymentStatusRequest) ProtoMessage() {}
 
 func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_proto_payment_payment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +652,7 @@ func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetPaymentStatusRequest) GetOrderId() string {
@@ -393,7 +672,7 @@ type GetPaymentStatusResponse struct {
 
 func (x *GetPaymentStatusResponse) Reset() {
 	*x = GetPaymentStatusResponse{}
-	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_proto_payment_payment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -405,7 +684,7 @@ func (x *GetPaymentStatusResponse) String() string {
 func (*GetPaymentStatusResponse) ProtoMessage() {}
 
 func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_proto_payment_payment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -418,7 +697,7 @@ func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPaymentStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_payment_proto_rawDescGZIP(), []int{6}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPaymentStatusResponse) GetStatus() PaymentStatus {
@@ -435,38 +714,292 @@ func (x *GetPaymentStatusResponse) GetErrorMessage() string {
 	return ""
 }
 
+// REFUND PAYMENT
+// amount 0 refunds everything not refunded yet
+type RefundPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RefundPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorMessage  string                 `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payment.PaymentStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_proto_payment_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefundPaymentResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *RefundPaymentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PENDING_PAYMENT
+}
+
+// LIST PAYMENT TRANSACTIONS
+type ListPaymentTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentTransactionsRequest) Reset() {
+	*x = ListPaymentTransactionsRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentTransactionsRequest) ProtoMessage() {}
+
+func (x *ListPaymentTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPaymentTransactionsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListPaymentTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*PaymentTransaction  `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentTransactionsResponse) Reset() {
+	*x = ListPaymentTransactionsResponse{}
+	mi := &file_proto_payment_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentTransactionsResponse) ProtoMessage() {}
+
+func (x *ListPaymentTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPaymentTransactionsResponse) GetTransactions() []*PaymentTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListPaymentTransactionsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 var File_proto_payment_payment_proto protoreflect.FileDescriptor
 
 const file_proto_payment_payment_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/payment/payment.proto\x12\apayment\"l\n" +
+	"\x1bproto/payment/payment.proto\x12\apayment\"\x9a\x02\n" +
+	"\x12PaymentTransaction\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12,\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x18.payment.TransactionTypeR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\tsucceeded\x18\x05 \x01(\bR\tsucceeded\x12%\n" +
+	"\x0efailure_reason\x18\x06 \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\apending\x18\b \x01(\bR\apending\"l\n" +
 	"\aPayment\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12.\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\"I\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\"r\n" +
 	"\x14CreatePaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\"<\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"<\n" +
 	"\x15CreatePaymentResponse\x12#\n" +
-	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"J\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"6\n" +
+	"\x13TokenizeCardRequest\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\"Z\n" +
+	"\x14TokenizeCardResponse\x12\x1d\n" +
+	"\n" +
+	"card_token\x18\x01 \x01(\tR\tcardToken\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xa5\x01\n" +
 	"\x15ProcessPaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\"=\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"card_token\x18\x05 \x01(\tR\tcardTokenJ\x04\b\x03\x10\x04R\vcard_number\"=\n" +
 	"\x16ProcessPaymentResponse\x12#\n" +
 	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\"4\n" +
 	"\x17GetPaymentStatusRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\"o\n" +
 	"\x18GetPaymentStatusResponse\x12.\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage*B\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"r\n" +
+	"\x14RefundPaymentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"l\n" +
+	"\x15RefundPaymentResponse\x12#\n" +
+	"\rerror_message\x18\x01 \x01(\tR\ferrorMessage\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\";\n" +
+	"\x1eListPaymentTransactionsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x87\x01\n" +
+	"\x1fListPaymentTransactionsResponse\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.payment.PaymentTransactionR\ftransactions\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage*h\n" +
 	"\rPaymentStatus\x12\x13\n" +
 	"\x0fPENDING_PAYMENT\x10\x00\x12\b\n" +
 	"\x04PAID\x10\x01\x12\x12\n" +
-	"\x0ePAYMENT_FAILED\x10\x022\x8c\x02\n" +
+	"\x0ePAYMENT_FAILED\x10\x02\x12\f\n" +
+	"\bREFUNDED\x10\x03\x12\x16\n" +
+	"\x12PARTIALLY_REFUNDED\x10\x04*7\n" +
+	"\x0fTransactionType\x12\v\n" +
+	"\aATTEMPT\x10\x00\x12\v\n" +
+	"\aCAPTURE\x10\x01\x12\n" +
+	"\n" +
+	"\x06REFUND\x10\x022\x97\x04\n" +
 	"\x0ePaymentService\x12N\n" +
-	"\rCreatePayment\x12\x1d.payment.CreatePaymentRequest\x1a\x1e.payment.CreatePaymentResponse\x12Q\n" +
+	"\rCreatePayment\x12\x1d.payment.CreatePaymentRequest\x1a\x1e.payment.CreatePaymentResponse\x12K\n" +
+	"\fTokenizeCard\x12\x1c.payment.TokenizeCardRequest\x1a\x1d.payment.TokenizeCardResponse\x12Q\n" +
 	"\x0eProcessPayment\x12\x1e.payment.ProcessPaymentRequest\x1a\x1f.payment.ProcessPaymentResponse\x12W\n" +
-	"\x10GetPaymentStatus\x12 .payment.GetPaymentStatusRequest\x1a!.payment.GetPaymentStatusResponseB^Z\\github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment;paymentb\x06proto3"
+	"\x10GetPaymentStatus\x12 .payment.GetPaymentStatusRequest\x1a!.payment.GetPaymentStatusResponse\x12N\n" +
+	"\rRefundPayment\x12\x1d.payment.RefundPaymentRequest\x1a\x1e.payment.RefundPaymentResponse\x12l\n" +
+	"\x17ListPaymentTransactions\x12'.payment.ListPaymentTransactionsRequest\x1a(.payment.ListPaymentTransactionsResponseB^Z\\github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment;paymentb\x06proto3"
 
 var (
 	file_proto_payment_payment_proto_rawDescOnce sync.Once
@@ -480,32 +1013,49 @@ func file_proto_payment_payment_proto_rawDescGZIP() []byte {
 	return file_proto_payment_payment_proto_rawDescData
 }
 
-var file_proto_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_payment_payment_proto_goTypes = []any{
-	(PaymentStatus)(0),               // 0: payment.PaymentStatus
-	(*Payment)(nil),                  // 1: payment.Payment
-	(*CreatePaymentRequest)(nil),     // 2: payment.CreatePaymentRequest
-	(*CreatePaymentResponse)(nil),    // 3: payment.CreatePaymentResponse
-	(*ProcessPaymentRequest)(nil),    // 4: payment.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil),   // 5: payment.ProcessPaymentResponse
-	(*GetPaymentStatusRequest)(nil),  // 6: payment.GetPaymentStatusRequest
-	(*GetPaymentStatusResponse)(nil), // 7: payment.GetPaymentStatusResponse
+	(PaymentStatus)(0),                      // 0: payment.PaymentStatus
+	(TransactionType)(0),                    // 1: payment.TransactionType
+	(*PaymentTransaction)(nil),              // 2: payment.PaymentTransaction
+	(*Payment)(nil),                         // 3: payment.Payment
+	(*CreatePaymentRequest)(nil),            // 4: payment.CreatePaymentRequest
+	(*CreatePaymentResponse)(nil),           // 5: payment.CreatePaymentResponse
+	(*TokenizeCardRequest)(nil),             // 6: payment.TokenizeCardRequest
+	(*TokenizeCardResponse)(nil),            // 7: payment.TokenizeCardResponse
+	(*ProcessPaymentRequest)(nil),           // 8: payment.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),          // 9: payment.ProcessPaymentResponse
+	(*GetPaymentStatusRequest)(nil),         // 10: payment.GetPaymentStatusRequest
+	(*GetPaymentStatusResponse)(nil),        // 11: payment.GetPaymentStatusResponse
+	(*RefundPaymentRequest)(nil),            // 12: payment.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),           // 13: payment.RefundPaymentResponse
+	(*ListPaymentTransactionsRequest)(nil),  // 14: payment.ListPaymentTransactionsRequest
+	(*ListPaymentTransactionsResponse)(nil), // 15: payment.ListPaymentTransactionsResponse
 }
 var file_proto_payment_payment_proto_depIdxs = []int32{
-	0, // 0: payment.Payment.status:type_name -> payment.PaymentStatus
-	0, // 1: payment.GetPaymentStatusResponse.status:type_name -> payment.PaymentStatus
-	2, // 2: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
-	4, // 3: payment.PaymentService.ProcessPayment:input_type -> payment.ProcessPaymentRequest
-	6, // 4: payment.PaymentService.GetPaymentStatus:input_type -> payment.GetPaymentStatusRequest
-	3, // 5: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
-	5, // 6: payment.PaymentService.ProcessPayment:output_type -> payment.ProcessPaymentResponse
-	7, // 7: payment.PaymentService.GetPaymentStatus:output_type -> payment.GetPaymentStatusResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: payment.PaymentTransaction.type:type_name -> payment.TransactionType
+	0,  // 1: payment.Payment.status:type_name -> payment.PaymentStatus
+	0,  // 2: payment.GetPaymentStatusResponse.status:type_name -> payment.PaymentStatus
+	0,  // 3: payment.RefundPaymentResponse.status:type_name -> payment.PaymentStatus
+	2,  // 4: payment.ListPaymentTransactionsResponse.transactions:type_name -> payment.PaymentTransaction
+	4,  // 5: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
+	6,  // 6: payment.PaymentService.TokenizeCard:input_type -> payment.TokenizeCardRequest
+	8,  // 7: payment.PaymentService.ProcessPayment:input_type -> payment.ProcessPaymentRequest
+	10, // 8: payment.PaymentService.GetPaymentStatus:input_type -> payment.GetPaymentStatusRequest
+	12, // 9: payment.PaymentService.RefundPayment:input_type -> payment.RefundPaymentRequest
+	14, // 10: payment.PaymentService.ListPaymentTransactions:input_type -> payment.ListPaymentTransactionsRequest
+	5,  // 11: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
+	7,  // 12: payment.PaymentService.TokenizeCard:output_type -> payment.TokenizeCardResponse
+	9,  // 13: payment.PaymentService.ProcessPayment:output_type -> payment.ProcessPaymentResponse
+	11, // 14: payment.PaymentService.GetPaymentStatus:output_type -> payment.GetPaymentStatusResponse
+	13, // 15: payment.PaymentService.RefundPayment:output_type -> payment.RefundPaymentResponse
+	15, // 16: payment.PaymentService.ListPaymentTransactions:output_type -> payment.ListPaymentTransactionsResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_payment_payment_proto_init() }
@@ -518,8 +1068,8 @@ func file_proto_payment_payment_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_payment_proto_rawDesc), len(file_proto_payment_payment_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -4,8 +4,11 @@ go 1.25.1
 
 replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto => ../../proto
 
+replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared => ../../shared
+
 require (
 	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0-20260118165007-b7a0cfe48df0
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared v0.0.0-00010101000000-000000000000
 	github.com/mattn/go-sqlite3 v1.14.33 // indirect
 	golang.org/x/crypto v0.47.0
 )
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// accountOwner returns the user targeted by a request.
+func accountOwner(req any) string {
+	if r, ok := req.(interface{ GetUsername() string }); ok {
+		return r.GetUsername()
+	}
+	return ""
+}
+
+// AuthPolicy defines who can call each RPC of the authentication service.
+// Login, registration and token management are open, they are how a token is obtained.
+var AuthPolicy = interceptor.Policy{
+	pb.AuthenticationService_Login_FullMethodName:          interceptor.Public(),
+	pb.AuthenticationService_Refresh_FullMethodName:        interceptor.Public(),
+	pb.AuthenticationService_Logout_FullMethodName:         interceptor.Public(),
+	pb.AuthenticationService_Register_FullMethodName:       interceptor.Public(),
+	pb.AuthenticationService_ChangePassword_FullMethodName: interceptor.OwnerOnly(accountOwner),
+	pb.AuthenticationService_GetUser_FullMethodName:        interceptor.OwnerOnly(accountOwner),
+	pb.AuthenticationService_GetAllUsers_FullMethodName:    interceptor.AdminOnly(),
+}
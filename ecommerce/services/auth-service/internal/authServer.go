@@ -2,10 +2,13 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -13,11 +16,12 @@ import (
 // AuthServer implements the authentication service gRPC server.
 type AuthServer struct {
 	pb.AuthenticationServiceServer
-	repo domain.AuthServiceInterface
+	repo   domain.AuthServiceInterface
+	tokens *token.Manager
 }
 
-func NewAuthServer(repo domain.AuthServiceInterface) *AuthServer {
-	return &AuthServer{repo: repo}
+func NewAuthServer(repo domain.AuthServiceInterface, tokens *token.Manager) *AuthServer {
+	return &AuthServer{repo: repo, tokens: tokens}
 }
 
 // Login authenticates a user with the given username and password.
@@ -32,11 +36,87 @@ func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 		return &pb.LoginResponse{User: nil, ErrorMessage: err.Error()}, err
 	}
 
+	accessToken, accessClaims, refreshToken, err := s.issueTokenPair(user)
+	if err != nil {
+		return &pb.LoginResponse{User: nil, ErrorMessage: err.Error()}, err
+	}
+
 	return &pb.LoginResponse{
-		User: user,
+		User:                 user,
+		AccessToken:          accessToken,
+		RefreshToken:         refreshToken,
+		AccessTokenExpiresAt: accessClaims.ExpiresAt,
+	}, nil
+}
+
+// Refresh exchanges a valid refresh token for a new pair of tokens.
+// The old refresh token is revoked, so that each refresh token can be used only once.
+func (s *AuthServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+
+	if req.RefreshToken == "" {
+		return &pb.RefreshResponse{
+			ErrorMessage: "Refresh token must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Refresh token must be provided and not empty")
+	}
+
+	claims, err := s.tokens.Verify(req.RefreshToken, token.Refresh)
+	if err != nil {
+		return &pb.RefreshResponse{ErrorMessage: err.Error()}, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// The token is revoked before anything else, a token used twice is rejected even by concurrent refreshes
+	if err := s.repo.ConsumeToken(claims.ID, claims.Expiration()); errors.Is(err, repository.ErrTokenAlreadyUsed) {
+		return &pb.RefreshResponse{
+			ErrorMessage: "Refresh token has been revoked",
+		}, status.Error(codes.Unauthenticated, "Refresh token has been revoked")
+	} else if err != nil {
+		return &pb.RefreshResponse{ErrorMessage: err.Error()}, err
+	}
+
+	// The user is read again, so that the new access token carries the current role
+	user, err := s.repo.GetUser(claims.Subject)
+	if err != nil {
+		return &pb.RefreshResponse{ErrorMessage: err.Error()}, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	accessToken, accessClaims, refreshToken, err := s.issueTokenPair(user)
+	if err != nil {
+		return &pb.RefreshResponse{ErrorMessage: err.Error()}, err
+	}
+
+	return &pb.RefreshResponse{
+		AccessToken:          accessToken,
+		RefreshToken:         refreshToken,
+		AccessTokenExpiresAt: accessClaims.ExpiresAt,
 	}, nil
 }
 
+// Logout revokes the refresh token of a session, so that it cannot be exchanged for new tokens.
+// Access tokens are not revoked: the services do not look them up, they stay valid until their expiration.
+func (s *AuthServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+
+	if req.RefreshToken == "" {
+		return &pb.LogoutResponse{
+			ErrorMessage: "Refresh token must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Refresh token must be provided and not empty")
+	}
+
+	// Expired tokens are already unusable -> nothing to revoke
+	claims, err := s.tokens.Verify(req.RefreshToken, token.Refresh)
+	if err == token.ErrExpiredToken {
+		return &pb.LogoutResponse{}, nil
+	}
+	if err != nil {
+		return &pb.LogoutResponse{ErrorMessage: err.Error()}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.repo.RevokeToken(claims.ID, claims.Expiration()); err != nil {
+		return &pb.LogoutResponse{ErrorMessage: err.Error()}, err
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
 // Register creates a new user account with the provided info.
 func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 
@@ -86,6 +166,22 @@ func (s *AuthServer) GetAllUsers(ctx context.Context, req *pb.GetAllUsersRequest
 	return &pb.GetAllUsersResponse{Users: users}, nil
 }
 
+// issueTokenPair creates a new access token and a new refresh token for the user.
+func (s *AuthServer) issueTokenPair(user *pb.User) (string, *token.Claims, string, error) {
+
+	accessToken, accessClaims, err := s.tokens.Issue(user.GetUsername(), user.GetRole().String(), token.Access)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	refreshToken, _, err := s.tokens.Issue(user.GetUsername(), user.GetRole().String(), token.Refresh)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	return accessToken, accessClaims, refreshToken, nil
+}
+
 // PRIVATE FUNCTIONS TO VALIDATE INPUTS
 
 // checkCredetials validates the provided username and password.
@@ -1,6 +1,10 @@
 package domain
 
-import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
+import (
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
+)
 
 // AuthServiceInterface defines the interface for user data access operations.
 type AuthServiceInterface interface {
@@ -18,4 +22,16 @@ type AuthServiceInterface interface {
 
 	// GetAllUsers retrieves all users registered in the system.
 	GetAllUsers() ([]*pb.User, error)
+
+	// RevokeToken adds a token to the revocation table until its expiration.
+	RevokeToken(tokenID string, expiresAt time.Time) error
+
+	// ConsumeToken revokes a token that can be used only once, failing if it was already revoked.
+	ConsumeToken(tokenID string, expiresAt time.Time) error
+
+	// IsTokenRevoked checks if a token is in the revocation table.
+	IsTokenRevoked(tokenID string) (bool, error)
+
+	// PurgeExpiredTokens removes from the revocation table the tokens that are expired anyway.
+	PurgeExpiredTokens() error
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RevokedToken is a token that can no longer be used, even if not expired yet.
+type RevokedToken struct {
+
+	// TokenID is the unique identifier (jti) of the revoked token.
+	TokenID string `gorm:"primaryKey; not null; check:token_id <> ''"`
+
+	// ExpiresAt is the original expiration of the token, after it the row can be purged.
+	ExpiresAt time.Time `gorm:"not null; index"`
+}
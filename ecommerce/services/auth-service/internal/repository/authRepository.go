@@ -3,14 +3,19 @@ package repository
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal/domain"
 )
 
+// ErrTokenAlreadyUsed is returned when a single use token is consumed again.
+var ErrTokenAlreadyUsed = errors.New("Token has already been used")
+
 type AuthRepository struct {
 	db *gorm.DB
 }
@@ -153,6 +158,56 @@ func (r *AuthRepository) CreateAdmin(username, password string) error {
 	return r.db.Create(&user).Error
 }
 
+// RevokeToken adds a token to the revocation table until its expiration.
+func (r *AuthRepository) RevokeToken(tokenID string, expiresAt time.Time) error {
+
+	if tokenID == "" {
+		return errors.New("Token ID cannot be empty")
+	}
+
+	// Revoking twice the same token is not an error
+	revoked := domain.RevokedToken{TokenID: tokenID, ExpiresAt: expiresAt}
+	return r.db.Where(domain.RevokedToken{TokenID: tokenID}).FirstOrCreate(&revoked).Error
+}
+
+// ConsumeToken revokes a token that can be used only once, ErrTokenAlreadyUsed if it was already revoked.
+// The check and the revocation are a single insert, so that two concurrent uses cannot both succeed.
+func (r *AuthRepository) ConsumeToken(tokenID string, expiresAt time.Time) error {
+
+	if tokenID == "" {
+		return errors.New("Token ID cannot be empty")
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.RevokedToken{TokenID: tokenID, ExpiresAt: expiresAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+	return nil
+}
+
+// IsTokenRevoked checks if a token is in the revocation table.
+func (r *AuthRepository) IsTokenRevoked(tokenID string) (bool, error) {
+
+	if tokenID == "" {
+		return false, errors.New("Token ID cannot be empty")
+	}
+
+	var count int64
+	if err := r.db.Model(&domain.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// PurgeExpiredTokens removes from the revocation table the tokens that are expired anyway.
+func (r *AuthRepository) PurgeExpiredTokens() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&domain.RevokedToken{}).Error
+}
+
 // HashPassword hashes the password using bcrypt.
 func (r *AuthRepository) HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -1,7 +1,10 @@
 package tests
 
 import (
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -17,7 +20,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect database: %v", err)
 	}
 
-	if err = db.AutoMigrate(&domain.User{}); err != nil {
+	if err = db.AutoMigrate(&domain.User{}, &domain.RevokedToken{}); err != nil {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
 	return db
@@ -352,3 +355,116 @@ func TestCreateDefaultUsersAdmins(t *testing.T) {
 		}
 	}
 }
+
+func TestRevokeToken(t *testing.T) {
+	_, repo := setupTest(t)
+
+	tokenID := "token123"
+
+	revoked, err := repo.IsTokenRevoked(tokenID)
+	if err != nil {
+		t.Fatalf("IsTokenRevoked failed: %v", err)
+	}
+	if revoked {
+		t.Fatalf("Token should not be revoked yet")
+	}
+
+	if err := repo.RevokeToken(tokenID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+
+	revoked, err = repo.IsTokenRevoked(tokenID)
+	if err != nil {
+		t.Fatalf("IsTokenRevoked failed: %v", err)
+	}
+	if !revoked {
+		t.Fatalf("Token should be revoked")
+	}
+}
+
+func TestRevokeTokenTwice(t *testing.T) {
+	_, repo := setupTest(t)
+
+	tokenID := "token123"
+	expiresAt := time.Now().Add(time.Hour)
+
+	if err := repo.RevokeToken(tokenID, expiresAt); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+	if err := repo.RevokeToken(tokenID, expiresAt); err != nil {
+		t.Fatalf("Revoking the same token twice should not fail: %v", err)
+	}
+}
+
+func TestConsumeTokenOnlyOnce(t *testing.T) {
+	db, repo := setupTest(t)
+
+	expiresAt := time.Now().Add(time.Hour)
+	if err := repo.ConsumeToken("refresh123", expiresAt); err != nil {
+		t.Fatalf("ConsumeToken failed: %v", err)
+	}
+	if err := repo.ConsumeToken("refresh123", expiresAt); !errors.Is(err, repository.ErrTokenAlreadyUsed) {
+		t.Fatalf("Expected ErrTokenAlreadyUsed, got %v", err)
+	}
+
+	// A single connection shares the in-memory database between the goroutines
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := repo.ConsumeToken("refresh456", expiresAt); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("Expected the token to be consumed once, got %d", succeeded)
+	}
+}
+
+func TestRevokeTokenWithEmptyID(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if err := repo.RevokeToken("", time.Now()); err == nil {
+		t.Fatalf("Expected RevokeToken to fail with empty token ID")
+	}
+}
+
+func TestPurgeExpiredTokens(t *testing.T) {
+	db, repo := setupTest(t)
+
+	if err := repo.RevokeToken("expired", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+	if err := repo.RevokeToken("valid", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+
+	if err := repo.PurgeExpiredTokens(); err != nil {
+		t.Fatalf("PurgeExpiredTokens failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&domain.RevokedToken{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("Expected 1 revoked token after purge, got %d", count)
+	}
+
+	revoked, err := repo.IsTokenRevoked("valid")
+	if err != nil {
+		t.Fatalf("IsTokenRevoked failed: %v", err)
+	}
+	if !revoked {
+		t.Fatalf("Not expired token should still be revoked")
+	}
+}
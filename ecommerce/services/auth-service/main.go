@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"gorm.io/driver/sqlite"
@@ -12,10 +13,15 @@ import (
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal/domain"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/auth-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 )
 
 var port = "8081"
 
+// purgeInterval is how often the expired tokens are removed from the revocation table
+var purgeInterval = time.Hour
+
 func main() {
 
 	// Initialize database connection with GORM
@@ -25,7 +31,7 @@ func main() {
 	}
 
 	// Migrate the schema
-	if err := db.AutoMigrate(&domain.User{}); err != nil {
+	if err := db.AutoMigrate(&domain.User{}, &domain.RevokedToken{}); err != nil {
 		log.Fatalf("Failed to migrate database schema: %v", err)
 	}
 
@@ -41,11 +47,26 @@ func main() {
 		log.Fatalf("Internal errors while creating default users: %v", err)
 	}
 
-	// Initialize AuthServer
-	authServer := internal.NewAuthServer(authRepo)
+	// Periodically clean the revocation table
+	go func() {
+		for {
+			if err := authRepo.PurgeExpiredTokens(); err != nil {
+				log.Printf("Failed to purge expired tokens: %v", err)
+			}
+			time.Sleep(purgeInterval)
+		}
+	}()
+
+	// Initialize AuthServer, tokens are signed with the secret shared by all services
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	authServer := internal.NewAuthServer(authRepo, tokens)
 
-	// Register gRPC server
-	grpcServer := grpc.NewServer()
+	// Register gRPC server, every call is checked against the authorization policy
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(authorizer.ServerOptions()...)
 	pb.RegisterAuthenticationServiceServer(grpcServer, authServer)
 
 	log.Printf("Auth service listening on port %s", port)
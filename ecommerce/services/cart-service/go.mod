@@ -2,7 +2,10 @@ module github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/se
 
 go 1.25.1
 
-require github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0
+require (
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared v0.0.0-00010101000000-000000000000
+)
 
 require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
@@ -22,3 +25,5 @@ require (
 )
 
 replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto => ../../proto
+
+replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared => ../../shared
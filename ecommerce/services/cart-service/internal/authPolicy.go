@@ -0,0 +1,25 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// cartOwner returns the owner of the cart targeted by a request.
+func cartOwner(req any) string {
+	if r, ok := req.(interface{ GetUsername() string }); ok {
+		return r.GetUsername()
+	}
+	return ""
+}
+
+// AuthPolicy defines who can call each RPC of the cart service.
+// Every user can only read and modify his own cart.
+var AuthPolicy = interceptor.Policy{
+	pb.CartService_AddItemToCart_FullMethodName:       interceptor.OwnerOnly(cartOwner),
+	pb.CartService_RemoveItemFromCart_FullMethodName:  interceptor.OwnerOnly(cartOwner),
+	pb.CartService_UpdateItemQuantity_FullMethodName:  interceptor.OwnerOnly(cartOwner),
+	pb.CartService_GetCart_FullMethodName:             interceptor.OwnerOnly(cartOwner),
+	pb.CartService_ClearCart_FullMethodName:           interceptor.OwnerOnly(cartOwner),
+	pb.CartService_CalculateTotalPrice_FullMethodName: interceptor.OwnerOnly(cartOwner),
+}
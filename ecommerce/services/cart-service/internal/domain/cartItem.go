@@ -19,6 +19,9 @@ type CartItem struct {
 
 	// Price indicates the price of a single item.
 	Price float64 `gorm:"not null; check:price >= 0"`
+
+	// SKU is the SKU of the catalog item, the variant chosen when the item is a product with variants.
+	SKU string `gorm:"column:sku; not null; default:''"`
 }
 
 // DomainCartItemToProtoCartItem converts a model.CartItem into a pb.CartItem
@@ -31,5 +34,6 @@ func DomainCartItemToProtoCartItem(cartItem *CartItem) (*pb.CartItem, error) {
 		ItemId:   cartItem.ItemID,
 		Quantity: uint32(cartItem.Quantity),
 		Price:    cartItem.Price,
+		Sku:      cartItem.SKU,
 	}, nil
 }
@@ -21,4 +21,10 @@ type CartServiceInterface interface {
 
 	// Calculate the total price of the cart
 	CalculateTotalPrice(username string) (float64, error)
+
+	// List the distinct IDs of the items in the carts
+	ListItemIDs() ([]string, error)
+
+	// Replace the IDs of the items in the carts with the new ones
+	RewriteItemIDs(itemIDs map[string]string) (int64, error)
 }
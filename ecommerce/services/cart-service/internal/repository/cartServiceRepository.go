@@ -45,12 +45,16 @@ func (r *CartServiceRepository) AddItemToCart(username string, item *pb.CartItem
 	itemIndex := findItemInCart(cart.Items, item.ItemId)
 	if itemIndex != -1 {
 		cart.Items[itemIndex].Quantity += item.Quantity
+		if item.Sku != "" {
+			cart.Items[itemIndex].SKU = item.Sku
+		}
 	} else {
 		cart.Items = append(cart.Items, domain.CartItem{
 			ItemID:       item.ItemId,
 			CartUsername: cart.Username,
 			Quantity:     item.Quantity,
 			Price:        item.Price,
+			SKU:          item.Sku,
 		})
 	}
 
@@ -195,6 +199,44 @@ func (r *CartServiceRepository) CalculateTotalPrice(username string) (float64, e
 	return total, nil
 }
 
+// ListItemIDs retrieves the distinct IDs of the items in the carts.
+func (r *CartServiceRepository) ListItemIDs() ([]string, error) {
+	var itemIDs []string
+	err := r.db.Model(&domain.CartItem{}).Distinct().Pluck("item_id", &itemIDs).Error
+	return itemIDs, err
+}
+
+// RewriteItemIDs replaces the IDs of the items in the carts with the new ones, it returns the number of rows changed.
+// A cart holding the item under both IDs keeps a single row with the quantities added up.
+func (r *CartServiceRepository) RewriteItemIDs(itemIDs map[string]string) (int64, error) {
+	var rewritten int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for legacyID, itemID := range itemIDs {
+
+			// Carts already holding the new ID
+			merged := tx.Model(&domain.CartItem{}).Select("cart_username").Where("item_id = ?", itemID)
+			if err := tx.Model(&domain.CartItem{}).
+				Where("item_id = ? AND cart_username IN (?)", itemID, tx.Model(&domain.CartItem{}).Select("cart_username").Where("item_id = ?", legacyID)).
+				Update("quantity", gorm.Expr("quantity + (SELECT legacy.quantity FROM cart_items legacy WHERE legacy.cart_username = cart_items.cart_username AND legacy.item_id = ?)", legacyID)).Error; err != nil {
+				return err
+			}
+			result := tx.Where("item_id = ? AND cart_username IN (?)", legacyID, merged).Delete(&domain.CartItem{})
+			if result.Error != nil {
+				return result.Error
+			}
+			rewritten += result.RowsAffected
+
+			result = tx.Model(&domain.CartItem{}).Where("item_id = ?", legacyID).Update("item_id", itemID)
+			if result.Error != nil {
+				return result.Error
+			}
+			rewritten += result.RowsAffected
+		}
+		return nil
+	})
+	return rewritten, err
+}
+
 // RetrieveCart retrieves the cart for a specific user from the database
 func (r *CartServiceRepository) RetrieveCart(username string) (bool, *domain.Cart, error) {
 
@@ -113,6 +113,34 @@ func TestAddExistingItemToCart(t *testing.T) {
 	}
 }
 
+func TestAddItemToCartWithSKU(t *testing.T) {
+	db, repo := setupTest(t)
+
+	// Test adding a variant keeps its SKU
+	if err := repo.AddItemToCart("user1", &pb.CartItem{ItemId: "item5", Quantity: 1, Price: 25.0, Sku: "ABC-HC"}); err != nil {
+		t.Errorf("Failed to add item to cart: %v", err)
+	}
+
+	// Test adding an existing item sets its SKU
+	if err := repo.AddItemToCart("user1", &pb.CartItem{ItemId: "item1", Quantity: 1, Price: 10.0, Sku: "ABC-PB"}); err != nil {
+		t.Errorf("Failed to add existing item to cart: %v", err)
+	}
+
+	var cart domain.Cart
+	err := db.Preload("Items").Where("username = ?", "user1").First(&cart).Error
+	if err != nil {
+		t.Errorf("Failed to retrieve cart from database: %v", err)
+	}
+	for _, item := range cart.Items {
+		if item.ItemID == "item5" && item.SKU != "ABC-HC" {
+			t.Errorf("Expected SKU ABC-HC for item5, got %q", item.SKU)
+		}
+		if item.ItemID == "item1" && item.SKU != "ABC-PB" {
+			t.Errorf("Expected SKU ABC-PB for item1, got %q", item.SKU)
+		}
+	}
+}
+
 func TestAddNewItemToNewCart(t *testing.T) {
 	db, repo := setupTest(t)
 
@@ -574,3 +602,41 @@ func TestCalculateTotalPriceOfCartWithEmptyUsername(t *testing.T) {
 		t.Errorf("Expected error when calculating total price of cart with empty username, got nil")
 	}
 }
+
+func TestRewriteItemIDs(t *testing.T) {
+	db, repo := setupTest(t)
+
+	// user2 holds item3 under both IDs
+	if err := repo.AddItemToCart("user2", &pb.CartItem{ItemId: "01NEWITEM3", Quantity: 1, Price: 5.0}); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+
+	itemIDs, err := repo.ListItemIDs()
+	if err != nil || len(itemIDs) != 5 {
+		t.Fatalf("Expected 5 item IDs, got %v (%v)", itemIDs, err)
+	}
+
+	if _, err := repo.RewriteItemIDs(map[string]string{"item1": "01NEWITEM1", "item3": "01NEWITEM3"}); err != nil {
+		t.Fatalf("Failed to rewrite item IDs: %v", err)
+	}
+
+	var count int64
+	db.Model(&domain.CartItem{}).Where("item_id IN ?", []string{"item1", "item3"}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no cart item with a legacy ID, got %v", count)
+	}
+
+	cart, err := repo.GetCart("user1")
+	if err != nil || cart.Items[0].ItemId != "01NEWITEM1" && cart.Items[1].ItemId != "01NEWITEM1" {
+		t.Errorf("Expected item1 rewritten in the cart of user1, got %v (%v)", cart, err)
+	}
+
+	// The quantities of the same item are added up
+	var item domain.CartItem
+	if err := db.Where("cart_username = ? AND item_id = ?", "user2", "01NEWITEM3").First(&item).Error; err != nil {
+		t.Fatalf("Failed to retrieve merged item: %v", err)
+	}
+	if item.Quantity != 6 {
+		t.Errorf("Expected quantity 6, got %v", item.Quantity)
+	}
+}
@@ -1,21 +1,33 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/cart-service/internal"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/cart-service/internal/domain"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/cart-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 )
 
 var port = "8082"
 
+// serviceName is the identity of the cart service when it calls the other services
+const serviceName = "cart-service"
+
+// migrationRetryInterval is how often the migration of the item IDs is retried while the catalog is unreachable
+const migrationRetryInterval = 30 * time.Second
+
 func main() {
 
 	// Initialize database connection with GORM
@@ -38,11 +50,39 @@ func main() {
 	// Initialize repository
 	cartRepo := repository.NewCartServiceRepository(db)
 
+	// Connection to the catalog, authenticated as a service
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	catalogConn, err := grpc.NewClient("localhost:8083",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(interceptor.NewServiceCredentials(tokens, serviceName)),
+	)
+	if err != nil {
+		log.Fatalf("Failed to connect to catalog service: %v", err)
+	}
+	defer catalogConn.Close()
+
+	// Items in the carts may still be identified by their title, their generated IDs are asked to the catalog
+	go func() {
+		catalog := pbCatalog.NewCatalogServiceClient(catalogConn)
+		for {
+			err := migrateItemIDs(catalog, cartRepo)
+			if err == nil {
+				return
+			}
+			log.Printf("Failed to migrate the item IDs, retrying: %v", err)
+			time.Sleep(migrationRetryInterval)
+		}
+	}()
+
 	// Initialize CartServer
 	cartServer := internal.NewCartServer(cartRepo)
 
-	// Register gRPC server
-	grpcServer := grpc.NewServer()
+	// Register gRPC server, every call is checked against the authorization policy
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(authorizer.ServerOptions()...)
 	pb.RegisterCartServiceServer(grpcServer, cartServer)
 
 	log.Printf("Cart service listening on port %s", port)
@@ -52,3 +92,28 @@ func main() {
 		log.Fatalf("gRPC cart service failed: %v", err)
 	}
 }
+
+// migrateItemIDs rewrites the items of the carts identified by their title with the IDs generated by the catalog
+func migrateItemIDs(catalog pbCatalog.CatalogServiceClient, cartRepo *repository.CartServiceRepository) error {
+	itemIDs, err := cartRepo.ListItemIDs()
+	if err != nil || len(itemIDs) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := catalog.ResolveLegacyItemIDs(ctx, &pbCatalog.ResolveLegacyItemIDsRequest{LegacyIds: itemIDs})
+	if err != nil {
+		return err
+	}
+	if len(res.GetItemIds()) == 0 {
+		return nil
+	}
+
+	rewritten, err := cartRepo.RewriteItemIDs(res.GetItemIds())
+	if err == nil {
+		log.Printf("Rewrote %d cart items with the generated item IDs", rewritten)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package allocation
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// earthRadius is the mean radius of the Earth, in kilometers
+const earthRadius = 6371.0
+
+// Location is a point on the Earth, in degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid tells if the location is a point on the Earth.
+func (l Location) Valid() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90 && l.Longitude >= -180 && l.Longitude <= 180
+}
+
+// Line is the quantity of an item to take from the warehouses.
+type Line struct {
+	ItemID   string
+	Quantity uint32
+}
+
+// Stock is the quantity of an item in stock at a warehouse.
+type Stock struct {
+	WarehouseID string
+	Location    Location
+	Quantity    uint32
+}
+
+// Allocation is the quantity of an item taken from a warehouse.
+type Allocation struct {
+	ItemID      string
+	WarehouseID string
+	Quantity    uint32
+}
+
+// Strategy chooses the warehouses the lines of an order are taken from.
+// stock holds, by item ID, the warehouses having each item; the caller checks that every line has enough of it.
+type Strategy interface {
+	Allocate(lines []Line, stock map[string][]Stock) []Allocation
+}
+
+// FewestSplits takes the lines from as few warehouses as possible, so that the order is shipped in fewer parcels.
+// Warehouses having whole lines are chosen first, the ones with the most lines; the lines that no warehouse
+// has whole are split among the warehouses with the most stock of them.
+func FewestSplits() Strategy {
+	return fewestSplits{}
+}
+
+// Nearest takes every line from the warehouses nearest to the destination, splitting it when the nearest one
+// has not enough of it. Without a destination it is FewestSplits.
+func Nearest(destination *Location) Strategy {
+	if destination == nil {
+		return fewestSplits{}
+	}
+	return nearest{destination: *destination}
+}
+
+type fewestSplits struct{}
+
+func (fewestSplits) Allocate(lines []Line, stock map[string][]Stock) []Allocation {
+	var allocations []Allocation
+	remaining := slices.Clone(lines)
+
+	for len(remaining) > 0 {
+
+		// The warehouse having the most of the remaining lines whole, ties broken by its ID
+		best, bestLines := "", 0
+		for _, warehouseID := range warehouseIDs(stock) {
+			whole := 0
+			for _, line := range remaining {
+				if quantityAt(stock[line.ItemID], warehouseID) >= line.Quantity {
+					whole++
+				}
+			}
+			if whole > bestLines {
+				best, bestLines = warehouseID, whole
+			}
+		}
+		if bestLines == 0 {
+			break
+		}
+
+		left := remaining[:0]
+		for _, line := range remaining {
+			if quantityAt(stock[line.ItemID], best) >= line.Quantity {
+				allocations = append(allocations, Allocation{ItemID: line.ItemID, WarehouseID: best, Quantity: line.Quantity})
+			} else {
+				left = append(left, line)
+			}
+		}
+		remaining = left
+	}
+
+	// No warehouse has any of the remaining lines whole
+	for _, line := range remaining {
+		warehouses := slices.Clone(stock[line.ItemID])
+		slices.SortStableFunc(warehouses, func(a, b Stock) int {
+			return cmp.Or(cmp.Compare(b.Quantity, a.Quantity), cmp.Compare(a.WarehouseID, b.WarehouseID))
+		})
+		allocations = append(allocations, split(line, warehouses)...)
+	}
+	return allocations
+}
+
+type nearest struct {
+	destination Location
+}
+
+func (n nearest) Allocate(lines []Line, stock map[string][]Stock) []Allocation {
+	var allocations []Allocation
+	for _, line := range lines {
+		warehouses := slices.Clone(stock[line.ItemID])
+		slices.SortStableFunc(warehouses, func(a, b Stock) int {
+			return cmp.Or(cmp.Compare(Distance(n.destination, a.Location), Distance(n.destination, b.Location)),
+				cmp.Compare(a.WarehouseID, b.WarehouseID))
+		})
+		allocations = append(allocations, split(line, warehouses)...)
+	}
+	return allocations
+}
+
+// Distance returns the great-circle distance between two locations, in kilometers.
+func Distance(from, to Location) float64 {
+	lat1, lat2 := radians(from.Latitude), radians(to.Latitude)
+	dLat, dLon := lat2-lat1, radians(to.Longitude-from.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// split takes a line from the warehouses in the given order, as much as each one has, until it is complete
+func split(line Line, warehouses []Stock) []Allocation {
+	var allocations []Allocation
+	left := line.Quantity
+	for _, warehouse := range warehouses {
+		if left == 0 {
+			break
+		}
+		taken := min(left, warehouse.Quantity)
+		if taken == 0 {
+			continue
+		}
+		allocations = append(allocations, Allocation{ItemID: line.ItemID, WarehouseID: warehouse.WarehouseID, Quantity: taken})
+		left -= taken
+	}
+	return allocations
+}
+
+// quantityAt returns the quantity in stock at a warehouse, zero if it has none
+func quantityAt(stock []Stock, warehouseID string) uint32 {
+	for _, s := range stock {
+		if s.WarehouseID == warehouseID {
+			return s.Quantity
+		}
+	}
+	return 0
+}
+
+// warehouseIDs returns the IDs of the warehouses having any of the items, sorted
+func warehouseIDs(stock map[string][]Stock) []string {
+	var ids []string
+	for _, warehouses := range stock {
+		for _, warehouse := range warehouses {
+			ids = append(ids, warehouse.WarehouseID)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// AuthPolicy defines who can call each RPC of the catalog service.
+// Everyone can browse the catalog, only admins can modify it.
+// Stock is reserved and given back only by other services, on behalf of the user.
+var AuthPolicy = interceptor.Policy{
+	pb.CatalogService_AddCatalogItem_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_RemoveCatalogItem_FullMethodName:       interceptor.AdminOnly(),
+	pb.CatalogService_GetCatalogItem_FullMethodName:          interceptor.Public(),
+	pb.CatalogService_UpdateQuantityAvailable_FullMethodName: interceptor.AdminOnly(),
+	pb.CatalogService_UpdatePrice_FullMethodName:             interceptor.AdminOnly(),
+	pb.CatalogService_ListCatalogItems_FullMethodName:        interceptor.Public(),
+	pb.CatalogService_ReserveStock_FullMethodName:            interceptor.ServiceOnly(),
+	pb.CatalogService_CommitReservation_FullMethodName:       interceptor.ServiceOnly(),
+	pb.CatalogService_ReleaseReservation_FullMethodName:      interceptor.ServiceOnly(),
+	pb.CatalogService_RestockItems_FullMethodName:            interceptor.ServiceOnly(),
+	pb.CatalogService_SearchCatalog_FullMethodName:           interceptor.Public(),
+	pb.CatalogService_GetCatalogItems_FullMethodName:         interceptor.Public(),
+	pb.CatalogService_UpdateCatalogItem_FullMethodName:       interceptor.AdminOnly(),
+	pb.CatalogService_ResolveLegacyItemIDs_FullMethodName:    interceptor.ServiceOnly(),
+	pb.CatalogService_CreateCategory_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_UpdateCategory_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_MoveCategory_FullMethodName:            interceptor.AdminOnly(),
+	pb.CatalogService_DeleteCategory_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_ListCategories_FullMethodName:          interceptor.Public(),
+	pb.CatalogService_SetItemCategory_FullMethodName:         interceptor.AdminOnly(),
+	pb.CatalogService_SetItemTags_FullMethodName:             interceptor.AdminOnly(),
+	pb.CatalogService_ListTags_FullMethodName:                interceptor.Public(),
+	pb.CatalogService_ImportCatalogItems_FullMethodName:      interceptor.AdminOnly(),
+	pb.CatalogService_ExportCatalogItems_FullMethodName:      interceptor.AdminOnly(),
+	pb.CatalogService_WatchCatalog_FullMethodName:            interceptor.Public(),
+	pb.CatalogService_GetPriceHistory_FullMethodName:         interceptor.Public(),
+	pb.CatalogService_SchedulePriceChange_FullMethodName:     interceptor.AdminOnly(),
+	pb.CatalogService_ListScheduledPrices_FullMethodName:     interceptor.AdminOnly(),
+	pb.CatalogService_CancelScheduledPrice_FullMethodName:    interceptor.AdminOnly(),
+	pb.CatalogService_ListStockMovements_FullMethodName:      interceptor.AdminOnly(),
+	pb.CatalogService_ReconcileStock_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_CreateWarehouse_FullMethodName:         interceptor.AdminOnly(),
+	pb.CatalogService_ListWarehouses_FullMethodName:          interceptor.AdminOnly(),
+	pb.CatalogService_GetItemStock_FullMethodName:            interceptor.Public(),
+	pb.CatalogService_TransferStock_FullMethodName:           interceptor.AdminOnly(),
+	pb.CatalogService_SetReorderThreshold_FullMethodName:     interceptor.AdminOnly(),
+	pb.CatalogService_ListStockAlerts_FullMethodName:         interceptor.AdminOnly(),
+	pb.CatalogService_GetReorderSuggestions_FullMethodName:   interceptor.AdminOnly(),
+	pb.CatalogService_AddItemImage_FullMethodName:            interceptor.AdminOnly(),
+	pb.CatalogService_RemoveItemImage_FullMethodName:         interceptor.AdminOnly(),
+	pb.CatalogService_ReorderItemImages_FullMethodName:       interceptor.AdminOnly(),
+	pb.CatalogService_GetImage_FullMethodName:                interceptor.Public(),
+	pb.CatalogService_SubmitReview_FullMethodName:            interceptor.Authenticated(),
+	pb.CatalogService_ListReviews_FullMethodName:             interceptor.Public(),
+	pb.CatalogService_ListPendingReviews_FullMethodName:      interceptor.AdminOnly(),
+	pb.CatalogService_ModerateReview_FullMethodName:          interceptor.AdminOnly(),
+}
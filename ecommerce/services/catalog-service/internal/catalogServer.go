@@ -1,31 +1,79 @@
 package internal
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
 	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/allocation"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
+// exportChunkSize is the size of the chunks of an exported file
+const exportChunkSize = 32 << 10
+
+// Periods of the reorder suggestions when not given, in days
+const (
+	defaultSalesWindowDays = 30
+	defaultCoverDays       = 14
+	maxSuggestionDays      = 365
+)
+
+// purchasedOrderStatuses are the statuses of the orders verifying a purchase: pending orders are not paid yet
+var purchasedOrderStatuses = []pbOrder.OrderStatus{
+	pbOrder.OrderStatus_PROCESSING,
+	pbOrder.OrderStatus_SHIPPED,
+	pbOrder.OrderStatus_DELIVERED,
+}
+
 // CatalogServer implements the catalog service gRPC server.
 type CatalogServer struct {
 	pb.CatalogServiceServer
 	repo domain.CatalogServiceInterface
+
+	// orders tells the sales of the items, for the reorder suggestions, and what a customer bought, for the reviews
+	orders pbOrder.OrderServiceClient
 }
 
-func NewCatalogServer(repo domain.CatalogServiceInterface) *CatalogServer {
-	return &CatalogServer{repo: repo}
+func NewCatalogServer(repo domain.CatalogServiceInterface, orders pbOrder.OrderServiceClient) *CatalogServer {
+	return &CatalogServer{repo: repo, orders: orders}
 }
 
-// AddCatalogItem adds an item to catalog.
+// AddCatalogItem adds an item to catalog, or a variant to one of its products.
 func (s *CatalogServer) AddCatalogItem(ctx context.Context, req *pb.AddCatalogItemRequest) (*pb.AddCatalogItemResponse, error) {
 
-	if req.Item.ItemId == "" || req.Item.Description == "" || req.Item == nil {
+	// Variants take the name and description of their product unless given
+	if req.Item == nil || (req.Item.ProductId == "" && (req.Item.Name == "" || req.Item.Description == "")) {
 		return &pb.AddCatalogItemResponse{
-			ErrorMessage: "ItemId and Description must be provided and not empty",
-		}, status.Error(codes.InvalidArgument, "ItemId and Description must be provided and not empty")
+			ErrorMessage: "Name and Description must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Name and Description must be provided and not empty")
+	}
+
+	if req.Item.ProductId != "" && (req.Item.Sku == "" || len(req.Item.Attributes) == 0) {
+		return &pb.AddCatalogItemResponse{
+			ErrorMessage: "Sku and Attributes of a variant must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Sku and Attributes of a variant must be provided and not empty")
+	}
+
+	if req.Item.ItemId != "" {
+		return &pb.AddCatalogItemResponse{
+			ErrorMessage: "ItemId is generated by the catalog and must be empty",
+		}, status.Error(codes.InvalidArgument, "ItemId is generated by the catalog and must be empty")
 	}
 
 	if req.Item.QuantityAvailable < 0 {
@@ -40,10 +88,14 @@ func (s *CatalogServer) AddCatalogItem(ctx context.Context, req *pb.AddCatalogIt
 		}, status.Error(codes.InvalidArgument, "Price must be non-negative")
 	}
 
-	if err := s.repo.AddCatalogItem(req.Item); err != nil {
+	itemID, err := s.repo.AddCatalogItem(req.Item, actorFromContext(ctx))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.AddCatalogItemResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "Product or category not found")
+	}
+	if err != nil {
 		return &pb.AddCatalogItemResponse{ErrorMessage: err.Error()}, err
 	}
-	return &pb.AddCatalogItemResponse{}, nil
+	return &pb.AddCatalogItemResponse{ItemId: itemID}, nil
 }
 
 // RemoveCatalogItem removes a catalog item from the catalog by its unique identifier.
@@ -61,23 +113,86 @@ func (s *CatalogServer) RemoveCatalogItem(ctx context.Context, req *pb.RemoveCat
 	return &pb.RemoveCatalogItemResponse{}, nil
 }
 
-// GetCatalogItem retrieves a catalog item by its unique identifier.
+// GetCatalogItem retrieves a catalog item by its unique identifier, its slug or its SKU.
 func (s *CatalogServer) GetCatalogItem(ctx context.Context, req *pb.GetCatalogItemRequest) (*pb.GetCatalogItemResponse, error) {
 
-	if req.ItemId == "" {
+	var item *pb.CatalogItem
+	var err error
+	switch {
+	case req.ItemId != "":
+		item, err = s.repo.GetCatalogItem(req.ItemId)
+	case req.Slug != "":
+		item, err = s.repo.GetCatalogItemBySlug(req.Slug)
+	case req.Sku != "":
+		item, err = s.repo.GetCatalogItemBySKU(req.Sku)
+	default:
 		return &pb.GetCatalogItemResponse{
 			Item:         nil,
-			ErrorMessage: "ItemId must be provided and not empty",
-		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+			ErrorMessage: "ItemId, Slug or Sku must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId, Slug or Sku must be provided and not empty")
 	}
 
-	item, err := s.repo.GetCatalogItem(req.ItemId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.GetCatalogItemResponse{Item: nil, ErrorMessage: err.Error()}, status.Error(codes.NotFound, "Item not found")
+	}
 	if err != nil {
 		return &pb.GetCatalogItemResponse{Item: nil, ErrorMessage: err.Error()}, err
 	}
 	return &pb.GetCatalogItemResponse{Item: item}, nil
 }
 
+// GetCatalogItems retrieves several catalog items by their unique identifiers, the ones not found are left out.
+func (s *CatalogServer) GetCatalogItems(ctx context.Context, req *pb.GetCatalogItemsRequest) (*pb.GetCatalogItemsResponse, error) {
+
+	if len(req.ItemIds) > domain.MaxPageSize {
+		return &pb.GetCatalogItemsResponse{
+			ErrorMessage: "Too many item IDs requested",
+		}, status.Errorf(codes.InvalidArgument, "At most %d item IDs can be requested", domain.MaxPageSize)
+	}
+
+	items, err := s.repo.GetCatalogItems(req.ItemIds)
+	if err != nil {
+		return &pb.GetCatalogItemsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.GetCatalogItemsResponse{Items: items}, nil
+}
+
+// UpdateCatalogItem updates the name, description, SKU and slug of a catalog item.
+func (s *CatalogServer) UpdateCatalogItem(ctx context.Context, req *pb.UpdateCatalogItemRequest) (*pb.UpdateCatalogItemResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.UpdateCatalogItemResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	err := s.repo.UpdateCatalogItem(&pb.CatalogItem{
+		ItemId:      req.ItemId,
+		Name:        req.Name,
+		Description: req.Description,
+		Sku:         req.Sku,
+		Slug:        req.Slug,
+		Attributes:  req.Attributes,
+	}, req.ExpectedVersion)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.UpdateCatalogItemResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "Item not found")
+	}
+	if err != nil {
+		return &pb.UpdateCatalogItemResponse{ErrorMessage: err.Error()}, updateError(err)
+	}
+	return &pb.UpdateCatalogItemResponse{}, nil
+}
+
+// ResolveLegacyItemIDs returns the generated IDs of the items formerly identified by their title.
+func (s *CatalogServer) ResolveLegacyItemIDs(ctx context.Context, req *pb.ResolveLegacyItemIDsRequest) (*pb.ResolveLegacyItemIDsResponse, error) {
+
+	itemIDs, err := s.repo.ResolveLegacyItemIDs(req.LegacyIds)
+	if err != nil {
+		return &pb.ResolveLegacyItemIDsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ResolveLegacyItemIDsResponse{ItemIds: itemIDs}, nil
+}
+
 // UpdateItemQuantity updates the quantity of an item in the cart of a specific user
 func (s *CatalogServer) UpdateQuantityAvailable(ctx context.Context, req *pb.UpdateQuantityAvailableRequest) (*pb.UpdateQuantityAvailableResponse, error) {
 
@@ -93,8 +208,15 @@ func (s *CatalogServer) UpdateQuantityAvailable(ctx context.Context, req *pb.Upd
 		}, status.Error(codes.InvalidArgument, "Quantity must be greater than zero")
 	}
 
-	if err := s.repo.UpdateQuantityAvailable(req.ItemId, req.Quantity); err != nil {
-		return &pb.UpdateQuantityAvailableResponse{ErrorMessage: err.Error()}, err
+	if req.Reason != pb.StockMovementReason_MOVEMENT_ADJUSTMENT && req.Reason != pb.StockMovementReason_MOVEMENT_RESTOCK {
+		return &pb.UpdateQuantityAvailableResponse{
+			ErrorMessage: "Reason must be an adjustment or a restock",
+		}, status.Error(codes.InvalidArgument, "Reason must be an adjustment or a restock")
+	}
+
+	reason := domain.ProtoMovementReasonToDomain(req.Reason)
+	if err := s.repo.UpdateQuantityAvailable(req.ItemId, req.WarehouseId, req.Quantity, req.ExpectedVersion, reason, actorFromContext(ctx)); err != nil {
+		return &pb.UpdateQuantityAvailableResponse{ErrorMessage: err.Error()}, updateError(err)
 	}
 	return &pb.UpdateQuantityAvailableResponse{}, nil
 }
@@ -114,19 +236,832 @@ func (s *CatalogServer) UpdatePrice(ctx context.Context, req *pb.UpdatePriceRequ
 		}, status.Error(codes.InvalidArgument, "Price must be non-negative")
 	}
 
-	if err := s.repo.UpdatePrice(req.ItemId, req.Price); err != nil {
-		return &pb.UpdatePriceResponse{ErrorMessage: err.Error()}, err
+	if err := s.repo.UpdatePrice(req.ItemId, req.Price, req.ExpectedVersion); err != nil {
+		return &pb.UpdatePriceResponse{ErrorMessage: err.Error()}, updateError(err)
 	}
 
 	return &pb.UpdatePriceResponse{}, nil
 }
 
-// ListCatalogItems retrieves all catalog items.
+// ListCatalogItems retrieves a page of the catalog items, filtered and sorted.
 func (s *CatalogServer) ListCatalogItems(ctx context.Context, req *pb.ListCatalogItemsRequest) (*pb.ListCatalogItemsResponse, error) {
 
-	catalogItems, err := s.repo.ListCatalogItems()
+	if req.PageSize < 0 {
+		return &pb.ListCatalogItemsResponse{
+			ErrorMessage: "Page size cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Page size cannot be negative")
+	}
+
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return &pb.ListCatalogItemsResponse{
+			ErrorMessage: "Prices cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Prices cannot be negative")
+	}
+
+	if req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return &pb.ListCatalogItemsResponse{
+			ErrorMessage: "Minimum price cannot be greater than maximum price",
+		}, status.Error(codes.InvalidArgument, "Minimum price cannot be greater than maximum price")
+	}
+
+	catalogItems, nextPageToken, err := s.repo.ListCatalogItems(domain.ProtoRequestToCatalogQuery(req))
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return &pb.ListCatalogItemsResponse{ErrorMessage: err.Error()}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.ListCatalogItemsResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "Category not found")
+	}
 	if err != nil {
 		return &pb.ListCatalogItemsResponse{Items: nil, ErrorMessage: err.Error()}, err
 	}
-	return &pb.ListCatalogItemsResponse{Items: catalogItems}, nil
+	return &pb.ListCatalogItemsResponse{Items: catalogItems, NextPageToken: nextPageToken}, nil
+}
+
+// SearchCatalog searches the IDs and descriptions of the catalog items.
+func (s *CatalogServer) SearchCatalog(ctx context.Context, req *pb.SearchCatalogRequest) (*pb.SearchCatalogResponse, error) {
+
+	if strings.TrimSpace(req.Query) == "" {
+		return &pb.SearchCatalogResponse{
+			ErrorMessage: "Query must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Query must be provided and not empty")
+	}
+
+	if req.Limit < 0 {
+		return &pb.SearchCatalogResponse{
+			ErrorMessage: "Limit cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Limit cannot be negative")
+	}
+
+	hits, err := s.repo.SearchCatalog(req.Query, int(req.Limit))
+	if err != nil {
+		return &pb.SearchCatalogResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.SearchCatalogResponse{Hits: hits}, nil
+}
+
+// ReserveStock reserves the quantity of several items, all or none of them.
+func (s *CatalogServer) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+
+	if len(req.Items) == 0 {
+		return &pb.ReserveStockResponse{
+			ErrorMessage: "At least one item must be reserved",
+		}, status.Error(codes.InvalidArgument, "At least one item must be reserved")
+	}
+
+	for _, item := range req.Items {
+		if item.ItemId == "" || item.Quantity == 0 {
+			return &pb.ReserveStockResponse{
+				ErrorMessage: "ItemId and a quantity greater than zero must be provided",
+			}, status.Error(codes.InvalidArgument, "ItemId and a quantity greater than zero must be provided")
+		}
+	}
+
+	if req.TtlSeconds < 0 {
+		return &pb.ReserveStockResponse{
+			ErrorMessage: "Ttl cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Ttl cannot be negative")
+	}
+
+	// The nearest warehouses are the ones nearest to the destination, without it the fewest are used
+	strategy := allocation.FewestSplits()
+	if req.Strategy == pb.AllocationStrategy_ALLOCATE_NEAREST && req.Destination != nil {
+		destination := allocation.Location{Latitude: req.Destination.Latitude, Longitude: req.Destination.Longitude}
+		if !destination.Valid() {
+			return &pb.ReserveStockResponse{
+				ErrorMessage: "Destination is not a valid location",
+			}, status.Error(codes.InvalidArgument, "Destination is not a valid location")
+		}
+		strategy = allocation.Nearest(&destination)
+	}
+
+	reservationID, expiresAt, allocations, err := s.repo.ReserveStock(req.Items, time.Duration(req.TtlSeconds)*time.Second, strategy, actorFromContext(ctx))
+	if err != nil {
+		return &pb.ReserveStockResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.ReserveStockResponse{ReservationId: reservationID, ExpiresAt: expiresAt.Unix(), Allocations: allocations}, nil
+}
+
+// CommitReservation makes a reservation definitive.
+func (s *CatalogServer) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+
+	if req.ReservationId == "" {
+		return &pb.CommitReservationResponse{
+			ErrorMessage: "ReservationId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ReservationId must be provided and not empty")
+	}
+
+	if err := s.repo.CommitReservation(req.ReservationId, req.OrderId); err != nil {
+		return &pb.CommitReservationResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.CommitReservationResponse{}, nil
+}
+
+// ReleaseReservation gives back the stock of a reservation.
+func (s *CatalogServer) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+
+	if req.ReservationId == "" {
+		return &pb.ReleaseReservationResponse{
+			ErrorMessage: "ReservationId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ReservationId must be provided and not empty")
+	}
+
+	if err := s.repo.ReleaseReservation(req.ReservationId, actorFromContext(ctx)); err != nil {
+		return &pb.ReleaseReservationResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.ReleaseReservationResponse{}, nil
+}
+
+// RestockItems gives back the quantity of several items.
+func (s *CatalogServer) RestockItems(ctx context.Context, req *pb.RestockItemsRequest) (*pb.RestockItemsResponse, error) {
+
+	if req.RestockId == "" {
+		return &pb.RestockItemsResponse{
+			ErrorMessage: "RestockId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "RestockId must be provided and not empty")
+	}
+
+	if len(req.Items) == 0 {
+		return &pb.RestockItemsResponse{
+			ErrorMessage: "At least one item must be restocked",
+		}, status.Error(codes.InvalidArgument, "At least one item must be restocked")
+	}
+
+	if err := s.repo.RestockItems(req.RestockId, req.OrderId, req.Items, actorFromContext(ctx)); err != nil {
+		return &pb.RestockItemsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.RestockItemsResponse{}, nil
+}
+
+// CreateCategory creates a category of the catalog.
+func (s *CatalogServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+
+	if strings.TrimSpace(req.Name) == "" {
+		return &pb.CreateCategoryResponse{
+			ErrorMessage: "Name must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Name must be provided and not empty")
+	}
+
+	categoryID, err := s.repo.CreateCategory(req.Name, req.Slug, req.ParentId)
+	if err != nil {
+		return &pb.CreateCategoryResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.CreateCategoryResponse{CategoryId: categoryID}, nil
+}
+
+// UpdateCategory renames a category.
+func (s *CatalogServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+
+	if req.CategoryId == "" {
+		return &pb.UpdateCategoryResponse{
+			ErrorMessage: "CategoryId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "CategoryId must be provided and not empty")
+	}
+
+	if err := s.repo.UpdateCategory(req.CategoryId, req.Name, req.Slug); err != nil {
+		return &pb.UpdateCategoryResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.UpdateCategoryResponse{}, nil
+}
+
+// MoveCategory moves a category under another one, or to the root.
+func (s *CatalogServer) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.MoveCategoryResponse, error) {
+
+	if req.CategoryId == "" {
+		return &pb.MoveCategoryResponse{
+			ErrorMessage: "CategoryId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "CategoryId must be provided and not empty")
+	}
+
+	if err := s.repo.MoveCategory(req.CategoryId, req.ParentId); err != nil {
+		return &pb.MoveCategoryResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.MoveCategoryResponse{}, nil
+}
+
+// DeleteCategory deletes a category without subcategories nor items.
+func (s *CatalogServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+
+	if req.CategoryId == "" {
+		return &pb.DeleteCategoryResponse{
+			ErrorMessage: "CategoryId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "CategoryId must be provided and not empty")
+	}
+
+	if err := s.repo.DeleteCategory(req.CategoryId); err != nil {
+		return &pb.DeleteCategoryResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.DeleteCategoryResponse{}, nil
+}
+
+// ListCategories lists all the categories of the catalog.
+func (s *CatalogServer) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+
+	categories, err := s.repo.ListCategories()
+	if err != nil {
+		return &pb.ListCategoriesResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListCategoriesResponse{Categories: categories}, nil
+}
+
+// SetItemCategory sets or removes the category of an item.
+func (s *CatalogServer) SetItemCategory(ctx context.Context, req *pb.SetItemCategoryRequest) (*pb.SetItemCategoryResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.SetItemCategoryResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	if err := s.repo.SetItemCategory(req.ItemId, req.CategoryId); err != nil {
+		return &pb.SetItemCategoryResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.SetItemCategoryResponse{}, nil
+}
+
+// SetItemTags replaces the tags of an item.
+func (s *CatalogServer) SetItemTags(ctx context.Context, req *pb.SetItemTagsRequest) (*pb.SetItemTagsResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.SetItemTagsResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	if err := s.repo.SetItemTags(req.ItemId, req.Tags); err != nil {
+		return &pb.SetItemTagsResponse{ErrorMessage: err.Error()}, categoryError(err)
+	}
+	return &pb.SetItemTagsResponse{}, nil
+}
+
+// ListTags lists the tags in use with the number of items having them.
+func (s *CatalogServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+
+	tags, err := s.repo.ListTags()
+	if err != nil {
+		return &pb.ListTagsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListTagsResponse{Tags: tags}, nil
+}
+
+// ImportCatalogItems adds or updates the items of a CSV or JSON file streamed in chunks, and reports the rows with errors.
+func (s *CatalogServer) ImportCatalogItems(stream pb.CatalogService_ImportCatalogItemsServer) error {
+
+	// The format and the dry-run mode are read from the first message
+	var data bytes.Buffer
+	var format pb.CatalogFileFormat
+	dryRun := false
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first {
+			format, dryRun = req.Format, req.DryRun
+		}
+		if data.Len()+len(req.Chunk) > repository.MaxImportSize {
+			return status.Errorf(codes.InvalidArgument, "File too large: at most %d bytes", repository.MaxImportSize)
+		}
+		data.Write(req.Chunk)
+	}
+
+	report, err := s.repo.ImportCatalogItems(format, &data, dryRun, actorFromContext(stream.Context()))
+	if errors.Is(err, repository.ErrInvalidImportFile) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(report)
+}
+
+// ExportCatalogItems streams every item of the catalog as a CSV or JSON file, in chunks.
+func (s *CatalogServer) ExportCatalogItems(req *pb.ExportCatalogItemsRequest, stream pb.CatalogService_ExportCatalogItemsServer) error {
+	writer := bufio.NewWriterSize(&exportWriter{stream: stream}, exportChunkSize)
+	if err := s.repo.ExportCatalogItems(req.Format, writer); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// exportWriter sends what is written to it as chunks of an exported file
+type exportWriter struct {
+	stream pb.CatalogService_ExportCatalogItemsServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.ExportCatalogItemsResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WatchCatalog streams the changes of the catalog until the caller stops watching.
+// A caller too slow to receive them is disconnected, it has to watch again and reload the catalog.
+func (s *CatalogServer) WatchCatalog(req *pb.WatchCatalogRequest, stream pb.CatalogService_WatchCatalogServer) error {
+	events, cancel := s.repo.WatchCatalog()
+	defer cancel()
+
+	// The headers tell the caller that the changes are watched from now on
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "Watcher too slow, some changes were lost")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// GetPriceHistory returns the prices an item has had, the most recent first.
+func (s *CatalogServer) GetPriceHistory(ctx context.Context, req *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.GetPriceHistoryResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	changes, err := s.repo.GetPriceHistory(req.ItemId)
+	if err != nil {
+		return &pb.GetPriceHistoryResponse{ErrorMessage: err.Error()}, priceError(err)
+	}
+	return &pb.GetPriceHistoryResponse{Changes: changes}, nil
+}
+
+// SchedulePriceChange schedules the price of an item, for good or for a sale until ends_at.
+func (s *CatalogServer) SchedulePriceChange(ctx context.Context, req *pb.SchedulePriceChangeRequest) (*pb.SchedulePriceChangeResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.SchedulePriceChangeResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	if req.Price < 0 {
+		return &pb.SchedulePriceChangeResponse{
+			ErrorMessage: "Price must be non-negative",
+		}, status.Error(codes.InvalidArgument, "Price must be non-negative")
+	}
+
+	if req.StartsAt <= time.Now().Unix() {
+		return &pb.SchedulePriceChangeResponse{
+			ErrorMessage: "StartsAt must be in the future",
+		}, status.Error(codes.InvalidArgument, "StartsAt must be in the future")
+	}
+
+	if req.EndsAt != 0 && req.EndsAt <= req.StartsAt {
+		return &pb.SchedulePriceChangeResponse{
+			ErrorMessage: "EndsAt must be after StartsAt, or 0 for a permanent change",
+		}, status.Error(codes.InvalidArgument, "EndsAt must be after StartsAt, or 0 for a permanent change")
+	}
+
+	var endsAt time.Time
+	if req.EndsAt > 0 {
+		endsAt = time.Unix(req.EndsAt, 0)
+	}
+
+	scheduleID, err := s.repo.SchedulePriceChange(req.ItemId, req.Price, time.Unix(req.StartsAt, 0), endsAt)
+	if err != nil {
+		return &pb.SchedulePriceChangeResponse{ErrorMessage: err.Error()}, priceError(err)
+	}
+	return &pb.SchedulePriceChangeResponse{ScheduleId: scheduleID}, nil
+}
+
+// ListScheduledPrices returns the scheduled prices of an item not applied yet and its sale in progress.
+func (s *CatalogServer) ListScheduledPrices(ctx context.Context, req *pb.ListScheduledPricesRequest) (*pb.ListScheduledPricesResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.ListScheduledPricesResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	schedules, err := s.repo.ListScheduledPrices(req.ItemId)
+	if err != nil {
+		return &pb.ListScheduledPricesResponse{ErrorMessage: err.Error()}, priceError(err)
+	}
+	return &pb.ListScheduledPricesResponse{Schedules: schedules}, nil
+}
+
+// CancelScheduledPrice cancels a scheduled price, a sale in progress is ended.
+func (s *CatalogServer) CancelScheduledPrice(ctx context.Context, req *pb.CancelScheduledPriceRequest) (*pb.CancelScheduledPriceResponse, error) {
+
+	if req.ScheduleId == "" {
+		return &pb.CancelScheduledPriceResponse{
+			ErrorMessage: "ScheduleId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ScheduleId must be provided and not empty")
+	}
+
+	if err := s.repo.CancelScheduledPrice(req.ScheduleId); err != nil {
+		return &pb.CancelScheduledPriceResponse{ErrorMessage: err.Error()}, priceError(err)
+	}
+	return &pb.CancelScheduledPriceResponse{}, nil
+}
+
+// ListStockMovements returns a page of the movements of the stock of an item, of an order or of the whole catalog.
+func (s *CatalogServer) ListStockMovements(ctx context.Context, req *pb.ListStockMovementsRequest) (*pb.ListStockMovementsResponse, error) {
+
+	if req.PageSize < 0 {
+		return &pb.ListStockMovementsResponse{
+			ErrorMessage: "Page size cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Page size cannot be negative")
+	}
+
+	movements, nextPageToken, err := s.repo.ListStockMovements(req.ItemId, req.OrderId, int(req.PageSize), req.PageToken)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return &pb.ListStockMovementsResponse{ErrorMessage: err.Error()}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &pb.ListStockMovementsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListStockMovementsResponse{Movements: movements, NextPageToken: nextPageToken}, nil
+}
+
+// ReconcileStock reports the items whose quantity available differs from their ledger, and fixes them if asked.
+func (s *CatalogServer) ReconcileStock(ctx context.Context, req *pb.ReconcileStockRequest) (*pb.ReconcileStockResponse, error) {
+
+	discrepancies, checked, err := s.repo.ReconcileStock(req.Apply)
+	if err != nil {
+		return &pb.ReconcileStockResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ReconcileStockResponse{Discrepancies: discrepancies, ItemsChecked: uint32(checked)}, nil
+}
+
+// CreateWarehouse adds a warehouse where the catalog items can be stocked.
+func (s *CatalogServer) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.CreateWarehouseResponse, error) {
+
+	if req.Warehouse == nil || req.Warehouse.Name == "" {
+		return &pb.CreateWarehouseResponse{
+			ErrorMessage: "Warehouse name must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Warehouse name must be provided and not empty")
+	}
+
+	location := req.Warehouse.GetLocation()
+	warehouseID, err := s.repo.CreateWarehouse(req.Warehouse.Name, location.GetLatitude(), location.GetLongitude())
+	if err != nil {
+		return &pb.CreateWarehouseResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.CreateWarehouseResponse{WarehouseId: warehouseID}, nil
+}
+
+// ListWarehouses returns all the warehouses.
+func (s *CatalogServer) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+
+	warehouses, err := s.repo.ListWarehouses()
+	if err != nil {
+		return &pb.ListWarehousesResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListWarehousesResponse{Warehouses: warehouses}, nil
+}
+
+// GetItemStock returns the quantity of an item in each warehouse having it.
+func (s *CatalogServer) GetItemStock(ctx context.Context, req *pb.GetItemStockRequest) (*pb.GetItemStockResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.GetItemStockResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	stock, err := s.repo.GetItemStock(req.ItemId)
+	if err != nil {
+		return &pb.GetItemStockResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.GetItemStockResponse{Stock: stock}, nil
+}
+
+// TransferStock moves a quantity of an item from a warehouse to another.
+func (s *CatalogServer) TransferStock(ctx context.Context, req *pb.TransferStockRequest) (*pb.TransferStockResponse, error) {
+
+	if req.ItemId == "" || req.FromWarehouseId == "" || req.ToWarehouseId == "" {
+		return &pb.TransferStockResponse{
+			ErrorMessage: "ItemId and the warehouses must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId and the warehouses must be provided and not empty")
+	}
+
+	if req.Quantity == 0 {
+		return &pb.TransferStockResponse{
+			ErrorMessage: "Quantity must be greater than zero",
+		}, status.Error(codes.InvalidArgument, "Quantity must be greater than zero")
+	}
+
+	if req.FromWarehouseId == req.ToWarehouseId {
+		return &pb.TransferStockResponse{
+			ErrorMessage: "Stock must be transferred to another warehouse",
+		}, status.Error(codes.InvalidArgument, "Stock must be transferred to another warehouse")
+	}
+
+	if err := s.repo.TransferStock(req.ItemId, req.FromWarehouseId, req.ToWarehouseId, req.Quantity, actorFromContext(ctx)); err != nil {
+		return &pb.TransferStockResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.TransferStockResponse{}, nil
+}
+
+// SetReorderThreshold sets the quantity available below which a low-stock alert is raised for an item.
+func (s *CatalogServer) SetReorderThreshold(ctx context.Context, req *pb.SetReorderThresholdRequest) (*pb.SetReorderThresholdResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.SetReorderThresholdResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	if err := s.repo.SetReorderThreshold(req.ItemId, req.Threshold); err != nil {
+		return &pb.SetReorderThresholdResponse{ErrorMessage: err.Error()}, reservationError(err)
+	}
+	return &pb.SetReorderThresholdResponse{}, nil
+}
+
+// ListStockAlerts returns the low-stock alerts, the most recent first.
+func (s *CatalogServer) ListStockAlerts(ctx context.Context, req *pb.ListStockAlertsRequest) (*pb.ListStockAlertsResponse, error) {
+
+	alerts, err := s.repo.ListStockAlerts(req.IncludeResolved)
+	if err != nil {
+		return &pb.ListStockAlertsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListStockAlertsResponse{Alerts: alerts}, nil
+}
+
+// GetReorderSuggestions suggests the quantity to reorder of the items from their recent sales, asked to the order service.
+func (s *CatalogServer) GetReorderSuggestions(ctx context.Context, req *pb.GetReorderSuggestionsRequest) (*pb.GetReorderSuggestionsResponse, error) {
+
+	windowDays, coverDays := cmp.Or(req.WindowDays, defaultSalesWindowDays), cmp.Or(req.CoverDays, defaultCoverDays)
+	if windowDays > maxSuggestionDays || coverDays > maxSuggestionDays {
+		return &pb.GetReorderSuggestionsResponse{
+			ErrorMessage: "Sales window and days of cover cannot be longer than a year",
+		}, status.Error(codes.InvalidArgument, "Sales window and days of cover cannot be longer than a year")
+	}
+
+	since := time.Now().AddDate(0, 0, -int(windowDays))
+	salesRes, err := s.orders.GetItemSales(ctx, &pbOrder.GetItemSalesRequest{Since: since.Unix()})
+	if err != nil {
+		return &pb.GetReorderSuggestionsResponse{
+			ErrorMessage: "Sales not available: " + status.Convert(err).Message(),
+		}, status.Error(codes.Unavailable, "Sales not available: "+status.Convert(err).Message())
+	}
+
+	sales := make(map[string]uint32, len(salesRes.GetSales()))
+	for _, itemSales := range salesRes.GetSales() {
+		sales[itemSales.GetItemId()] = itemSales.GetQuantity()
+	}
+
+	suggestions, err := s.repo.ReorderSuggestions(sales, windowDays, coverDays)
+	if err != nil {
+		return &pb.GetReorderSuggestionsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.GetReorderSuggestionsResponse{Suggestions: suggestions}, nil
+}
+
+// AddItemImage adds an image to an item, after its other images.
+func (s *CatalogServer) AddItemImage(ctx context.Context, req *pb.AddItemImageRequest) (*pb.AddItemImageResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.AddItemImageResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	if len(req.Data) == 0 {
+		return &pb.AddItemImageResponse{
+			ErrorMessage: "Image must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Image must be provided and not empty")
+	}
+
+	image, err := s.repo.AddItemImage(req.ItemId, req.Data)
+	if err != nil {
+		return &pb.AddItemImageResponse{ErrorMessage: err.Error()}, imageError(err)
+	}
+	return &pb.AddItemImageResponse{Image: image}, nil
+}
+
+// RemoveItemImage removes an image of an item.
+func (s *CatalogServer) RemoveItemImage(ctx context.Context, req *pb.RemoveItemImageRequest) (*pb.RemoveItemImageResponse, error) {
+
+	if req.ItemId == "" || req.ImageId == "" {
+		return &pb.RemoveItemImageResponse{
+			ErrorMessage: "ItemId and ImageId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId and ImageId must be provided and not empty")
+	}
+
+	if err := s.repo.RemoveItemImage(req.ItemId, req.ImageId); err != nil {
+		return &pb.RemoveItemImageResponse{ErrorMessage: err.Error()}, imageError(err)
+	}
+	return &pb.RemoveItemImageResponse{}, nil
+}
+
+// ReorderItemImages puts the images of an item in a new order.
+func (s *CatalogServer) ReorderItemImages(ctx context.Context, req *pb.ReorderItemImagesRequest) (*pb.ReorderItemImagesResponse, error) {
+
+	if req.ItemId == "" || len(req.ImageIds) == 0 {
+		return &pb.ReorderItemImagesResponse{
+			ErrorMessage: "ItemId and ImageIds must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId and ImageIds must be provided and not empty")
+	}
+
+	if err := s.repo.ReorderItemImages(req.ItemId, req.ImageIds); err != nil {
+		return &pb.ReorderItemImagesResponse{ErrorMessage: err.Error()}, imageError(err)
+	}
+	return &pb.ReorderItemImagesResponse{}, nil
+}
+
+// GetImage returns the content of an image, or of its thumbnail.
+func (s *CatalogServer) GetImage(ctx context.Context, req *pb.GetImageRequest) (*pb.GetImageResponse, error) {
+
+	if req.ImageId == "" {
+		return &pb.GetImageResponse{
+			ErrorMessage: "ImageId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ImageId must be provided and not empty")
+	}
+
+	data, contentType, err := s.repo.GetImage(req.ImageId, req.Thumbnail)
+	if err != nil {
+		return &pb.GetImageResponse{ErrorMessage: err.Error()}, imageError(err)
+	}
+	return &pb.GetImageResponse{Data: data, ContentType: contentType}, nil
+}
+
+// SubmitReview writes the review of an item by the calling customer, who must have bought it.
+func (s *CatalogServer) SubmitReview(ctx context.Context, req *pb.SubmitReviewRequest) (*pb.SubmitReviewResponse, error) {
+
+	if req.ItemId == "" || strings.TrimSpace(req.Text) == "" {
+		return &pb.SubmitReviewResponse{
+			ErrorMessage: "ItemId and Text must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId and Text must be provided and not empty")
+	}
+	if req.Rating < domain.MinRating || req.Rating > domain.MaxRating {
+		return &pb.SubmitReviewResponse{
+			ErrorMessage: "Rating must be from 1 to 5 stars",
+		}, status.Error(codes.InvalidArgument, "Rating must be from 1 to 5 stars")
+	}
+
+	// The items bought by the customer, in the orders paid and not canceled
+	username := actorFromContext(ctx)
+	ordersRes, err := s.orders.ListOrdersByUser(ctx, &pbOrder.ListOrdersByUserRequest{UserId: username})
+	if err != nil {
+		return &pb.SubmitReviewResponse{
+			ErrorMessage: "Orders not available: " + status.Convert(err).Message(),
+		}, status.Error(codes.Unavailable, "Orders not available: "+status.Convert(err).Message())
+	}
+	var purchased []string
+	for _, order := range ordersRes.GetOrders() {
+		if !slices.Contains(purchasedOrderStatuses, order.GetStatus()) {
+			continue
+		}
+		for _, item := range order.GetItems() {
+			purchased = append(purchased, item.GetItemId())
+		}
+	}
+
+	review, err := s.repo.SubmitReview(req.ItemId, username, req.Rating, req.Text, purchased)
+	if err != nil {
+		return &pb.SubmitReviewResponse{ErrorMessage: err.Error()}, reviewError(err)
+	}
+	return &pb.SubmitReviewResponse{Review: review}, nil
+}
+
+// ListReviews retrieves the approved reviews of an item.
+func (s *CatalogServer) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+
+	if req.ItemId == "" {
+		return &pb.ListReviewsResponse{
+			ErrorMessage: "ItemId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ItemId must be provided and not empty")
+	}
+
+	reviews, err := s.repo.ListReviews(req.ItemId)
+	if err != nil {
+		return &pb.ListReviewsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListReviewsResponse{Reviews: reviews}, nil
+}
+
+// ListPendingReviews retrieves the reviews waiting for moderation.
+func (s *CatalogServer) ListPendingReviews(ctx context.Context, req *pb.ListPendingReviewsRequest) (*pb.ListPendingReviewsResponse, error) {
+
+	reviews, err := s.repo.ListPendingReviews()
+	if err != nil {
+		return &pb.ListPendingReviewsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListPendingReviewsResponse{Reviews: reviews}, nil
+}
+
+// ModerateReview approves or rejects a review.
+func (s *CatalogServer) ModerateReview(ctx context.Context, req *pb.ModerateReviewRequest) (*pb.ModerateReviewResponse, error) {
+
+	if req.ReviewId == "" {
+		return &pb.ModerateReviewResponse{
+			ErrorMessage: "ReviewId must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "ReviewId must be provided and not empty")
+	}
+
+	if err := s.repo.ModerateReview(req.ReviewId, req.Approve); err != nil {
+		return &pb.ModerateReviewResponse{ErrorMessage: err.Error()}, reviewError(err)
+	}
+	return &pb.ModerateReviewResponse{}, nil
+}
+
+// actorFromContext returns the user or service calling the RPC, recorded in the movements of the stock.
+func actorFromContext(ctx context.Context) string {
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		return claims.Subject
+	}
+	return "unknown"
+}
+
+// categoryError maps the errors of the categories and tags to gRPC codes.
+func categoryError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, repository.ErrCategoryNotEmpty) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, repository.ErrCategoryCycle) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, repository.ErrVariantClassified) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// variantError maps the errors of the items whose stock and price are the ones of their variants.
+func variantError(err error) error {
+	if errors.Is(err, repository.ErrProductHasVariants) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// updateError maps the errors of the updates of an item to gRPC codes,
+// an update based on a stale version is aborted and can be retried with the fresh values.
+func updateError(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return variantError(err)
+}
+
+// reservationError maps the errors of the reservations to gRPC codes,
+// so that callers can tell a lack of stock from a failure of the service.
+func reservationError(err error) error {
+	if errors.Is(err, repository.ErrInsufficientStock) || errors.Is(err, repository.ErrReservationClosed) ||
+		errors.Is(err, repository.ErrProductHasVariants) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+// priceError maps the errors of the price history and the scheduled prices to gRPC codes.
+func priceError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, repository.ErrScheduleOverlap) || errors.Is(err, repository.ErrScheduleClosed) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return variantError(err)
+}
+
+// imageError maps the errors of the images of the items to gRPC codes.
+func imageError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, media.ErrBlobNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, media.ErrInvalidImage) || errors.Is(err, repository.ErrImageOrderMismatch) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, repository.ErrTooManyImages) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// reviewError maps the errors of the reviews to gRPC codes.
+func reviewError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, repository.ErrInvalidReview) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, repository.ErrNotPurchased) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// AppliedMigration records a data migration already applied, so that it runs only once.
+type AppliedMigration struct {
+
+	// Name of the migration
+	Name string `gorm:"primaryKey; not null; check:name <> ''"`
+
+	// CreatedAt is when the migration was applied
+	CreatedAt time.Time
+}
@@ -8,8 +8,17 @@ import (
 
 type CatalogItem struct {
 
-	// ItemID is the unique identifier for the catalog item.
-	ItemID string `gorm:"primaryKey;not null; check:item_id <> ''"`
+	// ItemID is the unique identifier for the catalog item, a ULID generated by the catalog that never changes.
+	ItemID string `gorm:"primaryKey;not null; check:item_id <> ''; index:idx_catalog_items_price,priority:2; index:idx_catalog_items_created,priority:2; index:idx_catalog_items_name,priority:2; index:idx_catalog_items_rating,priority:3"`
+
+	// Name is the title of the catalog item shown to the users.
+	Name string `gorm:"not null; default:''; index:idx_catalog_items_name,priority:1"`
+
+	// SKU is the optional stock keeping unit of the catalog item, unique when set.
+	SKU string `gorm:"column:sku; not null; default:''; uniqueIndex:idx_catalog_items_sku,where:sku <> ''"`
+
+	// Slug identifies the catalog item in URLs, unique and derived from the name when not chosen.
+	Slug string `gorm:"not null; default:''; uniqueIndex:idx_catalog_items_slug,where:slug <> ''"`
 
 	// Description provides details about the catalog item.
 	Description string `gorm:"not null; check:description <> ''"`
@@ -18,7 +27,31 @@ type CatalogItem struct {
 	QuantityAvailable uint32 `gorm:"not null; check:quantity_available >= 0"`
 
 	// Price indicates the price of the catalog item.
-	Price float64 `gorm:"not null; check:price >= 0"`
+	Price float64 `gorm:"not null; check:price >= 0; index:idx_catalog_items_price,priority:1"`
+
+	// ProductID is the item this one is a variant of, empty for a product or a standalone item.
+	// A product with variants has the lowest price and the total quantity of its variants, it is not sold itself.
+	ProductID string `gorm:"not null; default:''; index"`
+
+	// Attributes distinguish a variant from the others of its product, e.g. edition, language or format.
+	Attributes map[string]string `gorm:"type:text; serializer:json"`
+
+	// CategoryID is the category of the catalog item, empty if not categorized.
+	CategoryID string `gorm:"not null; default:''; index"`
+
+	// CreatedAt is when the item was added to the catalog, in nanoseconds since the epoch.
+	CreatedAt int64 `gorm:"not null; default:0; index:idx_catalog_items_created,priority:1"`
+
+	// Version is incremented by every change of the item, to detect the updates based on stale values.
+	Version uint64 `gorm:"not null; default:1"`
+
+	// ReorderThreshold is the quantity available below which a low-stock alert is raised, 0 for no alerts.
+	ReorderThreshold uint32 `gorm:"not null; default:0"`
+
+	// RatingAverage and RatingCount are the average stars and the number of the approved reviews of the item.
+	// They are kept by the reviews, an update of the item never changes them.
+	RatingAverage float64 `gorm:"not null; default:0; index:idx_catalog_items_rating,priority:1"`
+	RatingCount   uint32  `gorm:"not null; default:0; index:idx_catalog_items_rating,priority:2"`
 }
 
 // DomainCatalogItemToProtoCatalogItem converts a model.CatalogItem into a pb.CatalogItem
@@ -29,8 +62,18 @@ func DomainCatalogItemToProtoCatalogItem(item *CatalogItem) (*pb.CatalogItem, er
 
 	return &pb.CatalogItem{
 		ItemId:            item.ItemID,
+		Name:              item.Name,
+		Sku:               item.SKU,
+		Slug:              item.Slug,
+		CategoryId:        item.CategoryID,
+		ProductId:         item.ProductID,
+		Attributes:        item.Attributes,
 		Description:       item.Description,
 		QuantityAvailable: item.QuantityAvailable,
 		Price:             item.Price,
+		Version:           item.Version,
+		ReorderThreshold:  item.ReorderThreshold,
+		RatingAverage:     item.RatingAverage,
+		RatingCount:       item.RatingCount,
 	}, nil
 }
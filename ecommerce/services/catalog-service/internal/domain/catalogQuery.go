@@ -0,0 +1,49 @@
+package domain
+
+import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+
+// Limits on the size of a page of the catalog
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// CatalogQuery selects a page of the catalog.
+type CatalogQuery struct {
+
+	// PageSize is the maximum number of items returned, DefaultPageSize if 0.
+	PageSize int
+
+	// PageToken continues a previous listing, empty for the first page.
+	PageToken string
+
+	// MinPrice and MaxPrice restrict the price of the items, MaxPrice 0 means no upper bound.
+	MinPrice float64
+	MaxPrice float64
+
+	// InStockOnly excludes the items with no quantity available.
+	InStockOnly bool
+
+	// Sort is the order of the items.
+	Sort pb.CatalogSort
+
+	// CategoryID keeps the items of the category and of its subcategories, if set.
+	CategoryID string
+
+	// Tag keeps the items having the tag, if set.
+	Tag string
+}
+
+// ProtoRequestToCatalogQuery converts a pb.ListCatalogItemsRequest into a CatalogQuery
+func ProtoRequestToCatalogQuery(req *pb.ListCatalogItemsRequest) CatalogQuery {
+	return CatalogQuery{
+		PageSize:    int(req.PageSize),
+		PageToken:   req.PageToken,
+		MinPrice:    req.MinPrice,
+		MaxPrice:    req.MaxPrice,
+		InStockOnly: req.InStockOnly,
+		Sort:        req.Sort,
+		CategoryID:  req.CategoryId,
+		Tag:         req.Tag,
+	}
+}
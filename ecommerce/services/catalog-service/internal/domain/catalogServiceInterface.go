@@ -1,11 +1,17 @@
 package domain
 
-import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+import (
+	"io"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/allocation"
+)
 
 type CatalogServiceInterface interface {
 
-	// AddCatalogItem adds a new catalog item to the catalog.
-	AddCatalogItem(item *pb.CatalogItem) error
+	// AddCatalogItem adds a new catalog item to the catalog on behalf of actor and returns its generated ID.
+	AddCatalogItem(item *pb.CatalogItem, actor string) (string, error)
 
 	// RemoveCatalogItem removes a catalog item from the catalog by its unique identifier.
 	RemoveCatalogItem(itemID string) error
@@ -13,12 +19,150 @@ type CatalogServiceInterface interface {
 	// GetCatalogItem retrieves a catalog item by its unique identifier.
 	GetCatalogItem(itemID string) (*pb.CatalogItem, error)
 
-	// UpdateQuantityAvailable updates the quantity available of a catalog item.
-	UpdateQuantityAvailable(itemID string, quantity uint32) error
+	// GetCatalogItemBySlug retrieves a catalog item by its slug.
+	GetCatalogItemBySlug(slug string) (*pb.CatalogItem, error)
+
+	// GetCatalogItemBySKU retrieves a catalog item by its SKU.
+	GetCatalogItemBySKU(sku string) (*pb.CatalogItem, error)
+
+	// GetCatalogItems retrieves several catalog items, the ones not found are left out.
+	GetCatalogItems(itemIDs []string) ([]*pb.CatalogItem, error)
+
+	// UpdateCatalogItem updates the name, description, SKU and slug of a catalog item, the empty ones are left unchanged.
+	// Updates of the items given an expected version other than zero are rejected if the item changed since then.
+	UpdateCatalogItem(details *pb.CatalogItem, expectedVersion uint64) error
+
+	// UpdateQuantityAvailable updates the quantity of a catalog item at a warehouse, as an adjustment or a restock by actor.
+	UpdateQuantityAvailable(itemID, warehouseID string, quantity uint32, expectedVersion uint64, reason MovementReason, actor string) error
 
 	// UpdatePrice updates the price of a catalog item.
-	UpdatePrice(itemID string, price float64) error
+	UpdatePrice(itemID string, price float64, expectedVersion uint64) error
+
+	// ListCatalogItems retrieves a page of the catalog items matching the query, with the token of the next page.
+	ListCatalogItems(query CatalogQuery) ([]*pb.CatalogItem, string, error)
+
+	// SearchCatalog returns the catalog items matching the words of the query, the most relevant first.
+	SearchCatalog(query string, limit int) ([]*pb.SearchHit, error)
+
+	// ReserveStock takes the quantity of several items from the stock, all or none of them,
+	// from the warehouses chosen by the strategy.
+	// The reservation is released automatically if it is not committed before the ttl.
+	ReserveStock(items []*pb.StockItem, ttl time.Duration, strategy allocation.Strategy, actor string) (string, time.Time, []*pb.StockAllocation, error)
+
+	// CommitReservation makes a reservation definitive, the stock taken is recorded as sold to the order.
+	CommitReservation(reservationID, orderID string) error
+
+	// ReleaseReservation gives back the stock of a reservation not committed.
+	ReleaseReservation(reservationID, actor string) error
+
+	// ExpireReservations releases the reservations not committed before their expiration.
+	ExpireReservations(now time.Time) (int, error)
+
+	// ResolveLegacyItemIDs returns the generated IDs of the items formerly identified by the given titles.
+	ResolveLegacyItemIDs(legacyIDs []string) (map[string]string, error)
+
+	// CreateCategory creates a category under the parent one, or at the root if parentID is empty, and returns its generated ID.
+	CreateCategory(name string, slug string, parentID string) (string, error)
+
+	// UpdateCategory renames a category, the empty name or slug are left unchanged.
+	UpdateCategory(categoryID string, name string, slug string) error
+
+	// MoveCategory moves a category under another one, or to the root if parentID is empty.
+	MoveCategory(categoryID string, parentID string) error
+
+	// DeleteCategory deletes a category without subcategories nor items.
+	DeleteCategory(categoryID string) error
+
+	// ListCategories returns all the categories with the number of items they contain.
+	ListCategories() ([]*pb.Category, error)
+
+	// SetItemCategory sets the category of an item, an empty categoryID removes it.
+	SetItemCategory(itemID string, categoryID string) error
+
+	// SetItemTags replaces the tags of an item.
+	SetItemTags(itemID string, tags []string) error
+
+	// ListTags returns the tags in use with the number of items having them.
+	ListTags() ([]*pb.TagCount, error)
+
+	// ImportCatalogItems adds or updates the items of a CSV or JSON file, reporting the rows with errors.
+	ImportCatalogItems(format pb.CatalogFileFormat, data io.Reader, dryRun bool, actor string) (*pb.ImportCatalogItemsResponse, error)
+
+	// ExportCatalogItems writes every item of the catalog as a CSV or JSON file.
+	ExportCatalogItems(format pb.CatalogFileFormat, w io.Writer) error
+
+	// WatchCatalog subscribes to the changes of the catalog, until the returned function is called.
+	WatchCatalog() (<-chan *pb.CatalogEvent, func())
+
+	// RestockItems gives back the quantity of several items, a restock ID is applied only once.
+	RestockItems(restockID, orderID string, items []*pb.StockItem, actor string) error
+
+	// GetPriceHistory returns the prices a catalog item has had, the most recent first.
+	GetPriceHistory(itemID string) ([]*pb.PriceChange, error)
+
+	// SchedulePriceChange schedules the price of a catalog item, for good or for a sale until endsAt if not zero.
+	SchedulePriceChange(itemID string, price float64, startsAt, endsAt time.Time) (string, error)
+
+	// ListScheduledPrices returns the scheduled prices of a catalog item not applied yet and its sale in progress.
+	ListScheduledPrices(itemID string) ([]*pb.ScheduledPrice, error)
+
+	// CancelScheduledPrice cancels a scheduled price, a sale in progress is ended.
+	CancelScheduledPrice(scheduleID string) error
+
+	// ApplyScheduledPrices starts the scheduled prices and ends the sales due at the given time.
+	ApplyScheduledPrices(now time.Time) (int, error)
+
+	// ListStockMovements returns a page of the movements of the stock of an item or an order, the most recent first.
+	ListStockMovements(itemID, orderID string, pageSize int, pageToken string) ([]*pb.StockMovement, string, error)
+
+	// ReconcileStock returns the items whose quantity available differs from their ledger, fixing them with apply.
+	ReconcileStock(apply bool) ([]*pb.StockDiscrepancy, int, error)
+
+	// CreateWarehouse adds a warehouse at the given location and returns its ID.
+	CreateWarehouse(name string, latitude, longitude float64) (string, error)
+
+	// ListWarehouses returns all the warehouses, by name.
+	ListWarehouses() ([]*pb.Warehouse, error)
+
+	// GetItemStock returns the quantity of an item in the warehouses having it.
+	GetItemStock(itemID string) ([]*pb.WarehouseStock, error)
+
+	// TransferStock moves a quantity of an item from a warehouse to another, on behalf of actor.
+	TransferStock(itemID, fromWarehouseID, toWarehouseID string, quantity uint32, actor string) error
+
+	// SetReorderThreshold sets the quantity available below which a low-stock alert is raised for an item, 0 for no alerts.
+	SetReorderThreshold(itemID string, threshold uint32) error
+
+	// CheckLowStock raises the alerts of the items fallen below their threshold and resolves the others, returning the number raised.
+	CheckLowStock(now time.Time) (int, error)
+
+	// ListStockAlerts retrieves the open alerts, or all of them with includeResolved, the most recent first.
+	ListStockAlerts(includeResolved bool) ([]*pb.StockAlert, error)
+
+	// ReorderSuggestions suggests the quantity to reorder of the items, given the units of each item sold in the last windowDays.
+	ReorderSuggestions(sales map[string]uint32, windowDays, coverDays uint32) ([]*pb.ReorderSuggestion, error)
+
+	// AddItemImage adds an image to an item after its other images, with a thumbnail generated from it.
+	AddItemImage(itemID string, data []byte) (*pb.ItemImage, error)
+
+	// RemoveItemImage removes an image of an item with its content.
+	RemoveItemImage(itemID, imageID string) error
+
+	// ReorderItemImages puts the images of an item in the order given, which must list all of them.
+	ReorderItemImages(itemID string, imageIDs []string) error
+
+	// GetImage returns the content of an image, or of its thumbnail, with its content type.
+	GetImage(imageID string, thumbnail bool) ([]byte, string, error)
+
+	// SubmitReview writes the review of an item by a customer who bought it, given the IDs of the items they purchased.
+	SubmitReview(itemID, username string, rating uint32, text string, purchased []string) (*pb.Review, error)
+
+	// ModerateReview approves or rejects a review, updating the rating of its item.
+	ModerateReview(reviewID string, approve bool) error
+
+	// ListReviews retrieves the approved reviews of an item, the most recent first.
+	ListReviews(itemID string) ([]*pb.Review, error)
 
-	// ListCatalogItems retrieves all catalog items.
-	ListCatalogItems() ([]*pb.CatalogItem, error)
+	// ListPendingReviews retrieves the reviews waiting for moderation, the oldest first.
+	ListPendingReviews() ([]*pb.Review, error)
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// Category groups the catalog items, categories form a tree (e.g. Books > Fantasy).
+type Category struct {
+
+	// CategoryID is the unique identifier for the category, a ULID generated by the catalog.
+	CategoryID string `gorm:"primaryKey; not null; check:category_id <> ''"`
+
+	// Name is the title of the category shown to the users.
+	Name string `gorm:"not null; check:name <> ''"`
+
+	// Slug identifies the category in URLs, unique and derived from the name when not chosen.
+	Slug string `gorm:"not null; uniqueIndex; check:slug <> ''"`
+
+	// ParentID is the category containing this one, empty for a root category.
+	ParentID string `gorm:"not null; default:''; index"`
+}
+
+// ItemTag is a free-form tag of a catalog item.
+type ItemTag struct {
+
+	// ItemID is the ID of the tagged catalog item.
+	ItemID string `gorm:"primaryKey; not null"`
+
+	// Tag is the tag, in lower case.
+	Tag string `gorm:"primaryKey; not null; index; check:tag <> ''"`
+}
+
+// DomainCategoryToProtoCategory converts a model.Category into a pb.Category
+func DomainCategoryToProtoCategory(category *Category, itemCount uint32) (*pb.Category, error) {
+	if category == nil {
+		return nil, fmt.Errorf("Input argument is nil")
+	}
+
+	return &pb.Category{
+		CategoryId: category.CategoryID,
+		Name:       category.Name,
+		Slug:       category.Slug,
+		ParentId:   category.ParentID,
+		ItemCount:  itemCount,
+	}, nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+// ItemIDMapping records the generated ID given to an item that was identified by its title.
+// The other services look it up to rewrite their references to the item.
+type ItemIDMapping struct {
+
+	// LegacyID is the former ID of the item, its title.
+	LegacyID string `gorm:"primaryKey; not null; check:legacy_id <> ''"`
+
+	// ItemID is the generated ID of the item.
+	ItemID string `gorm:"not null; check:item_id <> ''"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// ItemImage is an image of a catalog item, its content and the one of its thumbnail are kept in the blob store.
+type ItemImage struct {
+
+	// ImageID is the unique identifier for the image, a ULID generated by the catalog and the key of its content.
+	ImageID string `gorm:"primaryKey; not null; check:image_id <> ''"`
+
+	// ItemID of the catalog item shown in the image.
+	ItemID string `gorm:"not null; index:idx_item_images_position,priority:1; check:item_id <> ''"`
+
+	// Position of the image among the ones of its item, from 0, the first one is the cover of the item.
+	Position uint32 `gorm:"not null; index:idx_item_images_position,priority:2"`
+
+	// ContentType of the image and of its thumbnail, e.g. image/jpeg.
+	ContentType          string `gorm:"not null; check:content_type <> ''"`
+	ThumbnailContentType string `gorm:"not null; check:thumbnail_content_type <> ''"`
+
+	// Width and Height of the image, in pixels.
+	Width  uint32 `gorm:"not null"`
+	Height uint32 `gorm:"not null"`
+}
+
+// ThumbnailKey is the key of the content of the thumbnail of an image in the blob store.
+func ThumbnailKey(imageID string) string {
+	return imageID + "-thumbnail"
+}
+
+// DomainItemImageToProtoItemImage converts a domain ItemImage to a protobuf ItemImage.
+func DomainItemImageToProtoItemImage(image *ItemImage) *pb.ItemImage {
+	return &pb.ItemImage{
+		ImageId:     image.ImageID,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+	}
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+type PriceChangeReason string
+
+const (
+	// PriceUpdated indicates that the price has been set by an admin or an import.
+	PriceUpdated PriceChangeReason = "UPDATED"
+
+	// PriceInitial indicates the price the item has been added with.
+	PriceInitial PriceChangeReason = "INITIAL"
+
+	// PriceScheduled indicates that a scheduled price has started.
+	PriceScheduled PriceChangeReason = "SCHEDULED"
+
+	// PriceRestored indicates that a sale has ended and the price before it has come back.
+	PriceRestored PriceChangeReason = "RESTORED"
+)
+
+// PriceHistory records a price an item has had.
+type PriceHistory struct {
+
+	// ID is the unique identifier of the row, increasing with the changes.
+	ID uint `gorm:"primaryKey; autoIncrement"`
+
+	// ItemID of the catalog item whose price has changed.
+	ItemID string `gorm:"not null; index; check:item_id <> ''"`
+
+	// Price set by the change.
+	Price float64 `gorm:"not null; check:price >= 0"`
+
+	// Reason of the change.
+	Reason PriceChangeReason `gorm:"not null; check:reason in ('UPDATED', 'INITIAL', 'SCHEDULED', 'RESTORED')"`
+
+	// ScheduleID of the scheduled price started or ended by the change, empty otherwise.
+	ScheduleID string
+
+	// ChangedAt is the time of the change.
+	ChangedAt time.Time `gorm:"not null"`
+}
+
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
+
+// DomainPriceHistoryToProtoPriceChange converts a domain PriceHistory to a protobuf PriceChange.
+func DomainPriceHistoryToProtoPriceChange(change *PriceHistory) *pb.PriceChange {
+	return &pb.PriceChange{
+		Price:      change.Price,
+		ChangedAt:  change.ChangedAt.Unix(),
+		Reason:     pb.PriceChangeReason(pb.PriceChangeReason_value["PRICE_"+string(change.Reason)]),
+		ScheduleId: change.ScheduleID,
+	}
+}
+
+type ScheduledPriceStatus string
+
+const (
+	// SchedulePending indicates that the price has not been applied yet.
+	SchedulePending ScheduledPriceStatus = "PENDING"
+
+	// ScheduleActive indicates a sale in progress, waiting for its end.
+	ScheduleActive ScheduledPriceStatus = "ACTIVE"
+
+	// ScheduleDone indicates that the price has been applied, and restored at the end of a sale.
+	ScheduleDone ScheduledPriceStatus = "DONE"
+
+	// ScheduleCanceled indicates that the schedule has been canceled, or its item removed, before its end.
+	ScheduleCanceled ScheduledPriceStatus = "CANCELED"
+)
+
+// ScheduledPrice is a price applied to an item in the future.
+// A sale has an end, when the price the item had before it comes back.
+type ScheduledPrice struct {
+
+	// ScheduleID is the unique identifier of the scheduled price.
+	ScheduleID string `gorm:"primaryKey; not null; check:schedule_id <> ''"`
+
+	// ItemID of the catalog item whose price is scheduled.
+	ItemID string `gorm:"not null; index; check:item_id <> ''"`
+
+	// Price applied at the start.
+	Price float64 `gorm:"not null; check:price >= 0"`
+
+	// StartsAt is the time the price is applied.
+	StartsAt time.Time `gorm:"not null; index"`
+
+	// EndsAt is the end of a sale, zero for a permanent change.
+	EndsAt time.Time
+
+	// PreviousPrice is the price of the item when the sale started, restored at its end.
+	PreviousPrice float64
+
+	// Status of the scheduled price.
+	Status ScheduledPriceStatus `gorm:"not null; index; check:status in ('PENDING', 'ACTIVE', 'DONE', 'CANCELED')"`
+
+	CreatedAt time.Time
+}
+
+// IsSale reports whether the price is restored at an end.
+func (s *ScheduledPrice) IsSale() bool {
+	return !s.EndsAt.IsZero()
+}
+
+// DomainScheduledPriceToProtoScheduledPrice converts a domain ScheduledPrice to a protobuf ScheduledPrice.
+func DomainScheduledPriceToProtoScheduledPrice(schedule *ScheduledPrice) *pb.ScheduledPrice {
+	endsAt := int64(0)
+	if schedule.IsSale() {
+		endsAt = schedule.EndsAt.Unix()
+	}
+
+	return &pb.ScheduledPrice{
+		ScheduleId: schedule.ScheduleID,
+		ItemId:     schedule.ItemID,
+		Price:      schedule.Price,
+		StartsAt:   schedule.StartsAt.Unix(),
+		EndsAt:     endsAt,
+		Status:     pb.ScheduledPriceStatus(pb.ScheduledPriceStatus_value["SCHEDULE_"+string(schedule.Status)]),
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type ReservationStatus string
+
+const (
+	// Reserved indicates that the stock is held for the reservation.
+	Reserved ReservationStatus = "RESERVED"
+
+	// Committed indicates that the stock has been definitively taken.
+	Committed ReservationStatus = "COMMITTED"
+
+	// Released indicates that the stock has been given back on request.
+	Released ReservationStatus = "RELEASED"
+
+	// Expired indicates that the stock has been given back because the reservation was never committed.
+	Expired ReservationStatus = "EXPIRED"
+)
+
+type Reservation struct {
+
+	// ReservationID is the unique identifier of the reservation.
+	ReservationID string `gorm:"primaryKey; not null; check:reservation_id <> ''"`
+
+	// Items reserved with their quantity.
+	Items []ReservationItem `gorm:"foreignKey:ReservationID;references:ReservationID;constraint:OnDelete:CASCADE"`
+
+	// Status of the reservation.
+	Status ReservationStatus `gorm:"not null; index; check:status in ('RESERVED', 'COMMITTED', 'RELEASED', 'EXPIRED')"`
+
+	// ExpiresAt is the time after which a reservation not committed is released.
+	ExpiresAt time.Time `gorm:"not null; index"`
+
+	CreatedAt time.Time
+}
+
+// ItemIDs returns the IDs of the items reserved, once even if taken from several warehouses.
+func (r *Reservation) ItemIDs() []string {
+	itemIDs := make([]string, 0, len(r.Items))
+	for _, item := range r.Items {
+		if !slices.Contains(itemIDs, item.ItemID) {
+			itemIDs = append(itemIDs, item.ItemID)
+		}
+	}
+	return itemIDs
+}
+
+type ReservationItem struct {
+
+	// ID is the unique identifier of the row.
+	ID uint `gorm:"primaryKey; autoIncrement"`
+
+	// ReservationID of the reservation the item belongs to.
+	ReservationID string `gorm:"not null; index"`
+
+	// ItemID of the catalog item reserved.
+	ItemID string `gorm:"not null; check:item_id <> ''"`
+
+	// Quantity reserved.
+	Quantity uint32 `gorm:"not null; check:quantity > 0"`
+
+	// WarehouseID of the warehouse the quantity is taken from, the default one for the reservations made before the warehouses.
+	WarehouseID string `gorm:"not null; default:''"`
+}
+
+// Restock records the stock given back to the catalog, so that the same restock is never applied twice.
+type Restock struct {
+
+	// RestockID is chosen by the caller, e.g. the ID of the canceled order.
+	RestockID string `gorm:"primaryKey; not null; check:restock_id <> ''"`
+
+	CreatedAt time.Time
+}
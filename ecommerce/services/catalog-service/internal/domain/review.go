@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// Bounds of the rating of a review, in stars
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+type ReviewStatus string
+
+const (
+	// ReviewPending indicates a review written, or rewritten, not moderated yet.
+	ReviewPending ReviewStatus = "PENDING"
+
+	// ReviewApproved indicates a review shown with the item and counted in its rating.
+	ReviewApproved ReviewStatus = "APPROVED"
+
+	// ReviewRejected indicates a review hidden by an admin.
+	ReviewRejected ReviewStatus = "REJECTED"
+)
+
+// Review is the opinion of a customer about a catalog item they bought.
+// A customer has at most one review of an item, it counts in the rating of the item once approved.
+type Review struct {
+
+	// ReviewID is the unique identifier for the review, a ULID generated by the catalog.
+	ReviewID string `gorm:"primaryKey; not null; check:review_id <> ''"`
+
+	// ItemID of the catalog item reviewed, the product for a variant.
+	ItemID string `gorm:"not null; uniqueIndex:idx_reviews_author,priority:1; index:idx_reviews_item,priority:1; check:item_id <> ''"`
+
+	// Username of the customer who wrote the review.
+	Username string `gorm:"not null; uniqueIndex:idx_reviews_author,priority:2; check:username <> ''"`
+
+	// Rating of the item, from MinRating to MaxRating stars.
+	Rating uint32 `gorm:"not null; check:rating BETWEEN 1 AND 5"`
+
+	// Text of the review.
+	Text string `gorm:"not null; check:text <> ''"`
+
+	// Status of the moderation of the review.
+	Status ReviewStatus `gorm:"not null; index:idx_reviews_item,priority:2; check:status in ('PENDING', 'APPROVED', 'REJECTED')"`
+
+	// CreatedAt is when the review was written, or rewritten.
+	CreatedAt time.Time `gorm:"not null; index:idx_reviews_item,priority:3"`
+}
+
+// DomainReviewToProtoReview converts a domain Review to a protobuf Review.
+func DomainReviewToProtoReview(review *Review) *pb.Review {
+	return &pb.Review{
+		ReviewId:  review.ReviewID,
+		ItemId:    review.ItemID,
+		Username:  review.Username,
+		Rating:    review.Rating,
+		Text:      review.Text,
+		Status:    pb.ReviewStatus(pb.ReviewStatus_value["REVIEW_"+string(review.Status)]),
+		CreatedAt: review.CreatedAt.Unix(),
+	}
+}
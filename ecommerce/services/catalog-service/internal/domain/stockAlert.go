@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// StockAlert is raised when the quantity available of an item falls below its reorder threshold.
+// An item has at most one open alert, it is resolved when the item is stocked up to the threshold again.
+type StockAlert struct {
+
+	// ID is the unique identifier of the alert, increasing with the alerts.
+	ID uint64 `gorm:"primaryKey; autoIncrement"`
+
+	// ItemID of the catalog item low on stock.
+	ItemID string `gorm:"not null; index; check:item_id <> ''"`
+
+	// QuantityAvailable of the item when the alert was raised.
+	QuantityAvailable uint32 `gorm:"not null"`
+
+	// Threshold of the item when the alert was raised.
+	Threshold uint32 `gorm:"not null; check:threshold > 0"`
+
+	// RaisedAt is when the alert was raised.
+	RaisedAt time.Time `gorm:"not null; index"`
+
+	// ResolvedAt is when the alert was resolved, nil while it is open.
+	ResolvedAt *time.Time `gorm:"index"`
+}
+
+// DomainStockAlertToProtoStockAlert converts a domain StockAlert to a protobuf StockAlert, with the name of its item.
+func DomainStockAlertToProtoStockAlert(alert *StockAlert, name string) *pb.StockAlert {
+	protoAlert := &pb.StockAlert{
+		AlertId:           alert.ID,
+		ItemId:            alert.ItemID,
+		Name:              name,
+		QuantityAvailable: alert.QuantityAvailable,
+		Threshold:         alert.Threshold,
+		RaisedAt:          alert.RaisedAt.Unix(),
+	}
+	if alert.ResolvedAt != nil {
+		protoAlert.ResolvedAt = alert.ResolvedAt.Unix()
+	}
+	return protoAlert
+}
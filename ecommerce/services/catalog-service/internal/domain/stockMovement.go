@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+type MovementReason string
+
+const (
+	// MovementAdjustment indicates a quantity set by an admin or an import.
+	MovementAdjustment MovementReason = "ADJUSTMENT"
+
+	// MovementInitial indicates the quantity an item has been added with, or had when the ledger was opened.
+	MovementInitial MovementReason = "INITIAL"
+
+	// MovementRestock indicates goods received, added by an admin.
+	MovementRestock MovementReason = "RESTOCK"
+
+	// MovementSale indicates the quantity reserved by a checkout.
+	MovementSale MovementReason = "SALE"
+
+	// MovementRelease indicates the quantity of a reservation given back on request.
+	MovementRelease MovementReason = "RELEASE"
+
+	// MovementExpiration indicates the quantity of a reservation given back because it was never committed.
+	MovementExpiration MovementReason = "EXPIRATION"
+
+	// MovementCancellation indicates the quantity given back by a canceled order.
+	MovementCancellation MovementReason = "CANCELLATION"
+
+	// MovementTransfer indicates the quantity moved from a warehouse to another.
+	MovementTransfer MovementReason = "TRANSFER"
+)
+
+// StockMovement is an entry of the inventory ledger: a change of the quantity available of an item.
+type StockMovement struct {
+
+	// ID is the unique identifier of the movement, increasing with the movements.
+	ID uint64 `gorm:"primaryKey; autoIncrement"`
+
+	// ItemID of the catalog item whose stock has changed.
+	ItemID string `gorm:"not null; index; check:item_id <> ''"`
+
+	// Delta is the quantity added, or taken if negative.
+	Delta int64 `gorm:"not null; check:delta <> 0"`
+
+	// QuantityAfter is the quantity available after the movement, in all the warehouses.
+	QuantityAfter uint32 `gorm:"not null"`
+
+	// WarehouseID of the warehouse where the quantity was added or taken.
+	WarehouseID string `gorm:"not null; default:''; index"`
+
+	// Reason of the movement.
+	Reason MovementReason `gorm:"not null; check:reason in ('ADJUSTMENT', 'INITIAL', 'RESTOCK', 'SALE', 'RELEASE', 'EXPIRATION', 'CANCELLATION', 'TRANSFER')"`
+
+	// ReservationID of the reservation taking or giving back the quantity, if any.
+	ReservationID string `gorm:"index"`
+
+	// OrderID of the order the quantity was sold to or given back by, if any.
+	OrderID string `gorm:"index"`
+
+	// Actor is the user or service that changed the stock.
+	Actor string
+
+	CreatedAt time.Time
+}
+
+// DomainStockMovementToProtoStockMovement converts a domain StockMovement to a protobuf StockMovement.
+func DomainStockMovementToProtoStockMovement(movement *StockMovement) *pb.StockMovement {
+	return &pb.StockMovement{
+		MovementId:    movement.ID,
+		ItemId:        movement.ItemID,
+		Delta:         movement.Delta,
+		QuantityAfter: movement.QuantityAfter,
+		Reason:        pb.StockMovementReason(pb.StockMovementReason_value["MOVEMENT_"+string(movement.Reason)]),
+		ReservationId: movement.ReservationID,
+		OrderId:       movement.OrderID,
+		Actor:         movement.Actor,
+		CreatedAt:     movement.CreatedAt.Unix(),
+		WarehouseId:   movement.WarehouseID,
+	}
+}
+
+// ProtoMovementReasonToDomain converts a protobuf StockMovementReason to a domain MovementReason.
+func ProtoMovementReasonToDomain(reason pb.StockMovementReason) MovementReason {
+	return MovementReason(strings.TrimPrefix(reason.String(), "MOVEMENT_"))
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// DefaultWarehouseID is the warehouse of the stock given without a warehouse, created with the catalog.
+const DefaultWarehouseID = "main"
+
+// Warehouse is a location where the catalog items are stocked.
+type Warehouse struct {
+
+	// WarehouseID is the unique identifier for the warehouse, a ULID generated by the catalog.
+	WarehouseID string `gorm:"primaryKey; not null; check:warehouse_id <> ''"`
+
+	// Name is the name of the warehouse shown to the admins, unique.
+	Name string `gorm:"not null; uniqueIndex; check:name <> ''"`
+
+	// Latitude and Longitude of the warehouse, in degrees, to find the nearest one to a destination.
+	Latitude  float64 `gorm:"not null; default:0; check:latitude BETWEEN -90 AND 90"`
+	Longitude float64 `gorm:"not null; default:0; check:longitude BETWEEN -180 AND 180"`
+}
+
+// WarehouseStock is the quantity of a catalog item in stock at a warehouse.
+// The quantity available of an item is the total of its stock in all the warehouses.
+type WarehouseStock struct {
+
+	// WarehouseID of the warehouse stocking the item.
+	WarehouseID string `gorm:"primaryKey; not null"`
+
+	// ItemID of the catalog item stocked.
+	ItemID string `gorm:"primaryKey; not null; index"`
+
+	// Quantity in stock at the warehouse.
+	Quantity uint32 `gorm:"not null; check:quantity >= 0"`
+}
+
+// DomainWarehouseToProtoWarehouse converts a model.Warehouse into a pb.Warehouse
+func DomainWarehouseToProtoWarehouse(warehouse *Warehouse) (*pb.Warehouse, error) {
+	if warehouse == nil {
+		return nil, fmt.Errorf("Input argument is nil")
+	}
+
+	return &pb.Warehouse{
+		WarehouseId: warehouse.WarehouseID,
+		Name:        warehouse.Name,
+		Location:    &pb.Location{Latitude: warehouse.Latitude, Longitude: warehouse.Longitude},
+	}, nil
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// watcherBuffer is the number of events a watcher can lag behind before being dropped
+const watcherBuffer = 256
+
+// Broker fans out the changes of the catalog to its watchers.
+// Publishing never waits for a watcher: one too slow to keep up is dropped, its channel is closed
+// and it has to watch again, reloading the catalog since it missed some changes.
+type Broker struct {
+	mu       sync.Mutex
+	watchers map[chan *pb.CatalogEvent]struct{}
+	sequence uint64
+}
+
+func NewBroker() *Broker {
+	return &Broker{watchers: make(map[chan *pb.CatalogEvent]struct{})}
+}
+
+// Subscribe returns the channel of the events published from now on, and the function to stop watching.
+// The channel is closed when the watcher stops or is dropped.
+func (b *Broker) Subscribe() (<-chan *pb.CatalogEvent, func()) {
+	watcher := make(chan *pb.CatalogEvent, watcherBuffer)
+
+	b.mu.Lock()
+	b.watchers[watcher] = struct{}{}
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(watcher)
+	}
+	return watcher, cancel
+}
+
+// Publish numbers an event and sends it to every watcher.
+func (b *Broker) Publish(event *pb.CatalogEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	event.Sequence = b.sequence
+	event.Timestamp = time.Now().UnixMilli()
+
+	for watcher := range b.watchers {
+		select {
+		case watcher <- event:
+		default:
+			b.drop(watcher)
+		}
+	}
+}
+
+// Watchers returns the number of watchers.
+func (b *Broker) Watchers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.watchers)
+}
+
+// drop removes a watcher and closes its channel, dropping it twice has no effect
+func (b *Broker) drop(watcher chan *pb.CatalogEvent) {
+	if _, ok := b.watchers[watcher]; ok {
+		delete(b.watchers, watcher)
+		close(watcher)
+	}
+}
@@ -0,0 +1,95 @@
+package media
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrBlobNotFound is returned when no blob is stored under a key.
+var ErrBlobNotFound = errors.New("Blob not found")
+
+// BlobStore keeps the content of the images, each under a key chosen by the catalog.
+// A blob is never changed once stored, it is only removed.
+type BlobStore interface {
+
+	// Put stores data under key, replacing the blob stored under it if any.
+	Put(key string, data []byte) error
+
+	// Get returns the blob stored under key, ErrBlobNotFound if there is none.
+	Get(key string) ([]byte, error)
+
+	// Delete removes the blob stored under key, if any.
+	Delete(key string) error
+}
+
+// FileStore is a BlobStore keeping each blob in a file of a directory of the local filesystem.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a store keeping the blobs in dir, created if missing.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	// The blob is written aside and renamed, so that a reader never sees it half written
+	file, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (s *FileStore) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (s *FileStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path is the file of the blob stored under key, a key cannot lead out of the directory of the store
+func (s *FileStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return "", errors.New("Invalid blob key")
+	}
+	return filepath.Join(s.dir, key), nil
+}
@@ -0,0 +1,130 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	// Decoders of the formats accepted besides JPEG and PNG
+	_ "image/gif"
+)
+
+// Limits on the images accepted, an image has to fit in a gRPC message (4 MiB by default)
+const (
+	MaxImageBytes  = 3 << 20
+	maxImagePixels = 40_000_000
+)
+
+// thumbnailSize is the largest side of a thumbnail, in pixels
+const thumbnailSize = 320
+
+// jpegQuality is the quality of the thumbnails of JPEG images
+const jpegQuality = 85
+
+// ErrInvalidImage is returned when the data is not an image of a format accepted, or is too large.
+var ErrInvalidImage = errors.New("Invalid image")
+
+// Image is an image checked and ready to be stored, with its thumbnail.
+type Image struct {
+	ContentType string
+	Width       uint32
+	Height      uint32
+
+	Thumbnail            []byte
+	ThumbnailContentType string
+}
+
+// Process checks that data is a JPEG, PNG or GIF image and generates its thumbnail,
+// scaled down to fit thumbnailSize. The thumbnail of a JPEG image is a JPEG, the others are PNG to keep their transparency;
+// the thumbnail of an animated GIF is its first frame.
+func Process(data []byte) (*Image, error) {
+	if len(data) > MaxImageBytes {
+		return nil, fmt.Errorf("%w: larger than %d MiB", ErrInvalidImage, MaxImageBytes>>20)
+	}
+
+	// The size is checked before decoding, a small file can hold a huge image
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width == 0 || config.Height == 0 || config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrInvalidImage, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	processed := &Image{
+		ContentType: "image/" + format,
+		Width:       uint32(config.Width),
+		Height:      uint32(config.Height),
+	}
+
+	var thumbnail bytes.Buffer
+	if format == "jpeg" {
+		processed.ThumbnailContentType = "image/jpeg"
+		err = jpeg.Encode(&thumbnail, scaleDown(img, thumbnailSize), &jpeg.Options{Quality: jpegQuality})
+	} else {
+		processed.ThumbnailContentType = "image/png"
+		err = png.Encode(&thumbnail, scaleDown(img, thumbnailSize))
+	}
+	if err != nil {
+		return nil, err
+	}
+	processed.Thumbnail = thumbnail.Bytes()
+	return processed, nil
+}
+
+// scaleDown returns the image scaled to fit a square of size pixels, keeping its proportions.
+// Each pixel of the result is the average of the pixels it covers; an image already small enough is only copied.
+func scaleDown(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// The pixels are read from an RGBA copy, way faster than through the image interface
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if width <= size && height <= size {
+		return src
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := range dstHeight {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := range dstWidth {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			// The colors are premultiplied by their alpha, so they can be averaged as they are
+			var sum [4]uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += uint64(row[i])
+					sum[1] += uint64(row[i+1])
+					sum[2] += uint64(row[i+2])
+					sum[3] += uint64(row[i+3])
+				}
+			}
+
+			count := uint64((y1 - y0) * (x1 - x0))
+			offset := y*dst.Stride + x*4
+			for c := range 4 {
+				dst.Pix[offset+c] = uint8((sum[c] + count/2) / count)
+			}
+		}
+	}
+	return dst
+}
@@ -1,65 +1,216 @@
 package repository
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
 
+	ulid "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/events"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/search"
 )
 
+// Limits on the SKU and slug of the items
+const (
+	maxSKULength  = 64
+	maxSlugLength = 80
+)
+
+// slugPattern matches lower case words of letters and digits separated by single dashes
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ErrInvalidPageToken is returned when a page token is malformed or was issued for another query.
+var ErrInvalidPageToken = errors.New("Invalid page token")
+
+// ErrVersionConflict is returned when an item has changed since the version the update was based on.
+var ErrVersionConflict = errors.New("The item has been changed in the meantime")
+
+// nextVersion moves an item to its next version, in the updates of its columns
+var nextVersion = gorm.Expr("version + 1")
+
 type CatalogServiceRepository struct {
 	db *gorm.DB
+
+	// index is the full-text index of the catalog, updated with every change of an item
+	index *search.Index
+
+	// events publishes the changes of the catalog once committed, nil in a transaction not committed yet
+	events *events.Broker
+
+	// images keeps the content of the images of the items and of their thumbnails
+	images media.BlobStore
 }
 
-func NewCatalogServiceRepository(db *gorm.DB) *CatalogServiceRepository {
-	return &CatalogServiceRepository{db: db}
+func NewCatalogServiceRepository(db *gorm.DB, images media.BlobStore) *CatalogServiceRepository {
+	return &CatalogServiceRepository{db: db, index: search.NewIndex(), events: events.NewBroker(), images: images}
 }
 
-// AddCatalogItem adds a new item to the catalog, if the item already exists it returns an error.
-func (r *CatalogServiceRepository) AddCatalogItem(item *pb.CatalogItem) error {
+// AddCatalogItem adds a new item to the catalog and returns its ID, if the item already exists it returns an error.
+// The ID is generated unless given, the slug is derived from the name unless given.
+// An item with a ProductId is a variant of that product: it needs a SKU and attributes,
+// its name and description are taken from the product unless given.
+// The quantity available is put in the default warehouse and recorded in the ledger as the initial movement, on behalf of actor.
+func (r *CatalogServiceRepository) AddCatalogItem(item *pb.CatalogItem, actor string) (string, error) {
 
-	// Check ItemID validity
-	if err := checkItemIDValidity(item.ItemId); err != nil {
-		return err
+	// Generate the ItemID, or check the one given
+	itemID := item.ItemId
+	if itemID == "" {
+		itemID = ulid.Make().String()
+	} else if err := checkItemIDUniqueness(itemID, r.db); err != nil {
+		return "", err
 	}
 
-	// Check ItemID uniqueness
-	if err := checkItemIDUniqueness(item.ItemId, r.db); err != nil {
-		return err
+	name, description := item.Name, item.Description
+
+	// Check the product and the attributes of a variant
+	if item.ProductId != "" {
+		product, err := retrieveProduct(r.db, item.ProductId)
+		if err != nil {
+			return "", err
+		}
+		if err := checkAttributesValidity(item.Attributes); err != nil {
+			return "", err
+		}
+		if err := checkVariantUniqueness(item.ProductId, item.Attributes, itemID, r.db); err != nil {
+			return "", err
+		}
+		if item.Sku == "" {
+			return "", errors.New("A variant must have a SKU")
+		}
+		if item.CategoryId != "" || len(item.Tags) > 0 {
+			return "", ErrVariantClassified
+		}
+		if name == "" {
+			name = variantName(product, item.Attributes)
+		}
+		if description == "" {
+			description = product.Description
+		}
+	} else if len(item.Attributes) > 0 {
+		return "", errors.New("Only variants have attributes")
+	}
+
+	// Check Name validity
+	if err := checkNameValidity(name); err != nil {
+		return "", err
 	}
 
 	// Check Description validity
-	if err := checkDescriptionValidity(item.Description); err != nil {
-		return err
+	if err := checkDescriptionValidity(description); err != nil {
+		return "", err
 	}
 
 	// Check QuantityAvailable validity
 	if err := checkQuantityAvailableValidity(item.QuantityAvailable); err != nil {
-		return err
+		return "", err
 	}
 
 	// Check Price validity
 	if err := checkPriceValidity(item.Price); err != nil {
-		return err
+		return "", err
+	}
+
+	// Check SKU validity and uniqueness, if given
+	if item.Sku != "" {
+		if err := checkSKUValidity(item.Sku); err != nil {
+			return "", err
+		}
+		if err := checkSKUUniqueness(item.Sku, itemID, r.db); err != nil {
+			return "", err
+		}
+	}
+
+	// Check Slug validity and uniqueness, or derive it from the name
+	slug := item.Slug
+	if slug != "" {
+		if err := checkSlugValidity(slug); err != nil {
+			return "", err
+		}
+		if err := checkSlugUniqueness(slug, itemID, r.db); err != nil {
+			return "", err
+		}
+	} else {
+		slug = uniqueSlug(name, itemID, r.db)
+	}
+
+	// Check the category exists, if given
+	if item.CategoryId != "" {
+		if _, err := retrieveCategory(r.db, item.CategoryId); err != nil {
+			return "", err
+		}
+	}
+
+	// Check Tags validity
+	tags, err := normalizeTags(item.Tags)
+	if err != nil {
+		return "", err
 	}
 
 	// Create CatalogItem domain model
 	catalogItem := &domain.CatalogItem{
-		ItemID:            item.ItemId,
-		Description:       item.Description,
+		ItemID:            itemID,
+		Name:              name,
+		SKU:               item.Sku,
+		Slug:              slug,
+		ProductID:         item.ProductId,
+		Attributes:        item.Attributes,
+		CategoryID:        item.CategoryId,
+		Description:       description,
 		QuantityAvailable: item.QuantityAvailable,
 		Price:             item.Price,
+		CreatedAt:         time.Now().UnixNano(),
+		Version:           1,
 	}
 
-	// Save to database
-	if err := r.db.Create(catalogItem).Error; err != nil {
-		return err
+	// Save to database, with the tags, a variant changes the price and quantity of its product
+	// and replaces the stock the product had of its own
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(catalogItem).Error; err != nil {
+			return err
+		}
+		if err := saveItemTags(tx, itemID, tags); err != nil {
+			return err
+		}
+		if err := recordPrice(tx, catalogItem, domain.PriceInitial, ""); err != nil {
+			return err
+		}
+		if err := addWarehouseStock(tx, domain.DefaultWarehouseID, itemID, catalogItem.QuantityAvailable); err != nil {
+			return err
+		}
+		if err := recordMovement(tx, &domain.StockMovement{
+			ItemID:      itemID,
+			WarehouseID: domain.DefaultWarehouseID,
+			Delta:       int64(catalogItem.QuantityAvailable),
+			Reason:      domain.MovementInitial,
+			Actor:       actor,
+		}); err != nil {
+			return err
+		}
+		if catalogItem.ProductID != "" {
+			if err := tx.Where("item_id = ?", catalogItem.ProductID).Delete(&domain.WarehouseStock{}).Error; err != nil {
+				return err
+			}
+		}
+		return syncProductsOf(tx, []string{itemID})
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return nil
+	r.indexItem(catalogItem)
+	r.publishChanges(pb.CatalogEventType_ITEM_ADDED, itemID)
+	return itemID, nil
 }
 
 // RemoveCatalogItem removes a catalog item from the catalog by its unique identifier.
@@ -76,11 +227,50 @@ func (r *CatalogServiceRepository) RemoveCatalogItem(itemID string) error {
 		return err
 	}
 
-	// If the item exists, remove it
-	if err := r.db.Delete(&item).Error; err != nil {
+	// If the item exists, remove it with its tags, its prices, its stock, its movements, its images, its reviews and its variants
+	removed := []*domain.CatalogItem{item}
+	var imageIDs []string
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var variants []*domain.CatalogItem
+		if err := tx.Where("product_id = ?", itemID).Find(&variants).Error; err != nil {
+			return err
+		}
+		removed = append(removed, variants...)
+
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("item_id = ?", itemID).Delete(&domain.ItemTag{}).Error; err != nil {
+			return err
+		}
+		if err := removePrices(tx, removed); err != nil {
+			return err
+		}
+		if err := removeWarehouseStock(tx, removed); err != nil {
+			return err
+		}
+		if err := removeMovements(tx, removed); err != nil {
+			return err
+		}
+		if err := removeReviews(tx, removed); err != nil {
+			return err
+		}
+		var err error
+		if imageIDs, err = removeImages(tx, removed); err != nil {
+			return err
+		}
+		if item.ProductID != "" {
+			return syncProducts(tx, []string{item.ProductID})
+		}
+		return tx.Where("product_id = ?", itemID).Delete(&domain.CatalogItem{}).Error
+	})
+	if err != nil {
 		return err
 	}
 
+	r.index.Remove(itemID)
+	r.deleteImageContent(imageIDs)
+	r.publishRemoval(removed)
 	return nil
 }
 
@@ -99,13 +289,127 @@ func (r *CatalogServiceRepository) GetCatalogItem(itemID string) (*pb.CatalogIte
 	}
 
 	// if the item exists, return it
-	protoItem, err := domain.DomainCatalogItemToProtoCatalogItem(item)
-	return protoItem, err
+	return r.toProtoItem(item)
+}
+
+// GetCatalogItemBySlug retrieves a catalog item by its slug.
+func (r *CatalogServiceRepository) GetCatalogItemBySlug(slug string) (*pb.CatalogItem, error) {
+	if slug == "" {
+		return nil, errors.New("Slug cannot be empty")
+	}
+
+	var item domain.CatalogItem
+	if err := r.db.Where("slug = ?", slug).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return r.toProtoItem(&item)
+}
+
+// GetCatalogItemBySKU retrieves a catalog item by its SKU.
+func (r *CatalogServiceRepository) GetCatalogItemBySKU(sku string) (*pb.CatalogItem, error) {
+	if sku == "" {
+		return nil, errors.New("SKU cannot be empty")
+	}
+
+	var item domain.CatalogItem
+	if err := r.db.Where("sku = ?", sku).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return r.toProtoItem(&item)
+}
+
+// GetCatalogItems retrieves several catalog items, the ones not found are left out.
+func (r *CatalogServiceRepository) GetCatalogItems(itemIDs []string) ([]*pb.CatalogItem, error) {
+	if len(itemIDs) == 0 {
+		return []*pb.CatalogItem{}, nil
+	}
+
+	var items []*domain.CatalogItem
+	if err := r.db.Where("item_id IN ?", itemIDs).Order("item_id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return r.toProtoItems(items)
+}
+
+// UpdateCatalogItem updates the name, description, SKU and slug of a catalog item, the empty ones are left unchanged.
+// The attributes of a variant are replaced if given.
+// The ID of the item never changes, so carts and orders referencing it are not affected.
+// The update is rejected if the item is no longer at the expected version, unless it is zero.
+func (r *CatalogServiceRepository) UpdateCatalogItem(details *pb.CatalogItem, expectedVersion uint64) error {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(details.ItemId); err != nil {
+		return err
+	}
+
+	// Retrieve item
+	item, err := r.RetrieveCatalogItem(details.ItemId)
+	if err != nil {
+		return err
+	}
+
+	if details.Name != "" {
+		if err := checkNameValidity(details.Name); err != nil {
+			return err
+		}
+		item.Name = details.Name
+	}
+
+	if details.Description != "" {
+		if err := checkDescriptionValidity(details.Description); err != nil {
+			return err
+		}
+		item.Description = details.Description
+	}
+
+	if details.Sku != "" {
+		if err := checkSKUValidity(details.Sku); err != nil {
+			return err
+		}
+		if err := checkSKUUniqueness(details.Sku, item.ItemID, r.db); err != nil {
+			return err
+		}
+		item.SKU = details.Sku
+	}
+
+	// The slug is kept on rename, links to the item stay valid
+	if details.Slug != "" {
+		if err := checkSlugValidity(details.Slug); err != nil {
+			return err
+		}
+		if err := checkSlugUniqueness(details.Slug, item.ItemID, r.db); err != nil {
+			return err
+		}
+		item.Slug = details.Slug
+	}
 
+	if len(details.Attributes) > 0 {
+		if item.ProductID == "" {
+			return errors.New("Only variants have attributes")
+		}
+		if err := checkAttributesValidity(details.Attributes); err != nil {
+			return err
+		}
+		if err := checkVariantUniqueness(item.ProductID, details.Attributes, item.ItemID, r.db); err != nil {
+			return err
+		}
+		item.Attributes = details.Attributes
+	}
+
+	if err := saveItem(r.db, item, expectedVersion); err != nil {
+		return err
+	}
+
+	r.indexItem(item)
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, item.ItemID)
+	return nil
 }
 
-// UpdateQuantityAvailable updates the quantity available of a catalog item.
-func (r *CatalogServiceRepository) UpdateQuantityAvailable(itemID string, quantity uint32) error {
+// UpdateQuantityAvailable updates the quantity of a catalog item in stock at a warehouse, the default one if empty.
+// The quantity available of the item becomes the total of all the warehouses.
+// The update is rejected if the item is no longer at the expected version, unless it is zero.
+// The difference is recorded in the ledger as an adjustment or a restock, on behalf of actor.
+func (r *CatalogServiceRepository) UpdateQuantityAvailable(itemID, warehouseID string, quantity uint32, expectedVersion uint64, reason domain.MovementReason, actor string) error {
 
 	// Check ItemID validity
 	if err := checkItemIDValidity(itemID); err != nil {
@@ -117,23 +421,71 @@ func (r *CatalogServiceRepository) UpdateQuantityAvailable(itemID string, quanti
 		return err
 	}
 
+	// Check reason validity
+	if err := checkAdjustmentReasonValidity(reason); err != nil {
+		return err
+	}
+
 	// Retrieve item
 	item, err := r.RetrieveCatalogItem(itemID)
 	if err != nil {
 		return err
 	}
 
-	// If the item exists, update its quantity available
-	item.QuantityAvailable = quantity
-	if err := r.db.Save(item).Error; err != nil {
+	// The quantity of a product with variants is the total of its variants
+	if err := checkNotProductWithVariants(item.ItemID, r.db); err != nil {
+		return err
+	}
+
+	// Retrieve the stock of the item, any change of it moves the item to its next version
+	if warehouseID == "" {
+		warehouseID = domain.DefaultWarehouseID
+	}
+	if _, err := retrieveWarehouse(r.db, warehouseID); err != nil {
+		return err
+	}
+	current, err := warehouseQuantity(r.db, warehouseID, item.ItemID)
+	if err != nil {
+		return err
+	}
+	var total int64
+	if err := r.db.Model(&domain.WarehouseStock{}).Select("COALESCE(SUM(quantity), 0)").
+		Where("item_id = ?", item.ItemID).Scan(&total).Error; err != nil {
+		return err
+	}
+
+	// If the item exists, update its stock at the warehouse and its quantity available and record the movement
+	movement := &domain.StockMovement{
+		ItemID:      item.ItemID,
+		WarehouseID: warehouseID,
+		Delta:       int64(quantity) - int64(current),
+		Reason:      reason,
+		Actor:       actor,
+	}
+	item.QuantityAvailable = uint32(total + movement.Delta)
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveItem(tx, item, expectedVersion); err != nil {
+			return err
+		}
+		if err := setWarehouseStock(tx, warehouseID, item.ItemID, quantity); err != nil {
+			return err
+		}
+		if err := recordMovement(tx, movement); err != nil {
+			return err
+		}
+		return syncProductsOf(tx, []string{item.ItemID})
+	})
+	if err != nil {
 		return err
 	}
 
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, item.ItemID)
 	return nil
 }
 
-// UpdatePrice updates the price of a catalog item.
-func (r *CatalogServiceRepository) UpdatePrice(itemID string, price float64) error {
+// UpdatePrice updates the price of a catalog item, the new price is recorded in its history.
+// The update is rejected if the item is no longer at the expected version, unless it is zero.
+func (r *CatalogServiceRepository) UpdatePrice(itemID string, price float64, expectedVersion uint64) error {
 
 	// Check ItemID validity
 	if err := checkItemIDValidity(itemID); err != nil {
@@ -151,30 +503,121 @@ func (r *CatalogServiceRepository) UpdatePrice(itemID string, price float64) err
 		return err
 	}
 
-	// If the item exists, update its price
-	item.Price = price
-	if err := r.db.Save(item).Error; err != nil {
+	// The price of a product with variants is the lowest of its variants
+	if err := checkNotProductWithVariants(item.ItemID, r.db); err != nil {
 		return err
 	}
 
+	// If the item exists, update its price and record it in the history
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		return setItemPrice(tx, item, price, expectedVersion, domain.PriceUpdated, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_PRICE_CHANGED, item.ItemID)
 	return nil
 }
 
-// ListCatalogItems retrieves all catalog items.
-func (r *CatalogServiceRepository) ListCatalogItems() ([]*pb.CatalogItem, error) {
-	var items []*pb.CatalogItem
-	err := r.db.Find(&items).Error
-	return items, err
+// ListCatalogItems retrieves a page of the catalog items matching the query.
+// Pages are selected by keyset, continuing after the last item of the previous page:
+// they stay fast on large catalogs and don't skip or repeat items when the catalog changes in between.
+// The returned token continues the listing, it is empty after the last page.
+func (r *CatalogServiceRepository) ListCatalogItems(query domain.CatalogQuery) ([]*pb.CatalogItem, string, error) {
+
+	// Validate the query
+	if err := checkCatalogQueryValidity(query); err != nil {
+		return nil, "", err
+	}
+
+	pageSize := query.PageSize
+	if pageSize == 0 {
+		pageSize = domain.DefaultPageSize
+	}
+	pageSize = min(pageSize, domain.MaxPageSize)
+
+	var cursor *pageCursor
+	if query.PageToken != "" {
+		var err error
+		if cursor, err = decodePageToken(query); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Filters, variants are listed with their product
+	db := r.db.Model(&domain.CatalogItem{}).Where("product_id = ''")
+	if query.MinPrice > 0 {
+		db = db.Where("price >= ?", query.MinPrice)
+	}
+	if query.MaxPrice > 0 {
+		db = db.Where("price <= ?", query.MaxPrice)
+	}
+	if query.InStockOnly {
+		db = db.Where("quantity_available > 0")
+	}
+	if query.CategoryID != "" {
+		categoryIDs, err := categoryAndDescendants(r.db, query.CategoryID)
+		if err != nil {
+			return nil, "", err
+		}
+		db = db.Where("category_id IN ?", categoryIDs)
+	}
+	if tag := normalizeTag(query.Tag); tag != "" {
+		db = db.Where("item_id IN (?)", r.db.Model(&domain.ItemTag{}).Select("item_id").Where("tag = ?", tag))
+	}
+
+	// Sort, starting after the cursor
+	switch query.Sort {
+	case pb.CatalogSort_PRICE_ASC:
+		if cursor != nil {
+			db = db.Where("(price, item_id) > (?, ?)", cursor.Price, cursor.ItemID)
+		}
+		db = db.Order("price, item_id")
+	case pb.CatalogSort_PRICE_DESC:
+		if cursor != nil {
+			db = db.Where("(price, item_id) < (?, ?)", cursor.Price, cursor.ItemID)
+		}
+		db = db.Order("price DESC, item_id DESC")
+	case pb.CatalogSort_NEWEST:
+		if cursor != nil {
+			db = db.Where("(created_at, item_id) < (?, ?)", cursor.CreatedAt, cursor.ItemID)
+		}
+		db = db.Order("created_at DESC, item_id DESC")
+	case pb.CatalogSort_RATING:
+		if cursor != nil {
+			db = db.Where("(rating_average, rating_count, item_id) < (?, ?, ?)", cursor.Rating, cursor.RatingCount, cursor.ItemID)
+		}
+		db = db.Order("rating_average DESC, rating_count DESC, item_id DESC")
+	default:
+		if cursor != nil {
+			db = db.Where("(name, item_id) > (?, ?)", cursor.Name, cursor.ItemID)
+		}
+		db = db.Order("name, item_id")
+	}
+
+	// One more item tells if there is a next page
+	var items []*domain.CatalogItem
+	if err := db.Limit(pageSize + 1).Find(&items).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		nextPageToken = encodePageToken(query, items[pageSize-1])
+	}
+
+	protoItems, err := r.toProtoItems(items)
+	if err != nil {
+		return nil, "", err
+	}
+	return protoItems, nextPageToken, nil
 }
 
 // RetrieveCatalogItem retrieves a catalog item by its unique identifier.
 func (r *CatalogServiceRepository) RetrieveCatalogItem(itemID string) (*domain.CatalogItem, error) {
-	var item domain.CatalogItem
-
-	if err := r.db.First(&item, "item_id = ?", itemID).Error; err != nil {
-		return nil, err
-	}
-	return &item, nil
+	return retrieveCatalogItem(r.db, itemID)
 }
 
 // CreateDefaultItems creates inital default catalog
@@ -189,10 +632,10 @@ func (r *CatalogServiceRepository) CreateDefaultItems() error {
 	// If database is empty, insert default items in the catalog
 	if count == 0 {
 		defaultItems := []domain.CatalogItem{
-			{ItemID: "The Lord of the Rings", Description: "A fantastic fantasy book", Price: 30.00, QuantityAvailable: 10},
-			{ItemID: "Berserk Deluxe Edition Vol.1", Description: "Best manga ever", Price: 53.00, QuantityAvailable: 25},
-			{ItemID: "Warhammer 40k, Ultramarines Titus Action Figure", Description: "Very nice figure", Price: 66.09, QuantityAvailable: 15},
-			{ItemID: "20th Century Boys Ultimate Deluxe Edition Vol.1-12", Description: "Most famous Urasawa's collection", Price: 163.90, QuantityAvailable: 20},
+			{Name: "The Lord of the Rings", Description: "A fantastic fantasy book", Price: 30.00, QuantityAvailable: 10},
+			{Name: "Berserk Deluxe Edition Vol.1", Description: "Best manga ever", Price: 53.00, QuantityAvailable: 25},
+			{Name: "Warhammer 40k, Ultramarines Titus Action Figure", Description: "Very nice figure", Price: 66.09, QuantityAvailable: 15},
+			{Name: "20th Century Boys Ultimate Deluxe Edition Vol.1-12", Description: "Most famous Urasawa's collection", Price: 163.90, QuantityAvailable: 20},
 		}
 
 		for _, p := range defaultItems {
@@ -200,7 +643,7 @@ func (r *CatalogServiceRepository) CreateDefaultItems() error {
 			if err != nil {
 				return err
 			}
-			if err = r.AddCatalogItem(protoCatalogItem); err != nil {
+			if _, err = r.AddCatalogItem(protoCatalogItem, SystemActor); err != nil {
 				return err
 			}
 		}
@@ -211,8 +654,220 @@ func (r *CatalogServiceRepository) CreateDefaultItems() error {
 	return nil
 }
 
+// PRIVATE FUNCTIONS TO SAVE THE ITEMS
+
+// saveItem saves the changes of an item and moves it to its next version.
+// The item must still be at the version it was read at, and at the expected one unless it is zero:
+// otherwise someone else changed it in the meantime and ErrVersionConflict is returned.
+func saveItem(tx *gorm.DB, item *domain.CatalogItem, expectedVersion uint64) error {
+	if expectedVersion != 0 && item.Version != expectedVersion {
+		return ErrVersionConflict
+	}
+
+	version := item.Version
+	item.Version++
+	// The rating is kept by the reviews, an update of the item never overwrites it
+	result := tx.Model(item).Where("version = ?", version).Select("*").Omit("rating_average", "rating_count").Updates(item)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		item.Version = version
+	}
+	return result.Error
+}
+
+// PRIVATE FUNCTIONS TO CONVERT THE ITEMS
+
+// toProtoItem converts an item into a pb.CatalogItem, with its tags
+func (r *CatalogServiceRepository) toProtoItem(item *domain.CatalogItem) (*pb.CatalogItem, error) {
+	protoItems, err := r.toProtoItems([]*domain.CatalogItem{item})
+	if err != nil {
+		return nil, err
+	}
+	return protoItems[0], nil
+}
+
+// toProtoItems converts items into pb.CatalogItem, reading the tags, the variants, the warehouses and the images of all the items at once
+func (r *CatalogServiceRepository) toProtoItems(items []*domain.CatalogItem) ([]*pb.CatalogItem, error) {
+	protoItems := make([]*pb.CatalogItem, 0, len(items))
+	if len(items) == 0 {
+		return protoItems, nil
+	}
+
+	tags, err := r.itemTags(items)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	var variants []*domain.CatalogItem
+	if err := r.db.Where("product_id IN ?", itemIDs).Order("created_at, item_id").Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	productVariants := make(map[string][]*pb.CatalogItem)
+	for _, variant := range variants {
+		protoVariant, err := domain.DomainCatalogItemToProtoCatalogItem(variant)
+		if err != nil {
+			return nil, err
+		}
+		productVariants[variant.ProductID] = append(productVariants[variant.ProductID], protoVariant)
+	}
+	locations, err := r.locationsInStock(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	images, err := r.itemImages(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		protoItem, err := domain.DomainCatalogItemToProtoCatalogItem(item)
+		if err != nil {
+			return nil, err
+		}
+		protoItem.Tags = tags[item.ItemID]
+		protoItem.Variants = productVariants[item.ItemID]
+		protoItem.LocationsInStock = locations[item.ItemID]
+		protoItem.Images = images[item.ItemID]
+		protoItems = append(protoItems, protoItem)
+	}
+	return protoItems, nil
+}
+
+// itemTags reads the tags of the items, sorted, by item ID
+func (r *CatalogServiceRepository) itemTags(items []*domain.CatalogItem) (map[string][]string, error) {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+
+	var itemTags []domain.ItemTag
+	if err := r.db.Where("item_id IN ?", itemIDs).Order("tag").Find(&itemTags).Error; err != nil {
+		return nil, err
+	}
+	tags := make(map[string][]string)
+	for _, itemTag := range itemTags {
+		tags[itemTag.ItemID] = append(tags[itemTag.ItemID], itemTag.Tag)
+	}
+	return tags, nil
+}
+
+// PRIVATE FUNCTIONS TO NAME THE ITEMS
+
+// slugify turns a name into lower case words of ASCII letters and digits separated by dashes
+func slugify(name string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			builder.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	slug := builder.String()
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		slug = "item"
+	}
+	return slug
+}
+
+// uniqueSlug derives a slug from the name not used by another item than itemID, adding a number if needed
+func uniqueSlug(name string, itemID string, db *gorm.DB) string {
+	return deriveSlug(name, func(slug string) error { return checkSlugUniqueness(slug, itemID, db) })
+}
+
+// deriveSlug derives a slug from the name accepted by checkUniqueness, adding a number if needed
+func deriveSlug(name string, checkUniqueness func(slug string) error) string {
+	base := slugify(name)
+	slug := base
+	for n := 2; checkUniqueness(slug) != nil; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug
+}
+
+// PRIVATE FUNCTIONS TO PAGINATE THE CATALOG
+
+// pageCursor is the content of a page token: the sort keys of the last item returned.
+// Sort and filters are recorded to reject a token used with another query.
+type pageCursor struct {
+	Sort        pb.CatalogSort `json:"s"`
+	Filters     string         `json:"f"`
+	Name        string         `json:"n"`
+	Price       float64        `json:"p"`
+	CreatedAt   int64          `json:"c"`
+	Rating      float64        `json:"r"`
+	RatingCount uint32         `json:"rc"`
+	ItemID      string         `json:"i"`
+}
+
+// queryFilters summarizes the filters of a query
+func queryFilters(query domain.CatalogQuery) string {
+	return fmt.Sprintf("%g|%g|%t|%s|%s", query.MinPrice, query.MaxPrice, query.InStockOnly, query.CategoryID, normalizeTag(query.Tag))
+}
+
+func encodePageToken(query domain.CatalogQuery, last *domain.CatalogItem) string {
+	raw, _ := json.Marshal(pageCursor{
+		Sort:        query.Sort,
+		Filters:     queryFilters(query),
+		Name:        last.Name,
+		Price:       last.Price,
+		CreatedAt:   last.CreatedAt,
+		Rating:      last.RatingAverage,
+		RatingCount: last.RatingCount,
+		ItemID:      last.ItemID,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageToken(query domain.CatalogQuery) (*pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(query.PageToken)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ItemID == "" {
+		return nil, ErrInvalidPageToken
+	}
+	if cursor.Sort != query.Sort || cursor.Filters != queryFilters(query) {
+		return nil, fmt.Errorf("%w: the query changed", ErrInvalidPageToken)
+	}
+	return &cursor, nil
+}
+
 // PRIVATE FUNCTIONS TO VALIDATE INPUTS
 
+func checkCatalogQueryValidity(query domain.CatalogQuery) error {
+	if query.PageSize < 0 {
+		return errors.New("Invalid page size: cannot be negative")
+	}
+	if query.MinPrice < 0 || query.MaxPrice < 0 {
+		return errors.New("Invalid price range: prices cannot be negative")
+	}
+	if query.MaxPrice > 0 && query.MinPrice > query.MaxPrice {
+		return errors.New("Invalid price range: minimum price is greater than maximum price")
+	}
+	if _, ok := pb.CatalogSort_name[int32(query.Sort)]; !ok {
+		return errors.New("Invalid sort order")
+	}
+	return nil
+}
+
 func checkItemIDValidity(itemID string) error {
 	if itemID == "" {
 		return errors.New("Item ID cannot be empty")
@@ -233,8 +888,49 @@ func checkItemIDUniqueness(itemID string, db *gorm.DB) error {
 	return nil
 }
 
+func checkNameValidity(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("Name cannot be empty")
+	}
+	return nil
+}
+
+func checkSKUValidity(sku string) error {
+	if len(sku) > maxSKULength || strings.ContainsFunc(sku, unicode.IsSpace) {
+		return fmt.Errorf("Invalid SKU: at most %d characters, without spaces", maxSKULength)
+	}
+	return nil
+}
+
+// checkSKUUniqueness checks that no other item than itemID has the SKU
+func checkSKUUniqueness(sku string, itemID string, db *gorm.DB) error {
+	var count int64
+	db.Model(&domain.CatalogItem{}).Where("sku = ? AND item_id <> ?", sku, itemID).Count(&count)
+	if count > 0 {
+		return errors.New("SKU must be unique")
+	}
+	return nil
+}
+
+func checkSlugValidity(slug string) error {
+	if !slugPattern.MatchString(slug) {
+		return errors.New("Invalid slug: only lower case letters and digits separated by single dashes")
+	}
+	return nil
+}
+
+// checkSlugUniqueness checks that no other item than itemID has the slug
+func checkSlugUniqueness(slug string, itemID string, db *gorm.DB) error {
+	var count int64
+	db.Model(&domain.CatalogItem{}).Where("slug = ? AND item_id <> ?", slug, itemID).Count(&count)
+	if count > 0 {
+		return errors.New("Slug must be unique")
+	}
+	return nil
+}
+
 func checkDescriptionValidity(description string) error {
-	if description == "" {
+	if strings.TrimSpace(description) == "" {
 		return errors.New("Description cannot be empty")
 	}
 	return nil
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// Limits on the tags of the items
+const (
+	maxTagsPerItem = 20
+	maxTagLength   = 32
+)
+
+// ErrCategoryNotEmpty is returned when deleting a category still containing subcategories or items.
+var ErrCategoryNotEmpty = errors.New("Category is not empty")
+
+// ErrCategoryCycle is returned when moving a category under itself or one of its subcategories.
+var ErrCategoryCycle = errors.New("A category cannot be moved under itself or one of its subcategories")
+
+// ErrVariantClassified is returned when setting the category or the tags of a variant instead of its product.
+var ErrVariantClassified = errors.New("A variant has the category and the tags of its product")
+
+// CreateCategory creates a category under the parent one, or at the root if parentID is empty, and returns its generated ID.
+// The slug is derived from the name unless given.
+func (r *CatalogServiceRepository) CreateCategory(name string, slug string, parentID string) (string, error) {
+
+	// Check Name validity
+	if err := checkNameValidity(name); err != nil {
+		return "", err
+	}
+
+	categoryID := ulid.Make().String()
+
+	// Check Slug validity and uniqueness, or derive it from the name
+	if slug != "" {
+		if err := checkSlugValidity(slug); err != nil {
+			return "", err
+		}
+		if err := checkCategorySlugUniqueness(slug, categoryID, r.db); err != nil {
+			return "", err
+		}
+	} else {
+		slug = deriveSlug(name, func(slug string) error { return checkCategorySlugUniqueness(slug, categoryID, r.db) })
+	}
+
+	// Check the parent exists, if given
+	if parentID != "" {
+		if _, err := retrieveCategory(r.db, parentID); err != nil {
+			return "", err
+		}
+	}
+
+	category := &domain.Category{
+		CategoryID: categoryID,
+		Name:       strings.TrimSpace(name),
+		Slug:       slug,
+		ParentID:   parentID,
+	}
+	if err := r.db.Create(category).Error; err != nil {
+		return "", err
+	}
+
+	r.publishCategoriesChanged()
+	return categoryID, nil
+}
+
+// UpdateCategory renames a category, the empty name or slug are left unchanged.
+func (r *CatalogServiceRepository) UpdateCategory(categoryID string, name string, slug string) error {
+
+	// Retrieve category
+	category, err := retrieveCategory(r.db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		if err := checkNameValidity(name); err != nil {
+			return err
+		}
+		category.Name = strings.TrimSpace(name)
+	}
+
+	if slug != "" {
+		if err := checkSlugValidity(slug); err != nil {
+			return err
+		}
+		if err := checkCategorySlugUniqueness(slug, categoryID, r.db); err != nil {
+			return err
+		}
+		category.Slug = slug
+	}
+
+	if err := r.db.Save(category).Error; err != nil {
+		return err
+	}
+
+	r.publishCategoriesChanged()
+	return nil
+}
+
+// MoveCategory moves a category, with its subcategories and items, under another one or to the root if parentID is empty.
+func (r *CatalogServiceRepository) MoveCategory(categoryID string, parentID string) error {
+
+	// Retrieve category
+	category, err := retrieveCategory(r.db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	// The new parent must exist and be outside the subtree of the category
+	if parentID != "" {
+		subtree, err := categoryAndDescendants(r.db, categoryID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(subtree, parentID) {
+			return ErrCategoryCycle
+		}
+		if _, err := retrieveCategory(r.db, parentID); err != nil {
+			return err
+		}
+	}
+
+	category.ParentID = parentID
+	if err := r.db.Save(category).Error; err != nil {
+		return err
+	}
+
+	r.publishCategoriesChanged()
+	return nil
+}
+
+// DeleteCategory deletes a category without subcategories nor items.
+func (r *CatalogServiceRepository) DeleteCategory(categoryID string) error {
+
+	// Retrieve category
+	category, err := retrieveCategory(r.db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	// Subcategories and items would be left without a category
+	var children, items int64
+	if err := r.db.Model(&domain.Category{}).Where("parent_id = ?", categoryID).Count(&children).Error; err != nil {
+		return err
+	}
+	if err := r.db.Model(&domain.CatalogItem{}).Where("category_id = ?", categoryID).Count(&items).Error; err != nil {
+		return err
+	}
+	if children > 0 || items > 0 {
+		return fmt.Errorf("%w: %d subcategories and %d items", ErrCategoryNotEmpty, children, items)
+	}
+
+	if err := r.db.Delete(category).Error; err != nil {
+		return err
+	}
+
+	r.publishCategoriesChanged()
+	return nil
+}
+
+// ListCategories returns all the categories sorted by name, with the number of items they contain.
+// The items of a subcategory are counted in all its ancestors too.
+func (r *CatalogServiceRepository) ListCategories() ([]*pb.Category, error) {
+	var categories []*domain.Category
+	if err := r.db.Order("name, category_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	// Items directly in each category
+	var counts []struct {
+		CategoryID string
+		Count      uint32
+	}
+	if err := r.db.Model(&domain.CatalogItem{}).Select("category_id, COUNT(*) AS count").
+		Where("category_id <> ''").Group("category_id").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	parents := make(map[string]string, len(categories))
+	for _, category := range categories {
+		parents[category.CategoryID] = category.ParentID
+	}
+
+	// Every category counts the items of its subtree, the walk up is bounded in case of a corrupted tree
+	itemCounts := make(map[string]uint32, len(categories))
+	for _, count := range counts {
+		id := count.CategoryID
+		for depth := 0; id != "" && depth <= len(categories); depth++ {
+			itemCounts[id] += count.Count
+			id = parents[id]
+		}
+	}
+
+	protoCategories := make([]*pb.Category, 0, len(categories))
+	for _, category := range categories {
+		protoCategory, err := domain.DomainCategoryToProtoCategory(category, itemCounts[category.CategoryID])
+		if err != nil {
+			return nil, err
+		}
+		protoCategories = append(protoCategories, protoCategory)
+	}
+	return protoCategories, nil
+}
+
+// SetItemCategory sets the category of an item, an empty categoryID removes it.
+func (r *CatalogServiceRepository) SetItemCategory(itemID string, categoryID string) error {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return err
+	}
+
+	// Retrieve item
+	item, err := r.RetrieveCatalogItem(itemID)
+	if err != nil {
+		return err
+	}
+	if item.ProductID != "" {
+		return ErrVariantClassified
+	}
+
+	// Check the category exists, if given
+	if categoryID != "" {
+		if _, err := retrieveCategory(r.db, categoryID); err != nil {
+			return err
+		}
+	}
+
+	if err := r.db.Model(item).Updates(map[string]any{"category_id": categoryID, "version": nextVersion}).Error; err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return nil
+}
+
+// SetItemTags replaces the tags of an item, tags are stored in lower case.
+func (r *CatalogServiceRepository) SetItemTags(itemID string, tags []string) error {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return err
+	}
+
+	// Check Tags validity
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	// Check the item exists
+	item, err := r.RetrieveCatalogItem(itemID)
+	if err != nil {
+		return err
+	}
+	if item.ProductID != "" {
+		return ErrVariantClassified
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id = ?", itemID).Delete(&domain.ItemTag{}).Error; err != nil {
+			return err
+		}
+		return saveItemTags(tx, itemID, normalized)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return nil
+}
+
+// ListTags returns the tags in use sorted alphabetically, with the number of items having them.
+func (r *CatalogServiceRepository) ListTags() ([]*pb.TagCount, error) {
+	var counts []struct {
+		Tag   string
+		Count uint32
+	}
+	if err := r.db.Model(&domain.ItemTag{}).Select("tag, COUNT(*) AS count").
+		Group("tag").Order("tag").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]*pb.TagCount, len(counts))
+	for i, count := range counts {
+		tags[i] = &pb.TagCount{Tag: count.Tag, ItemCount: count.Count}
+	}
+	return tags, nil
+}
+
+// PRIVATE FUNCTIONS FOR CATEGORIES AND TAGS
+
+func retrieveCategory(db *gorm.DB, categoryID string) (*domain.Category, error) {
+	if categoryID == "" {
+		return nil, errors.New("Category ID cannot be empty")
+	}
+
+	var category domain.Category
+	if err := db.First(&category, "category_id = ?", categoryID).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// categoryAndDescendants returns the ID of a category and of all its subcategories
+func categoryAndDescendants(db *gorm.DB, categoryID string) ([]string, error) {
+	if _, err := retrieveCategory(db, categoryID); err != nil {
+		return nil, err
+	}
+
+	var categories []domain.Category
+	if err := db.Select("category_id", "parent_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	children := make(map[string][]string)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.CategoryID)
+	}
+
+	// Breadth-first visit, the seen set protects from a corrupted tree
+	subtree := []string{categoryID}
+	seen := map[string]bool{categoryID: true}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i]] {
+			if !seen[child] {
+				seen[child] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree, nil
+}
+
+// checkCategorySlugUniqueness checks that no other category than categoryID has the slug
+func checkCategorySlugUniqueness(slug string, categoryID string, db *gorm.DB) error {
+	var count int64
+	db.Model(&domain.Category{}).Where("slug = ? AND category_id <> ?", slug, categoryID).Count(&count)
+	if count > 0 {
+		return errors.New("Slug must be unique")
+	}
+	return nil
+}
+
+// normalizeTag lower cases a tag and collapses its spaces
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// normalizeTags normalizes the tags of an item, dropping the duplicates and sorting them
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" {
+			return nil, errors.New("Invalid tag: cannot be empty")
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return nil, fmt.Errorf("Invalid tag %q: at most %d characters", tag, maxTagLength)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+	if len(normalized) > maxTagsPerItem {
+		return nil, fmt.Errorf("Invalid tags: at most %d per item", maxTagsPerItem)
+	}
+	return normalized, nil
+}
+
+// saveItemTags stores the tags of an item, already normalized
+func saveItemTags(tx *gorm.DB, itemID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	itemTags := make([]domain.ItemTag, len(tags))
+	for i, tag := range tags {
+		itemTags[i] = domain.ItemTag{ItemID: itemID, Tag: tag}
+	}
+	return tx.Create(&itemTags).Error
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
+)
+
+// maxItemImages bounds the number of images of an item
+const maxItemImages = 20
+
+// ErrTooManyImages is returned when an image is added to an item having already maxItemImages.
+var ErrTooManyImages = fmt.Errorf("An item cannot have more than %d images", maxItemImages)
+
+// ErrImageOrderMismatch is returned when the images given to reorder are not exactly the ones of the item.
+var ErrImageOrderMismatch = errors.New("The images must be all the ones of the item, each once")
+
+// AddItemImage adds an image to an item, after its other images, and returns it.
+// The image is checked and its thumbnail generated, both are kept in the blob store.
+func (r *CatalogServiceRepository) AddItemImage(itemID string, data []byte) (*pb.ItemImage, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return nil, err
+	}
+
+	// Check the image and generate its thumbnail
+	processed, err := media.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &domain.ItemImage{
+		ImageID:              ulid.Make().String(),
+		ItemID:               itemID,
+		ContentType:          processed.ContentType,
+		ThumbnailContentType: processed.ThumbnailContentType,
+		Width:                processed.Width,
+		Height:               processed.Height,
+	}
+
+	// The content is stored before the image is added, so that an image added can always be served
+	if err := r.images.Put(image.ImageID, data); err != nil {
+		return nil, err
+	}
+	if err := r.images.Put(domain.ThumbnailKey(image.ImageID), processed.Thumbnail); err != nil {
+		r.deleteImageContent([]string{image.ImageID})
+		return nil, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.ItemImage{}).Where("item_id = ?", itemID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxItemImages {
+			return ErrTooManyImages
+		}
+
+		image.Position = uint32(count)
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		r.deleteImageContent([]string{image.ImageID})
+		return nil, err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return domain.DomainItemImageToProtoItemImage(image), nil
+}
+
+// RemoveItemImage removes an image of an item with its content, the images after it move up.
+func (r *CatalogServiceRepository) RemoveItemImage(itemID, imageID string) error {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var image domain.ItemImage
+		if err := tx.Where("image_id = ? AND item_id = ?", imageID, itemID).First(&image).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&image).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ItemImage{}).
+			Where("item_id = ? AND position > ?", itemID, image.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.deleteImageContent([]string{imageID})
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return nil
+}
+
+// ReorderItemImages puts the images of an item in the order of imageIDs, which must be all of its images.
+func (r *CatalogServiceRepository) ReorderItemImages(itemID string, imageIDs []string) error {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var images []*domain.ItemImage
+		if err := tx.Where("item_id = ?", itemID).Find(&images).Error; err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Every image of the item must be given once
+		current := make([]string, len(images))
+		for i, image := range images {
+			current[i] = image.ImageID
+		}
+		given := slices.Clone(imageIDs)
+		slices.Sort(current)
+		slices.Sort(given)
+		if !slices.Equal(current, given) {
+			return ErrImageOrderMismatch
+		}
+
+		for position, imageID := range imageIDs {
+			if err := tx.Model(&domain.ItemImage{}).Where("image_id = ?", imageID).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return nil
+}
+
+// GetImage returns the content of an image, or of its thumbnail, with its content type.
+func (r *CatalogServiceRepository) GetImage(imageID string, thumbnail bool) ([]byte, string, error) {
+
+	var image domain.ItemImage
+	if err := r.db.Where("image_id = ?", imageID).First(&image).Error; err != nil {
+		return nil, "", err
+	}
+
+	key, contentType := image.ImageID, image.ContentType
+	if thumbnail {
+		key, contentType = domain.ThumbnailKey(image.ImageID), image.ThumbnailContentType
+	}
+	data, err := r.images.Get(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentType, nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE THE IMAGES
+
+// itemImages reads the images of the items, in their order, by item ID
+func (r *CatalogServiceRepository) itemImages(itemIDs []string) (map[string][]*pb.ItemImage, error) {
+	var images []*domain.ItemImage
+	if err := r.db.Where("item_id IN ?", itemIDs).Order("item_id, position").Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	itemImages := make(map[string][]*pb.ItemImage)
+	for _, image := range images {
+		itemImages[image.ItemID] = append(itemImages[image.ItemID], domain.DomainItemImageToProtoItemImage(image))
+	}
+	return itemImages, nil
+}
+
+// removeImages removes the images of the items removed from the catalog and returns their IDs,
+// their content is deleted once the removal is committed
+func removeImages(tx *gorm.DB, items []*domain.CatalogItem) ([]string, error) {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+
+	var imageIDs []string
+	if err := tx.Model(&domain.ItemImage{}).Where("item_id IN ?", itemIDs).Pluck("image_id", &imageIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("item_id IN ?", itemIDs).Delete(&domain.ItemImage{}).Error; err != nil {
+		return nil, err
+	}
+	return imageIDs, nil
+}
+
+// deleteImageContent deletes the content of the images and of their thumbnails from the blob store.
+// A content left behind is only wasted space, the failures are logged
+func (r *CatalogServiceRepository) deleteImageContent(imageIDs []string) {
+	if r.images == nil {
+		return
+	}
+
+	for _, imageID := range imageIDs {
+		for _, key := range []string{imageID, domain.ThumbnailKey(imageID)} {
+			if err := r.images.Delete(key); err != nil {
+				log.Printf("Failed to delete the content %s of a removed image: %v", key, err)
+			}
+		}
+	}
+}
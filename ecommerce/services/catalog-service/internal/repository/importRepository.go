@@ -0,0 +1,594 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/search"
+)
+
+const (
+	// MaxImportSize bounds the size in bytes of an imported file
+	MaxImportSize = 16 << 20
+
+	// maxImportRows bounds the number of rows of an imported file
+	maxImportRows = 10000
+
+	// exportBatchSize is the number of items read at once while exporting the catalog
+	exportBatchSize = 500
+)
+
+// catalogColumns are the columns of the CSV files, and the fields of the JSON ones
+var catalogColumns = []string{"item_id", "sku", "slug", "name", "description", "price", "quantity", "category_id", "tags", "product_id", "attributes"}
+
+// ErrInvalidImportFile is returned when an imported file cannot be read at all, as opposed to the errors of single rows.
+var ErrInvalidImportFile = errors.New("Invalid import file")
+
+// errDryRun rolls back the transaction of an import in dry-run mode
+var errDryRun = errors.New("Dry run")
+
+// catalogRow is a row of an imported or exported file, the empty fields of an imported row are left unchanged
+type catalogRow struct {
+	ItemID      string            `json:"item_id,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Slug        string            `json:"slug,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Price       *float64          `json:"price,omitempty"`
+	Quantity    *uint32           `json:"quantity,omitempty"`
+	CategoryID  string            `json:"category_id,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	ProductID   string            `json:"product_id,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// decodedRow is a row read from an imported file, or the reason it could not be read
+type decodedRow struct {
+	row *catalogRow
+	err error
+}
+
+// ImportCatalogItems adds the rows of a CSV or JSON file to the catalog, each row on its own:
+// the rows with errors are reported and skipped, the others are imported.
+// A row updates the item with the same item_id, or else the same sku, and adds a new item otherwise.
+// In dry-run mode the rows are checked and reported the same way, but nothing is changed.
+// The quantities imported are recorded in the ledger on behalf of actor.
+func (r *CatalogServiceRepository) ImportCatalogItems(format pb.CatalogFileFormat, data io.Reader, dryRun bool, actor string) (*pb.ImportCatalogItemsResponse, error) {
+
+	// Read the whole file, rows which cannot be decoded are reported with the others
+	rows, err := decodeCatalogRows(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &pb.ImportCatalogItemsResponse{DryRun: dryRun}
+	var added, updated []string
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for i, decoded := range rows {
+			created := false
+			err := decoded.err
+			if err == nil {
+
+				// Every row is imported in a savepoint, undone alone if the row fails.
+				// The search index and the watchers are updated only after the commit.
+				err = tx.Transaction(func(rowTx *gorm.DB) error {
+					rowRepo := &CatalogServiceRepository{db: rowTx, index: search.NewIndex()}
+					itemID, isNew, err := rowRepo.importCatalogRow(decoded.row, actor)
+					if err != nil {
+						return err
+					}
+					if isNew {
+						added = append(added, itemID)
+					} else {
+						updated = append(updated, itemID)
+					}
+					created = isNew
+					return nil
+				})
+			}
+
+			switch {
+			case err != nil:
+				report.Failed++
+				report.Errors = append(report.Errors, &pb.ImportRowError{
+					Row:       uint32(i + 1),
+					Reference: rowReference(decoded.row),
+					Message:   importErrorMessage(err),
+				})
+			case created:
+				report.Created++
+			default:
+				report.Updated++
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	// The search index and the watchers follow the committed items only
+	if !dryRun {
+		if err := r.reindexItems(append(added, updated...)); err != nil {
+			return nil, err
+		}
+		r.publishChanges(pb.CatalogEventType_ITEM_ADDED, added...)
+		r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, updated...)
+	}
+	return report, nil
+}
+
+// ExportCatalogItems writes every item of the catalog as a CSV or JSON file which can be imported back.
+// Products come before their variants, the items are read in batches.
+func (r *CatalogServiceRepository) ExportCatalogItems(format pb.CatalogFileFormat, w io.Writer) error {
+	encoder, err := newCatalogEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range []string{"product_id = ''", "product_id <> ''"} {
+		var items []*domain.CatalogItem
+		result := r.db.Where(condition).FindInBatches(&items, exportBatchSize, func(tx *gorm.DB, batch int) error {
+			tags, err := r.itemTags(items)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := encoder.write(exportedRow(item, tags[item.ItemID])); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return encoder.close()
+}
+
+// PRIVATE FUNCTIONS TO IMPORT THE ITEMS
+
+// importCatalogRow adds or updates the item of a row on behalf of actor, returning its ID and whether it was added
+func (r *CatalogServiceRepository) importCatalogRow(row *catalogRow, actor string) (string, bool, error) {
+	existing, err := findImportedItem(r.db, row)
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := checkCatalogRowValidity(row, existing == nil); err != nil {
+		return "", false, err
+	}
+
+	// A new item needs every field AddCatalogItem does
+	if existing == nil {
+		item := &pb.CatalogItem{
+			ItemId:      row.ItemID,
+			Sku:         row.SKU,
+			Slug:        row.Slug,
+			Name:        row.Name,
+			Description: row.Description,
+			Price:       *row.Price,
+			CategoryId:  row.CategoryID,
+			Tags:        row.Tags,
+			ProductId:   row.ProductID,
+			Attributes:  row.Attributes,
+		}
+		if row.Quantity != nil {
+			item.QuantityAvailable = *row.Quantity
+		}
+		itemID, err := r.AddCatalogItem(item, actor)
+		return itemID, true, err
+	}
+
+	if row.ProductID != "" && row.ProductID != existing.ProductID {
+		return "", false, errors.New("The product of an item cannot be changed")
+	}
+
+	err = r.UpdateCatalogItem(&pb.CatalogItem{
+		ItemId:      existing.ItemID,
+		Name:        row.Name,
+		Description: row.Description,
+		Sku:         row.SKU,
+		Slug:        row.Slug,
+		Attributes:  row.Attributes,
+	}, 0)
+	if err != nil {
+		return "", false, err
+	}
+
+	// The price and quantity of a product with variants come from its variants, the ones of the row are ignored
+	found, err := hasVariants(r.db, existing.ItemID)
+	if err != nil {
+		return "", false, err
+	}
+	if !found && row.Price != nil && *row.Price != existing.Price {
+		if err := r.UpdatePrice(existing.ItemID, *row.Price, 0); err != nil {
+			return "", false, err
+		}
+	}
+
+	// The quantity of the row is the total of the warehouses, the difference is made up in the default one
+	if !found && row.Quantity != nil && *row.Quantity != existing.QuantityAvailable {
+		current, err := warehouseQuantity(r.db, domain.DefaultWarehouseID, existing.ItemID)
+		if err != nil {
+			return "", false, err
+		}
+		quantity := int64(current) + int64(*row.Quantity) - int64(existing.QuantityAvailable)
+		if quantity < 0 {
+			return "", false, fmt.Errorf("Quantity cannot be lower than the %d in stock at the other warehouses", existing.QuantityAvailable-current)
+		}
+		if err := r.UpdateQuantityAvailable(existing.ItemID, domain.DefaultWarehouseID, uint32(quantity), 0, domain.MovementAdjustment, actor); err != nil {
+			return "", false, err
+		}
+	}
+
+	if row.CategoryID != "" && row.CategoryID != existing.CategoryID {
+		if err := r.SetItemCategory(existing.ItemID, row.CategoryID); err != nil {
+			return "", false, err
+		}
+	}
+	if len(row.Tags) > 0 {
+		if err := r.SetItemTags(existing.ItemID, row.Tags); err != nil {
+			return "", false, err
+		}
+	}
+	return existing.ItemID, false, nil
+}
+
+// findImportedItem finds the item updated by a row, by its ID or else by its SKU, nil if the row is a new item
+func findImportedItem(db *gorm.DB, row *catalogRow) (*domain.CatalogItem, error) {
+	var items []*domain.CatalogItem
+	if row.ItemID != "" {
+		if err := db.Where("item_id = ?", row.ItemID).Limit(1).Find(&items).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(items) == 0 && row.SKU != "" {
+		if err := db.Where("sku = ?", row.SKU).Limit(1).Find(&items).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0], nil
+}
+
+// reindexItems updates the search index with the current state of the items
+func (r *CatalogServiceRepository) reindexItems(itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	var items []*domain.CatalogItem
+	if err := r.db.Where("item_id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return err
+	}
+	for _, item := range items {
+		r.indexItem(item)
+	}
+	return nil
+}
+
+func checkCatalogRowValidity(row *catalogRow, isNew bool) error {
+	if row.Price != nil {
+		if err := checkPriceValidity(*row.Price); err != nil {
+			return err
+		}
+	} else if isNew {
+		return errors.New("Price of a new item cannot be empty")
+	}
+
+	if row.Quantity != nil {
+		if err := checkQuantityAvailableValidity(*row.Quantity); err != nil {
+			return err
+		}
+	}
+
+	// Variants take the name and description of their product
+	if isNew && row.ProductID == "" {
+		if err := checkNameValidity(row.Name); err != nil {
+			return err
+		}
+		if err := checkDescriptionValidity(row.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowReference names the item of a row in the report of an import
+func rowReference(row *catalogRow) string {
+	if row == nil {
+		return ""
+	}
+	for _, reference := range []string{row.ItemID, row.SKU, row.Name} {
+		if reference != "" {
+			return reference
+		}
+	}
+	return ""
+}
+
+func importErrorMessage(err error) string {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "Product or category not found"
+	}
+	return err.Error()
+}
+
+// PRIVATE FUNCTIONS TO READ AND WRITE THE FILES
+
+func decodeCatalogRows(format pb.CatalogFileFormat, data io.Reader) ([]decodedRow, error) {
+	var rows []decodedRow
+	var err error
+	switch format {
+	case pb.CatalogFileFormat_CSV:
+		rows, err = decodeCSVRows(data)
+	case pb.CatalogFileFormat_JSON:
+		rows, err = decodeJSONRows(data)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %v", ErrInvalidImportFile, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > maxImportRows {
+		return nil, fmt.Errorf("%w: at most %d rows", ErrInvalidImportFile, maxImportRows)
+	}
+	return rows, nil
+}
+
+// decodeCSVRows reads a CSV file with a header naming its columns, a subset of catalogColumns in any order
+func decodeCSVRows(data io.Reader) ([]decodedRow, error) {
+	reader := csv.NewReader(data)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header: %v", ErrInvalidImportFile, err)
+	}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !slices.Contains(catalogColumns, column) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImportFile, column)
+		}
+		header[i] = column
+	}
+
+	var rows []decodedRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		// A record with a wrong number of fields is a bad row, the file can still be read
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		if err != nil {
+			rows = append(rows, decodedRow{err: fmt.Errorf("Expected %d fields, got %d", len(header), len(record))})
+			continue
+		}
+
+		row, err := csvRow(header, record)
+		rows = append(rows, decodedRow{row: row, err: err})
+	}
+}
+
+func csvRow(header []string, record []string) (*catalogRow, error) {
+	row := &catalogRow{}
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+
+		switch column {
+		case "item_id":
+			row.ItemID = value
+		case "sku":
+			row.SKU = value
+		case "slug":
+			row.Slug = value
+		case "name":
+			row.Name = value
+		case "description":
+			row.Description = value
+		case "price":
+			price, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return row, fmt.Errorf("Invalid price %q", value)
+			}
+			row.Price = &price
+		case "quantity":
+			quantity, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return row, fmt.Errorf("Invalid quantity %q", value)
+			}
+			quantity32 := uint32(quantity)
+			row.Quantity = &quantity32
+		case "category_id":
+			row.CategoryID = value
+		case "tags":
+			row.Tags = strings.Split(value, ";")
+		case "product_id":
+			row.ProductID = value
+		case "attributes":
+			attributes, err := parseCSVAttributes(value)
+			if err != nil {
+				return row, err
+			}
+			row.Attributes = attributes
+		}
+	}
+	return row, nil
+}
+
+// parseCSVAttributes parses the attributes of a variant written as "format=Hardcover;language=English"
+func parseCSVAttributes(value string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		name, attributeValue, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid attribute %q: expected name=value", pair)
+		}
+		attributes[strings.TrimSpace(name)] = strings.TrimSpace(attributeValue)
+	}
+	return attributes, nil
+}
+
+// decodeJSONRows reads a JSON array of objects with the fields of catalogRow
+func decodeJSONRows(data io.Reader) ([]decodedRow, error) {
+	decoder := json.NewDecoder(data)
+	decoder.DisallowUnknownFields()
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: expected an array of items", ErrInvalidImportFile)
+	}
+
+	var rows []decodedRow
+	for decoder.More() {
+		row := &catalogRow{}
+
+		// A value of the wrong type is a bad row, a syntax error ends the file
+		err := decoder.Decode(row)
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		if err != nil {
+			err = fmt.Errorf("Invalid item: %v", err)
+		}
+		rows = append(rows, decodedRow{row: row, err: err})
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
+
+// exportedRow is the row of an item in an exported file
+func exportedRow(item *domain.CatalogItem, tags []string) *catalogRow {
+	price, quantity := item.Price, item.QuantityAvailable
+	return &catalogRow{
+		ItemID:      item.ItemID,
+		SKU:         item.SKU,
+		Slug:        item.Slug,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       &price,
+		Quantity:    &quantity,
+		CategoryID:  item.CategoryID,
+		Tags:        tags,
+		ProductID:   item.ProductID,
+		Attributes:  item.Attributes,
+	}
+}
+
+// catalogEncoder writes the rows of an exported file
+type catalogEncoder interface {
+	write(row *catalogRow) error
+	close() error
+}
+
+func newCatalogEncoder(format pb.CatalogFileFormat, w io.Writer) (catalogEncoder, error) {
+	switch format {
+	case pb.CatalogFileFormat_CSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(catalogColumns); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{writer: writer}, nil
+	case pb.CatalogFileFormat_JSON:
+		return &jsonEncoder{writer: bufio.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("Unknown format %v", format)
+	}
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) write(row *catalogRow) error {
+	attributes := make([]string, 0, len(row.Attributes))
+	for _, name := range slices.Sorted(maps.Keys(row.Attributes)) {
+		attributes = append(attributes, name+"="+row.Attributes[name])
+	}
+
+	return e.writer.Write([]string{
+		row.ItemID,
+		row.SKU,
+		row.Slug,
+		row.Name,
+		row.Description,
+		strconv.FormatFloat(*row.Price, 'f', -1, 64),
+		strconv.FormatUint(uint64(*row.Quantity), 10),
+		row.CategoryID,
+		strings.Join(row.Tags, ";"),
+		row.ProductID,
+		strings.Join(attributes, ";"),
+	})
+}
+
+func (e *csvEncoder) close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// jsonEncoder writes an array with an item per line
+type jsonEncoder struct {
+	writer *bufio.Writer
+	rows   int
+}
+
+func (e *jsonEncoder) write(row *catalogRow) error {
+	encoded, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.rows == 0 {
+		separator = "[\n"
+	}
+	e.rows++
+	if _, err := e.writer.WriteString(separator); err != nil {
+		return err
+	}
+	_, err = e.writer.Write(encoded)
+	return err
+}
+
+func (e *jsonEncoder) close() error {
+	closing := "\n]\n"
+	if e.rows == 0 {
+		closing = "[]\n"
+	}
+	if _, err := e.writer.WriteString(closing); err != nil {
+		return err
+	}
+	return e.writer.Flush()
+}
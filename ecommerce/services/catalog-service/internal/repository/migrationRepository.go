@@ -0,0 +1,100 @@
+package repository
+
+import (
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// legacyItemIDsMigration is the name of the migration of the legacy item IDs among the applied migrations
+const legacyItemIDsMigration = "legacy-item-ids"
+
+// MigrateLegacyItemIDs gives a generated ID to the items still identified by their title.
+// The title becomes the name of the item, the former ID is recorded so that the other services can rewrite their references.
+// It returns the number of items migrated. The migration is applied once: the items added afterwards keep their IDs,
+// even the ones given that are not ULIDs.
+func (r *CatalogServiceRepository) MigrateLegacyItemIDs() (int, error) {
+	var applied int64
+	if err := r.db.Model(&domain.AppliedMigration{}).Where("name = ?", legacyItemIDsMigration).Count(&applied).Error; err != nil {
+		return 0, err
+	}
+	if applied > 0 {
+		return 0, nil
+	}
+
+	var items []*domain.CatalogItem
+	if err := r.db.Find(&items).Error; err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, item := range items {
+
+		// Generated IDs are ULIDs, anything else is a title
+		if _, err := ulid.ParseStrict(item.ItemID); err == nil {
+			continue
+		}
+
+		legacyID := item.ItemID
+		itemID := ulid.Make().String()
+
+		name := item.Name
+		if name == "" {
+			name = legacyID
+		}
+		slug := item.Slug
+		if slug == "" {
+			slug = uniqueSlug(name, legacyID, r.db)
+		}
+
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&domain.CatalogItem{}).Where("item_id = ?", legacyID).
+				Updates(map[string]any{"item_id": itemID, "name": name, "slug": slug}).Error; err != nil {
+				return err
+			}
+
+			// Reservations, tags, prices and movements reference the items too
+			for _, model := range []any{&domain.ReservationItem{}, &domain.ItemTag{}, &domain.PriceHistory{}, &domain.ScheduledPrice{}, &domain.StockMovement{}, &domain.WarehouseStock{}, &domain.StockAlert{}} {
+				if err := tx.Model(model).Where("item_id = ?", legacyID).
+					Update("item_id", itemID).Error; err != nil {
+					return err
+				}
+			}
+
+			return tx.Create(&domain.ItemIDMapping{LegacyID: legacyID, ItemID: itemID}).Error
+		})
+		if err != nil {
+			return migrated, err
+		}
+
+		r.index.Remove(legacyID)
+		r.index.Add(itemID, name, item.Description)
+		migrated++
+	}
+
+	// An interrupted migration goes on at the next run
+	if err := r.db.Create(&domain.AppliedMigration{Name: legacyItemIDsMigration}).Error; err != nil {
+		return migrated, err
+	}
+	return migrated, nil
+}
+
+// ResolveLegacyItemIDs returns the generated IDs of the items formerly identified by the given titles.
+// Titles of items never migrated are left out.
+func (r *CatalogServiceRepository) ResolveLegacyItemIDs(legacyIDs []string) (map[string]string, error) {
+	itemIDs := make(map[string]string)
+	if len(legacyIDs) == 0 {
+		return itemIDs, nil
+	}
+
+	var mappings []domain.ItemIDMapping
+	if err := r.db.Where("legacy_id IN ?", legacyIDs).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappings {
+		itemIDs[mapping.LegacyID] = mapping.ItemID
+	}
+	return itemIDs, nil
+}
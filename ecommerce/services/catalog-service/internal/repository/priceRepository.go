@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+var (
+	// ErrScheduleOverlap is returned when a price is scheduled in a period where another price of the item applies
+	ErrScheduleOverlap = errors.New("Another price of the item is scheduled in the same period")
+
+	// ErrScheduleClosed is returned when a scheduled price has already ended or has been canceled
+	ErrScheduleClosed = errors.New("Scheduled price has already ended or has been canceled")
+)
+
+// GetPriceHistory returns the prices a catalog item has had, the most recent first.
+func (r *CatalogServiceRepository) GetPriceHistory(itemID string) ([]*pb.PriceChange, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+
+	// Check the item exists
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return nil, err
+	}
+
+	var history []*domain.PriceHistory
+	if err := r.db.Where("item_id = ?", itemID).Order("id DESC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	changes := make([]*pb.PriceChange, len(history))
+	for i, change := range history {
+		changes[i] = domain.DomainPriceHistoryToProtoPriceChange(change)
+	}
+	return changes, nil
+}
+
+// SchedulePriceChange schedules the price of a catalog item from startsAt and returns the ID of the schedule.
+// A sale ends at endsAt, when the price the item had before it comes back; a zero endsAt changes the price for good.
+// The scheduled prices of the same item cannot overlap.
+func (r *CatalogServiceRepository) SchedulePriceChange(itemID string, price float64, startsAt, endsAt time.Time) (string, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return "", err
+	}
+
+	// Check price validity
+	if err := checkPriceValidity(price); err != nil {
+		return "", err
+	}
+
+	// Check the period of the schedule
+	if err := checkSchedulePeriodValidity(startsAt, endsAt, time.Now()); err != nil {
+		return "", err
+	}
+
+	schedule := &domain.ScheduledPrice{
+		ScheduleID: ulid.Make().String(),
+		ItemID:     itemID,
+		Price:      price,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		Status:     domain.SchedulePending,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := retrieveCatalogItem(tx, itemID); err != nil {
+			return err
+		}
+
+		// The price of a product with variants is the lowest of its variants
+		if err := checkNotProductWithVariants(itemID, tx); err != nil {
+			return err
+		}
+
+		if err := checkScheduleOverlap(tx, schedule); err != nil {
+			return err
+		}
+		return tx.Create(schedule).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return schedule.ScheduleID, nil
+}
+
+// ListScheduledPrices returns the scheduled prices of a catalog item not applied yet and its sale in progress,
+// in the order they start.
+func (r *CatalogServiceRepository) ListScheduledPrices(itemID string) ([]*pb.ScheduledPrice, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+
+	// Check the item exists
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return nil, err
+	}
+
+	var scheduled []*domain.ScheduledPrice
+	if err := r.db.Where("item_id = ? AND status IN ?", itemID, []domain.ScheduledPriceStatus{domain.SchedulePending, domain.ScheduleActive}).
+		Order("starts_at").Find(&scheduled).Error; err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*pb.ScheduledPrice, len(scheduled))
+	for i, schedule := range scheduled {
+		schedules[i] = domain.DomainScheduledPriceToProtoScheduledPrice(schedule)
+	}
+	return schedules, nil
+}
+
+// CancelScheduledPrice cancels a scheduled price not applied yet.
+// A sale in progress is ended, the price the item had before it comes back.
+func (r *CatalogServiceRepository) CancelScheduledPrice(scheduleID string) error {
+
+	// Check ScheduleID validity
+	if scheduleID == "" {
+		return errors.New("Schedule ID cannot be empty")
+	}
+
+	var restored string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		schedule, err := retrieveScheduledPrice(tx, scheduleID)
+		if err != nil {
+			return err
+		}
+
+		switch schedule.Status {
+		case domain.ScheduleDone, domain.ScheduleCanceled:
+			return ErrScheduleClosed
+		case domain.SchedulePending:
+			return tx.Model(schedule).Update("status", domain.ScheduleCanceled).Error
+		}
+
+		changed, err := endSale(tx, schedule, domain.ScheduleCanceled)
+		if changed {
+			restored = schedule.ItemID
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if restored != "" {
+		r.publishChanges(pb.CatalogEventType_PRICE_CHANGED, restored)
+	}
+	return nil
+}
+
+// ApplyScheduledPrices starts the scheduled prices and ends the sales due at the given time, in the order they are due.
+// It returns the number of prices changed.
+func (r *CatalogServiceRepository) ApplyScheduledPrices(now time.Time) (int, error) {
+
+	var schedules []*domain.ScheduledPrice
+	if err := r.db.Where("(status = ? AND starts_at <= ?) OR (status = ? AND ends_at <= ?)",
+		domain.SchedulePending, now, domain.ScheduleActive, now).Find(&schedules).Error; err != nil {
+		return 0, err
+	}
+
+	// A sale ending when the next one starts is ended first, so the next one saves the right previous price
+	slices.SortStableFunc(schedules, func(a, b *domain.ScheduledPrice) int {
+		if order := dueAt(a).Compare(dueAt(b)); order != 0 {
+			return order
+		}
+		if a.Status == b.Status {
+			return 0
+		}
+		if a.Status == domain.ScheduleActive {
+			return -1
+		}
+		return 1
+	})
+
+	changed := 0
+	for _, candidate := range schedules {
+		var itemID string
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+
+			// Read again inside the transaction, it may have been canceled or removed with its item in the meantime
+			schedule, err := retrieveScheduledPrice(tx, candidate.ScheduleID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			applied, err := applySchedule(tx, schedule, now)
+			if applied {
+				itemID = schedule.ItemID
+			}
+			return err
+		})
+		if err != nil {
+			return changed, err
+		}
+
+		if itemID != "" {
+			changed++
+			r.publishChanges(pb.CatalogEventType_PRICE_CHANGED, itemID)
+		}
+	}
+	return changed, nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE PRICES
+
+// retrieveScheduledPrice retrieves a scheduled price using the given connection or transaction.
+func retrieveScheduledPrice(db *gorm.DB, scheduleID string) (*domain.ScheduledPrice, error) {
+	var schedule domain.ScheduledPrice
+	if err := db.First(&schedule, "schedule_id = ?", scheduleID).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// setItemPrice saves the new price of an item, records it in the history and updates its product.
+func setItemPrice(tx *gorm.DB, item *domain.CatalogItem, price float64, expectedVersion uint64, reason domain.PriceChangeReason, scheduleID string) error {
+	changed := item.Price != price
+	item.Price = price
+	if err := saveItem(tx, item, expectedVersion); err != nil {
+		return err
+	}
+	if changed {
+		if err := recordPrice(tx, item, reason, scheduleID); err != nil {
+			return err
+		}
+	}
+	return syncProductsOf(tx, []string{item.ItemID})
+}
+
+// recordPrice adds the current price of an item to its history
+func recordPrice(tx *gorm.DB, item *domain.CatalogItem, reason domain.PriceChangeReason, scheduleID string) error {
+	return tx.Create(&domain.PriceHistory{
+		ItemID:     item.ItemID,
+		Price:      item.Price,
+		Reason:     reason,
+		ScheduleID: scheduleID,
+		ChangedAt:  time.Now(),
+	}).Error
+}
+
+// removePrices removes the price history and the scheduled prices of the items removed from the catalog
+func removePrices(tx *gorm.DB, items []*domain.CatalogItem) error {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+
+	if err := tx.Where("item_id IN ?", itemIDs).Delete(&domain.PriceHistory{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("item_id IN ?", itemIDs).Delete(&domain.ScheduledPrice{}).Error
+}
+
+// applySchedule starts or ends a scheduled price due at the given time, it reports whether the price of the item changed.
+func applySchedule(tx *gorm.DB, schedule *domain.ScheduledPrice, now time.Time) (bool, error) {
+	switch {
+	case schedule.Status == domain.ScheduleActive && !schedule.EndsAt.After(now):
+		return endSale(tx, schedule, domain.ScheduleDone)
+	case schedule.Status == domain.SchedulePending && !schedule.StartsAt.After(now):
+		return startSchedule(tx, schedule, now)
+	}
+	return false, nil
+}
+
+// startSchedule applies a scheduled price, saving the price of the item to restore at the end of a sale.
+func startSchedule(tx *gorm.DB, schedule *domain.ScheduledPrice, now time.Time) (bool, error) {
+
+	// A sale already over when it is reached, e.g. while the service was down, leaves the price as it is
+	if schedule.IsSale() && !schedule.EndsAt.After(now) {
+		return false, tx.Model(schedule).Update("status", domain.ScheduleDone).Error
+	}
+
+	item, err := retrieveCatalogItem(tx, schedule.ItemID)
+	if err != nil {
+		return false, err
+	}
+
+	// The item has become a product with variants in the meantime, its price is the lowest of its variants
+	found, err := hasVariants(tx, item.ItemID)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		return false, tx.Model(schedule).Update("status", domain.ScheduleCanceled).Error
+	}
+
+	schedule.PreviousPrice = item.Price
+	schedule.Status = domain.ScheduleDone
+	if schedule.IsSale() {
+		schedule.Status = domain.ScheduleActive
+	}
+	if err := setItemPrice(tx, item, schedule.Price, 0, domain.PriceScheduled, schedule.ScheduleID); err != nil {
+		return false, err
+	}
+	return true, tx.Select("previous_price", "status").Updates(schedule).Error
+}
+
+// endSale closes a sale with the given status and restores the price the item had before it.
+// A price changed by someone else during the sale is left as it is.
+func endSale(tx *gorm.DB, schedule *domain.ScheduledPrice, status domain.ScheduledPriceStatus) (bool, error) {
+	restore := false
+	item, err := retrieveCatalogItem(tx, schedule.ItemID)
+	if err == nil && item.Price == schedule.Price {
+		found, err := hasVariants(tx, item.ItemID)
+		if err != nil {
+			return false, err
+		}
+		restore = !found
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	if restore {
+		if err := setItemPrice(tx, item, schedule.PreviousPrice, 0, domain.PriceRestored, schedule.ScheduleID); err != nil {
+			return false, err
+		}
+	}
+	return restore, tx.Model(schedule).Update("status", status).Error
+}
+
+// dueAt is the time a scheduled price has to be started or, once active, ended
+func dueAt(schedule *domain.ScheduledPrice) time.Time {
+	if schedule.Status == domain.ScheduleActive {
+		return schedule.EndsAt
+	}
+	return schedule.StartsAt
+}
+
+// periodEnd is the end of the period a scheduled price applies to, a permanent change takes the instant it starts
+func periodEnd(schedule *domain.ScheduledPrice) time.Time {
+	if schedule.IsSale() {
+		return schedule.EndsAt
+	}
+	return schedule.StartsAt.Add(time.Nanosecond)
+}
+
+// PRIVATE FUNCTIONS TO VALIDATE PRICE INPUTS
+
+func checkSchedulePeriodValidity(startsAt, endsAt, now time.Time) error {
+	if !startsAt.After(now) {
+		return errors.New("Scheduled price must start in the future")
+	}
+	if !endsAt.IsZero() && !endsAt.After(startsAt) {
+		return errors.New("End of the sale must be after its start")
+	}
+	return nil
+}
+
+// checkScheduleOverlap checks that no other price of the item is scheduled in the period of the schedule
+func checkScheduleOverlap(tx *gorm.DB, schedule *domain.ScheduledPrice) error {
+	var scheduled []*domain.ScheduledPrice
+	if err := tx.Where("item_id = ? AND status IN ?", schedule.ItemID, []domain.ScheduledPriceStatus{domain.SchedulePending, domain.ScheduleActive}).
+		Find(&scheduled).Error; err != nil {
+		return err
+	}
+
+	for _, other := range scheduled {
+		if schedule.StartsAt.Before(periodEnd(other)) && other.StartsAt.Before(periodEnd(schedule)) {
+			return ErrScheduleOverlap
+		}
+	}
+	return nil
+}
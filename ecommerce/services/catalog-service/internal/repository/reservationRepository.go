@@ -0,0 +1,423 @@
+package repository
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/allocation"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+const (
+	// DefaultReservationTTL is used when the caller doesn't ask for a ttl
+	DefaultReservationTTL = 10 * time.Minute
+
+	// MaxReservationTTL bounds how long stock can be held without being bought
+	MaxReservationTTL = time.Hour
+)
+
+var (
+	// ErrInsufficientStock is returned when an item has not enough quantity available
+	ErrInsufficientStock = errors.New("Not enough quantity available")
+
+	// ErrReservationClosed is returned when a reservation has already been released or has expired
+	ErrReservationClosed = errors.New("Reservation has been released or has expired")
+)
+
+// ReserveStock takes the quantity of several items from the stock in a single transaction, on behalf of actor.
+// The quantity of each item is taken from the warehouses chosen by the strategy, the fewest splits if nil.
+// Each decrement is conditional on the quantity in stock, so concurrent reservations never oversell.
+// The quantities are recorded in the ledger as sales of the reservation, one for each warehouse.
+// It returns the ID of the reservation, its expiration and the quantities taken from each warehouse.
+func (r *CatalogServiceRepository) ReserveStock(items []*pb.StockItem, ttl time.Duration, strategy allocation.Strategy, actor string) (string, time.Time, []*pb.StockAllocation, error) {
+
+	// Check items validity, the same item can appear only once
+	quantities, err := checkStockItemsValidity(items)
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+
+	// Check ttl validity
+	if ttl == 0 {
+		ttl = DefaultReservationTTL
+	}
+	if ttl < 0 || ttl > MaxReservationTTL {
+		return "", time.Time{}, nil, fmt.Errorf("Reservation ttl must be between 0 and %v", MaxReservationTTL)
+	}
+
+	if strategy == nil {
+		strategy = allocation.FewestSplits()
+	}
+
+	reservation := &domain.Reservation{
+		ReservationID: ulid.Make().String(),
+		Status:        domain.Reserved,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+	lines := make([]allocation.Line, len(items))
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = allocation.Line{ItemID: item.ItemId, Quantity: quantities[item.ItemId]}
+		itemIDs[i] = item.ItemId
+	}
+
+	// Products with variants are not sold themselves, their stock is the one of the variants
+	var allocations []*pb.StockAllocation
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		stock, err := itemStock(tx, itemIDs)
+		if err != nil {
+			return err
+		}
+
+		// Not enough stock in all the warehouses -> the item doesn't exist, has variants or has not enough quantity
+		for _, line := range lines {
+			var inStock uint32
+			for _, warehouse := range stock[line.ItemID] {
+				inStock += warehouse.Quantity
+			}
+			if inStock >= line.Quantity {
+				continue
+			}
+			if _, err := retrieveCatalogItem(tx, line.ItemID); err != nil {
+				return err
+			}
+			if err := checkNotProductWithVariants(line.ItemID, tx); err != nil {
+				return fmt.Errorf("%w: %s", err, line.ItemID)
+			}
+			return fmt.Errorf("%w for item %s", ErrInsufficientStock, line.ItemID)
+		}
+
+		for _, taken := range strategy.Allocate(lines, stock) {
+			if err := takeWarehouseStock(tx, taken.WarehouseID, taken.ItemID, taken.Quantity); err != nil {
+				return err
+			}
+			result := tx.Model(&domain.CatalogItem{}).
+				Where("item_id = ? AND quantity_available >= ?", taken.ItemID, taken.Quantity).
+				Updates(map[string]any{"quantity_available": gorm.Expr("quantity_available - ?", taken.Quantity), "version": nextVersion})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w for item %s", ErrInsufficientStock, taken.ItemID)
+			}
+
+			if err := recordMovement(tx, &domain.StockMovement{
+				ItemID:        taken.ItemID,
+				WarehouseID:   taken.WarehouseID,
+				Delta:         -int64(taken.Quantity),
+				Reason:        domain.MovementSale,
+				ReservationID: reservation.ReservationID,
+				Actor:         actor,
+			}); err != nil {
+				return err
+			}
+			reservation.Items = append(reservation.Items, domain.ReservationItem{
+				ItemID:      taken.ItemID,
+				WarehouseID: taken.WarehouseID,
+				Quantity:    taken.Quantity,
+			})
+			allocations = append(allocations, &pb.StockAllocation{
+				ItemId:      taken.ItemID,
+				WarehouseId: taken.WarehouseID,
+				Quantity:    taken.Quantity,
+			})
+		}
+		if err := syncProductsOf(tx, itemIDs); err != nil {
+			return err
+		}
+		return tx.Create(reservation).Error
+	})
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, itemIDs...)
+	return reservation.ReservationID, reservation.ExpiresAt, allocations, nil
+}
+
+// CommitReservation makes a reservation definitive, committing it twice has no effect.
+// The order, if given, is recorded in the movements of the reservation.
+// A reservation expired is closed: its stock is given back, if the sweeper has not done it yet.
+func (r *CatalogServiceRepository) CommitReservation(reservationID, orderID string) error {
+
+	// Check ReservationID validity
+	if err := checkReservationIDValidity(reservationID); err != nil {
+		return err
+	}
+
+	var restored []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		reservation, err := retrieveReservation(tx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		switch reservation.Status {
+		case domain.Committed:
+			return nil
+		case domain.Released, domain.Expired:
+			return ErrReservationClosed
+		}
+
+		// Expired but not yet collected by the sweeper, the stock given back is committed before the commit is refused
+		if time.Now().After(reservation.ExpiresAt) {
+			restored = reservation.ItemIDs()
+			return restoreStock(tx, reservation, domain.Expired, SystemActor)
+		}
+
+		if orderID != "" {
+			if err := tx.Model(&domain.StockMovement{}).Where("reservation_id = ?", reservationID).
+				Update("order_id", orderID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(reservation).Update("status", domain.Committed).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if restored != nil {
+		r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, restored...)
+		return ErrReservationClosed
+	}
+	return nil
+}
+
+// ReleaseReservation gives back the stock of a reservation on behalf of actor, releasing it twice has no effect.
+func (r *CatalogServiceRepository) ReleaseReservation(reservationID, actor string) error {
+
+	// Check ReservationID validity
+	if err := checkReservationIDValidity(reservationID); err != nil {
+		return err
+	}
+
+	var restored []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		reservation, err := retrieveReservation(tx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		switch reservation.Status {
+		case domain.Released, domain.Expired:
+			return nil
+		case domain.Committed:
+			return errors.New("Reservation has already been committed")
+		}
+
+		restored = reservation.ItemIDs()
+		return restoreStock(tx, reservation, domain.Released, actor)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, restored...)
+	return nil
+}
+
+// ExpireReservations gives back the stock of the reservations not committed before their expiration.
+// It returns the number of reservations expired.
+func (r *CatalogServiceRepository) ExpireReservations(now time.Time) (int, error) {
+
+	var reservations []*domain.Reservation
+	if err := r.db.Where("status = ? AND expires_at < ?", domain.Reserved, now).Find(&reservations).Error; err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, candidate := range reservations {
+		var restored []string
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+
+			// Read again inside the transaction, it may have been committed in the meantime
+			reservation, err := retrieveReservation(tx, candidate.ReservationID)
+			if err != nil {
+				return err
+			}
+			if reservation.Status != domain.Reserved {
+				return nil
+			}
+
+			expired++
+			restored = reservation.ItemIDs()
+			return restoreStock(tx, reservation, domain.Expired, SystemActor)
+		})
+		if err != nil {
+			return expired, err
+		}
+		r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, restored...)
+	}
+	return expired, nil
+}
+
+// RestockItems gives back the quantity of several items in a single transaction, on behalf of actor.
+// Retrying with the same restock ID has no effect, items removed from the catalog are skipped.
+// The quantities go back to the warehouses they were sold from by the order, if given, the rest to the default one.
+// They are recorded in the ledger as cancellations of the order.
+func (r *CatalogServiceRepository) RestockItems(restockID, orderID string, items []*pb.StockItem, actor string) error {
+
+	// Check RestockID validity
+	if restockID == "" {
+		return errors.New("Restock ID cannot be empty")
+	}
+
+	// Check items validity, the same item can appear only once
+	quantities, err := checkStockItemsValidity(items)
+	if err != nil {
+		return err
+	}
+
+	var restocked []string
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+
+		// Already applied
+		var count int64
+		if err := tx.Model(&domain.Restock{}).Where("restock_id = ?", restockID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		itemIDs := make([]string, 0, len(quantities))
+		for itemID, quantity := range quantities {
+			returned, err := returnedStock(tx, orderID, itemID, quantity)
+			if err != nil {
+				return err
+			}
+
+			restored := false
+			for _, back := range returned {
+				restored, err = giveBackStock(tx, back, &domain.StockMovement{
+					Reason:  domain.MovementCancellation,
+					OrderID: orderID,
+					Actor:   actor,
+				})
+				if err != nil {
+					return err
+				}
+				if !restored {
+					break
+				}
+			}
+			if restored {
+				itemIDs = append(itemIDs, itemID)
+			}
+		}
+		if err := syncProductsOf(tx, itemIDs); err != nil {
+			return err
+		}
+		restocked = itemIDs
+		return tx.Create(&domain.Restock{RestockID: restockID}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, restocked...)
+	return nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE RESERVATIONS
+
+// retrieveReservation retrieves a reservation with its items.
+func retrieveReservation(db *gorm.DB, reservationID string) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	if err := db.Preload("Items").First(&reservation, "reservation_id = ?", reservationID).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// retrieveCatalogItem retrieves a catalog item using the given connection or transaction.
+func retrieveCatalogItem(db *gorm.DB, itemID string) (*domain.CatalogItem, error) {
+	var item domain.CatalogItem
+	if err := db.First(&item, "item_id = ?", itemID).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// restoreStock gives back the quantities of a reservation on behalf of actor and closes it with the given status.
+// Items removed from the catalog in the meantime are skipped.
+func restoreStock(tx *gorm.DB, reservation *domain.Reservation, status domain.ReservationStatus, actor string) error {
+	reason := domain.MovementRelease
+	if status == domain.Expired {
+		reason = domain.MovementExpiration
+	}
+
+	for _, item := range reservation.Items {
+		back := allocation.Allocation{ItemID: item.ItemID, WarehouseID: cmp.Or(item.WarehouseID, domain.DefaultWarehouseID), Quantity: item.Quantity}
+		if _, err := giveBackStock(tx, back, &domain.StockMovement{
+			Reason:        reason,
+			ReservationID: reservation.ReservationID,
+			Actor:         actor,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := syncProductsOf(tx, reservation.ItemIDs()); err != nil {
+		return err
+	}
+	return tx.Model(reservation).Update("status", status).Error
+}
+
+// giveBackStock adds a quantity of an item back to the stock of a warehouse and records it with the given movement.
+// It returns false if the item has been removed from the catalog in the meantime.
+func giveBackStock(tx *gorm.DB, back allocation.Allocation, movement *domain.StockMovement) (bool, error) {
+	result := tx.Model(&domain.CatalogItem{}).Where("item_id = ?", back.ItemID).
+		Updates(map[string]any{"quantity_available": gorm.Expr("quantity_available + ?", back.Quantity), "version": nextVersion})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	if err := addWarehouseStock(tx, back.WarehouseID, back.ItemID, back.Quantity); err != nil {
+		return false, err
+	}
+
+	movement.ItemID = back.ItemID
+	movement.WarehouseID = back.WarehouseID
+	movement.Delta = int64(back.Quantity)
+	return true, recordMovement(tx, movement)
+}
+
+// PRIVATE FUNCTIONS TO VALIDATE RESERVATION INPUTS
+
+func checkReservationIDValidity(reservationID string) error {
+	if reservationID == "" {
+		return errors.New("Reservation ID cannot be empty")
+	}
+	return nil
+}
+
+// checkStockItemsValidity checks the items of a reservation and returns the quantity of each item
+func checkStockItemsValidity(items []*pb.StockItem) (map[string]uint32, error) {
+	if len(items) == 0 {
+		return nil, errors.New("Reservation must contain at least one item")
+	}
+
+	quantities := make(map[string]uint32, len(items))
+	for _, item := range items {
+		if err := checkItemIDValidity(item.GetItemId()); err != nil {
+			return nil, err
+		}
+		if item.GetQuantity() == 0 {
+			return nil, errors.New("Reserved quantity must be greater than zero")
+		}
+		if _, found := quantities[item.GetItemId()]; found {
+			return nil, fmt.Errorf("Item %s appears more than once", item.GetItemId())
+		}
+		quantities[item.GetItemId()] = item.GetQuantity()
+	}
+	return quantities, nil
+}
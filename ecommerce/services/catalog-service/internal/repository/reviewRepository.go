@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// maxReviewLength bounds the text of a review, in characters
+const maxReviewLength = 2000
+
+// maxReviewsListed bounds the number of reviews returned by a listing
+const maxReviewsListed = 100
+
+// ErrInvalidReview is returned when the rating or the text of a review are not valid.
+var ErrInvalidReview = errors.New("Invalid review")
+
+// ErrNotPurchased is returned when a customer reviews an item they never bought.
+var ErrNotPurchased = errors.New("Only the customers who bought the item can review it")
+
+// SubmitReview writes the review of an item by username and returns it, waiting for moderation.
+// purchased are the IDs of the items username bought: the item, or one of its variants for a product, must be among them.
+// The review of a variant is the one of its product; a new review of the same item replaces the previous one.
+func (r *CatalogServiceRepository) SubmitReview(itemID, username string, rating uint32, text string, purchased []string) (*pb.Review, error) {
+
+	// Check the review validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+	if username == "" {
+		return nil, errors.New("Username cannot be empty")
+	}
+	if err := checkReviewValidity(rating, text); err != nil {
+		return nil, err
+	}
+
+	// The review of a variant is the one of its product
+	item, err := r.RetrieveCatalogItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.ProductID != "" {
+		itemID = item.ProductID
+	}
+
+	// The customer bought the item, or one of its variants
+	var bought []string
+	if err := r.db.Model(&domain.CatalogItem{}).Where("product_id = ?", itemID).Pluck("item_id", &bought).Error; err != nil {
+		return nil, err
+	}
+	bought = append(bought, itemID)
+	if !slices.ContainsFunc(bought, func(id string) bool { return slices.Contains(purchased, id) }) {
+		return nil, ErrNotPurchased
+	}
+
+	review := &domain.Review{
+		ReviewID:  ulid.Make().String(),
+		ItemID:    itemID,
+		Username:  username,
+		Rating:    rating,
+		Text:      strings.TrimSpace(text),
+		Status:    domain.ReviewPending,
+		CreatedAt: time.Now(),
+	}
+	ratingChanged := false
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var previous domain.Review
+		err := tx.Where("item_id = ? AND username = ?", itemID, username).First(&previous).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(review).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// The review rewritten has to be moderated again, it does not count in the rating meanwhile
+		review.ReviewID = previous.ReviewID
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		if previous.Status == domain.ReviewApproved {
+			ratingChanged = true
+			return updateRating(tx, itemID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ratingChanged {
+		r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	}
+	return domain.DomainReviewToProtoReview(review), nil
+}
+
+// ModerateReview approves or rejects a review, the rating of its item counts the approved ones.
+func (r *CatalogServiceRepository) ModerateReview(reviewID string, approve bool) error {
+
+	var review domain.Review
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("review_id = ?", reviewID).First(&review).Error; err != nil {
+			return err
+		}
+
+		status := domain.ReviewRejected
+		if approve {
+			status = domain.ReviewApproved
+		}
+		if err := tx.Model(&review).Update("status", status).Error; err != nil {
+			return err
+		}
+		return updateRating(tx, review.ItemID)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, review.ItemID)
+	return nil
+}
+
+// ListReviews retrieves the approved reviews of an item, the most recent first.
+func (r *CatalogServiceRepository) ListReviews(itemID string) ([]*pb.Review, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+
+	var reviews []*domain.Review
+	if err := r.db.Where("item_id = ? AND status = ?", itemID, domain.ReviewApproved).
+		Order("created_at DESC, review_id DESC").
+		Limit(maxReviewsListed).
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return toProtoReviews(reviews), nil
+}
+
+// ListPendingReviews retrieves the reviews waiting for moderation, the oldest first.
+func (r *CatalogServiceRepository) ListPendingReviews() ([]*pb.Review, error) {
+
+	var reviews []*domain.Review
+	if err := r.db.Where("status = ?", domain.ReviewPending).
+		Order("created_at, review_id").
+		Limit(maxReviewsListed).
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return toProtoReviews(reviews), nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE THE REVIEWS
+
+// updateRating sets the rating of an item from its approved reviews
+func updateRating(tx *gorm.DB, itemID string) error {
+	var rating struct {
+		Average float64
+		Count   uint32
+	}
+	if err := tx.Model(&domain.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("item_id = ? AND status = ?", itemID, domain.ReviewApproved).
+		Scan(&rating).Error; err != nil {
+		return err
+	}
+
+	// The version does not change, the rating is not part of the details of the item
+	return tx.Model(&domain.CatalogItem{}).Where("item_id = ?", itemID).
+		Updates(map[string]any{"rating_average": rating.Average, "rating_count": rating.Count}).Error
+}
+
+// removeReviews removes the reviews of the items removed from the catalog
+func removeReviews(tx *gorm.DB, items []*domain.CatalogItem) error {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	return tx.Where("item_id IN ?", itemIDs).Delete(&domain.Review{}).Error
+}
+
+func toProtoReviews(reviews []*domain.Review) []*pb.Review {
+	protoReviews := make([]*pb.Review, len(reviews))
+	for i, review := range reviews {
+		protoReviews[i] = domain.DomainReviewToProtoReview(review)
+	}
+	return protoReviews
+}
+
+func checkReviewValidity(rating uint32, text string) error {
+	if rating < domain.MinRating || rating > domain.MaxRating {
+		return fmt.Errorf("%w: rating must be from %d to %d stars", ErrInvalidReview, domain.MinRating, domain.MaxRating)
+	}
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("%w: text cannot be empty", ErrInvalidReview)
+	}
+	if utf8.RuneCountInString(text) > maxReviewLength {
+		return fmt.Errorf("%w: text cannot be longer than %d characters", ErrInvalidReview, maxReviewLength)
+	}
+	return nil
+}
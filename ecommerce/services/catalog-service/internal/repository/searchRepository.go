@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+const (
+	// DefaultSearchLimit is used when the caller doesn't ask for a number of results
+	DefaultSearchLimit = 20
+
+	// MaxSearchLimit bounds the number of results of a search
+	MaxSearchLimit = 100
+)
+
+// BuildSearchIndex indexes every product of the catalog, the items are then indexed as they change.
+func (r *CatalogServiceRepository) BuildSearchIndex() error {
+	var items []*domain.CatalogItem
+	if err := r.db.Where("product_id = ''").Find(&items).Error; err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		r.indexItem(item)
+	}
+	return nil
+}
+
+// SearchCatalog returns the catalog items matching the words of the query, the most relevant first.
+func (r *CatalogServiceRepository) SearchCatalog(query string, limit int) ([]*pb.SearchHit, error) {
+
+	// Check query and limit validity
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("Invalid query: cannot be empty")
+	}
+	if limit < 0 {
+		return nil, errors.New("Invalid limit: cannot be negative")
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	hits := r.index.Search(query, limit)
+	if len(hits) == 0 {
+		return []*pb.SearchHit{}, nil
+	}
+
+	// Stock and price of the items are read from the database, they are not part of the index
+	itemIDs := make([]string, len(hits))
+	for i, hit := range hits {
+		itemIDs[i] = hit.ItemID
+	}
+
+	var items []*domain.CatalogItem
+	if err := r.db.Where("item_id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	protoItems, err := r.toProtoItems(items)
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[string]*pb.CatalogItem, len(protoItems))
+	for _, item := range protoItems {
+		itemsByID[item.ItemId] = item
+	}
+
+	results := make([]*pb.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+
+		// The item may have been removed since the search
+		item, ok := itemsByID[hit.ItemID]
+		if !ok {
+			continue
+		}
+
+		highlights := make([]*pb.Highlight, len(hit.Highlights))
+		for i, h := range hit.Highlights {
+			highlights[i] = &pb.Highlight{Start: int32(h[0]), End: int32(h[1])}
+		}
+
+		results = append(results, &pb.SearchHit{
+			Item:       item,
+			Score:      hit.Score,
+			Snippet:    hit.Snippet,
+			Highlights: highlights,
+		})
+	}
+	return results, nil
+}
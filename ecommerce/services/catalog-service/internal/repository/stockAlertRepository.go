@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// SetReorderThreshold sets the quantity available below which a low-stock alert is raised for an item, 0 for no alerts.
+// The alerts are raised and resolved by CheckLowStock.
+func (r *CatalogServiceRepository) SetReorderThreshold(itemID string, threshold uint32) error {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return err
+	}
+
+	// Check the item exists, the stock of a product with variants is the one of its variants
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return err
+	}
+	if err := checkNotProductWithVariants(itemID, r.db); err != nil {
+		return err
+	}
+
+	// The item is moved to its next version, so that a concurrent update of the item does not undo the threshold
+	if err := r.db.Model(&domain.CatalogItem{}).Where("item_id = ?", itemID).
+		Updates(map[string]any{"reorder_threshold": threshold, "version": nextVersion}).Error; err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, itemID)
+	return nil
+}
+
+// CheckLowStock raises an alert for every item whose quantity available fell below its threshold at the given time,
+// and resolves the alerts of the items stocked up to their threshold again, without a threshold or removed.
+// The alerts raised are published to the watchers of the catalog, it returns their number.
+func (r *CatalogServiceRepository) CheckLowStock(now time.Time) (int, error) {
+
+	var raised []*domain.StockAlert
+	names := make(map[string]string)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+
+		// The open alerts whose item is not low on stock anymore
+		if err := tx.Model(&domain.StockAlert{}).
+			Where("resolved_at IS NULL").
+			Where("NOT EXISTS (SELECT 1 FROM catalog_items i WHERE i.item_id = stock_alerts.item_id AND i.quantity_available < i.reorder_threshold)").
+			Update("resolved_at", now).Error; err != nil {
+			return err
+		}
+
+		var items []*domain.CatalogItem
+		if err := tx.Where("quantity_available < reorder_threshold").
+			Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = catalog_items.item_id)").
+			Where("NOT EXISTS (SELECT 1 FROM stock_alerts a WHERE a.item_id = catalog_items.item_id AND a.resolved_at IS NULL)").
+			Order("item_id").
+			Find(&items).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			alert := &domain.StockAlert{
+				ItemID:            item.ItemID,
+				QuantityAvailable: item.QuantityAvailable,
+				Threshold:         item.ReorderThreshold,
+				RaisedAt:          now,
+			}
+			if err := tx.Create(alert).Error; err != nil {
+				return err
+			}
+			raised = append(raised, alert)
+			names[item.ItemID] = item.Name
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if r.events != nil {
+		for _, alert := range raised {
+			r.events.Publish(&pb.CatalogEvent{
+				Type:   pb.CatalogEventType_LOW_STOCK,
+				ItemId: alert.ItemID,
+				Alert:  domain.DomainStockAlertToProtoStockAlert(alert, names[alert.ItemID]),
+			})
+		}
+	}
+	return len(raised), nil
+}
+
+// ListStockAlerts retrieves the open alerts, or all of them with includeResolved, the most recent first.
+func (r *CatalogServiceRepository) ListStockAlerts(includeResolved bool) ([]*pb.StockAlert, error) {
+
+	query := r.db.Order("raised_at DESC, id DESC")
+	if !includeResolved {
+		query = query.Where("resolved_at IS NULL")
+	}
+
+	var alerts []*domain.StockAlert
+	if err := query.Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	names, err := itemNames(r.db, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	protoAlerts := make([]*pb.StockAlert, len(alerts))
+	for i, alert := range alerts {
+		protoAlerts[i] = domain.DomainStockAlertToProtoStockAlert(alert, names[alert.ItemID])
+	}
+	return protoAlerts, nil
+}
+
+// ReorderSuggestions suggests the quantity to reorder of the items, given the units of each item sold in the last windowDays.
+// An item is reordered to last coverDays at the pace of its sales, on top of its threshold;
+// the items with enough stock are left out, the others are sorted by the days their stock lasts.
+func (r *CatalogServiceRepository) ReorderSuggestions(sales map[string]uint32, windowDays, coverDays uint32) ([]*pb.ReorderSuggestion, error) {
+
+	// Check the periods validity
+	if windowDays == 0 || coverDays == 0 {
+		return nil, errors.New("Sales window and days of cover must be greater than zero")
+	}
+
+	// The items sold or with a threshold, the stock of a product with variants is the one of its variants
+	soldIDs := make([]string, 0, len(sales))
+	for itemID := range sales {
+		soldIDs = append(soldIDs, itemID)
+	}
+	var items []*domain.CatalogItem
+	if err := r.db.Where("item_id IN ? OR reorder_threshold > 0", soldIDs).
+		Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = catalog_items.item_id)").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	var suggestions []*pb.ReorderSuggestion
+	for _, item := range items {
+		dailySales := float64(sales[item.ItemID]) / float64(windowDays)
+		target := uint64(item.ReorderThreshold) + uint64(math.Ceil(dailySales*float64(coverDays)))
+		if target <= uint64(item.QuantityAvailable) {
+			continue
+		}
+
+		suggestion := &pb.ReorderSuggestion{
+			ItemId:            item.ItemID,
+			Name:              item.Name,
+			QuantityAvailable: item.QuantityAvailable,
+			Threshold:         item.ReorderThreshold,
+			UnitsSold:         sales[item.ItemID],
+			DailySales:        dailySales,
+			SuggestedQuantity: uint32(min(target-uint64(item.QuantityAvailable), math.MaxUint32)),
+		}
+		if dailySales > 0 {
+			suggestion.DaysOfStock = float64(item.QuantityAvailable) / dailySales
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// The items running out first come first, the ones without sales last
+	slices.SortFunc(suggestions, func(a, b *pb.ReorderSuggestion) int {
+		if (a.DailySales > 0) != (b.DailySales > 0) {
+			if a.DailySales > 0 {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(cmp.Compare(a.DaysOfStock, b.DaysOfStock), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ItemId, b.ItemId))
+	})
+	return suggestions, nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE THE ALERTS
+
+// itemNames returns the names of the items of the alerts, by item ID, the removed items have none
+func itemNames(db *gorm.DB, alerts []*domain.StockAlert) (map[string]string, error) {
+	itemIDs := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		itemIDs = append(itemIDs, alert.ItemID)
+	}
+
+	var items []*domain.CatalogItem
+	if err := db.Select("item_id", "name").Where("item_id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(items))
+	for _, item := range items {
+		names[item.ItemID] = item.Name
+	}
+	return names, nil
+}
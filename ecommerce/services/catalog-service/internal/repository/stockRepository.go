@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// SystemActor is the actor of the movements made by the catalog itself, e.g. the expired reservations
+const SystemActor = "system"
+
+// ledgerBalance is the quantity available of an item next to the sum of its movements
+type ledgerBalance struct {
+	ItemID            string
+	Name              string
+	QuantityAvailable uint32
+	LedgerQuantity    int64
+}
+
+// warehouseBalance is the stock of an item at a warehouse next to the sum of its movements there
+type warehouseBalance struct {
+	ItemID         string
+	WarehouseID    string
+	Quantity       uint32
+	LedgerQuantity int64
+}
+
+// ListStockMovements returns a page of the movements of an item, of an order or of the whole catalog if both are empty,
+// the most recent first, with the token of the next page.
+func (r *CatalogServiceRepository) ListStockMovements(itemID, orderID string, pageSize int, pageToken string) ([]*pb.StockMovement, string, error) {
+
+	// Check page size validity
+	if pageSize < 0 {
+		return nil, "", errors.New("Page size cannot be negative")
+	}
+	if pageSize == 0 {
+		pageSize = domain.DefaultPageSize
+	}
+	pageSize = min(pageSize, domain.MaxPageSize)
+
+	db := r.db.Order("id DESC").Limit(pageSize + 1)
+	if itemID != "" {
+		db = db.Where("item_id = ?", itemID)
+	}
+	if orderID != "" {
+		db = db.Where("order_id = ?", orderID)
+	}
+
+	// The token is the ID of the last movement of the previous page
+	if pageToken != "" {
+		lastID, err := strconv.ParseUint(pageToken, 10, 64)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		db = db.Where("id < ?", lastID)
+	}
+
+	var movements []*domain.StockMovement
+	if err := db.Find(&movements).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(movements) > pageSize {
+		movements = movements[:pageSize]
+		nextPageToken = strconv.FormatUint(movements[pageSize-1].ID, 10)
+	}
+
+	protoMovements := make([]*pb.StockMovement, len(movements))
+	for i, movement := range movements {
+		protoMovements[i] = domain.DomainStockMovementToProtoStockMovement(movement)
+	}
+	return protoMovements, nextPageToken, nil
+}
+
+// ReconcileStock compares the quantity available of the items, and their stock at each warehouse,
+// with the sum of their movements and returns where they differ, with the number of items checked.
+// With apply the stock of the items at those warehouses is set to the one of the ledger, unless it is negative,
+// and the quantity available of the items to the total of their warehouses.
+func (r *CatalogServiceRepository) ReconcileStock(apply bool) ([]*pb.StockDiscrepancy, int, error) {
+
+	var balances []ledgerBalance
+	var warehouseBalances []warehouseBalance
+	var fixed []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+
+		// Products with variants have no stock of their own
+		if err := tx.Table("catalog_items AS i").
+			Select("i.item_id, i.name, i.quantity_available, COALESCE(SUM(m.delta), 0) AS ledger_quantity").
+			Joins("LEFT JOIN stock_movements m ON m.item_id = i.item_id").
+			Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = i.item_id)").
+			Group("i.item_id, i.name, i.quantity_available").
+			Order("i.item_id").
+			Scan(&balances).Error; err != nil {
+			return err
+		}
+
+		var err error
+		warehouseBalances, err = balancesByWarehouse(tx)
+		if err != nil || !apply {
+			return err
+		}
+
+		for _, balance := range warehouseBalances {
+			if int64(balance.Quantity) == balance.LedgerQuantity || balance.LedgerQuantity < 0 {
+				continue
+			}
+			if err := setWarehouseStock(tx, balance.WarehouseID, balance.ItemID, uint32(balance.LedgerQuantity)); err != nil {
+				return err
+			}
+			fixed = append(fixed, balance.ItemID)
+		}
+		for _, balance := range balances {
+			if int64(balance.QuantityAvailable) != balance.LedgerQuantity {
+				fixed = append(fixed, balance.ItemID)
+			}
+		}
+		slices.Sort(fixed)
+		fixed = slices.Compact(fixed)
+
+		if err := syncQuantityAvailable(tx, fixed); err != nil {
+			return err
+		}
+		return syncProductsOf(tx, fixed)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, fixed...)
+
+	names := make(map[string]string, len(balances))
+	var discrepancies []*pb.StockDiscrepancy
+	for _, balance := range balances {
+		names[balance.ItemID] = balance.Name
+		if int64(balance.QuantityAvailable) != balance.LedgerQuantity {
+			discrepancies = append(discrepancies, &pb.StockDiscrepancy{
+				ItemId:            balance.ItemID,
+				Name:              balance.Name,
+				QuantityAvailable: balance.QuantityAvailable,
+				LedgerQuantity:    balance.LedgerQuantity,
+			})
+		}
+	}
+	for _, balance := range warehouseBalances {
+		if int64(balance.Quantity) != balance.LedgerQuantity {
+			discrepancies = append(discrepancies, &pb.StockDiscrepancy{
+				ItemId:            balance.ItemID,
+				Name:              names[balance.ItemID],
+				QuantityAvailable: balance.Quantity,
+				LedgerQuantity:    balance.LedgerQuantity,
+				WarehouseId:       balance.WarehouseID,
+			})
+		}
+	}
+	return discrepancies, len(balances), nil
+}
+
+// OpenStockLedger records the quantity of the items in stock before the ledger as their initial movement,
+// so that the ledger accounts for all the quantity available.
+// It returns the number of items recorded, running it again has no effect.
+func (r *CatalogServiceRepository) OpenStockLedger() (int, error) {
+	var items []*domain.CatalogItem
+	if err := r.db.Where("quantity_available > 0").
+		Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = catalog_items.item_id)").
+		Where("NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.item_id = catalog_items.item_id)").
+		Find(&items).Error; err != nil {
+		return 0, err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			if err := recordMovement(tx, &domain.StockMovement{
+				ItemID: item.ItemID,
+				Delta:  int64(item.QuantityAvailable),
+				Reason: domain.MovementInitial,
+				Actor:  SystemActor,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// PRIVATE FUNCTIONS TO RECORD THE MOVEMENTS OF THE STOCK
+
+// balancesByWarehouse returns the stock of the items at each warehouse next to the sum of their movements there,
+// for every warehouse having either of them. Products with variants have no stock of their own.
+func balancesByWarehouse(tx *gorm.DB) ([]warehouseBalance, error) {
+	var stock []domain.WarehouseStock
+	if err := tx.Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = warehouse_stocks.item_id)").
+		Find(&stock).Error; err != nil {
+		return nil, err
+	}
+	var ledger []warehouseBalance
+	if err := tx.Model(&domain.StockMovement{}).
+		Select("item_id, warehouse_id, SUM(delta) AS ledger_quantity").
+		Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = stock_movements.item_id)").
+		Group("item_id, warehouse_id").
+		Scan(&ledger).Error; err != nil {
+		return nil, err
+	}
+
+	type key struct{ itemID, warehouseID string }
+	balances := make(map[key]*warehouseBalance)
+	for _, s := range stock {
+		balances[key{s.ItemID, s.WarehouseID}] = &warehouseBalance{ItemID: s.ItemID, WarehouseID: s.WarehouseID, Quantity: s.Quantity}
+	}
+	for _, l := range ledger {
+		k := key{l.ItemID, l.WarehouseID}
+		if balances[k] == nil {
+			balances[k] = &warehouseBalance{ItemID: l.ItemID, WarehouseID: l.WarehouseID}
+		}
+		balances[k].LedgerQuantity = l.LedgerQuantity
+	}
+
+	sorted := make([]warehouseBalance, 0, len(balances))
+	for _, balance := range balances {
+		sorted = append(sorted, *balance)
+	}
+	slices.SortFunc(sorted, func(a, b warehouseBalance) int {
+		return cmp.Or(cmp.Compare(a.ItemID, b.ItemID), cmp.Compare(a.WarehouseID, b.WarehouseID))
+	})
+	return sorted, nil
+}
+
+// recordMovement adds a movement of the stock of an item to the ledger, with the quantity available after it.
+// Movements of no quantity are not recorded, the ones without a warehouse are of the default one.
+func recordMovement(tx *gorm.DB, movement *domain.StockMovement) error {
+	if movement.Delta == 0 {
+		return nil
+	}
+	if movement.WarehouseID == "" {
+		movement.WarehouseID = domain.DefaultWarehouseID
+	}
+
+	var item domain.CatalogItem
+	if err := tx.Select("quantity_available").First(&item, "item_id = ?", movement.ItemID).Error; err != nil {
+		return err
+	}
+	movement.QuantityAfter = item.QuantityAvailable
+	return tx.Create(movement).Error
+}
+
+// removeMovements removes the movements of the items removed from the catalog
+func removeMovements(tx *gorm.DB, items []*domain.CatalogItem) error {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	return tx.Where("item_id IN ?", itemIDs).Delete(&domain.StockMovement{}).Error
+}
+
+// PRIVATE FUNCTIONS TO VALIDATE STOCK INPUTS
+
+// checkAdjustmentReasonValidity checks that a quantity set by an admin is an adjustment or a restock
+func checkAdjustmentReasonValidity(reason domain.MovementReason) error {
+	if reason != domain.MovementAdjustment && reason != domain.MovementRestock {
+		return errors.New("Quantity can only be set as an adjustment or a restock")
+	}
+	return nil
+}
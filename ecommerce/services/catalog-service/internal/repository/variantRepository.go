@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// Limits on the attributes of the variants
+const (
+	maxAttributes      = 10
+	maxAttributeLength = 64
+)
+
+// attributeNamePattern matches lower case attribute names, e.g. edition or print_run
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ErrProductHasVariants is returned when selling or restocking a product instead of one of its variants.
+var ErrProductHasVariants = errors.New("Item has variants, one of them must be chosen")
+
+// PRIVATE FUNCTIONS FOR VARIANTS
+
+// retrieveProduct retrieves the product a variant is added to, variants cannot have variants themselves
+func retrieveProduct(db *gorm.DB, productID string) (*domain.CatalogItem, error) {
+	product, err := retrieveCatalogItem(db, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.ProductID != "" {
+		return nil, errors.New("A variant cannot have variants")
+	}
+	return product, nil
+}
+
+// hasVariants tells if an item is a product with variants
+func hasVariants(db *gorm.DB, itemID string) (bool, error) {
+	var count int64
+	if err := db.Model(&domain.CatalogItem{}).Where("product_id = ?", itemID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// variantName names a variant after its product and the values of its attributes, e.g. "Berserk (Hardcover, English)"
+func variantName(product *domain.CatalogItem, attributes map[string]string) string {
+	values := make([]string, 0, len(attributes))
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		values = append(values, attributes[name])
+	}
+	return product.Name + " (" + strings.Join(values, ", ") + ")"
+}
+
+// indexItem indexes an item for the full-text search, variants are found through their product
+func (r *CatalogServiceRepository) indexItem(item *domain.CatalogItem) {
+	if item.ProductID == "" {
+		r.index.Add(item.ItemID, item.Name, item.Description)
+	}
+}
+
+// syncProducts sets the price of the products to the lowest of their variants and their quantity to the total.
+// A product left without variants keeps its price and has no quantity until it is set again.
+func syncProducts(tx *gorm.DB, productIDs []string) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&domain.CatalogItem{}).Where("item_id IN ?", productIDs).Updates(map[string]any{
+		"price":              gorm.Expr("COALESCE((SELECT MIN(v.price) FROM catalog_items v WHERE v.product_id = catalog_items.item_id), price)"),
+		"quantity_available": gorm.Expr("COALESCE((SELECT SUM(v.quantity_available) FROM catalog_items v WHERE v.product_id = catalog_items.item_id), 0)"),
+		"version":            nextVersion,
+	}).Error
+}
+
+// syncProductsOf updates the products of the given items which are variants
+func syncProductsOf(tx *gorm.DB, itemIDs []string) error {
+	var productIDs []string
+	if err := tx.Model(&domain.CatalogItem{}).Distinct("product_id").
+		Where("item_id IN ? AND product_id <> ''", itemIDs).Pluck("product_id", &productIDs).Error; err != nil {
+		return err
+	}
+	return syncProducts(tx, productIDs)
+}
+
+// checkNotProductWithVariants checks that the stock and price of an item are its own
+func checkNotProductWithVariants(itemID string, db *gorm.DB) error {
+	found, err := hasVariants(db, itemID)
+	if err != nil {
+		return err
+	}
+	if found {
+		return ErrProductHasVariants
+	}
+	return nil
+}
+
+func checkAttributesValidity(attributes map[string]string) error {
+	if len(attributes) == 0 {
+		return errors.New("A variant must have at least one attribute")
+	}
+	if len(attributes) > maxAttributes {
+		return fmt.Errorf("Invalid attributes: at most %d per variant", maxAttributes)
+	}
+
+	for name, value := range attributes {
+		if !attributeNamePattern.MatchString(name) {
+			return fmt.Errorf("Invalid attribute name %q: only lower case letters, digits and underscores", name)
+		}
+		if strings.TrimSpace(value) == "" || len(value) > maxAttributeLength {
+			return fmt.Errorf("Invalid value of attribute %s: not empty and at most %d characters", name, maxAttributeLength)
+		}
+	}
+	return nil
+}
+
+// checkVariantUniqueness checks that no other variant than itemID of the product has the same attributes
+func checkVariantUniqueness(productID string, attributes map[string]string, itemID string, db *gorm.DB) error {
+	var variants []domain.CatalogItem
+	if err := db.Select("item_id", "attributes").Where("product_id = ? AND item_id <> ?", productID, itemID).Find(&variants).Error; err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		if maps.Equal(variant.Attributes, attributes) {
+			return errors.New("A variant with the same attributes already exists")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/allocation"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// defaultWarehouseName is the name of the default warehouse when it is created
+const defaultWarehouseName = "Main warehouse"
+
+// CreateWarehouse adds a warehouse with the given name and location and returns its ID.
+func (r *CatalogServiceRepository) CreateWarehouse(name string, latitude, longitude float64) (string, error) {
+
+	// Check Name validity and uniqueness
+	if err := checkNameValidity(name); err != nil {
+		return "", err
+	}
+	name = strings.TrimSpace(name)
+	if err := checkWarehouseNameUniqueness(name, r.db); err != nil {
+		return "", err
+	}
+
+	// Check Location validity
+	if err := checkLocationValidity(latitude, longitude); err != nil {
+		return "", err
+	}
+
+	warehouse := &domain.Warehouse{
+		WarehouseID: ulid.Make().String(),
+		Name:        name,
+		Latitude:    latitude,
+		Longitude:   longitude,
+	}
+	if err := r.db.Create(warehouse).Error; err != nil {
+		return "", err
+	}
+	return warehouse.WarehouseID, nil
+}
+
+// ListWarehouses returns all the warehouses, by name.
+func (r *CatalogServiceRepository) ListWarehouses() ([]*pb.Warehouse, error) {
+	var warehouses []*domain.Warehouse
+	if err := r.db.Order("name").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+
+	protoWarehouses := make([]*pb.Warehouse, 0, len(warehouses))
+	for _, warehouse := range warehouses {
+		protoWarehouse, err := domain.DomainWarehouseToProtoWarehouse(warehouse)
+		if err != nil {
+			return nil, err
+		}
+		protoWarehouses = append(protoWarehouses, protoWarehouse)
+	}
+	return protoWarehouses, nil
+}
+
+// GetItemStock returns the quantity of an item in the warehouses having it, by warehouse name.
+// The stock of a product with variants is the total of its variants in each warehouse.
+func (r *CatalogServiceRepository) GetItemStock(itemID string) ([]*pb.WarehouseStock, error) {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return nil, err
+	}
+
+	// Check the item exists
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return nil, err
+	}
+
+	var stock []*pb.WarehouseStock
+	if err := r.db.Table("warehouse_stocks AS s").
+		Select("s.warehouse_id, w.name, SUM(s.quantity) AS quantity").
+		Joins("JOIN warehouses w ON w.warehouse_id = s.warehouse_id").
+		Where("s.quantity > 0").
+		Where("s.item_id = ? OR s.item_id IN (SELECT item_id FROM catalog_items WHERE product_id = ?)", itemID, itemID).
+		Group("s.warehouse_id, w.name").
+		Order("w.name").
+		Scan(&stock).Error; err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+// TransferStock moves a quantity of an item from a warehouse to another, on behalf of actor.
+// The quantity available of the item does not change, the two sides are recorded in the ledger as transfers.
+// The item is moved to its next version, the quantities of its warehouses loaded before are stale.
+func (r *CatalogServiceRepository) TransferStock(itemID, fromWarehouseID, toWarehouseID string, quantity uint32, actor string) error {
+
+	// Check ItemID validity
+	if err := checkItemIDValidity(itemID); err != nil {
+		return err
+	}
+
+	// Check quantity validity
+	if quantity == 0 {
+		return errors.New("Transferred quantity must be greater than zero")
+	}
+
+	// Check the warehouses exist and differ
+	if fromWarehouseID == toWarehouseID {
+		return errors.New("Stock must be transferred to another warehouse")
+	}
+	for _, warehouseID := range []string{fromWarehouseID, toWarehouseID} {
+		if _, err := retrieveWarehouse(r.db, warehouseID); err != nil {
+			return err
+		}
+	}
+
+	// Check the item exists, the stock of a product with variants is the one of its variants
+	if _, err := r.RetrieveCatalogItem(itemID); err != nil {
+		return err
+	}
+	if err := checkNotProductWithVariants(itemID, r.db); err != nil {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := takeWarehouseStock(tx, fromWarehouseID, itemID, quantity); err != nil {
+			return err
+		}
+		if err := addWarehouseStock(tx, toWarehouseID, itemID, quantity); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.CatalogItem{}).Where("item_id = ?", itemID).Update("version", nextVersion).Error; err != nil {
+			return err
+		}
+		for _, movement := range []*domain.StockMovement{
+			{ItemID: itemID, WarehouseID: fromWarehouseID, Delta: -int64(quantity), Reason: domain.MovementTransfer, Actor: actor},
+			{ItemID: itemID, WarehouseID: toWarehouseID, Delta: int64(quantity), Reason: domain.MovementTransfer, Actor: actor},
+		} {
+			if err := recordMovement(tx, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.publishChanges(pb.CatalogEventType_STOCK_CHANGED, itemID)
+	return nil
+}
+
+// OpenWarehouses creates the default warehouse and puts there the stock of the items not in any warehouse,
+// as well as the movements recorded before the warehouses.
+// It returns the number of items whose stock has been put in the default warehouse, running it again has no effect.
+func (r *CatalogServiceRepository) OpenWarehouses() (int, error) {
+
+	var items []*domain.CatalogItem
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.Warehouse{
+			WarehouseID: domain.DefaultWarehouseID,
+			Name:        defaultWarehouseName,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("quantity_available > 0").
+			Where("NOT EXISTS (SELECT 1 FROM catalog_items v WHERE v.product_id = catalog_items.item_id)").
+			Where("NOT EXISTS (SELECT 1 FROM warehouse_stocks s WHERE s.item_id = catalog_items.item_id)").
+			Find(&items).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := addWarehouseStock(tx, domain.DefaultWarehouseID, item.ItemID, item.QuantityAvailable); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&domain.StockMovement{}).Where("warehouse_id = ''").
+			Update("warehouse_id", domain.DefaultWarehouseID).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// PRIVATE FUNCTIONS TO MANAGE THE STOCK IN THE WAREHOUSES
+
+// retrieveWarehouse retrieves a warehouse by its ID
+func retrieveWarehouse(db *gorm.DB, warehouseID string) (*domain.Warehouse, error) {
+	if warehouseID == "" {
+		return nil, errors.New("Warehouse ID cannot be empty")
+	}
+
+	var warehouse domain.Warehouse
+	if err := db.First(&warehouse, "warehouse_id = ?", warehouseID).Error; err != nil {
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+// warehouseQuantity returns the quantity of an item in stock at a warehouse, zero if it has none
+func warehouseQuantity(db *gorm.DB, warehouseID, itemID string) (uint32, error) {
+	var quantities []uint32
+	if err := db.Model(&domain.WarehouseStock{}).Where("warehouse_id = ? AND item_id = ?", warehouseID, itemID).
+		Pluck("quantity", &quantities).Error; err != nil {
+		return 0, err
+	}
+	if len(quantities) == 0 {
+		return 0, nil
+	}
+	return quantities[0], nil
+}
+
+// addWarehouseStock adds a quantity of an item to the stock of a warehouse
+func addWarehouseStock(tx *gorm.DB, warehouseID, itemID string, quantity uint32) error {
+	if quantity == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "item_id"}},
+		DoUpdates: clause.Assignments(map[string]any{"quantity": gorm.Expr("warehouse_stocks.quantity + excluded.quantity")}),
+	}).Create(&domain.WarehouseStock{WarehouseID: warehouseID, ItemID: itemID, Quantity: quantity}).Error
+}
+
+// takeWarehouseStock takes a quantity of an item from the stock of a warehouse, if it has enough of it
+func takeWarehouseStock(tx *gorm.DB, warehouseID, itemID string, quantity uint32) error {
+	result := tx.Model(&domain.WarehouseStock{}).
+		Where("warehouse_id = ? AND item_id = ? AND quantity >= ?", warehouseID, itemID, quantity).
+		Update("quantity", gorm.Expr("quantity - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w for item %s at warehouse %s", ErrInsufficientStock, itemID, warehouseID)
+	}
+	return nil
+}
+
+// setWarehouseStock sets the quantity of an item in stock at a warehouse
+func setWarehouseStock(tx *gorm.DB, warehouseID, itemID string, quantity uint32) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "item_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity"}),
+	}).Create(&domain.WarehouseStock{WarehouseID: warehouseID, ItemID: itemID, Quantity: quantity}).Error
+}
+
+// syncQuantityAvailable sets the quantity available of the items to the total of their stock in the warehouses
+func syncQuantityAvailable(tx *gorm.DB, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&domain.CatalogItem{}).Where("item_id IN ?", itemIDs).Updates(map[string]any{
+		"quantity_available": gorm.Expr("COALESCE((SELECT SUM(s.quantity) FROM warehouse_stocks s WHERE s.item_id = catalog_items.item_id), 0)"),
+		"version":            nextVersion,
+	}).Error
+}
+
+// itemStock returns, by item ID, the warehouses having the items in stock with their location
+func itemStock(tx *gorm.DB, itemIDs []string) (map[string][]allocation.Stock, error) {
+	var rows []struct {
+		ItemID      string
+		WarehouseID string
+		Latitude    float64
+		Longitude   float64
+		Quantity    uint32
+	}
+	if err := tx.Table("warehouse_stocks AS s").
+		Select("s.item_id, s.warehouse_id, w.latitude, w.longitude, s.quantity").
+		Joins("JOIN warehouses w ON w.warehouse_id = s.warehouse_id").
+		Where("s.item_id IN ? AND s.quantity > 0", itemIDs).
+		Order("s.warehouse_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	stock := make(map[string][]allocation.Stock)
+	for _, row := range rows {
+		stock[row.ItemID] = append(stock[row.ItemID], allocation.Stock{
+			WarehouseID: row.WarehouseID,
+			Location:    allocation.Location{Latitude: row.Latitude, Longitude: row.Longitude},
+			Quantity:    row.Quantity,
+		})
+	}
+	return stock, nil
+}
+
+// returnedStock chooses the warehouses a quantity of an item given back by an order is returned to:
+// the ones it was sold from, as much as was sold from each, and the default warehouse for the rest
+func returnedStock(tx *gorm.DB, orderID, itemID string, quantity uint32) ([]allocation.Allocation, error) {
+	var sold []struct {
+		WarehouseID string
+		Quantity    int64
+	}
+	if orderID != "" {
+		if err := tx.Model(&domain.StockMovement{}).
+			Select("warehouse_id, -SUM(delta) AS quantity").
+			Where("order_id = ? AND item_id = ? AND reason = ?", orderID, itemID, domain.MovementSale).
+			Group("warehouse_id").
+			Order("warehouse_id").
+			Scan(&sold).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var allocations []allocation.Allocation
+	left := quantity
+	for _, s := range sold {
+		if left == 0 {
+			break
+		}
+		returned := uint32(min(int64(left), max(s.Quantity, 0)))
+		if returned == 0 {
+			continue
+		}
+		allocations = append(allocations, allocation.Allocation{ItemID: itemID, WarehouseID: s.WarehouseID, Quantity: returned})
+		left -= returned
+	}
+	if left > 0 {
+		allocations = append(allocations, allocation.Allocation{ItemID: itemID, WarehouseID: domain.DefaultWarehouseID, Quantity: left})
+	}
+	return allocations, nil
+}
+
+// locationsInStock returns, by item ID, the number of warehouses having the items in stock,
+// the ones having any of its variants for a product
+func (r *CatalogServiceRepository) locationsInStock(itemIDs []string) (map[string]uint32, error) {
+	var rows []struct {
+		ItemID    string
+		Locations uint32
+	}
+
+	locations := make(map[string]uint32)
+	if err := r.db.Model(&domain.WarehouseStock{}).
+		Select("item_id, COUNT(*) AS locations").
+		Where("item_id IN ? AND quantity > 0", itemIDs).
+		Group("item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		locations[row.ItemID] = row.Locations
+	}
+
+	rows = nil
+	if err := r.db.Table("warehouse_stocks AS s").
+		Select("v.product_id AS item_id, COUNT(DISTINCT s.warehouse_id) AS locations").
+		Joins("JOIN catalog_items v ON v.item_id = s.item_id").
+		Where("v.product_id IN ? AND s.quantity > 0", itemIDs).
+		Group("v.product_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		locations[row.ItemID] = row.Locations
+	}
+	return locations, nil
+}
+
+// removeWarehouseStock removes the stock of the items removed from the catalog
+func removeWarehouseStock(tx *gorm.DB, items []*domain.CatalogItem) error {
+	itemIDs := make([]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	return tx.Where("item_id IN ?", itemIDs).Delete(&domain.WarehouseStock{}).Error
+}
+
+// PRIVATE FUNCTIONS TO VALIDATE WAREHOUSE INPUTS
+
+func checkLocationValidity(latitude, longitude float64) error {
+	if !(allocation.Location{Latitude: latitude, Longitude: longitude}).Valid() {
+		return errors.New("Invalid location: latitude between -90 and 90 degrees, longitude between -180 and 180")
+	}
+	return nil
+}
+
+func checkWarehouseNameUniqueness(name string, db *gorm.DB) error {
+	var names []string
+	if err := db.Model(&domain.Warehouse{}).Pluck("name", &names).Error; err != nil {
+		return err
+	}
+	if slices.ContainsFunc(names, func(other string) bool { return strings.EqualFold(other, name) }) {
+		return errors.New("A warehouse with the same name already exists")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"log"
+	"slices"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+)
+
+// WatchCatalog subscribes to the changes of the catalog, until the returned function is called.
+// The channel is closed if the watcher falls too far behind, some changes are then lost.
+func (r *CatalogServiceRepository) WatchCatalog() (<-chan *pb.CatalogEvent, func()) {
+	return r.events.Subscribe()
+}
+
+// PRIVATE FUNCTIONS TO PUBLISH THE CHANGES
+
+// publishChanges publishes a change of the items once it is committed, with their state after the change.
+// The products of the variants among them change too: their price and stock come from the variants.
+func (r *CatalogServiceRepository) publishChanges(eventType pb.CatalogEventType, itemIDs ...string) {
+	if r.events == nil || len(itemIDs) == 0 {
+		return
+	}
+
+	var items []*domain.CatalogItem
+	if err := r.db.Where("item_id IN ?", itemIDs).Order("item_id").Find(&items).Error; err != nil {
+		log.Printf("Failed to publish the changes of the items %v: %v", itemIDs, err)
+		return
+	}
+	r.publishItems(eventType, items)
+
+	var productIDs []string
+	for _, item := range items {
+		if item.ProductID != "" && !slices.Contains(itemIDs, item.ProductID) && !slices.Contains(productIDs, item.ProductID) {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	r.publishChanges(productEventType(eventType), productIDs...)
+}
+
+// publishRemoval publishes the removal of the items, with their state before it, and the change of their products
+func (r *CatalogServiceRepository) publishRemoval(items []*domain.CatalogItem) {
+	if r.events == nil || len(items) == 0 {
+		return
+	}
+
+	removed := make([]string, len(items))
+	for i, item := range items {
+		removed[i] = item.ItemID
+	}
+
+	var productIDs []string
+	for _, item := range items {
+		event := &pb.CatalogEvent{Type: pb.CatalogEventType_ITEM_REMOVED, ItemId: item.ItemID}
+		if protoItem, err := domain.DomainCatalogItemToProtoCatalogItem(item); err == nil {
+			event.Item = protoItem
+		}
+		r.events.Publish(event)
+
+		if item.ProductID != "" && !slices.Contains(removed, item.ProductID) && !slices.Contains(productIDs, item.ProductID) {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+	r.publishChanges(pb.CatalogEventType_ITEM_UPDATED, productIDs...)
+}
+
+// publishCategoriesChanged publishes a change of the category tree
+func (r *CatalogServiceRepository) publishCategoriesChanged() {
+	if r.events != nil {
+		r.events.Publish(&pb.CatalogEvent{Type: pb.CatalogEventType_CATEGORIES_CHANGED})
+	}
+}
+
+func (r *CatalogServiceRepository) publishItems(eventType pb.CatalogEventType, items []*domain.CatalogItem) {
+	protoItems, err := r.toProtoItems(items)
+	if err != nil {
+		log.Printf("Failed to publish the changes of the items: %v", err)
+		return
+	}
+	for _, item := range protoItems {
+		r.events.Publish(&pb.CatalogEvent{Type: eventType, ItemId: item.ItemId, Item: item})
+	}
+}
+
+// productEventType is the change of a product caused by the change of one of its variants
+func productEventType(eventType pb.CatalogEventType) pb.CatalogEventType {
+	switch eventType {
+	case pb.CatalogEventType_PRICE_CHANGED, pb.CatalogEventType_STOCK_CHANGED:
+		return eventType
+	default:
+		return pb.CatalogEventType_ITEM_UPDATED
+	}
+}
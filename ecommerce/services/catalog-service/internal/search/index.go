@@ -0,0 +1,247 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Parameters of the BM25 ranking
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Weights of the words of the item name and of the kinds of match
+const (
+	nameBoost    = 2
+	prefixWeight = 0.8
+)
+
+// maxExpansions bounds the indexed words a query word can match by prefix or with typos
+const maxExpansions = 50
+
+// Size of the snippets, in words
+const (
+	snippetWords   = 16
+	snippetContext = 4
+)
+
+const ellipsis = "…"
+
+// Hit is an item matching a search, with the part of its description showing the matched words.
+type Hit struct {
+	ItemID     string
+	Score      float64
+	Snippet    string
+	Highlights [][2]int
+}
+
+// document is an indexed item
+type document struct {
+	description string
+	length      int
+}
+
+// Index is an in-memory inverted index over the names and descriptions of the catalog items.
+// Items are ranked by BM25, query words match indexed words exactly, as a prefix or with a few typos.
+type Index struct {
+	mu sync.RWMutex
+
+	docs map[string]*document
+
+	// postings maps every indexed word to the weighted frequency of the word in each item containing it
+	postings map[string]map[string]int
+
+	// vocabulary holds the indexed words sorted, to find the words by prefix
+	vocabulary []string
+
+	totalLength int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// Add indexes an item, replacing the previous version of the item if any.
+func (i *Index) Add(itemID, name, description string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(itemID)
+
+	// Words of the name weigh more than the ones of the description
+	frequencies := make(map[string]int)
+	length := 0
+	for _, tok := range tokenize(name) {
+		frequencies[tok.term] += nameBoost
+		length++
+	}
+	for _, tok := range tokenize(description) {
+		frequencies[tok.term]++
+		length++
+	}
+
+	for term, frequency := range frequencies {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]int)
+			pos, _ := slices.BinarySearch(i.vocabulary, term)
+			i.vocabulary = slices.Insert(i.vocabulary, pos, term)
+		}
+		i.postings[term][itemID] = frequency
+	}
+
+	i.docs[itemID] = &document{description: description, length: length}
+	i.totalLength += length
+}
+
+// Remove removes an item from the index.
+func (i *Index) Remove(itemID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(itemID)
+}
+
+// Len returns the number of indexed items.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.docs)
+}
+
+// Search returns at most limit items matching any word of the query, the best first.
+func (i *Index) Search(query string, limit int) []Hit {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(i.docs) == 0 {
+		return nil
+	}
+
+	averageLength := float64(i.totalLength) / float64(len(i.docs))
+	scores := make(map[string]float64)
+	matched := make(map[string]map[string]bool)
+
+	seen := make(map[string]bool)
+	for _, tok := range tokenize(query) {
+		if seen[tok.term] {
+			continue
+		}
+		seen[tok.term] = true
+
+		// An item scores the best of the indexed words matching the query word
+		best := make(map[string]float64)
+		for term, weight := range i.expand(tok.term) {
+			postings := i.postings[term]
+			idf := math.Log(1 + (float64(len(i.docs))-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+
+			for itemID, frequency := range postings {
+				tf := float64(frequency)
+				norm := k1 * (1 - b + b*float64(i.docs[itemID].length)/averageLength)
+				best[itemID] = max(best[itemID], weight*idf*tf*(k1+1)/(tf+norm))
+
+				if matched[itemID] == nil {
+					matched[itemID] = make(map[string]bool)
+				}
+				matched[itemID][term] = true
+			}
+		}
+
+		for itemID, score := range best {
+			scores[itemID] += score
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for itemID, score := range scores {
+		hits = append(hits, Hit{ItemID: itemID, Score: score})
+	}
+	sort.Slice(hits, func(a, c int) bool {
+		if hits[a].Score != hits[c].Score {
+			return hits[a].Score > hits[c].Score
+		}
+		return hits[a].ItemID < hits[c].ItemID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	for h := range hits {
+		hits[h].Snippet, hits[h].Highlights = snippet(i.docs[hits[h].ItemID].description, matched[hits[h].ItemID])
+	}
+	return hits
+}
+
+// PRIVATE FUNCTIONS
+
+// remove removes an item from the index, the caller holds the lock
+func (i *Index) remove(itemID string) {
+	doc, ok := i.docs[itemID]
+	if !ok {
+		return
+	}
+
+	for term, postings := range i.postings {
+		if _, ok := postings[itemID]; !ok {
+			continue
+		}
+		delete(postings, itemID)
+		if len(postings) == 0 {
+			delete(i.postings, term)
+			if pos, found := slices.BinarySearch(i.vocabulary, term); found {
+				i.vocabulary = slices.Delete(i.vocabulary, pos, pos+1)
+			}
+		}
+	}
+
+	i.totalLength -= doc.length
+	delete(i.docs, itemID)
+}
+
+// expand returns the indexed words matching a query word, with the weight of the match:
+// the word itself, the words it is a prefix of and the words within a few typos.
+func (i *Index) expand(term string) map[string]float64 {
+	expansions := make(map[string]float64)
+	if _, ok := i.postings[term]; ok {
+		expansions[term] = 1
+	}
+
+	// The vocabulary is sorted, the words starting with term follow its position
+	if len([]rune(term)) >= 2 {
+		pos, _ := slices.BinarySearch(i.vocabulary, term)
+		for ; pos < len(i.vocabulary) && len(expansions) < maxExpansions; pos++ {
+			word := i.vocabulary[pos]
+			if !strings.HasPrefix(word, term) {
+				break
+			}
+			if word != term {
+				expansions[word] = prefixWeight
+			}
+		}
+	}
+
+	typos := maxTypos(term)
+	if typos == 0 {
+		return expansions
+	}
+	for _, word := range i.vocabulary {
+		if len(expansions) >= maxExpansions {
+			break
+		}
+		if _, ok := expansions[word]; ok {
+			continue
+		}
+		if distance := editDistance(term, word, typos); distance <= typos {
+			expansions[word] = 1 / float64(1+distance)
+		}
+	}
+	return expansions
+}
@@ -0,0 +1,144 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a word of a text, with its byte range in the text
+type token struct {
+	term  string
+	start int
+	end   int
+}
+
+// tokenize splits a text into lower case words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		}
+		if !isWord && start >= 0 {
+			tokens = append(tokens, token{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{term: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// maxTypos is the number of typos tolerated in a query word, longer words tolerate more
+func maxTypos(term string) int {
+	switch length := utf8.RuneCountInString(term); {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and transpositions
+// turning a into b, or max+1 as soon as it exceeds max.
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > max {
+		return max + 1
+	}
+
+	// Three rows of the matrix are enough, the one before the previous is needed by transpositions
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(rb)]
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// snippet returns the part of the text around its first matched word, with the byte ranges of the matched words in it.
+// Without matches, the snippet is the beginning of the text.
+func snippet(text string, matched map[string]bool) (string, [][2]int) {
+	tokens := tokenize(text)
+	if len(tokens) <= snippetWords {
+		return text, highlights(tokens, matched, 0)
+	}
+
+	first := 0
+	for i, tok := range tokens {
+		if matched[tok.term] {
+			first = i
+			break
+		}
+	}
+
+	// Some context before the first match, and a full window when the match is near the end
+	from := max(0, first-snippetContext)
+	to := min(len(tokens), from+snippetWords)
+	from = max(0, to-snippetWords)
+
+	start, end := tokens[from].start, tokens[to-1].end
+	if from == 0 {
+		start = 0
+	}
+	if to == len(tokens) {
+		end = len(text)
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = ellipsis
+	}
+	if end < len(text) {
+		suffix = ellipsis
+	}
+
+	// Ranges are shifted to the start of the snippet
+	ranges := highlights(tokens[from:to], matched, start-len(prefix))
+	return prefix + text[start:end] + suffix, ranges
+}
+
+// highlights returns the byte ranges of the matched words, moved back by offset
+func highlights(tokens []token, matched map[string]bool, offset int) [][2]int {
+	var ranges [][2]int
+	for _, tok := range tokens {
+		if matched[tok.term] {
+			ranges = append(ranges, [2]int{tok.start - offset, tok.end - offset})
+		}
+	}
+	return ranges
+}
@@ -1,13 +1,17 @@
 package tests
 
 import (
+	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
 )
 
@@ -17,22 +21,35 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect database: %v", err)
 	}
 
-	if err = db.AutoMigrate(&domain.CatalogItem{}); err != nil {
+	if err = db.AutoMigrate(&domain.CatalogItem{}, &domain.Category{}, &domain.ItemTag{}, &domain.PriceHistory{}, &domain.ScheduledPrice{}, &domain.StockMovement{}, &domain.Warehouse{}, &domain.WarehouseStock{}, &domain.StockAlert{}, &domain.ItemImage{}, &domain.Review{}); err != nil {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
 	return db
 }
 
+// setupTestImages returns a store keeping the images in a directory removed with the test
+func setupTestImages(t *testing.T) media.BlobStore {
+	images, err := media.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to open the image store: %v", err)
+	}
+	return images
+}
+
 func setupDefaultCatalogItems(db *gorm.DB) {
-	defaultItem1 := &pb.CatalogItem{
-		ItemId:            "item123",
+	defaultItem1 := &domain.CatalogItem{
+		ItemID:            "item123",
+		Name:              "Default Item",
+		Slug:              "default-item",
 		Description:       "Default Item",
 		QuantityAvailable: 10,
 		Price:             99.99,
 	}
 
-	defaultItem2 := &pb.CatalogItem{
-		ItemId:            "item456",
+	defaultItem2 := &domain.CatalogItem{
+		ItemID:            "item456",
+		Name:              "Another Item",
+		Slug:              "another-item",
 		Description:       "Another Item",
 		QuantityAvailable: 5,
 		Price:             49.99,
@@ -44,9 +61,12 @@ func setupDefaultCatalogItems(db *gorm.DB) {
 
 func setupTest(t *testing.T) (*gorm.DB, *repository.CatalogServiceRepository) {
 	db := setupTestDB(t)
-	repo := repository.NewCatalogServiceRepository(db)
+	repo := repository.NewCatalogServiceRepository(db, setupTestImages(t))
 
 	setupDefaultCatalogItems(db)
+	if _, err := repo.OpenWarehouses(); err != nil {
+		t.Fatalf("Failed to open the warehouses: %v", err)
+	}
 
 	return db, repo
 }
@@ -56,11 +76,12 @@ func TestAddNewCatalogItem(t *testing.T) {
 
 	newItem := &pb.CatalogItem{
 		ItemId:            "item789",
+		Name:              "New Test Item",
 		Description:       "New Test Item",
 		QuantityAvailable: 20,
 		Price:             29.99,
 	}
-	if err := repo.AddCatalogItem(newItem); err != nil {
+	if _, err := repo.AddCatalogItem(newItem, "admin"); err != nil {
 		t.Errorf("Failed to add new catalog item: %v", err)
 	}
 
@@ -85,12 +106,13 @@ func TestAddExistingCatalogItem(t *testing.T) {
 
 	existingItem := &pb.CatalogItem{
 		ItemId:            "item123",
+		Name:              "Updated Default Item",
 		Description:       "Updated Default Item",
 		QuantityAvailable: 15,
 		Price:             89.99,
 	}
 
-	if err := repo.AddCatalogItem(existingItem); err == nil {
+	if _, err := repo.AddCatalogItem(existingItem, "admin"); err == nil {
 		t.Errorf("Expected error when adding existing catalog item, but got none")
 	}
 
@@ -111,17 +133,17 @@ func TestAddExistingCatalogItem(t *testing.T) {
 	}
 }
 
-func TestAddCatalogItemInvalidID(t *testing.T) {
+func TestAddCatalogItemInvalidName(t *testing.T) {
 	_, repo := setupTest(t)
 
 	invalidItem := &pb.CatalogItem{
-		ItemId:            "",
+		Name:              " ",
 		Description:       "Invalid Item",
 		QuantityAvailable: 10,
 		Price:             19.99,
 	}
 
-	if err := repo.AddCatalogItem(invalidItem); err == nil {
+	if _, err := repo.AddCatalogItem(invalidItem, "admin"); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 }
@@ -130,13 +152,13 @@ func TestAddCatalogItemInvalidDescription(t *testing.T) {
 	_, repo := setupTest(t)
 
 	invalidItem := &pb.CatalogItem{
-		ItemId:            "item999",
+		Name:              "Item 999",
 		Description:       "",
 		QuantityAvailable: 10,
 		Price:             19.99,
 	}
 
-	if err := repo.AddCatalogItem(invalidItem); err == nil {
+	if _, err := repo.AddCatalogItem(invalidItem, "admin"); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 }
@@ -145,13 +167,13 @@ func TestAddCatalogItemInvalidPrice(t *testing.T) {
 	_, repo := setupTest(t)
 
 	invalidItem := &pb.CatalogItem{
-		ItemId:            "item999",
+		Name:              "Item 999",
 		Description:       "Invalid Price Item",
 		QuantityAvailable: 10,
 		Price:             -5.00,
 	}
 
-	if err := repo.AddCatalogItem(invalidItem); err == nil {
+	if _, err := repo.AddCatalogItem(invalidItem, "admin"); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 }
@@ -226,7 +248,7 @@ func TestGetCatalogItemInvalidID(t *testing.T) {
 func TestUpdateQuantityAvailableValid(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.UpdateQuantityAvailable("item123", 25); err != nil {
+	if err := repo.UpdateQuantityAvailable("item123", "", 25, 0, domain.MovementAdjustment, "admin"); err != nil {
 		t.Errorf("Failed to update quantity available: %v", err)
 	}
 
@@ -242,7 +264,7 @@ func TestUpdateQuantityAvailableValid(t *testing.T) {
 func TestUpdateQuantityAvailableInvalidID(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.UpdateQuantityAvailable("", 15); err == nil {
+	if err := repo.UpdateQuantityAvailable("", "", 15, 0, domain.MovementAdjustment, "admin"); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 }
@@ -250,7 +272,7 @@ func TestUpdateQuantityAvailableInvalidID(t *testing.T) {
 func TestUpdateQuantityAvailableNonExistingItem(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.UpdateQuantityAvailable("nonexistent_item", 15); err == nil {
+	if err := repo.UpdateQuantityAvailable("nonexistent_item", "", 15, 0, domain.MovementAdjustment, "admin"); err == nil {
 		t.Errorf("Expected error: %v but got none", err)
 	}
 }
@@ -258,7 +280,7 @@ func TestUpdateQuantityAvailableNonExistingItem(t *testing.T) {
 func TestUpdatePriceValid(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.UpdatePrice("item123", 79.99); err != nil {
+	if err := repo.UpdatePrice("item123", 79.99, 0); err != nil {
 		t.Errorf("Failed to update price: %v", err)
 	}
 
@@ -274,7 +296,7 @@ func TestUpdatePriceValid(t *testing.T) {
 func TestUpdatePriceInvalid(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.UpdatePrice("item123", -10.00); err == nil {
+	if err := repo.UpdatePrice("item123", -10.00, 0); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 
@@ -290,7 +312,7 @@ func TestUpdatePriceInvalid(t *testing.T) {
 func TestUpdatePriceInvalidID(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.UpdatePrice("", 49.99); err == nil {
+	if err := repo.UpdatePrice("", 49.99, 0); err == nil {
 		t.Errorf("Expected error: %v, but got none", err)
 	}
 }
@@ -298,7 +320,7 @@ func TestUpdatePriceInvalidID(t *testing.T) {
 func TestUpdatePriceNonExistingItem(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.UpdatePrice("nonexistent_item", 49.99); err == nil {
+	if err := repo.UpdatePrice("nonexistent_item", 49.99, 0); err == nil {
 		t.Errorf("Expected error: %v but got none", err)
 	}
 }
@@ -306,13 +328,16 @@ func TestUpdatePriceNonExistingItem(t *testing.T) {
 func TestListCatalogItems(t *testing.T) {
 	_, repo := setupTest(t)
 
-	items, err := repo.ListCatalogItems()
+	items, nextPageToken, err := repo.ListCatalogItems(domain.CatalogQuery{})
 	if err != nil {
 		t.Errorf("Failed to list catalog items: %v", err)
 	}
 	if len(items) != 2 {
 		t.Errorf("Expected 2 catalog items, got %v", len(items))
 	}
+	if nextPageToken != "" {
+		t.Errorf("Expected no next page, got token %v", nextPageToken)
+	}
 
 	expectedItems := map[string]*pb.CatalogItem{
 		"item123": {
@@ -348,10 +373,167 @@ func TestListCatalogItems(t *testing.T) {
 	}
 }
 
+// addListingItems adds items named after their ID with the given prices, in the order given
+func addListingItems(t *testing.T, repo *repository.CatalogServiceRepository, prices map[string]float64, order []string) {
+	for _, id := range order {
+		quantity := uint32(3)
+		if prices[id] >= 80 {
+			quantity = 0
+		}
+		item := &pb.CatalogItem{ItemId: id, Name: id, Description: "Listing " + id, QuantityAvailable: quantity, Price: prices[id]}
+		if _, err := repo.AddCatalogItem(item, "admin"); err != nil {
+			t.Fatalf("Failed to add item %v: %v", id, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// listAll follows the page tokens and returns the item IDs of all pages
+func listAll(t *testing.T, repo *repository.CatalogServiceRepository, query domain.CatalogQuery) ([]string, int) {
+	var ids []string
+	pages := 0
+	for {
+		items, nextPageToken, err := repo.ListCatalogItems(query)
+		if err != nil {
+			t.Fatalf("Failed to list catalog items: %v", err)
+		}
+		pages++
+		for _, item := range items {
+			ids = append(ids, item.ItemId)
+		}
+		if nextPageToken == "" {
+			return ids, pages
+		}
+		query.PageToken = nextPageToken
+	}
+}
+
+func TestListCatalogItemsPagination(t *testing.T) {
+	_, repo := setupTest(t)
+
+	addListingItems(t, repo, map[string]float64{"a1": 10, "a2": 20, "a3": 30}, []string{"a1", "a2", "a3"})
+
+	// Upper case names come first
+	ids, pages := listAll(t, repo, domain.CatalogQuery{PageSize: 2})
+	want := []string{"item456", "item123", "a1", "a2", "a3"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %v", pages)
+	}
+
+	// An item added before the cursor is not returned, the next ones are not skipped
+	items, nextPageToken, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 2})
+	if err != nil || len(items) != 2 {
+		t.Fatalf("Failed to list first page: %v", err)
+	}
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{ItemId: "a0", Name: "Aardvark", Description: "Listing a0", Price: 5}, "admin"); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	ids, _ = listAll(t, repo, domain.CatalogQuery{PageSize: 2, PageToken: nextPageToken})
+	want = []string{"a1", "a2", "a3"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+}
+
+func TestListCatalogItemsSort(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewCatalogServiceRepository(db, setupTestImages(t))
+
+	prices := map[string]float64{"b": 30, "a": 10, "d": 30, "c": 20}
+	addListingItems(t, repo, prices, []string{"b", "a", "d", "c"})
+
+	tests := []struct {
+		sort pb.CatalogSort
+		want []string
+	}{
+		{pb.CatalogSort_NAME, []string{"a", "b", "c", "d"}},
+		{pb.CatalogSort_PRICE_ASC, []string{"a", "c", "b", "d"}},
+		{pb.CatalogSort_PRICE_DESC, []string{"d", "b", "c", "a"}},
+		{pb.CatalogSort_NEWEST, []string{"c", "d", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		// Pages of one item cross every tie
+		ids, _ := listAll(t, repo, domain.CatalogQuery{PageSize: 1, Sort: tt.sort})
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("Sort %v: expected %v, got %v", tt.sort, tt.want, ids)
+		}
+	}
+}
+
+func TestListCatalogItemsFilters(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// Items priced 80 or more are out of stock
+	addListingItems(t, repo, map[string]float64{"f1": 15, "f2": 85, "f3": 40}, []string{"f1", "f2", "f3"})
+
+	tests := []struct {
+		name  string
+		query domain.CatalogQuery
+		want  []string
+	}{
+		{"min price", domain.CatalogQuery{MinPrice: 40}, []string{"item456", "item123", "f2", "f3"}},
+		{"max price", domain.CatalogQuery{MaxPrice: 40}, []string{"f1", "f3"}},
+		{"price range", domain.CatalogQuery{MinPrice: 20, MaxPrice: 90, Sort: pb.CatalogSort_PRICE_ASC}, []string{"f3", "item456", "f2"}},
+		{"in stock", domain.CatalogQuery{InStockOnly: true, PageSize: 1}, []string{"item456", "item123", "f1", "f3"}},
+	}
+
+	for _, tt := range tests {
+		ids, _ := listAll(t, repo, tt.query)
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.name, tt.want, ids)
+		}
+	}
+}
+
+func TestListCatalogItemsInvalidQuery(t *testing.T) {
+	_, repo := setupTest(t)
+
+	invalidQueries := []domain.CatalogQuery{
+		{PageSize: -1},
+		{MinPrice: -5},
+		{MinPrice: 50, MaxPrice: 10},
+		{Sort: pb.CatalogSort(42)},
+	}
+	for _, query := range invalidQueries {
+		if _, _, err := repo.ListCatalogItems(query); err == nil {
+			t.Errorf("Expected error for query %+v but got none", query)
+		}
+	}
+
+	// Page size is capped
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: domain.MaxPageSize + 1}); err != nil {
+		t.Errorf("Expected page size to be capped, got error: %v", err)
+	}
+}
+
+func TestListCatalogItemsInvalidPageToken(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{PageToken: "not-a-token"}); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+	}
+
+	// A token cannot be reused with another sort or other filters
+	_, nextPageToken, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 1})
+	if err != nil || nextPageToken == "" {
+		t.Fatalf("Expected a next page token, got %v", err)
+	}
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 1, PageToken: nextPageToken, Sort: pb.CatalogSort_PRICE_ASC}); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for another sort, got %v", err)
+	}
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 1, PageToken: nextPageToken, InStockOnly: true}); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for other filters, got %v", err)
+	}
+}
+
 func TestCreateDefaultProducts(t *testing.T) {
 	// Creation of an empty database
 	db := setupTestDB(t)
-	repo := repository.NewCatalogServiceRepository(db)
+	repo := repository.NewCatalogServiceRepository(db, setupTestImages(t))
 
 	if err := repo.CreateDefaultItems(); err != nil {
 		t.Fatalf("Failed to create default products on empty DB: %v", err)
@@ -366,9 +548,15 @@ func TestCreateDefaultProducts(t *testing.T) {
 
 	// Retrieving a specific item
 	var berserkItem domain.CatalogItem
-	if err := db.Where("item_id = ?", "Berserk Deluxe Edition Vol.1").First(&berserkItem).Error; err != nil {
+	if err := db.Where("name = ?", "Berserk Deluxe Edition Vol.1").First(&berserkItem).Error; err != nil {
 		t.Errorf("Could not find Berserk in default catalog: %v", err)
 	}
+	if len(berserkItem.ItemID) != 26 {
+		t.Errorf("Expected a generated ULID, got %v", berserkItem.ItemID)
+	}
+	if berserkItem.Slug != "berserk-deluxe-edition-vol-1" {
+		t.Errorf("Berserk slug mismatch: got %v, want berserk-deluxe-edition-vol-1", berserkItem.Slug)
+	}
 	if berserkItem.QuantityAvailable != 25 {
 		t.Errorf("Berserk quantity mismatch: got %v, want 25", berserkItem.QuantityAvailable)
 	}
@@ -387,3 +575,160 @@ func TestCreationDefaultProductsInNonEmptyCatalog(t *testing.T) {
 		t.Fatalf("Error occured in creation of default product in NON empty catalog")
 	}
 }
+
+func TestAddCatalogItemGeneratesIDAndSlug(t *testing.T) {
+	_, repo := setupTest(t)
+
+	first, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Dune: Deluxe Édition", Description: "Spice", Price: 20}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	second, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Dune  Deluxe dition", Description: "Spice again", Price: 20}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	if first == second || len(first) != 26 {
+		t.Fatalf("Expected two distinct generated IDs, got %v and %v", first, second)
+	}
+
+	// Slugs are derived from the name and made unique
+	item, _ := repo.GetCatalogItem(first)
+	if item.Slug != "dune-deluxe-dition" {
+		t.Errorf("Slug mismatch: got %v, want dune-deluxe-dition", item.Slug)
+	}
+	item, _ = repo.GetCatalogItem(second)
+	if item.Slug != "dune-deluxe-dition-2" {
+		t.Errorf("Slug mismatch: got %v, want dune-deluxe-dition-2", item.Slug)
+	}
+
+	bySlug, err := repo.GetCatalogItemBySlug("dune-deluxe-dition-2")
+	if err != nil || bySlug.ItemId != second {
+		t.Errorf("Expected %v by slug, got %v (%v)", second, bySlug, err)
+	}
+}
+
+func TestAddCatalogItemSKUAndSlug(t *testing.T) {
+	_, repo := setupTest(t)
+
+	itemID, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Hobbit", Description: "Book", Price: 10, Sku: "BK-001", Slug: "the-hobbit"}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+
+	item, err := repo.GetCatalogItemBySKU("BK-001")
+	if err != nil || item.ItemId != itemID || item.Slug != "the-hobbit" {
+		t.Errorf("Expected %v by SKU, got %v (%v)", itemID, item, err)
+	}
+
+	invalidItems := []*pb.CatalogItem{
+		{Name: "Duplicate SKU", Description: "Book", Sku: "BK-001"},
+		{Name: "Duplicate slug", Description: "Book", Slug: "the-hobbit"},
+		{Name: "Invalid SKU", Description: "Book", Sku: "BK 002"},
+		{Name: "Invalid slug", Description: "Book", Slug: "The Hobbit"},
+	}
+	for _, invalidItem := range invalidItems {
+		if _, err := repo.AddCatalogItem(invalidItem, "admin"); err == nil {
+			t.Errorf("Expected error for %v but got none", invalidItem.Name)
+		}
+	}
+
+	// Items without SKU don't conflict
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "No SKU", Description: "Book"}, "admin"); err != nil {
+		t.Errorf("Failed to add item without SKU: %v", err)
+	}
+}
+
+func TestUpdateCatalogItem(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// A typo in the name is fixed without changing the ID or the slug
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item123", Name: "Defaullt Item fixed", Sku: "DEF-1"}, 0); err != nil {
+		t.Fatalf("Failed to update item: %v", err)
+	}
+	item, err := repo.GetCatalogItem("item123")
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	if item.Name != "Defaullt Item fixed" || item.Sku != "DEF-1" || item.Slug != "default-item" || item.Description != "Default Item" {
+		t.Errorf("Unexpected item after update: %v", item)
+	}
+
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item123", Slug: "default-item-fixed"}, 0); err != nil {
+		t.Fatalf("Failed to update slug: %v", err)
+	}
+	if item, _ := repo.GetCatalogItemBySlug("default-item-fixed"); item == nil || item.ItemId != "item123" {
+		t.Errorf("Expected item123 by its new slug, got %v", item)
+	}
+
+	// SKU and slug of another item are rejected
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item456", Sku: "DEF-1"}, 0); err == nil {
+		t.Errorf("Expected error for a duplicate SKU but got none")
+	}
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item456", Slug: "default-item-fixed"}, 0); err == nil {
+		t.Errorf("Expected error for a duplicate slug but got none")
+	}
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "nonexistent", Name: "Name"}, 0); err == nil {
+		t.Errorf("Expected error for a nonexistent item but got none")
+	}
+
+	// The description is checked as when the item is added
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item456", Description: "   "}, 0); err == nil {
+		t.Errorf("Expected error for a blank description but got none")
+	}
+	if item, _ := repo.GetCatalogItem("item456"); item.Description != "Another Item" {
+		t.Errorf("Expected the description unchanged, got %q", item.Description)
+	}
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	db, repo := setupTest(t)
+	db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{})
+
+	// Two admins read the item at the same version
+	item, _ := repo.GetCatalogItem("item123")
+	if item.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", item.Version)
+	}
+
+	if err := repo.UpdatePrice("item123", 80, item.Version); err != nil {
+		t.Fatalf("Failed to update price: %v", err)
+	}
+
+	// The second one is rejected instead of overwriting the first
+	if err := repo.UpdateQuantityAvailable("item123", "", 3, item.Version, domain.MovementAdjustment, "admin"); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("Expected a version conflict, got %v", err)
+	}
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item123", Name: "Stale Name"}, item.Version); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("Expected a version conflict, got %v", err)
+	}
+	fresh, _ := repo.GetCatalogItem("item123")
+	if fresh.Version != 2 || fresh.Price != 80 || fresh.QuantityAvailable != 10 || fresh.Name != "Default Item" {
+		t.Fatalf("Expected only the price updated, got %v", fresh)
+	}
+
+	// Stock changes move the item to the next version too
+	repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 1}}, time.Minute, nil, "checkout")
+	if err := repo.UpdatePrice("item123", 70, fresh.Version); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("Expected a version conflict after a reservation, got %v", err)
+	}
+
+	// Updates without an expected version always apply
+	if err := repo.UpdateQuantityAvailable("item123", "", 3, 0, domain.MovementAdjustment, "admin"); err != nil {
+		t.Errorf("Failed to update quantity: %v", err)
+	}
+	if item, _ := repo.GetCatalogItem("item123"); item.Version != 4 || item.QuantityAvailable != 3 {
+		t.Errorf("Expected version 4 with quantity 3, got %v", item)
+	}
+}
+
+func TestGetCatalogItems(t *testing.T) {
+	_, repo := setupTest(t)
+
+	items, err := repo.GetCatalogItems([]string{"item456", "nonexistent", "item123"})
+	if err != nil {
+		t.Fatalf("Failed to get items: %v", err)
+	}
+	if len(items) != 2 || items[0].ItemId != "item123" || items[1].Name != "Another Item" {
+		t.Errorf("Unexpected items: %v", items)
+	}
+}
@@ -0,0 +1,273 @@
+package tests
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// setupCategories creates the tree Books > Fantasy, Books > Manga and Figures
+func setupCategories(t *testing.T, repo *repository.CatalogServiceRepository) map[string]string {
+	ids := make(map[string]string)
+	create := func(name, parent string) {
+		id, err := repo.CreateCategory(name, "", ids[parent])
+		if err != nil {
+			t.Fatalf("Failed to create category %s: %v", name, err)
+		}
+		ids[name] = id
+	}
+	create("Books", "")
+	create("Fantasy", "Books")
+	create("Manga", "Books")
+	create("Figures", "")
+	return ids
+}
+
+// categoryCounts returns the item count of each category by name
+func categoryCounts(t *testing.T, repo *repository.CatalogServiceRepository) map[string]uint32 {
+	categories, err := repo.ListCategories()
+	if err != nil {
+		t.Fatalf("Failed to list categories: %v", err)
+	}
+	counts := make(map[string]uint32)
+	for _, category := range categories {
+		counts[category.Name] = category.ItemCount
+	}
+	return counts
+}
+
+func TestCreateCategory(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	categories, err := repo.ListCategories()
+	if err != nil {
+		t.Fatalf("Failed to list categories: %v", err)
+	}
+	if len(categories) != 4 || categories[0].Name != "Books" || categories[0].Slug != "books" {
+		t.Fatalf("Expected 4 categories sorted by name, got %v", categories)
+	}
+	for _, category := range categories {
+		if category.Name == "Fantasy" && category.ParentId != ids["Books"] {
+			t.Errorf("Expected Fantasy under Books, got parent %q", category.ParentId)
+		}
+	}
+
+	// Slugs are unique, derived ones get a number
+	if _, err := repo.CreateCategory("Other", "books", ""); err == nil {
+		t.Errorf("Expected error for a duplicate slug but got none")
+	}
+	id, err := repo.CreateCategory("Books", "", ids["Figures"])
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+	categories, _ = repo.ListCategories()
+	for _, category := range categories {
+		if category.CategoryId == id && category.Slug != "books-2" {
+			t.Errorf("Expected slug books-2, got %q", category.Slug)
+		}
+	}
+
+	// The parent must exist
+	if _, err := repo.CreateCategory("Orphan", "", "missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing parent, got %v", err)
+	}
+	if _, err := repo.CreateCategory("  ", "", ""); err == nil {
+		t.Errorf("Expected error for an empty name but got none")
+	}
+}
+
+func TestUpdateAndMoveCategory(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	if err := repo.UpdateCategory(ids["Manga"], "Comics", ""); err != nil {
+		t.Fatalf("Failed to update category: %v", err)
+	}
+
+	// A category cannot be moved into its own subtree
+	if err := repo.MoveCategory(ids["Books"], ids["Fantasy"]); !errors.Is(err, repository.ErrCategoryCycle) {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+	if err := repo.MoveCategory(ids["Books"], ids["Books"]); !errors.Is(err, repository.ErrCategoryCycle) {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+
+	if err := repo.MoveCategory(ids["Manga"], ""); err != nil {
+		t.Fatalf("Failed to move category: %v", err)
+	}
+	categories, _ := repo.ListCategories()
+	for _, category := range categories {
+		if category.CategoryId == ids["Manga"] && (category.Name != "Comics" || category.Slug != "manga" || category.ParentId != "") {
+			t.Errorf("Expected Comics at the root keeping its slug, got %v", category)
+		}
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	// Categories with subcategories or items are kept
+	if err := repo.DeleteCategory(ids["Books"]); !errors.Is(err, repository.ErrCategoryNotEmpty) {
+		t.Errorf("Expected a not empty error, got %v", err)
+	}
+	if err := repo.SetItemCategory("item123", ids["Figures"]); err != nil {
+		t.Fatalf("Failed to set item category: %v", err)
+	}
+	if err := repo.DeleteCategory(ids["Figures"]); !errors.Is(err, repository.ErrCategoryNotEmpty) {
+		t.Errorf("Expected a not empty error, got %v", err)
+	}
+
+	if err := repo.DeleteCategory(ids["Fantasy"]); err != nil {
+		t.Fatalf("Failed to delete category: %v", err)
+	}
+	if err := repo.DeleteCategory(ids["Fantasy"]); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a deleted category, got %v", err)
+	}
+}
+
+func TestSetItemCategory(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	if err := repo.SetItemCategory("item123", ids["Fantasy"]); err != nil {
+		t.Fatalf("Failed to set item category: %v", err)
+	}
+	if err := repo.SetItemCategory("item456", ids["Manga"]); err != nil {
+		t.Fatalf("Failed to set item category: %v", err)
+	}
+
+	item, _ := repo.GetCatalogItem("item123")
+	if item.CategoryId != ids["Fantasy"] {
+		t.Errorf("Expected category Fantasy, got %q", item.CategoryId)
+	}
+
+	// Parents count the items of their subcategories
+	counts := categoryCounts(t, repo)
+	if counts["Books"] != 2 || counts["Fantasy"] != 1 || counts["Manga"] != 1 || counts["Figures"] != 0 {
+		t.Errorf("Unexpected item counts %v", counts)
+	}
+
+	// An empty category removes it
+	if err := repo.SetItemCategory("item456", ""); err != nil {
+		t.Fatalf("Failed to remove item category: %v", err)
+	}
+	if counts := categoryCounts(t, repo); counts["Books"] != 1 {
+		t.Errorf("Expected 1 item in Books, got %v", counts)
+	}
+
+	if err := repo.SetItemCategory("item123", "missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing category, got %v", err)
+	}
+	if err := repo.SetItemCategory("missing", ids["Books"]); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing item, got %v", err)
+	}
+}
+
+func TestSetItemTags(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if err := repo.SetItemTags("item123", []string{"Signed", "  first   edition ", "signed"}); err != nil {
+		t.Fatalf("Failed to set item tags: %v", err)
+	}
+	item, _ := repo.GetCatalogItem("item123")
+	if !slices.Equal(item.Tags, []string{"first edition", "signed"}) {
+		t.Errorf("Expected normalized tags, got %v", item.Tags)
+	}
+
+	// Tags are replaced
+	repo.SetItemTags("item456", []string{"signed"})
+	if err := repo.SetItemTags("item123", []string{"limited"}); err != nil {
+		t.Fatalf("Failed to set item tags: %v", err)
+	}
+	tags, err := repo.ListTags()
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	if len(tags) != 2 || tags[0].Tag != "limited" || tags[1].Tag != "signed" || tags[1].ItemCount != 1 {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+
+	if err := repo.SetItemTags("item123", []string{" "}); err == nil {
+		t.Errorf("Expected error for an empty tag but got none")
+	}
+	if err := repo.SetItemTags("missing", []string{"signed"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing item, got %v", err)
+	}
+
+	// Tags are removed with the item
+	repo.RemoveCatalogItem("item456")
+	if tags, _ := repo.ListTags(); len(tags) != 1 {
+		t.Errorf("Expected 1 tag left, got %v", tags)
+	}
+}
+
+func TestAddCatalogItemWithCategoryAndTags(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	itemID, err := repo.AddCatalogItem(&pb.CatalogItem{
+		Name: "The Hobbit", Description: "There and back again", QuantityAvailable: 4, Price: 20,
+		CategoryId: ids["Fantasy"], Tags: []string{"Tolkien"},
+	}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	item, _ := repo.GetCatalogItem(itemID)
+	if item.CategoryId != ids["Fantasy"] || !slices.Equal(item.Tags, []string{"tolkien"}) {
+		t.Errorf("Expected category and tags stored, got %v", item)
+	}
+
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{
+		Name: "Lost", Description: "No category", QuantityAvailable: 1, Price: 1, CategoryId: "missing",
+	}, "admin"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing category, got %v", err)
+	}
+}
+
+func TestListCatalogItemsByCategoryAndTag(t *testing.T) {
+	_, repo := setupTest(t)
+	ids := setupCategories(t, repo)
+
+	repo.SetItemCategory("item123", ids["Fantasy"])
+	repo.SetItemCategory("item456", ids["Manga"])
+	repo.SetItemTags("item456", []string{"deluxe"})
+
+	// A category includes its subcategories
+	items, _, err := repo.ListCatalogItems(domain.CatalogQuery{CategoryID: ids["Books"]})
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("Expected 2 items in Books, got %v", items)
+	}
+	items, _, _ = repo.ListCatalogItems(domain.CatalogQuery{CategoryID: ids["Fantasy"]})
+	if len(items) != 1 || items[0].ItemId != "item123" {
+		t.Errorf("Expected item123 in Fantasy, got %v", items)
+	}
+	items, _, _ = repo.ListCatalogItems(domain.CatalogQuery{CategoryID: ids["Figures"]})
+	if len(items) != 0 {
+		t.Errorf("Expected no items in Figures, got %v", items)
+	}
+
+	items, _, _ = repo.ListCatalogItems(domain.CatalogQuery{Tag: "Deluxe", CategoryID: ids["Books"]})
+	if len(items) != 1 || items[0].ItemId != "item456" || !slices.Equal(items[0].Tags, []string{"deluxe"}) {
+		t.Errorf("Expected item456 with its tag, got %v", items)
+	}
+
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{CategoryID: "missing"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected not found for a missing category, got %v", err)
+	}
+
+	// A page token is bound to the category
+	_, token, _ := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 1, CategoryID: ids["Books"]})
+	if _, _, err := repo.ListCatalogItems(domain.CatalogQuery{PageSize: 1, PageToken: token}); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Errorf("Expected an invalid page token, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"slices"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+// encodedImage returns a PNG, or a JPEG, image of the given size
+func encodedImage(t *testing.T, width, height int, asJPEG bool) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var data bytes.Buffer
+	var err error
+	if asJPEG {
+		err = jpeg.Encode(&data, img, nil)
+	} else {
+		err = png.Encode(&data, img)
+	}
+	if err != nil {
+		t.Fatalf("Failed to encode the image: %v", err)
+	}
+	return data.Bytes()
+}
+
+// itemImageIDs returns the IDs of the images of an item, in their order
+func itemImageIDs(t *testing.T, repo *repository.CatalogServiceRepository, itemID string) []string {
+	item, err := repo.GetCatalogItem(itemID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve item %s: %v", itemID, err)
+	}
+
+	ids := make([]string, len(item.Images))
+	for i, image := range item.Images {
+		ids[i] = image.ImageId
+	}
+	return ids
+}
+
+func TestAddItemImage(t *testing.T) {
+	_, repo := setupTest(t)
+	data := encodedImage(t, 800, 400, true)
+
+	added, err := repo.AddItemImage("item123", data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if added.ContentType != "image/jpeg" || added.Width != 800 || added.Height != 400 {
+		t.Fatalf("Expected an 800x400 JPEG image, got %v", added)
+	}
+
+	// The image is served as uploaded, the thumbnail is scaled down keeping the proportions
+	content, contentType, err := repo.GetImage(added.ImageId, false)
+	if err != nil || !bytes.Equal(content, data) || contentType != "image/jpeg" {
+		t.Fatalf("Expected the image as uploaded, got %s (%v)", contentType, err)
+	}
+	thumbnail, contentType, err := repo.GetImage(added.ImageId, true)
+	if err != nil || contentType != "image/jpeg" {
+		t.Fatalf("Expected a JPEG thumbnail, got %s (%v)", contentType, err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+	if err != nil || config.Width != 320 || config.Height != 160 {
+		t.Fatalf("Expected a 320x160 thumbnail, got %dx%d (%v)", config.Width, config.Height, err)
+	}
+
+	// The images of an item are in the order they were added
+	second, _ := repo.AddItemImage("item123", encodedImage(t, 10, 10, false))
+	item, _ := repo.GetCatalogItem("item123")
+	if len(item.Images) != 2 || item.Images[0].ImageId != added.ImageId || item.Images[1].ImageId != second.ImageId ||
+		item.Images[1].ContentType != "image/png" {
+		t.Fatalf("Expected the two images in order, got %v", item.Images)
+	}
+
+	if _, err := repo.AddItemImage("item123", []byte("not an image")); !errors.Is(err, media.ErrInvalidImage) {
+		t.Fatalf("Expected ErrInvalidImage, got %v", err)
+	}
+	if _, err := repo.AddItemImage("nonexistent", data); err == nil {
+		t.Fatalf("Expected error for a nonexistent item, got nil")
+	}
+	if _, _, err := repo.GetImage("nonexistent", false); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestReorderAndRemoveItemImages(t *testing.T) {
+	_, repo := setupTest(t)
+
+	var imageIDs []string
+	for range 3 {
+		added, err := repo.AddItemImage("item123", encodedImage(t, 10, 10, false))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		imageIDs = append(imageIDs, added.ImageId)
+	}
+
+	// The last image becomes the cover
+	reordered := []string{imageIDs[2], imageIDs[0], imageIDs[1]}
+	if err := repo.ReorderItemImages("item123", reordered); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ids := itemImageIDs(t, repo, "item123"); !slices.Equal(ids, reordered) {
+		t.Fatalf("Expected %v, got %v", reordered, ids)
+	}
+
+	if err := repo.ReorderItemImages("item123", reordered[:2]); !errors.Is(err, repository.ErrImageOrderMismatch) {
+		t.Fatalf("Expected ErrImageOrderMismatch for images missing, got %v", err)
+	}
+	if err := repo.ReorderItemImages("item123", []string{imageIDs[0], imageIDs[0], imageIDs[1]}); !errors.Is(err, repository.ErrImageOrderMismatch) {
+		t.Fatalf("Expected ErrImageOrderMismatch for an image given twice, got %v", err)
+	}
+
+	// The images after the one removed move up, its content is gone
+	if err := repo.RemoveItemImage("item123", imageIDs[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ids := itemImageIDs(t, repo, "item123"); !slices.Equal(ids, []string{imageIDs[2], imageIDs[1]}) {
+		t.Fatalf("Expected the two images left in order, got %v", ids)
+	}
+	if _, _, err := repo.GetImage(imageIDs[0], true); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+
+	// An image is removed only from its own item
+	if err := repo.RemoveItemImage("item456", imageIDs[1]); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestRemoveCatalogItemWithImages(t *testing.T) {
+	db, repo := setupTest(t)
+	added, _ := repo.AddItemImage("item123", encodedImage(t, 10, 10, false))
+
+	if err := repo.RemoveCatalogItem("item123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var count int64
+	db.Table("item_images").Count(&count)
+	if count != 0 {
+		t.Fatalf("Expected the images removed with the item, got %d", count)
+	}
+	if _, _, err := repo.GetImage(added.ImageId, false); err == nil {
+		t.Fatalf("Expected error for an image of a removed item, got nil")
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+// importCSV adds a new item, a variant of item123, and updates item456, with two wrong rows
+const importCSV = `name,description,price,quantity,item_id,sku,tags,product_id,attributes
+The Hobbit,There and back again,20,4,,HOB-1,fantasy;tolkien,,
+,,35.5,2,,DEF-HC,,item123,format=Hardcover
+,,45,,item456,,,,
+Broken,Negative price,-3,1,,,,,
+No Price,Missing price,,1,,,,,
+`
+
+func TestImportCatalogItemsCSV(t *testing.T) {
+	_, repo := setupTest(t)
+
+	report, err := repo.ImportCatalogItems(pb.CatalogFileFormat_CSV, strings.NewReader(importCSV), false, "admin")
+	if err != nil {
+		t.Fatalf("Failed to import items: %v", err)
+	}
+	if report.Created != 2 || report.Updated != 1 || report.Failed != 2 {
+		t.Fatalf("Expected 2 created, 1 updated and 2 failed, got %v", report)
+	}
+	if report.Errors[0].Row != 4 || report.Errors[0].Reference != "Broken" || report.Errors[1].Row != 5 {
+		t.Errorf("Unexpected errors %v", report.Errors)
+	}
+
+	hobbit, err := repo.GetCatalogItemBySKU("HOB-1")
+	if err != nil {
+		t.Fatalf("Failed to get imported item: %v", err)
+	}
+	if hobbit.Price != 20 || hobbit.QuantityAvailable != 4 || !slices.Equal(hobbit.Tags, []string{"fantasy", "tolkien"}) {
+		t.Errorf("Unexpected imported item %v", hobbit)
+	}
+
+	// Empty fields are left unchanged
+	item, _ := repo.GetCatalogItem("item456")
+	if item.Price != 45 || item.QuantityAvailable != 5 || item.Name != "Another Item" {
+		t.Errorf("Expected only the price updated, got %v", item)
+	}
+	product, _ := repo.GetCatalogItem("item123")
+	if len(product.Variants) != 1 || product.Price != 35.5 {
+		t.Errorf("Expected the variant added to item123, got %v", product)
+	}
+
+	// Imported items can be searched
+	if hits, _ := repo.SearchCatalog("hobbit", 10); len(hits) != 1 {
+		t.Errorf("Expected the imported item in the search index, got %v", hits)
+	}
+}
+
+func TestImportCatalogItemsDryRun(t *testing.T) {
+	db, repo := setupTest(t)
+
+	report, err := repo.ImportCatalogItems(pb.CatalogFileFormat_CSV, strings.NewReader(importCSV), true, "admin")
+	if err != nil {
+		t.Fatalf("Failed to import items: %v", err)
+	}
+	if !report.DryRun || report.Created != 2 || report.Updated != 1 || report.Failed != 2 {
+		t.Fatalf("Expected the same report of a real import, got %v", report)
+	}
+
+	// Nothing changed
+	var count int64
+	db.Model(&domain.CatalogItem{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected 2 items, got %d", count)
+	}
+	if item, _ := repo.GetCatalogItem("item456"); item.Price != 49.99 {
+		t.Errorf("Expected the price unchanged, got %v", item.Price)
+	}
+	if hits, _ := repo.SearchCatalog("hobbit", 10); len(hits) != 0 {
+		t.Errorf("Expected nothing indexed, got %v", hits)
+	}
+}
+
+func TestImportCatalogItemsJSON(t *testing.T) {
+	_, repo := setupTest(t)
+	repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item123", Sku: "DEF-1"}, 0)
+
+	data := `[
+		{"sku": "DEF-1", "name": "Renamed Item", "quantity": 3},
+		{"name": "Wrong", "description": "Wrong", "price": "free"},
+		{"name": "Unknown", "description": "Unknown", "price": 1, "colour": "red"}
+	]`
+	report, err := repo.ImportCatalogItems(pb.CatalogFileFormat_JSON, strings.NewReader(data), false, "admin")
+	if err != nil {
+		t.Fatalf("Failed to import items: %v", err)
+	}
+	if report.Updated != 1 || report.Failed != 2 {
+		t.Fatalf("Expected 1 updated and 2 failed, got %v", report)
+	}
+
+	// The item is found by its SKU
+	item, _ := repo.GetCatalogItem("item123")
+	if item.Name != "Renamed Item" || item.QuantityAvailable != 3 || item.Price != 99.99 {
+		t.Errorf("Unexpected updated item %v", item)
+	}
+}
+
+func TestImportInvalidFile(t *testing.T) {
+	_, repo := setupTest(t)
+
+	tests := []struct {
+		name   string
+		format pb.CatalogFileFormat
+		data   string
+	}{
+		{"unknown column", pb.CatalogFileFormat_CSV, "name,colour\nBook,red\n"},
+		{"empty CSV", pb.CatalogFileFormat_CSV, ""},
+		{"not an array", pb.CatalogFileFormat_JSON, `{"name": "Book"}`},
+		{"truncated JSON", pb.CatalogFileFormat_JSON, `[{"name": "Book"`},
+	}
+
+	for _, tt := range tests {
+		if _, err := repo.ImportCatalogItems(tt.format, strings.NewReader(tt.data), false, "admin"); !errors.Is(err, repository.ErrInvalidImportFile) {
+			t.Errorf("%s: expected an invalid file, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestExportImportCatalogItems(t *testing.T) {
+	_, repo := setupTest(t)
+	setupVariants(t, repo)
+	repo.SetItemTags("item456", []string{"deluxe", "signed"})
+
+	for _, format := range []pb.CatalogFileFormat{pb.CatalogFileFormat_CSV, pb.CatalogFileFormat_JSON} {
+		var exported bytes.Buffer
+		if err := repo.ExportCatalogItems(format, &exported); err != nil {
+			t.Fatalf("Failed to export items: %v", err)
+		}
+
+		// The file imported in an empty catalog gives back the same items
+		_, target := setupTest(t)
+		target.RemoveCatalogItem("item123")
+		target.RemoveCatalogItem("item456")
+		report, err := target.ImportCatalogItems(format, &exported, false, "admin")
+		if err != nil {
+			t.Fatalf("Failed to import exported items: %v", err)
+		}
+		if report.Created != 4 || report.Failed != 0 {
+			t.Fatalf("Expected 4 items created, got %v", report)
+		}
+
+		product, _ := target.GetCatalogItem("item123")
+		if product.Price != 80 || product.QuantityAvailable != 7 || len(product.Variants) != 2 || product.Variants[0].Attributes["format"] != "Hardcover" {
+			t.Errorf("Unexpected product %v", product)
+		}
+		item, _ := target.GetCatalogItem("item456")
+		if item.Slug != "another-item" || !slices.Equal(item.Tags, []string{"deluxe", "signed"}) {
+			t.Errorf("Unexpected item %v", item)
+		}
+
+		// Imported again, every item is updated and nothing changes
+		exported.Reset()
+		target.ExportCatalogItems(format, &exported)
+		report, _ = target.ImportCatalogItems(format, bytes.NewReader(exported.Bytes()), false, "admin")
+		if report.Updated != 4 || report.Failed != 0 {
+			t.Errorf("Expected 4 items updated, got %v", report)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func TestMigrateLegacyItemIDs(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{}, &domain.ItemIDMapping{}, &domain.AppliedMigration{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	// Items identified by their title, as before the generated IDs
+	db.Create(&domain.CatalogItem{ItemID: "The Lord of the Rings", Description: "A fantastic fantasy book", Price: 30, QuantityAvailable: 10})
+	db.Create(&domain.CatalogItem{ItemID: "Berserk", Description: "Best manga ever", Price: 53, QuantityAvailable: 25})
+
+	repo := repository.NewCatalogServiceRepository(db, setupTestImages(t))
+	if _, err := repo.OpenWarehouses(); err != nil {
+		t.Fatalf("Failed to open the warehouses: %v", err)
+	}
+	reservationID, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "Berserk", Quantity: 2}}, time.Minute, nil, "checkout")
+	if err != nil {
+		t.Fatalf("Failed to reserve stock: %v", err)
+	}
+
+	migrated, err := repo.MigrateLegacyItemIDs()
+	if err != nil || migrated != 2 {
+		t.Fatalf("Expected 2 items migrated, got %v (%v)", migrated, err)
+	}
+
+	itemIDs, err := repo.ResolveLegacyItemIDs([]string{"The Lord of the Rings", "Berserk", "Unknown"})
+	if err != nil || len(itemIDs) != 2 {
+		t.Fatalf("Expected 2 resolved IDs, got %v (%v)", itemIDs, err)
+	}
+
+	item, err := repo.GetCatalogItem(itemIDs["The Lord of the Rings"])
+	if err != nil {
+		t.Fatalf("Failed to get migrated item: %v", err)
+	}
+	if item.Name != "The Lord of the Rings" || item.Slug != "the-lord-of-the-rings" || item.QuantityAvailable != 10 {
+		t.Errorf("Unexpected migrated item: %v", item)
+	}
+
+	// The reservation follows the item
+	if err := repo.ReleaseReservation(reservationID, "checkout"); err != nil {
+		t.Fatalf("Failed to release reservation: %v", err)
+	}
+	item, _ = repo.GetCatalogItem(itemIDs["Berserk"])
+	if item.QuantityAvailable != 25 {
+		t.Errorf("Expected the stock back on the migrated item, got %v", item.QuantityAvailable)
+	}
+
+	// The search finds the migrated items by their name
+	if err := repo.BuildSearchIndex(); err != nil {
+		t.Fatalf("Failed to build search index: %v", err)
+	}
+	hits, _ := repo.SearchCatalog("berserk", 0)
+	if len(hits) != 1 || hits[0].Item.ItemId != itemIDs["Berserk"] {
+		t.Errorf("Expected the migrated item, got %v", hitNames(hits))
+	}
+
+	// Running it again has no effect, even on the items added afterwards with an ID that is not a ULID
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{ItemId: "Vinland Saga", Name: "Vinland Saga", Description: "Vikings", Price: 12}, "admin"); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	if migrated, err := repo.MigrateLegacyItemIDs(); err != nil || migrated != 0 {
+		t.Errorf("Expected nothing to migrate, got %v (%v)", migrated, err)
+	}
+	if _, err := repo.GetCatalogItem("Vinland Saga"); err != nil {
+		t.Errorf("Expected the item added to keep its ID, got %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func priceOf(t *testing.T, db *gorm.DB, itemID string) float64 {
+	var item domain.CatalogItem
+	if err := db.First(&item, "item_id = ?", itemID).Error; err != nil {
+		t.Fatalf("Failed to retrieve item: %v", err)
+	}
+	return item.Price
+}
+
+func scheduleStatusOf(t *testing.T, db *gorm.DB, scheduleID string) domain.ScheduledPriceStatus {
+	var schedule domain.ScheduledPrice
+	if err := db.First(&schedule, "schedule_id = ?", scheduleID).Error; err != nil {
+		t.Fatalf("Failed to retrieve scheduled price: %v", err)
+	}
+	return schedule.Status
+}
+
+func TestPriceHistory(t *testing.T) {
+	_, repo := setupTest(t)
+
+	itemID, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Priced Item", Description: "Priced Item", QuantityAvailable: 1, Price: 10}, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	repo.UpdatePrice(itemID, 12, 0)
+	repo.UpdatePrice(itemID, 12, 0)
+	repo.UpdatePrice(itemID, 9.5, 0)
+
+	changes, err := repo.GetPriceHistory(itemID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The same price set again is not a change
+	expected := []struct {
+		price  float64
+		reason pb.PriceChangeReason
+	}{
+		{9.5, pb.PriceChangeReason_PRICE_UPDATED},
+		{12, pb.PriceChangeReason_PRICE_UPDATED},
+		{10, pb.PriceChangeReason_PRICE_INITIAL},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d price changes, got %v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if change.Price != expected[i].price || change.Reason != expected[i].reason || change.ChangedAt == 0 {
+			t.Fatalf("Expected change %d to be %v, got %v", i, expected[i], change)
+		}
+	}
+
+	if _, err := repo.GetPriceHistory("nonexistent"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+
+	// The history is removed with the item
+	repo.RemoveCatalogItem(itemID)
+	if _, err := repo.GetPriceHistory(itemID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound after removal, got %v", err)
+	}
+}
+
+func TestScheduledSale(t *testing.T) {
+	db, repo := setupTest(t)
+	now := time.Now()
+
+	scheduleID, err := repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	watcher, cancel := repo.WatchCatalog()
+	defer cancel()
+
+	// Not started yet
+	if changed, err := repo.ApplyScheduledPrices(now); err != nil || changed != 0 {
+		t.Fatalf("Expected no price changed, got %d (%v)", changed, err)
+	}
+
+	changed, err := repo.ApplyScheduledPrices(now.Add(90 * time.Minute))
+	if err != nil || changed != 1 {
+		t.Fatalf("Expected 1 price changed, got %d (%v)", changed, err)
+	}
+	if p := priceOf(t, db, "item123"); p != 50 {
+		t.Fatalf("Expected the sale price 50, got %v", p)
+	}
+	if s := scheduleStatusOf(t, db, scheduleID); s != domain.ScheduleActive {
+		t.Fatalf("Expected status ACTIVE, got %v", s)
+	}
+	received := receivedEvents(watcher)
+	if len(received) != 1 || received[0].Type != pb.CatalogEventType_PRICE_CHANGED || received[0].Item.Price != 50 {
+		t.Fatalf("Expected the price change to be announced, got %v", received)
+	}
+
+	changed, err = repo.ApplyScheduledPrices(now.Add(3 * time.Hour))
+	if err != nil || changed != 1 {
+		t.Fatalf("Expected 1 price changed, got %d (%v)", changed, err)
+	}
+	if p := priceOf(t, db, "item123"); p != 99.99 {
+		t.Fatalf("Expected the price before the sale 99.99, got %v", p)
+	}
+	if s := scheduleStatusOf(t, db, scheduleID); s != domain.ScheduleDone {
+		t.Fatalf("Expected status DONE, got %v", s)
+	}
+
+	changes, _ := repo.GetPriceHistory("item123")
+	if len(changes) != 2 || changes[0].Reason != pb.PriceChangeReason_PRICE_RESTORED || changes[1].Reason != pb.PriceChangeReason_PRICE_SCHEDULED ||
+		changes[0].ScheduleId != scheduleID || changes[1].ScheduleId != scheduleID {
+		t.Fatalf("Expected the start and the end of the sale in the history, got %v", changes)
+	}
+}
+
+func TestScheduledPermanentChange(t *testing.T) {
+	db, repo := setupTest(t)
+	now := time.Now()
+
+	scheduleID, _ := repo.SchedulePriceChange("item456", 59.99, now.Add(time.Hour), time.Time{})
+
+	if changed, err := repo.ApplyScheduledPrices(now.Add(2 * time.Hour)); err != nil || changed != 1 {
+		t.Fatalf("Expected 1 price changed, got %d (%v)", changed, err)
+	}
+	if p := priceOf(t, db, "item456"); p != 59.99 {
+		t.Fatalf("Expected price 59.99, got %v", p)
+	}
+	if s := scheduleStatusOf(t, db, scheduleID); s != domain.ScheduleDone {
+		t.Fatalf("Expected status DONE, got %v", s)
+	}
+
+	// Nothing left to apply
+	if changed, err := repo.ApplyScheduledPrices(now.Add(10 * time.Hour)); err != nil || changed != 0 {
+		t.Fatalf("Expected no price changed, got %d (%v)", changed, err)
+	}
+}
+
+func TestConsecutiveSales(t *testing.T) {
+	db, repo := setupTest(t)
+	now := time.Now()
+
+	repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(2*time.Hour))
+	second, err := repo.SchedulePriceChange("item123", 40, now.Add(2*time.Hour), now.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error for a sale starting when the other ends, got %v", err)
+	}
+
+	// The first sale starts and ends in the same run, before the second starts
+	repo.ApplyScheduledPrices(now.Add(90 * time.Minute))
+	repo.ApplyScheduledPrices(now.Add(150 * time.Minute))
+	if p := priceOf(t, db, "item123"); p != 40 {
+		t.Fatalf("Expected the price of the second sale 40, got %v", p)
+	}
+
+	repo.ApplyScheduledPrices(now.Add(4 * time.Hour))
+	if p := priceOf(t, db, "item123"); p != 99.99 {
+		t.Fatalf("Expected the price before the sales 99.99, got %v", p)
+	}
+	if s := scheduleStatusOf(t, db, second); s != domain.ScheduleDone {
+		t.Fatalf("Expected status DONE, got %v", s)
+	}
+}
+
+func TestSaleKeepsPriceChangedDuringIt(t *testing.T) {
+	db, repo := setupTest(t)
+	now := time.Now()
+
+	repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(2*time.Hour))
+	repo.ApplyScheduledPrices(now.Add(90 * time.Minute))
+	repo.UpdatePrice("item123", 70, 0)
+
+	if changed, err := repo.ApplyScheduledPrices(now.Add(3 * time.Hour)); err != nil || changed != 0 {
+		t.Fatalf("Expected no price changed, got %d (%v)", changed, err)
+	}
+	if p := priceOf(t, db, "item123"); p != 70 {
+		t.Fatalf("Expected the price set during the sale 70, got %v", p)
+	}
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	db, repo := setupTest(t)
+	now := time.Now()
+
+	pending, _ := repo.SchedulePriceChange("item456", 30, now.Add(time.Hour), time.Time{})
+	if err := repo.CancelScheduledPrice(pending); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.CancelScheduledPrice(pending); !errors.Is(err, repository.ErrScheduleClosed) {
+		t.Fatalf("Expected ErrScheduleClosed, got %v", err)
+	}
+
+	// A sale in progress is ended
+	active, _ := repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(2*time.Hour))
+	repo.ApplyScheduledPrices(now.Add(90 * time.Minute))
+	if err := repo.CancelScheduledPrice(active); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p := priceOf(t, db, "item123"); p != 99.99 {
+		t.Fatalf("Expected the price before the sale 99.99, got %v", p)
+	}
+
+	schedules, err := repo.ListScheduledPrices("item123")
+	if err != nil || len(schedules) != 0 {
+		t.Fatalf("Expected no scheduled price left, got %v (%v)", schedules, err)
+	}
+	if changed, _ := repo.ApplyScheduledPrices(now.Add(10 * time.Hour)); changed != 0 {
+		t.Fatalf("Expected the canceled prices not to be applied, got %d changes", changed)
+	}
+	if p := priceOf(t, db, "item456"); p != 49.99 {
+		t.Fatalf("Expected price 49.99, got %v", p)
+	}
+
+	if err := repo.CancelScheduledPrice("nonexistent"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestListScheduledPrices(t *testing.T) {
+	_, repo := setupTest(t)
+	now := time.Now()
+
+	later, _ := repo.SchedulePriceChange("item123", 80, now.Add(5*time.Hour), time.Time{})
+	sooner, _ := repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(2*time.Hour))
+
+	schedules, err := repo.ListScheduledPrices("item123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(schedules) != 2 || schedules[0].ScheduleId != sooner || schedules[1].ScheduleId != later {
+		t.Fatalf("Expected the scheduled prices in the order they start, got %v", schedules)
+	}
+	if schedules[0].EndsAt != now.Add(2*time.Hour).Unix() || schedules[1].EndsAt != 0 || schedules[0].Status != pb.ScheduledPriceStatus_SCHEDULE_PENDING {
+		t.Fatalf("Unexpected scheduled prices %v", schedules)
+	}
+}
+
+func TestSchedulePriceChangeInvalidInputs(t *testing.T) {
+	_, repo := setupTest(t)
+	now := time.Now()
+
+	if _, err := repo.SchedulePriceChange("item123", 50, now.Add(-time.Hour), time.Time{}); err == nil {
+		t.Fatalf("Expected error for a start in the past, got nil")
+	}
+	if _, err := repo.SchedulePriceChange("item123", 50, now.Add(2*time.Hour), now.Add(time.Hour)); err == nil {
+		t.Fatalf("Expected error for an end before the start, got nil")
+	}
+	if _, err := repo.SchedulePriceChange("item123", -1, now.Add(time.Hour), time.Time{}); err == nil {
+		t.Fatalf("Expected error for a negative price, got nil")
+	}
+	if _, err := repo.SchedulePriceChange("nonexistent", 50, now.Add(time.Hour), time.Time{}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+
+	// Overlapping periods of the same item
+	repo.SchedulePriceChange("item123", 50, now.Add(time.Hour), now.Add(3*time.Hour))
+	if _, err := repo.SchedulePriceChange("item123", 40, now.Add(2*time.Hour), now.Add(4*time.Hour)); !errors.Is(err, repository.ErrScheduleOverlap) {
+		t.Fatalf("Expected ErrScheduleOverlap for an overlapping sale, got %v", err)
+	}
+	if _, err := repo.SchedulePriceChange("item123", 40, now.Add(2*time.Hour), time.Time{}); !errors.Is(err, repository.ErrScheduleOverlap) {
+		t.Fatalf("Expected ErrScheduleOverlap for a change during a sale, got %v", err)
+	}
+	if _, err := repo.SchedulePriceChange("item456", 40, now.Add(2*time.Hour), time.Time{}); err != nil {
+		t.Fatalf("Expected no error for another item, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func setupReservationTest(t *testing.T) (*gorm.DB, *repository.CatalogServiceRepository) {
+	db, repo := setupTest(t)
+
+	if err := db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{}, &domain.Restock{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db, repo
+}
+
+func quantityOf(t *testing.T, db *gorm.DB, itemID string) uint32 {
+	var item domain.CatalogItem
+	if err := db.First(&item, "item_id = ?", itemID).Error; err != nil {
+		t.Fatalf("Failed to retrieve item: %v", err)
+	}
+	return item.QuantityAvailable
+}
+
+func statusOf(t *testing.T, db *gorm.DB, reservationID string) domain.ReservationStatus {
+	var reservation domain.Reservation
+	if err := db.First(&reservation, "reservation_id = ?", reservationID).Error; err != nil {
+		t.Fatalf("Failed to retrieve reservation: %v", err)
+	}
+	return reservation.Status
+}
+
+func TestReserveStock(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	reservationID, expiresAt, _, err := repo.ReserveStock([]*pb.StockItem{
+		{ItemId: "item123", Quantity: 3},
+		{ItemId: "item456", Quantity: 5},
+	}, time.Minute, nil, "checkout")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reservationID == "" {
+		t.Fatalf("Expected a reservation ID")
+	}
+	if time.Until(expiresAt) > time.Minute || time.Until(expiresAt) <= 0 {
+		t.Fatalf("Unexpected expiration %v", expiresAt)
+	}
+
+	if q := quantityOf(t, db, "item123"); q != 7 {
+		t.Fatalf("Expected 7 units of item123, got %d", q)
+	}
+	if q := quantityOf(t, db, "item456"); q != 0 {
+		t.Fatalf("Expected 0 units of item456, got %d", q)
+	}
+	if s := statusOf(t, db, reservationID); s != domain.Reserved {
+		t.Fatalf("Expected status RESERVED, got %v", s)
+	}
+}
+
+func TestReserveStockIsAllOrNothing(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	// item456 has only 5 units, item123 must not be decremented
+	_, _, _, err := repo.ReserveStock([]*pb.StockItem{
+		{ItemId: "item123", Quantity: 3},
+		{ItemId: "item456", Quantity: 6},
+	}, time.Minute, nil, "checkout")
+	if !errors.Is(err, repository.ErrInsufficientStock) {
+		t.Fatalf("Expected ErrInsufficientStock, got %v", err)
+	}
+
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+}
+
+func TestReserveStockInvalidInputs(t *testing.T) {
+	_, repo := setupReservationTest(t)
+
+	if _, _, _, err := repo.ReserveStock(nil, time.Minute, nil, "checkout"); err == nil {
+		t.Fatalf("Expected error for empty reservation, got nil")
+	}
+	if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 0}}, time.Minute, nil, "checkout"); err == nil {
+		t.Fatalf("Expected error for zero quantity, got nil")
+	}
+	if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 1}, {ItemId: "item123", Quantity: 1}}, time.Minute, nil, "checkout"); err == nil {
+		t.Fatalf("Expected error for duplicated item, got nil")
+	}
+	if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "nonexistent", Quantity: 1}}, time.Minute, nil, "checkout"); err == nil {
+		t.Fatalf("Expected error for nonexistent item, got nil")
+	}
+	if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 1}}, 2*repository.MaxReservationTTL, nil, "checkout"); err == nil {
+		t.Fatalf("Expected error for ttl too long, got nil")
+	}
+}
+
+func TestConcurrentReservationsDoNotOversell(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	// A single connection shares the in-memory database between the goroutines
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item456", Quantity: 1}}, time.Minute, nil, "checkout"); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 5 {
+		t.Fatalf("Expected 5 reservations to succeed, got %d", succeeded)
+	}
+	if q := quantityOf(t, db, "item456"); q != 0 {
+		t.Fatalf("Expected 0 units of item456, got %d", q)
+	}
+}
+
+func TestCommitReservation(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	reservationID, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 2}}, time.Minute, nil, "checkout")
+
+	if err := repo.CommitReservation(reservationID, ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.CommitReservation(reservationID, ""); err != nil {
+		t.Fatalf("Expected second commit to have no effect, got %v", err)
+	}
+	if s := statusOf(t, db, reservationID); s != domain.Committed {
+		t.Fatalf("Expected status COMMITTED, got %v", s)
+	}
+
+	// Committed stock is not given back
+	if err := repo.ReleaseReservation(reservationID, "checkout"); err == nil {
+		t.Fatalf("Expected error when releasing a committed reservation, got nil")
+	}
+	if q := quantityOf(t, db, "item123"); q != 8 {
+		t.Fatalf("Expected 8 units of item123, got %d", q)
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	reservationID, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 2}}, time.Minute, nil, "checkout")
+
+	if err := repo.ReleaseReservation(reservationID, "checkout"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.ReleaseReservation(reservationID, "checkout"); err != nil {
+		t.Fatalf("Expected second release to have no effect, got %v", err)
+	}
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+
+	if err := repo.CommitReservation(reservationID, ""); !errors.Is(err, repository.ErrReservationClosed) {
+		t.Fatalf("Expected ErrReservationClosed, got %v", err)
+	}
+}
+
+func TestCommitExpiredReservation(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	reservationID, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 2}}, time.Minute, nil, "checkout")
+	db.Model(&domain.Reservation{}).Where("reservation_id = ?", reservationID).Update("expires_at", time.Now().Add(-time.Second))
+
+	// Expired before the sweeper ran: the commit is refused and the stock is back
+	if err := repo.CommitReservation(reservationID, ""); !errors.Is(err, repository.ErrReservationClosed) {
+		t.Fatalf("Expected ErrReservationClosed, got %v", err)
+	}
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+	if s := statusOf(t, db, reservationID); s != domain.Expired {
+		t.Fatalf("Expected reservation EXPIRED, got %v", s)
+	}
+
+	// The sweeper finds nothing left to give back
+	if expired, err := repo.ExpireReservations(time.Now()); err != nil || expired != 0 {
+		t.Fatalf("Expected no reservation to expire, got %d (%v)", expired, err)
+	}
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+}
+
+func TestReleaseNonExistingReservation(t *testing.T) {
+	_, repo := setupReservationTest(t)
+
+	if err := repo.ReleaseReservation("nonexistent", "checkout"); err == nil {
+		t.Fatalf("Expected error for nonexistent reservation, got nil")
+	}
+}
+
+func TestExpireReservations(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	expiring, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 2}}, time.Minute, nil, "checkout")
+	committed, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 3}}, time.Minute, nil, "checkout")
+	repo.CommitReservation(committed, "")
+
+	// Nothing expired yet
+	if expired, err := repo.ExpireReservations(time.Now()); err != nil || expired != 0 {
+		t.Fatalf("Expected no expired reservation, got %d (%v)", expired, err)
+	}
+
+	expired, err := repo.ExpireReservations(time.Now().Add(2 * time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("Expected 1 expired reservation, got %d", expired)
+	}
+	if s := statusOf(t, db, expiring); s != domain.Expired {
+		t.Fatalf("Expected status EXPIRED, got %v", s)
+	}
+	if q := quantityOf(t, db, "item123"); q != 7 {
+		t.Fatalf("Expected 7 units of item123, got %d", q)
+	}
+
+	if err := repo.CommitReservation(expiring, ""); !errors.Is(err, repository.ErrReservationClosed) {
+		t.Fatalf("Expected ErrReservationClosed, got %v", err)
+	}
+}
+
+func TestRestockItems(t *testing.T) {
+	db, repo := setupReservationTest(t)
+
+	items := []*pb.StockItem{
+		{ItemId: "item123", Quantity: 2},
+		{ItemId: "item456", Quantity: 1},
+		{ItemId: "removed", Quantity: 4},
+	}
+	if err := repo.RestockItems("order1", "order1", items, "order-service"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Retrying the same restock has no effect
+	if err := repo.RestockItems("order1", "order1", items, "order-service"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if q := quantityOf(t, db, "item123"); q != 12 {
+		t.Fatalf("Expected 12 units of item123, got %d", q)
+	}
+	if q := quantityOf(t, db, "item456"); q != 6 {
+		t.Fatalf("Expected 6 units of item456, got %d", q)
+	}
+}
+
+func TestRestockItemsInvalidInputs(t *testing.T) {
+	_, repo := setupReservationTest(t)
+
+	if err := repo.RestockItems("", "order1", []*pb.StockItem{{ItemId: "item123", Quantity: 1}}, "order-service"); err == nil {
+		t.Fatalf("Expected error for empty restock ID, got nil")
+	}
+	if err := repo.RestockItems("order1", "order1", nil, "order-service"); err == nil {
+		t.Fatalf("Expected error for empty items, got nil")
+	}
+}
@@ -0,0 +1,251 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// fakeOrders returns the same orders for every customer
+type fakeOrders struct {
+	pbOrder.OrderServiceClient
+	orders []*pbOrder.Order
+}
+
+func (o *fakeOrders) ListOrdersByUser(ctx context.Context, req *pbOrder.ListOrdersByUserRequest, opts ...grpc.CallOption) (*pbOrder.ListOrdersByUserResponse, error) {
+	return &pbOrder.ListOrdersByUserResponse{Orders: o.orders}, nil
+}
+
+// submitReviewAs calls SubmitReview through the authorizer, as the given customer
+func submitReviewAs(t *testing.T, server *internal.CatalogServer, username string, req *pb.SubmitReviewRequest) error {
+	tokens := token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+	raw, _, err := tokens.Issue(username, interceptor.RoleUser, token.Access)
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+raw))
+	info := &grpc.UnaryServerInfo{FullMethod: pb.CatalogService_SubmitReview_FullMethodName}
+	_, err = interceptor.NewAuthorizer(tokens, internal.AuthPolicy).Unary()(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return server.SubmitReview(ctx, req.(*pb.SubmitReviewRequest))
+	})
+	return err
+}
+
+// approvedReview submits the review of an item by a customer who bought it and approves it
+func approvedReview(t *testing.T, repo *repository.CatalogServiceRepository, itemID, username string, rating uint32) *pb.Review {
+	review, err := repo.SubmitReview(itemID, username, rating, "Review of "+itemID, []string{itemID})
+	if err != nil {
+		t.Fatalf("Failed to submit review: %v", err)
+	}
+	if err := repo.ModerateReview(review.ReviewId, true); err != nil {
+		t.Fatalf("Failed to approve review: %v", err)
+	}
+	return review
+}
+
+func TestSubmitReview(t *testing.T) {
+	_, repo := setupTest(t)
+
+	review, err := repo.SubmitReview("item123", "alice", 4, "  Good value  ", []string{"item456", "item123"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if review.ItemId != "item123" || review.Username != "alice" || review.Rating != 4 || review.Text != "Good value" ||
+		review.Status != pb.ReviewStatus_REVIEW_PENDING {
+		t.Fatalf("Expected a pending review of item123 by alice, got %v", review)
+	}
+
+	// A review waiting for moderation is not shown and does not count in the rating
+	if reviews, _ := repo.ListReviews("item123"); len(reviews) != 0 {
+		t.Fatalf("Expected no approved reviews, got %v", reviews)
+	}
+	if pending, _ := repo.ListPendingReviews(); len(pending) != 1 || pending[0].ReviewId != review.ReviewId {
+		t.Fatalf("Expected the review pending, got %v", pending)
+	}
+	if item, _ := repo.GetCatalogItem("item123"); item.RatingCount != 0 {
+		t.Fatalf("Expected no rating, got %v (%d)", item.RatingAverage, item.RatingCount)
+	}
+}
+
+func TestSubmitReviewInvalid(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.SubmitReview("item123", "alice", 4, "Good value", []string{"item456"}); !errors.Is(err, repository.ErrNotPurchased) {
+		t.Fatalf("Expected ErrNotPurchased, got %v", err)
+	}
+	if _, err := repo.SubmitReview("item123", "alice", 0, "Good value", []string{"item123"}); !errors.Is(err, repository.ErrInvalidReview) {
+		t.Fatalf("Expected ErrInvalidReview for no stars, got %v", err)
+	}
+	if _, err := repo.SubmitReview("item123", "alice", 6, "Good value", []string{"item123"}); !errors.Is(err, repository.ErrInvalidReview) {
+		t.Fatalf("Expected ErrInvalidReview for six stars, got %v", err)
+	}
+	if _, err := repo.SubmitReview("item123", "alice", 4, "   ", []string{"item123"}); !errors.Is(err, repository.ErrInvalidReview) {
+		t.Fatalf("Expected ErrInvalidReview for an empty text, got %v", err)
+	}
+	if _, err := repo.SubmitReview("nonexistent", "alice", 4, "Good value", []string{"nonexistent"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestSubmitReviewOfVariant(t *testing.T) {
+	_, repo := setupTest(t)
+	hardcover, paperback := setupVariants(t, repo)
+
+	// Buying a variant is buying the product, the review is the one of the product
+	review, err := repo.SubmitReview(paperback, "alice", 5, "Nice print", []string{hardcover})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if review.ItemId != "item123" {
+		t.Fatalf("Expected the review of the product, got %v", review.ItemId)
+	}
+
+	if _, err := repo.SubmitReview("item123", "bob", 5, "Nice print", []string{"item456"}); !errors.Is(err, repository.ErrNotPurchased) {
+		t.Fatalf("Expected ErrNotPurchased, got %v", err)
+	}
+}
+
+func TestSubmitReviewOnlyForPaidOrders(t *testing.T) {
+	_, repo := setupTest(t)
+	orders := &fakeOrders{}
+	server := internal.NewCatalogServer(repo, orders)
+	req := &pb.SubmitReviewRequest{ItemId: "item123", Rating: 5, Text: "Good value"}
+
+	// Orders not paid yet or canceled do not verify the purchase
+	for _, orderStatus := range []pbOrder.OrderStatus{pbOrder.OrderStatus_PENDING, pbOrder.OrderStatus_CANCELED} {
+		orders.orders = []*pbOrder.Order{{OrderId: "order1", Status: orderStatus, Items: []*pbOrder.OrderItem{{ItemId: "item123"}}}}
+		if err := submitReviewAs(t, server, "alice", req); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("Expected FailedPrecondition with a %v order, got %v", orderStatus, err)
+		}
+	}
+
+	for _, orderStatus := range []pbOrder.OrderStatus{pbOrder.OrderStatus_PROCESSING, pbOrder.OrderStatus_SHIPPED, pbOrder.OrderStatus_DELIVERED} {
+		orders.orders = []*pbOrder.Order{{OrderId: "order1", Status: orderStatus, Items: []*pbOrder.OrderItem{{ItemId: "item123"}}}}
+		if err := submitReviewAs(t, server, "alice", req); err != nil {
+			t.Fatalf("Expected no error with a %v order, got %v", orderStatus, err)
+		}
+	}
+}
+
+func TestModerateReview(t *testing.T) {
+	_, repo := setupTest(t)
+
+	approvedReview(t, repo, "item123", "alice", 5)
+	approvedReview(t, repo, "item123", "bob", 2)
+	rejected, _ := repo.SubmitReview("item123", "carol", 1, "Never arrived", []string{"item123"})
+	if err := repo.ModerateReview(rejected.ReviewId, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Only the approved reviews are shown and counted
+	item, _ := repo.GetCatalogItem("item123")
+	if item.RatingAverage != 3.5 || item.RatingCount != 2 {
+		t.Fatalf("Expected a rating of 3.5 from 2 reviews, got %v from %d", item.RatingAverage, item.RatingCount)
+	}
+	reviews, _ := repo.ListReviews("item123")
+	if len(reviews) != 2 || slices.ContainsFunc(reviews, func(r *pb.Review) bool { return r.Username == "carol" }) {
+		t.Fatalf("Expected the two approved reviews, got %v", reviews)
+	}
+	if pending, _ := repo.ListPendingReviews(); len(pending) != 0 {
+		t.Fatalf("Expected no pending reviews, got %v", pending)
+	}
+
+	if err := repo.ModerateReview("nonexistent", true); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestResubmitReview(t *testing.T) {
+	_, repo := setupTest(t)
+
+	first := approvedReview(t, repo, "item123", "alice", 5)
+	approvedReview(t, repo, "item123", "bob", 3)
+
+	// The review rewritten replaces the previous one and waits for moderation again
+	second, err := repo.SubmitReview("item123", "alice", 1, "Broke after a week", []string{"item123"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if second.ReviewId != first.ReviewId || second.Status != pb.ReviewStatus_REVIEW_PENDING {
+		t.Fatalf("Expected the same review pending, got %v", second)
+	}
+	item, _ := repo.GetCatalogItem("item123")
+	if item.RatingAverage != 3 || item.RatingCount != 1 {
+		t.Fatalf("Expected a rating of 3 from 1 review, got %v from %d", item.RatingAverage, item.RatingCount)
+	}
+
+	if err := repo.ModerateReview(second.ReviewId, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	item, _ = repo.GetCatalogItem("item123")
+	if item.RatingAverage != 2 || item.RatingCount != 2 {
+		t.Fatalf("Expected a rating of 2 from 2 reviews, got %v from %d", item.RatingAverage, item.RatingCount)
+	}
+}
+
+func TestUpdateCatalogItemKeepsRating(t *testing.T) {
+	_, repo := setupTest(t)
+	approvedReview(t, repo, "item123", "alice", 4)
+
+	// The update of the details never overwrites the rating kept by the reviews
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item123", Name: "Renamed item"}, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	item, _ := repo.GetCatalogItem("item123")
+	if item.Name != "Renamed item" || item.RatingAverage != 4 || item.RatingCount != 1 {
+		t.Fatalf("Expected the item renamed with its rating, got %v (%v from %d)", item.Name, item.RatingAverage, item.RatingCount)
+	}
+}
+
+func TestRemoveCatalogItemWithReviews(t *testing.T) {
+	db, repo := setupTest(t)
+	approvedReview(t, repo, "item123", "alice", 4)
+
+	if err := repo.RemoveCatalogItem("item123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var count int64
+	db.Model(&domain.Review{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("Expected the reviews removed with the item, got %d", count)
+	}
+}
+
+func TestListCatalogItemsSortByRating(t *testing.T) {
+	_, repo := setupTest(t)
+	addListingItems(t, repo, map[string]float64{"a": 10, "b": 10, "c": 10, "d": 10}, []string{"a", "b", "c", "d"})
+
+	approvedReview(t, repo, "item456", "alice", 5)
+	for _, id := range []string{"a", "b"} {
+		approvedReview(t, repo, id, "alice", 4)
+		approvedReview(t, repo, id, "bob", 5)
+	}
+	approvedReview(t, repo, "d", "alice", 5)
+	approvedReview(t, repo, "d", "bob", 4)
+	approvedReview(t, repo, "d", "carol", 4)
+	approvedReview(t, repo, "item123", "alice", 3)
+
+	// The best rated first, the most reviewed among the same rating, pages of one item cross every tie
+	ids, _ := listAll(t, repo, domain.CatalogQuery{PageSize: 1, Sort: pb.CatalogSort_RATING})
+	want := []string{"item456", "b", "a", "d", "item123", "c"}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("Expected %v, got %v", want, ids)
+	}
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/search"
+)
+
+func setupSearchTest(t *testing.T) *repository.CatalogServiceRepository {
+	db := setupTestDB(t)
+	repo := repository.NewCatalogServiceRepository(db, setupTestImages(t))
+
+	if err := repo.CreateDefaultItems(); err != nil {
+		t.Fatalf("Failed to create default items: %v", err)
+	}
+	return repo
+}
+
+// hitNames returns the names of the items of the hits, in order
+func hitNames(hits []*pb.SearchHit) []string {
+	names := make([]string, len(hits))
+	for i, hit := range hits {
+		names[i] = hit.Item.Name
+	}
+	return names
+}
+
+func TestSearchCatalog(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	hits, err := repo.SearchCatalog("manga", 0)
+	if err != nil {
+		t.Fatalf("Failed to search catalog: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Item.Name != "Berserk Deluxe Edition Vol.1" {
+		t.Fatalf("Expected Berserk, got %v", hitNames(hits))
+	}
+	if hits[0].Item.Price != 53.00 || hits[0].Item.QuantityAvailable != 25 {
+		t.Errorf("Expected the item from the database, got %v", hits[0].Item)
+	}
+	if hits[0].Score <= 0 {
+		t.Errorf("Expected a positive score, got %v", hits[0].Score)
+	}
+}
+
+func TestSearchCatalogRanking(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	// Both deluxe editions match, the one matching both words comes first
+	hits, err := repo.SearchCatalog("deluxe berserk", 0)
+	if err != nil {
+		t.Fatalf("Failed to search catalog: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Item.Name != "Berserk Deluxe Edition Vol.1" {
+		t.Fatalf("Expected Berserk first out of 2 hits, got %v", hitNames(hits))
+	}
+
+	// Words of the item ID weigh more than the ones of the description
+	items := []*pb.CatalogItem{
+		{Name: "Dragon Figure", Description: "A statue", QuantityAvailable: 1, Price: 10},
+		{Name: "Knight Statue", Description: "A knight fighting a dragon", QuantityAvailable: 1, Price: 10},
+	}
+	for _, item := range items {
+		if _, err := repo.AddCatalogItem(item, "admin"); err != nil {
+			t.Fatalf("Failed to add item: %v", err)
+		}
+	}
+	hits, _ = repo.SearchCatalog("dragon", 0)
+	if len(hits) != 2 || hits[0].Item.Name != "Dragon Figure" {
+		t.Errorf("Expected Dragon Figure first, got %v", hitNames(hits))
+	}
+}
+
+func TestSearchCatalogPrefixAndTypos(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"ultram", "Warhammer 40k, Ultramarines Titus Action Figure"},
+		{"urasaw", "20th Century Boys Ultimate Deluxe Edition Vol.1-12"},
+		{"fantasi", "The Lord of the Rings"},
+		{"berzerk", "Berserk Deluxe Edition Vol.1"},
+		{"warhamemr", "Warhammer 40k, Ultramarines Titus Action Figure"},
+	}
+
+	for _, tt := range tests {
+		hits, err := repo.SearchCatalog(tt.query, 0)
+		if err != nil {
+			t.Fatalf("Failed to search %q: %v", tt.query, err)
+		}
+		if len(hits) == 0 || hits[0].Item.Name != tt.want {
+			t.Errorf("Search %q: expected %v first, got %v", tt.query, tt.want, hitNames(hits))
+		}
+	}
+
+	// Short words are not corrected
+	hits, _ := repo.SearchCatalog("bok", 0)
+	if len(hits) != 0 {
+		t.Errorf("Expected no hits, got %v", hitNames(hits))
+	}
+}
+
+func TestSearchCatalogSnippet(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	hits, _ := repo.SearchCatalog("fantastic", 0)
+	if len(hits) != 1 {
+		t.Fatalf("Expected 1 hit, got %v", hitNames(hits))
+	}
+	if hits[0].Snippet != "A fantastic fantasy book" || len(hits[0].Highlights) != 1 {
+		t.Fatalf("Unexpected snippet %q with highlights %v", hits[0].Snippet, hits[0].Highlights)
+	}
+	h := hits[0].Highlights[0]
+	if got := hits[0].Snippet[h.Start:h.End]; got != "fantastic" {
+		t.Errorf("Expected fantastic highlighted, got %q", got)
+	}
+
+	// Long descriptions are cut around the first match
+	long := "One two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty hidden treasure map"
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Scroll", Description: long, QuantityAvailable: 1, Price: 5}, "admin"); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	hits, _ = repo.SearchCatalog("treasure", 0)
+	if len(hits) != 1 {
+		t.Fatalf("Expected 1 hit, got %v", hitNames(hits))
+	}
+	snippet := hits[0].Snippet
+	if !strings.HasPrefix(snippet, "…") || strings.Contains(snippet, "One") || !strings.Contains(snippet, "treasure map") {
+		t.Errorf("Unexpected snippet %q", snippet)
+	}
+	for _, h := range hits[0].Highlights {
+		if got := snippet[h.Start:h.End]; got != "treasure" {
+			t.Errorf("Expected treasure highlighted, got %q", got)
+		}
+	}
+}
+
+func TestSearchCatalogStaysInSync(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Elven Cloak", Description: "Woven in Lothlorien", QuantityAvailable: 3, Price: 80}, "admin"); err != nil {
+		t.Fatalf("Failed to add item: %v", err)
+	}
+	hits, _ := repo.SearchCatalog("lothlorien", 0)
+	if len(hits) != 1 || hits[0].Item.Name != "Elven Cloak" {
+		t.Fatalf("Expected the added item, got %v", hitNames(hits))
+	}
+
+	if err := repo.UpdatePrice(hits[0].Item.ItemId, 95, 0); err != nil {
+		t.Fatalf("Failed to update price: %v", err)
+	}
+	hits, _ = repo.SearchCatalog("elven", 0)
+	if len(hits) != 1 || hits[0].Item.Price != 95 {
+		t.Fatalf("Expected the updated price, got %v", hits)
+	}
+
+	if err := repo.RemoveCatalogItem(hits[0].Item.ItemId); err != nil {
+		t.Fatalf("Failed to remove item: %v", err)
+	}
+	hits, _ = repo.SearchCatalog("lothlorien", 0)
+	if len(hits) != 0 {
+		t.Errorf("Expected no hits after removal, got %v", hitNames(hits))
+	}
+}
+
+func TestBuildSearchIndex(t *testing.T) {
+	// Items stored before the repository is created are indexed by the build
+	_, repo := setupTest(t)
+
+	hits, _ := repo.SearchCatalog("another", 0)
+	if len(hits) != 0 {
+		t.Fatalf("Expected an empty index, got %v", hitNames(hits))
+	}
+
+	if err := repo.BuildSearchIndex(); err != nil {
+		t.Fatalf("Failed to build search index: %v", err)
+	}
+	hits, _ = repo.SearchCatalog("another", 0)
+	if len(hits) != 1 || hits[0].Item.ItemId != "item456" {
+		t.Errorf("Expected item456, got %v", hitNames(hits))
+	}
+
+	// Building again doesn't duplicate the items
+	repo.BuildSearchIndex()
+	hits, _ = repo.SearchCatalog("item", 0)
+	if len(hits) != 2 {
+		t.Errorf("Expected 2 hits, got %v", hitNames(hits))
+	}
+}
+
+func TestSearchCatalogInvalidInput(t *testing.T) {
+	repo := setupSearchTest(t)
+
+	if _, err := repo.SearchCatalog("   ", 0); err == nil {
+		t.Errorf("Expected error for an empty query but got none")
+	}
+	if _, err := repo.SearchCatalog("book", -1); err == nil {
+		t.Errorf("Expected error for a negative limit but got none")
+	}
+
+	// The limit bounds the results
+	hits, _ := repo.SearchCatalog("deluxe", 1)
+	if len(hits) != 1 {
+		t.Errorf("Expected 1 hit, got %v", hitNames(hits))
+	}
+}
+
+func TestSearchIndexVocabulary(t *testing.T) {
+	index := search.NewIndex()
+	index.Add("a", "shared", "word")
+	index.Add("b", "shared", "other")
+
+	// Replacing an item drops its old words
+	index.Add("a", "renewed", "")
+	if hits := index.Search("word", 0); len(hits) != 0 {
+		t.Errorf("Expected no hits for a replaced word, got %v", hits)
+	}
+	if hits := index.Search("shared", 0); len(hits) != 1 || hits[0].ItemID != "b" {
+		t.Errorf("Expected b only, got %v", hits)
+	}
+
+	index.Remove("b")
+	index.Remove("missing")
+	if index.Len() != 1 {
+		t.Errorf("Expected 1 indexed item, got %v", index.Len())
+	}
+	if hits := index.Search("shar", 0); len(hits) != 0 {
+		t.Errorf("Expected no hits after removal, got %v", hits)
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func TestSetReorderThreshold(t *testing.T) {
+	_, repo := setupTest(t)
+
+	item, _ := repo.GetCatalogItem("item123")
+	if err := repo.SetReorderThreshold("item123", 4); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, _ := repo.GetCatalogItem("item123")
+	if updated.ReorderThreshold != 4 || updated.Version == item.Version {
+		t.Fatalf("Expected threshold 4 at a new version, got %d at %d", updated.ReorderThreshold, updated.Version)
+	}
+
+	if err := repo.SetReorderThreshold("nonexistent", 4); err == nil {
+		t.Fatalf("Expected error for a nonexistent item, got nil")
+	}
+	if err := repo.SetReorderThreshold("", 4); err == nil {
+		t.Fatalf("Expected error for an empty item ID, got nil")
+	}
+}
+
+func TestSetReorderThresholdOfProductWithVariants(t *testing.T) {
+	_, repo := setupTest(t)
+	setupVariants(t, repo)
+
+	if err := repo.SetReorderThreshold("item123", 4); !errors.Is(err, repository.ErrProductHasVariants) {
+		t.Fatalf("Expected ErrProductHasVariants, got %v", err)
+	}
+}
+
+func TestCheckLowStock(t *testing.T) {
+	_, repo := setupTest(t)
+	now := time.Now()
+
+	watcher, cancel := repo.WatchCatalog()
+	defer cancel()
+
+	// item456 has 5 units, below its threshold; item123 has 10, at its threshold
+	repo.SetReorderThreshold("item456", 6)
+	repo.SetReorderThreshold("item123", 10)
+	receivedEvents(watcher)
+
+	raised, err := repo.CheckLowStock(now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if raised != 1 {
+		t.Fatalf("Expected 1 alert raised, got %d", raised)
+	}
+
+	received := receivedEvents(watcher)
+	if len(received) != 1 || received[0].Type != pb.CatalogEventType_LOW_STOCK || received[0].Alert.GetItemId() != "item456" ||
+		received[0].Alert.GetQuantityAvailable() != 5 || received[0].Alert.GetThreshold() != 6 || received[0].Alert.GetName() != "Another Item" {
+		t.Fatalf("Expected the alert of item456 published, got %v", received)
+	}
+
+	// An item already alerted is not alerted again
+	if raised, _ := repo.CheckLowStock(now.Add(time.Minute)); raised != 0 {
+		t.Fatalf("Expected no alert raised again, got %d", raised)
+	}
+
+	// Stocked up to its threshold the alert is resolved, it is raised again when it falls below it
+	repo.UpdateQuantityAvailable("item456", "", 6, 0, domain.MovementRestock, "admin")
+	repo.CheckLowStock(now.Add(2 * time.Minute))
+	if alerts, _ := repo.ListStockAlerts(false); len(alerts) != 0 {
+		t.Fatalf("Expected no open alert, got %v", alerts)
+	}
+
+	repo.UpdateQuantityAvailable("item456", "", 2, 0, domain.MovementAdjustment, "admin")
+	if raised, _ := repo.CheckLowStock(now.Add(3 * time.Minute)); raised != 1 {
+		t.Fatalf("Expected the alert raised again, got %d", raised)
+	}
+
+	alerts, err := repo.ListStockAlerts(true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].QuantityAvailable != 2 || alerts[0].ResolvedAt != 0 ||
+		alerts[1].QuantityAvailable != 5 || alerts[1].ResolvedAt != now.Add(2*time.Minute).Unix() {
+		t.Fatalf("Expected the open alert and the resolved one, got %v", alerts)
+	}
+
+	// Without a threshold the alert is resolved
+	repo.SetReorderThreshold("item456", 0)
+	repo.CheckLowStock(now.Add(4 * time.Minute))
+	if alerts, _ := repo.ListStockAlerts(false); len(alerts) != 0 {
+		t.Fatalf("Expected no open alert, got %v", alerts)
+	}
+}
+
+func TestReorderSuggestions(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// item123 sells 1 unit a day and has 10, item456 sells nothing but is below its threshold
+	repo.SetReorderThreshold("item456", 8)
+	sales := map[string]uint32{"item123": 30, "removed": 4}
+
+	suggestions, err := repo.ReorderSuggestions(sales, 30, 14)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %v", suggestions)
+	}
+	if s := suggestions[0]; s.ItemId != "item123" || s.UnitsSold != 30 || s.DailySales != 1 || s.DaysOfStock != 10 || s.SuggestedQuantity != 4 {
+		t.Fatalf("Expected 4 units of item123 to last 14 days, got %v", s)
+	}
+	if s := suggestions[1]; s.ItemId != "item456" || s.DaysOfStock != 0 || s.SuggestedQuantity != 3 {
+		t.Fatalf("Expected 3 units of item456 to reach its threshold, got %v", s)
+	}
+
+	// Enough stock for a shorter cover
+	if suggestions, _ := repo.ReorderSuggestions(sales, 30, 7); len(suggestions) != 1 || suggestions[0].ItemId != "item456" {
+		t.Fatalf("Expected only item456 to reorder, got %v", suggestions)
+	}
+
+	if _, err := repo.ReorderSuggestions(sales, 0, 14); err == nil {
+		t.Fatalf("Expected error for an empty window, got nil")
+	}
+}
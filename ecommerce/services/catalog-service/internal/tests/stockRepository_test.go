@@ -0,0 +1,217 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func TestOpenStockLedger(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// The default items are in stock without any movement
+	opened, err := repo.OpenStockLedger()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opened != 2 {
+		t.Fatalf("Expected 2 items recorded, got %d", opened)
+	}
+	if opened, _ := repo.OpenStockLedger(); opened != 0 {
+		t.Fatalf("Expected no item recorded again, got %d", opened)
+	}
+
+	movements, _, err := repo.ListStockMovements("item123", "", 0, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(movements) != 1 || movements[0].Delta != 10 || movements[0].QuantityAfter != 10 ||
+		movements[0].Reason != pb.StockMovementReason_MOVEMENT_INITIAL || movements[0].Actor != repository.SystemActor {
+		t.Fatalf("Expected the initial movement of 10 units, got %v", movements)
+	}
+}
+
+func TestStockMovementsOfAdmin(t *testing.T) {
+	_, repo := setupTest(t)
+
+	itemID, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Stocked Item", Description: "Stocked Item", QuantityAvailable: 4, Price: 10}, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.UpdateQuantityAvailable(itemID, "", 10, 0, domain.MovementRestock, "admin"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.UpdateQuantityAvailable(itemID, "", 9, 0, domain.MovementAdjustment, "clerk"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The same quantity set again is not a movement
+	if err := repo.UpdateQuantityAvailable(itemID, "", 9, 0, domain.MovementAdjustment, "clerk"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Admins cannot record sales
+	if err := repo.UpdateQuantityAvailable(itemID, "", 5, 0, domain.MovementSale, "admin"); err == nil {
+		t.Fatalf("Expected error for a sale set by an admin, got nil")
+	}
+
+	movements, _, err := repo.ListStockMovements(itemID, "", 0, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		delta  int64
+		after  uint32
+		reason pb.StockMovementReason
+		actor  string
+	}{
+		{-1, 9, pb.StockMovementReason_MOVEMENT_ADJUSTMENT, "clerk"},
+		{6, 10, pb.StockMovementReason_MOVEMENT_RESTOCK, "admin"},
+		{4, 4, pb.StockMovementReason_MOVEMENT_INITIAL, "admin"},
+	}
+	if len(movements) != len(expected) {
+		t.Fatalf("Expected %d movements, got %v", len(expected), movements)
+	}
+	for i, movement := range movements {
+		if movement.Delta != expected[i].delta || movement.QuantityAfter != expected[i].after ||
+			movement.Reason != expected[i].reason || movement.Actor != expected[i].actor || movement.CreatedAt == 0 {
+			t.Fatalf("Expected movement %v, got %v", expected[i], movement)
+		}
+	}
+}
+
+func TestStockMovementsOfReservations(t *testing.T) {
+	db, repo := setupReservationTest(t)
+	repo.OpenStockLedger()
+
+	committed, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 2}}, time.Minute, nil, "checkout")
+	released, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 3}}, time.Minute, nil, "checkout")
+	expiring, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 1}}, time.Minute, nil, "checkout")
+
+	repo.CommitReservation(committed, "order1")
+	repo.ReleaseReservation(released, "checkout")
+	repo.ExpireReservations(time.Now().Add(2 * time.Minute))
+	repo.RestockItems("cancel:order1", "order1", []*pb.StockItem{{ItemId: "item123", Quantity: 2}}, "order-service")
+
+	movements, _, err := repo.ListStockMovements("item123", "", 0, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		delta       int64
+		reason      pb.StockMovementReason
+		reservation string
+		order       string
+	}{
+		{2, pb.StockMovementReason_MOVEMENT_CANCELLATION, "", "order1"},
+		{1, pb.StockMovementReason_MOVEMENT_EXPIRATION, expiring, ""},
+		{3, pb.StockMovementReason_MOVEMENT_RELEASE, released, ""},
+		{-1, pb.StockMovementReason_MOVEMENT_SALE, expiring, ""},
+		{-3, pb.StockMovementReason_MOVEMENT_SALE, released, ""},
+		{-2, pb.StockMovementReason_MOVEMENT_SALE, committed, "order1"},
+		{10, pb.StockMovementReason_MOVEMENT_INITIAL, "", ""},
+	}
+	if len(movements) != len(expected) {
+		t.Fatalf("Expected %d movements, got %v", len(expected), movements)
+	}
+	for i, movement := range movements {
+		if movement.Delta != expected[i].delta || movement.Reason != expected[i].reason ||
+			movement.ReservationId != expected[i].reservation || movement.OrderId != expected[i].order {
+			t.Fatalf("Expected movement %v, got %v", expected[i], movement)
+		}
+	}
+
+	// The ledger accounts for the quantity available
+	if q := quantityOf(t, db, "item123"); q != 10 || movements[0].QuantityAfter != q {
+		t.Fatalf("Expected 10 units of item123 after the last movement, got %d and %d", q, movements[0].QuantityAfter)
+	}
+
+	// The movements of an order are the sale and the cancellation
+	orderMovements, _, _ := repo.ListStockMovements("", "order1", 0, "")
+	if len(orderMovements) != 2 {
+		t.Fatalf("Expected 2 movements of order1, got %v", orderMovements)
+	}
+}
+
+func TestListStockMovementsPagination(t *testing.T) {
+	_, repo := setupTest(t)
+	repo.OpenStockLedger()
+
+	for quantity := uint32(1); quantity <= 4; quantity++ {
+		repo.UpdateQuantityAvailable("item456", "", quantity, 0, domain.MovementAdjustment, "admin")
+	}
+
+	// 1 initial movement and 4 adjustments of item456, 1 initial movement of item123
+	var seen []uint64
+	pageToken := ""
+	for {
+		movements, nextPageToken, err := repo.ListStockMovements("", "", 2, pageToken)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, movement := range movements {
+			seen = append(seen, movement.MovementId)
+		}
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	if len(seen) != 6 {
+		t.Fatalf("Expected 6 movements, got %v", seen)
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i] >= seen[i-1] {
+			t.Fatalf("Expected the most recent movements first, got %v", seen)
+		}
+	}
+
+	if _, _, err := repo.ListStockMovements("", "", 2, "not-a-token"); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Fatalf("Expected ErrInvalidPageToken, got %v", err)
+	}
+}
+
+func TestReconcileStock(t *testing.T) {
+	db, repo := setupTest(t)
+	repo.OpenStockLedger()
+
+	discrepancies, checked, err := repo.ReconcileStock(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if checked != 2 || len(discrepancies) != 0 {
+		t.Fatalf("Expected 2 items checked and no discrepancy, got %d and %v", checked, discrepancies)
+	}
+
+	// The stock changed without a movement
+	db.Model(&domain.CatalogItem{}).Where("item_id = ?", "item123").Update("quantity_available", 7)
+
+	discrepancies, _, err = repo.ReconcileStock(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(discrepancies) != 1 || discrepancies[0].ItemId != "item123" ||
+		discrepancies[0].QuantityAvailable != 7 || discrepancies[0].LedgerQuantity != 10 {
+		t.Fatalf("Expected item123 with 7 units and 10 in the ledger, got %v", discrepancies)
+	}
+	if q := quantityOf(t, db, "item123"); q != 7 {
+		t.Fatalf("Expected the report to leave 7 units of item123, got %d", q)
+	}
+
+	// Applying the report sets the quantity of the ledger
+	if discrepancies, _, _ := repo.ReconcileStock(true); len(discrepancies) != 1 {
+		t.Fatalf("Expected the discrepancy fixed to be reported, got %v", discrepancies)
+	}
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+	if discrepancies, _, _ := repo.ReconcileStock(false); len(discrepancies) != 0 {
+		t.Fatalf("Expected no discrepancy left, got %v", discrepancies)
+	}
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// setupVariants adds two formats of item123, returning the IDs of the hardcover and of the paperback
+func setupVariants(t *testing.T, repo *repository.CatalogServiceRepository) (string, string) {
+	hardcover, err := repo.AddCatalogItem(&pb.CatalogItem{
+		ProductId: "item123", Sku: "DEF-HC", Attributes: map[string]string{"format": "Hardcover", "language": "English"},
+		Price: 120, QuantityAvailable: 3,
+	}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add variant: %v", err)
+	}
+	paperback, err := repo.AddCatalogItem(&pb.CatalogItem{
+		ProductId: "item123", Sku: "DEF-PB", Attributes: map[string]string{"format": "Paperback", "language": "English"},
+		Price: 80, QuantityAvailable: 4,
+	}, "admin")
+	if err != nil {
+		t.Fatalf("Failed to add variant: %v", err)
+	}
+	return hardcover, paperback
+}
+
+func TestAddVariant(t *testing.T) {
+	db, repo := setupTest(t)
+	db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{})
+	hardcover, _ := setupVariants(t, repo)
+
+	variant, err := repo.GetCatalogItemBySKU("DEF-HC")
+	if err != nil {
+		t.Fatalf("Failed to get variant by SKU: %v", err)
+	}
+	if variant.ItemId != hardcover || variant.ProductId != "item123" || variant.Attributes["format"] != "Hardcover" {
+		t.Errorf("Unexpected variant %v", variant)
+	}
+
+	// Name and description come from the product
+	if variant.Name != "Default Item (Hardcover, English)" || variant.Description != "Default Item" {
+		t.Errorf("Expected name and description from the product, got %q and %q", variant.Name, variant.Description)
+	}
+
+	// The product has the lowest price and the total quantity of its variants
+	product, _ := repo.GetCatalogItem("item123")
+	if product.Price != 80 || product.QuantityAvailable != 7 || len(product.Variants) != 2 || product.Variants[0].ItemId != hardcover {
+		t.Errorf("Unexpected product %v", product)
+	}
+}
+
+func TestAddVariantInvalid(t *testing.T) {
+	_, repo := setupTest(t)
+	hardcover, _ := setupVariants(t, repo)
+
+	tests := []struct {
+		name    string
+		variant *pb.CatalogItem
+	}{
+		{"missing product", &pb.CatalogItem{ProductId: "missing", Sku: "X1", Attributes: map[string]string{"format": "Hardcover"}}},
+		{"variant of a variant", &pb.CatalogItem{ProductId: hardcover, Sku: "X2", Attributes: map[string]string{"format": "Hardcover"}}},
+		{"no attributes", &pb.CatalogItem{ProductId: "item123", Sku: "X3"}},
+		{"invalid attribute", &pb.CatalogItem{ProductId: "item123", Sku: "X4", Attributes: map[string]string{"Format": "Hardcover"}}},
+		{"same attributes", &pb.CatalogItem{ProductId: "item123", Sku: "X5", Attributes: map[string]string{"format": "Hardcover", "language": "English"}}},
+		{"no SKU", &pb.CatalogItem{ProductId: "item123", Attributes: map[string]string{"format": "Audiobook"}}},
+		{"tagged", &pb.CatalogItem{ProductId: "item123", Sku: "X6", Attributes: map[string]string{"format": "Audiobook"}, Tags: []string{"audio"}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := repo.AddCatalogItem(tt.variant, "admin"); err == nil {
+			t.Errorf("%s: expected error but got none", tt.name)
+		}
+	}
+
+	// Only variants have attributes
+	if _, err := repo.AddCatalogItem(&pb.CatalogItem{Name: "Plain", Description: "Plain", Attributes: map[string]string{"format": "Hardcover"}}, "admin"); err == nil {
+		t.Errorf("Expected error for attributes of a product but got none")
+	}
+}
+
+func TestProductWithVariantsStock(t *testing.T) {
+	db, repo := setupTest(t)
+	db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{}, &domain.Restock{})
+	hardcover, paperback := setupVariants(t, repo)
+
+	// The product itself is not sold, nor its price and quantity set
+	_, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 1}}, time.Minute, nil, "checkout")
+	if !errors.Is(err, repository.ErrProductHasVariants) {
+		t.Errorf("Expected error for a product with variants, got %v", err)
+	}
+	if err := repo.UpdatePrice("item123", 10, 0); !errors.Is(err, repository.ErrProductHasVariants) {
+		t.Errorf("Expected error updating the price of a product with variants, got %v", err)
+	}
+	if err := repo.UpdateQuantityAvailable("item123", "", 10, 0, domain.MovementAdjustment, "admin"); !errors.Is(err, repository.ErrProductHasVariants) {
+		t.Errorf("Expected error updating the quantity of a product with variants, got %v", err)
+	}
+
+	// Reservations, restocks and updates of the variants change the product
+	reservationID, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: hardcover, Quantity: 2}}, time.Minute, nil, "checkout")
+	if err != nil {
+		t.Fatalf("Failed to reserve variant: %v", err)
+	}
+	if product, _ := repo.GetCatalogItem("item123"); product.QuantityAvailable != 5 {
+		t.Errorf("Expected 5 left, got %v", product.QuantityAvailable)
+	}
+	repo.ReleaseReservation(reservationID, "checkout")
+	if product, _ := repo.GetCatalogItem("item123"); product.QuantityAvailable != 7 {
+		t.Errorf("Expected 7 after release, got %v", product.QuantityAvailable)
+	}
+	repo.RestockItems("restock-1", "", []*pb.StockItem{{ItemId: paperback, Quantity: 3}}, "order-service")
+	repo.UpdatePrice(hardcover, 60, 0)
+	if product, _ := repo.GetCatalogItem("item123"); product.QuantityAvailable != 10 || product.Price != 60 {
+		t.Errorf("Expected 10 at 60, got %v at %v", product.QuantityAvailable, product.Price)
+	}
+
+	// Removing a variant updates the product, removing the product removes its variants
+	if err := repo.RemoveCatalogItem(hardcover); err != nil {
+		t.Fatalf("Failed to remove variant: %v", err)
+	}
+	if product, _ := repo.GetCatalogItem("item123"); product.QuantityAvailable != 7 || product.Price != 80 || len(product.Variants) != 1 {
+		t.Errorf("Unexpected product after removing a variant %v", product)
+	}
+	if err := repo.RemoveCatalogItem("item123"); err != nil {
+		t.Fatalf("Failed to remove product: %v", err)
+	}
+	if _, err := repo.GetCatalogItem(paperback); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the variants removed with the product, got %v", err)
+	}
+}
+
+func TestListCatalogItemsWithVariants(t *testing.T) {
+	_, repo := setupTest(t)
+	setupVariants(t, repo)
+
+	// Variants are listed with their product, not on their own
+	items, _, err := repo.ListCatalogItems(domain.CatalogQuery{})
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 products, got %v", items)
+	}
+	for _, item := range items {
+		if item.ItemId == "item123" && len(item.Variants) != 2 {
+			t.Errorf("Expected the variants of item123, got %v", item.Variants)
+		}
+	}
+
+	// The price of a product with variants is the lowest one
+	items, _, _ = repo.ListCatalogItems(domain.CatalogQuery{MaxPrice: 90})
+	if len(items) != 2 {
+		t.Errorf("Expected both products under 90, got %v", items)
+	}
+}
+
+func TestUpdateVariantAttributes(t *testing.T) {
+	_, repo := setupTest(t)
+	hardcover, paperback := setupVariants(t, repo)
+
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: hardcover, Attributes: map[string]string{"format": "Hardcover", "language": "Italian"}}, 0); err != nil {
+		t.Fatalf("Failed to update attributes: %v", err)
+	}
+	variant, _ := repo.GetCatalogItem(hardcover)
+	if variant.Attributes["language"] != "Italian" {
+		t.Errorf("Expected updated attributes, got %v", variant.Attributes)
+	}
+
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: paperback, Attributes: map[string]string{"format": "Hardcover", "language": "Italian"}}, 0); err == nil {
+		t.Errorf("Expected error for duplicate attributes but got none")
+	}
+	if err := repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item456", Attributes: map[string]string{"format": "Hardcover"}}, 0); err == nil {
+		t.Errorf("Expected error for attributes of a product but got none")
+	}
+	if err := repo.SetItemTags(hardcover, []string{"signed"}); !errors.Is(err, repository.ErrVariantClassified) {
+		t.Errorf("Expected error tagging a variant, got %v", err)
+	}
+}
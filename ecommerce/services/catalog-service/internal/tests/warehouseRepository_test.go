@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/allocation"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+)
+
+func warehouseQuantityOf(t *testing.T, db *gorm.DB, warehouseID, itemID string) uint32 {
+	var stock domain.WarehouseStock
+	err := db.First(&stock, "warehouse_id = ? AND item_id = ?", warehouseID, itemID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("Failed to retrieve warehouse stock: %v", err)
+	}
+	return stock.Quantity
+}
+
+func TestCreateAndListWarehouses(t *testing.T) {
+	_, repo := setupTest(t)
+
+	warehouseID, err := repo.CreateWarehouse("Milan", 45.46, 9.19)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := repo.CreateWarehouse("milan", 0, 0); err == nil {
+		t.Fatalf("Expected error for a duplicate name, got nil")
+	}
+	if _, err := repo.CreateWarehouse("Nowhere", 91, 0); err == nil {
+		t.Fatalf("Expected error for an invalid location, got nil")
+	}
+	if _, err := repo.CreateWarehouse("", 0, 0); err == nil {
+		t.Fatalf("Expected error for an empty name, got nil")
+	}
+
+	warehouses, err := repo.ListWarehouses()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(warehouses) != 2 || warehouses[1].WarehouseId != warehouseID || warehouses[1].Location.Latitude != 45.46 ||
+		warehouses[0].WarehouseId != domain.DefaultWarehouseID {
+		t.Fatalf("Expected the default warehouse and Milan, got %v", warehouses)
+	}
+}
+
+func TestOpenWarehouses(t *testing.T) {
+	db, repo := setupTest(t)
+
+	// setupTest already opened the warehouses
+	if opened, err := repo.OpenWarehouses(); err != nil || opened != 0 {
+		t.Fatalf("Expected no item stocked again, got %d (%v)", opened, err)
+	}
+	if q := warehouseQuantityOf(t, db, domain.DefaultWarehouseID, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123 in the default warehouse, got %d", q)
+	}
+}
+
+func TestTransferStock(t *testing.T) {
+	db, repo := setupTest(t)
+	milan, _ := repo.CreateWarehouse("Milan", 45.46, 9.19)
+
+	item, _ := repo.GetCatalogItem("item123")
+	if err := repo.TransferStock("item123", domain.DefaultWarehouseID, milan, 4, "admin"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if q := warehouseQuantityOf(t, db, domain.DefaultWarehouseID, "item123"); q != 6 {
+		t.Fatalf("Expected 6 units left in the default warehouse, got %d", q)
+	}
+	if q := warehouseQuantityOf(t, db, milan, "item123"); q != 4 {
+		t.Fatalf("Expected 4 units in Milan, got %d", q)
+	}
+
+	// The quantity available does not change, the version does
+	transferred, _ := repo.GetCatalogItem("item123")
+	if transferred.QuantityAvailable != 10 || transferred.Version == item.Version {
+		t.Fatalf("Expected 10 units at a new version, got %d at %d", transferred.QuantityAvailable, transferred.Version)
+	}
+	if transferred.LocationsInStock != 2 {
+		t.Fatalf("Expected item123 in 2 warehouses, got %d", transferred.LocationsInStock)
+	}
+
+	movements, _, _ := repo.ListStockMovements("item123", "", 0, "")
+	if len(movements) != 2 || movements[0].Reason != pb.StockMovementReason_MOVEMENT_TRANSFER ||
+		movements[0].WarehouseId != milan || movements[0].Delta != 4 || movements[1].Delta != -4 {
+		t.Fatalf("Expected the two sides of the transfer, got %v", movements)
+	}
+
+	stock, err := repo.GetItemStock("item123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(stock) != 2 || stock[0].Quantity != 6 || stock[1].Name != "Milan" || stock[1].Quantity != 4 {
+		t.Fatalf("Expected the stock of both warehouses, got %v", stock)
+	}
+
+	if err := repo.TransferStock("item123", milan, domain.DefaultWarehouseID, 5, "admin"); !errors.Is(err, repository.ErrInsufficientStock) {
+		t.Fatalf("Expected ErrInsufficientStock, got %v", err)
+	}
+	if err := repo.TransferStock("item123", milan, milan, 1, "admin"); err == nil {
+		t.Fatalf("Expected error for a transfer to the same warehouse, got nil")
+	}
+	if err := repo.TransferStock("item123", milan, "nonexistent", 1, "admin"); err == nil {
+		t.Fatalf("Expected error for a nonexistent warehouse, got nil")
+	}
+}
+
+func TestUpdateQuantityAvailableOfWarehouse(t *testing.T) {
+	db, repo := setupTest(t)
+	milan, _ := repo.CreateWarehouse("Milan", 45.46, 9.19)
+
+	if err := repo.UpdateQuantityAvailable("item123", milan, 7, 0, domain.MovementRestock, "admin"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if q := quantityOf(t, db, "item123"); q != 17 {
+		t.Fatalf("Expected 17 units of item123 in total, got %d", q)
+	}
+	if q := warehouseQuantityOf(t, db, domain.DefaultWarehouseID, "item123"); q != 10 {
+		t.Fatalf("Expected the default warehouse untouched, got %d", q)
+	}
+
+	if err := repo.UpdateQuantityAvailable("item123", "nonexistent", 7, 0, domain.MovementRestock, "admin"); err == nil {
+		t.Fatalf("Expected error for a nonexistent warehouse, got nil")
+	}
+}
+
+func TestReserveStockAllocation(t *testing.T) {
+	db, repo := setupReservationTest(t)
+	milan, _ := repo.CreateWarehouse("Milan", 45.46, 9.19)
+	repo.TransferStock("item123", domain.DefaultWarehouseID, milan, 4, "admin")
+
+	// The default warehouse has both lines whole, Milan only one
+	_, _, allocations, err := repo.ReserveStock([]*pb.StockItem{
+		{ItemId: "item123", Quantity: 2},
+		{ItemId: "item456", Quantity: 1},
+	}, time.Minute, allocation.FewestSplits(), "checkout")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(allocations) != 2 || allocations[0].WarehouseId != domain.DefaultWarehouseID || allocations[1].WarehouseId != domain.DefaultWarehouseID {
+		t.Fatalf("Expected everything from the default warehouse, got %v", allocations)
+	}
+
+	// Milan is the nearest to Turin, the rest of the line comes from the default warehouse
+	turin := allocation.Location{Latitude: 45.07, Longitude: 7.69}
+	reservationID, _, allocations, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 6}}, time.Minute, allocation.Nearest(&turin), "checkout")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(allocations) != 2 || allocations[0].WarehouseId != milan || allocations[0].Quantity != 4 ||
+		allocations[1].WarehouseId != domain.DefaultWarehouseID || allocations[1].Quantity != 2 {
+		t.Fatalf("Expected 4 units from Milan and 2 from the default warehouse, got %v", allocations)
+	}
+	if q := warehouseQuantityOf(t, db, milan, "item123"); q != 0 {
+		t.Fatalf("Expected Milan emptied, got %d", q)
+	}
+	if q := quantityOf(t, db, "item123"); q != 2 {
+		t.Fatalf("Expected 2 units of item123 left, got %d", q)
+	}
+
+	// The stock goes back to the warehouses it was reserved from
+	if err := repo.ReleaseReservation(reservationID, "checkout"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if q := warehouseQuantityOf(t, db, milan, "item123"); q != 4 {
+		t.Fatalf("Expected 4 units back in Milan, got %d", q)
+	}
+
+	// No warehouse has enough, nor all of them together
+	if _, _, _, err := repo.ReserveStock([]*pb.StockItem{{ItemId: "item123", Quantity: 9}}, time.Minute, nil, "checkout"); !errors.Is(err, repository.ErrInsufficientStock) {
+		t.Fatalf("Expected ErrInsufficientStock, got %v", err)
+	}
+}
+
+func TestRestockToSoldWarehouse(t *testing.T) {
+	db, repo := setupReservationTest(t)
+	milan, _ := repo.CreateWarehouse("Milan", 45.46, 9.19)
+	repo.TransferStock("item456", domain.DefaultWarehouseID, milan, 5, "admin")
+
+	reservationID, _, _, _ := repo.ReserveStock([]*pb.StockItem{{ItemId: "item456", Quantity: 3}}, time.Minute, nil, "checkout")
+	if err := repo.CommitReservation(reservationID, "order1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := repo.RestockItems("cancel:order1", "order1", []*pb.StockItem{{ItemId: "item456", Quantity: 3}}, "order-service"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if q := warehouseQuantityOf(t, db, milan, "item456"); q != 5 {
+		t.Fatalf("Expected the units back in Milan, got %d", q)
+	}
+	if q := warehouseQuantityOf(t, db, domain.DefaultWarehouseID, "item456"); q != 0 {
+		t.Fatalf("Expected nothing in the default warehouse, got %d", q)
+	}
+}
+
+func TestReconcileWarehouseStock(t *testing.T) {
+	db, repo := setupTest(t)
+	repo.OpenStockLedger()
+
+	// The stock of a warehouse changed behind the ledger, the total did not
+	milan, _ := repo.CreateWarehouse("Milan", 45.46, 9.19)
+	db.Create(&domain.WarehouseStock{WarehouseID: milan, ItemID: "item123", Quantity: 3})
+
+	discrepancies, _, err := repo.ReconcileStock(true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(discrepancies) != 1 || discrepancies[0].WarehouseId != milan || discrepancies[0].QuantityAvailable != 3 {
+		t.Fatalf("Expected the discrepancy of Milan, got %v", discrepancies)
+	}
+	if q := warehouseQuantityOf(t, db, milan, "item123"); q != 0 {
+		t.Fatalf("Expected Milan set to the ledger, got %d", q)
+	}
+	if q := quantityOf(t, db, "item123"); q != 10 {
+		t.Fatalf("Expected 10 units of item123, got %d", q)
+	}
+}
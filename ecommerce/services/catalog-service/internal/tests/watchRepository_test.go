@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/events"
+)
+
+// receivedEvents returns the events already published to a watcher
+func receivedEvents(watcher <-chan *pb.CatalogEvent) []*pb.CatalogEvent {
+	var received []*pb.CatalogEvent
+	for {
+		select {
+		case event, ok := <-watcher:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func TestWatchCatalogItemChanges(t *testing.T) {
+	db, repo := setupTest(t)
+	db.AutoMigrate(&domain.Reservation{}, &domain.ReservationItem{})
+
+	watcher, cancel := repo.WatchCatalog()
+	defer cancel()
+
+	repo.UpdatePrice("item123", 80, 0)
+	repo.UpdateCatalogItem(&pb.CatalogItem{ItemId: "item456", Name: "Renamed Item"}, 0)
+	repo.ReserveStock([]*pb.StockItem{{ItemId: "item456", Quantity: 2}}, time.Minute, nil, "checkout")
+	repo.RemoveCatalogItem("item123")
+
+	received := receivedEvents(watcher)
+	if len(received) != 4 {
+		t.Fatalf("Expected 4 events, got %v", received)
+	}
+	expected := []struct {
+		eventType pb.CatalogEventType
+		itemID    string
+	}{
+		{pb.CatalogEventType_PRICE_CHANGED, "item123"},
+		{pb.CatalogEventType_ITEM_UPDATED, "item456"},
+		{pb.CatalogEventType_STOCK_CHANGED, "item456"},
+		{pb.CatalogEventType_ITEM_REMOVED, "item123"},
+	}
+	for i, event := range received {
+		if event.Type != expected[i].eventType || event.ItemId != expected[i].itemID || event.Sequence != uint64(i+1) {
+			t.Errorf("Expected %v of %s, got %v", expected[i].eventType, expected[i].itemID, event)
+		}
+	}
+
+	// Events carry the state of the item after the change
+	if received[0].Item.Price != 80 || received[1].Item.Name != "Renamed Item" || received[2].Item.QuantityAvailable != 3 {
+		t.Errorf("Unexpected items %v", received)
+	}
+
+	// Failed changes are not published
+	repo.UpdatePrice("missing", 10, 0)
+	if received := receivedEvents(watcher); len(received) != 0 {
+		t.Errorf("Expected no events, got %v", received)
+	}
+}
+
+func TestWatchCatalogVariantChanges(t *testing.T) {
+	_, repo := setupTest(t)
+	hardcover, _ := setupVariants(t, repo)
+
+	watcher, cancel := repo.WatchCatalog()
+	defer cancel()
+
+	// The product changes with its variants
+	repo.UpdatePrice(hardcover, 60, 0)
+	received := receivedEvents(watcher)
+	if len(received) != 2 || received[0].ItemId != hardcover || received[1].ItemId != "item123" ||
+		received[1].Type != pb.CatalogEventType_PRICE_CHANGED || received[1].Item.Price != 60 {
+		t.Fatalf("Expected the price changes of the variant and of the product, got %v", received)
+	}
+
+	// Removing a product removes its variants
+	repo.RemoveCatalogItem("item123")
+	removed := 0
+	for _, event := range receivedEvents(watcher) {
+		if event.Type == pb.CatalogEventType_ITEM_REMOVED {
+			removed++
+		}
+	}
+	if removed != 3 {
+		t.Errorf("Expected the product and its 2 variants removed, got %d", removed)
+	}
+}
+
+func TestWatchCatalogImport(t *testing.T) {
+	_, repo := setupTest(t)
+
+	watcher, cancel := repo.WatchCatalog()
+	defer cancel()
+
+	// A dry run changes nothing
+	repo.ImportCatalogItems(pb.CatalogFileFormat_CSV, strings.NewReader(importCSV), true, "admin")
+	if received := receivedEvents(watcher); len(received) != 0 {
+		t.Fatalf("Expected no events for a dry run, got %v", received)
+	}
+
+	repo.ImportCatalogItems(pb.CatalogFileFormat_CSV, strings.NewReader(importCSV), false, "admin")
+	counts := make(map[pb.CatalogEventType]int)
+	for _, event := range receivedEvents(watcher) {
+		counts[event.Type]++
+	}
+	if counts[pb.CatalogEventType_ITEM_ADDED] != 2 || counts[pb.CatalogEventType_ITEM_UPDATED] != 2 {
+		t.Errorf("Expected 2 items added and 2 updated, the product of the variant too, got %v", counts)
+	}
+}
+
+func TestBrokerDropsSlowWatchers(t *testing.T) {
+	broker := events.NewBroker()
+	slow, _ := broker.Subscribe()
+	fast, cancel := broker.Subscribe()
+
+	// The fast watcher keeps up, the slow one never reads
+	for i := 0; i < 1000; i++ {
+		broker.Publish(&pb.CatalogEvent{ItemId: "item123"})
+		if event := <-fast; event.Sequence != uint64(i+1) {
+			t.Fatalf("Expected event %d, got %d", i+1, event.Sequence)
+		}
+	}
+
+	if broker.Watchers() != 1 {
+		t.Errorf("Expected the slow watcher dropped, got %d watchers", broker.Watchers())
+	}
+	receivedEvents(slow)
+	if _, ok := <-slow; ok {
+		t.Errorf("Expected the channel of the slow watcher closed")
+	}
+
+	cancel()
+	cancel()
+	if broker.Watchers() != 0 {
+		t.Errorf("Expected no watchers, got %d", broker.Watchers())
+	}
+}
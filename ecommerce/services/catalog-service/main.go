@@ -3,29 +3,52 @@ package main
 import (
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/media"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/catalog-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 )
 
 var port = "8083"
 
+// expirationInterval is how often the reservations not committed in time are released
+const expirationInterval = 30 * time.Second
+
+// priceScheduleInterval is how often the scheduled prices due are started and the sales over are ended
+const priceScheduleInterval = 30 * time.Second
+
+// lowStockInterval is how often the items are checked against their reorder threshold
+const lowStockInterval = time.Minute
+
+// imagesDir is the directory keeping the images of the items and their thumbnails
+const imagesDir = "images"
+
+// serviceName is the identity of the catalog service when it calls the other services
+const serviceName = "catalog-service"
+
 func main() {
 
 	// Initialize database connection with GORM
-	db, err := gorm.Open(sqlite.Open("catalog.db"), &gorm.Config{})
+	// Transactions lock the database immediately and wait for each other, stock is updated concurrently
+	db, err := gorm.Open(sqlite.Open("catalog.db?_busy_timeout=5000&_txlock=immediate"), &gorm.Config{})
 	if err != nil {
 		log.Fatalf("Failed to connect database: %v", err)
 	}
 
 	// Migrate the schema
-	if err := db.AutoMigrate(&domain.CatalogItem{}); err != nil {
+	if err := db.AutoMigrate(&domain.CatalogItem{}, &domain.Reservation{}, &domain.ReservationItem{}, &domain.Restock{}, &domain.ItemIDMapping{}, &domain.AppliedMigration{}, &domain.Category{}, &domain.ItemTag{},
+		&domain.PriceHistory{}, &domain.ScheduledPrice{}, &domain.StockMovement{}, &domain.Warehouse{}, &domain.WarehouseStock{}, &domain.StockAlert{}, &domain.ItemImage{}, &domain.Review{}); err != nil {
 		log.Fatalf("Failed to migrate database schema: %v", err)
 	}
 
@@ -35,17 +58,99 @@ func main() {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 
+	// The images are kept on the local filesystem
+	images, err := media.NewFileStore(imagesDir)
+	if err != nil {
+		log.Fatalf("Failed to open the image store: %v", err)
+	}
+
 	// Initialize repository and create default catalog items
-	catalogRepo := repository.NewCatalogServiceRepository(db)
+	catalogRepo := repository.NewCatalogServiceRepository(db, images)
+
+	// Items added before the generated IDs were identified by their title
+	if migrated, err := catalogRepo.MigrateLegacyItemIDs(); err != nil {
+		log.Fatalf("Failed to migrate the item IDs: %v", err)
+	} else if migrated > 0 {
+		log.Printf("Generated IDs for %d items identified by their title", migrated)
+	}
 	if err := catalogRepo.CreateDefaultItems(); err != nil {
 		log.Fatalf("Internal errors while creating default items: %v", err)
 	}
 
+	// Items in stock before the warehouses are stocked in the default one
+	if opened, err := catalogRepo.OpenWarehouses(); err != nil {
+		log.Fatalf("Failed to open the warehouses: %v", err)
+	} else if opened > 0 {
+		log.Printf("Put the stock of %d items in the default warehouse", opened)
+	}
+
+	// Items in stock before the inventory ledger are recorded with their quantity as the initial movement
+	if opened, err := catalogRepo.OpenStockLedger(); err != nil {
+		log.Fatalf("Failed to open the stock ledger: %v", err)
+	} else if opened > 0 {
+		log.Printf("Recorded the stock of %d items in the ledger", opened)
+	}
+
+	// Index the catalog for the full-text search
+	if err := catalogRepo.BuildSearchIndex(); err != nil {
+		log.Fatalf("Failed to build the search index: %v", err)
+	}
+
+	// Release the reservations expired
+	go func() {
+		for range time.Tick(expirationInterval) {
+			expired, err := catalogRepo.ExpireReservations(time.Now())
+			if err != nil {
+				log.Printf("Failed to expire reservations: %v", err)
+			} else if expired > 0 {
+				log.Printf("Released %d expired reservations", expired)
+			}
+		}
+	}()
+
+	// Apply the scheduled prices, the changes are announced to the watchers of the catalog
+	go func() {
+		for range time.Tick(priceScheduleInterval) {
+			changed, err := catalogRepo.ApplyScheduledPrices(time.Now())
+			if err != nil {
+				log.Printf("Failed to apply scheduled prices: %v", err)
+			} else if changed > 0 {
+				log.Printf("Changed %d prices as scheduled", changed)
+			}
+		}
+	}()
+
+	// Raise the low-stock alerts, they are announced to the watchers of the catalog
+	go func() {
+		for range time.Tick(lowStockInterval) {
+			raised, err := catalogRepo.CheckLowStock(time.Now())
+			if err != nil {
+				log.Printf("Failed to check the low stock: %v", err)
+			} else if raised > 0 {
+				log.Printf("Raised %d low-stock alerts", raised)
+			}
+		}
+	}()
+
+	// Connection to the order service for the sales of the items and the purchases of the customers, authenticated as a service
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	orderConn, err := grpc.NewClient("localhost:8084",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(interceptor.NewServiceCredentials(tokens, serviceName)))
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer orderConn.Close()
+
 	// Initialize CatalogServer
-	catalogServer := internal.NewCatalogServer(catalogRepo)
+	catalogServer := internal.NewCatalogServer(catalogRepo, pbOrder.NewOrderServiceClient(orderConn))
 
-	// Register gRPC server
-	grpcServer := grpc.NewServer()
+	// Register gRPC server, every call is checked against the authorization policy
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(authorizer.ServerOptions()...)
 	pb.RegisterCatalogServiceServer(grpcServer, catalogServer)
 
 	log.Printf("Catalog service listening on port %s", port)
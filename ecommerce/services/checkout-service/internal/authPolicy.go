@@ -0,0 +1,21 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// checkoutOwner returns the user whose cart is bought.
+func checkoutOwner(req any) string {
+	if r, ok := req.(interface{ GetUsername() string }); ok {
+		return r.GetUsername()
+	}
+	return ""
+}
+
+// AuthPolicy defines who can call each RPC of the checkout service.
+// The owner of a checkout is known only after reading it, GetCheckoutStatus checks it in the server.
+var AuthPolicy = interceptor.Policy{
+	pb.CheckoutService_Checkout_FullMethodName:          interceptor.OwnerOnly(checkoutOwner),
+	pb.CheckoutService_GetCheckoutStatus_FullMethodName: interceptor.Authenticated(),
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/orchestrator"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// CheckoutServer implements the checkout service gRPC server.
+type CheckoutServer struct {
+	pb.CheckoutServiceServer
+	repo         domain.CheckoutServiceInterface
+	orchestrator *orchestrator.Orchestrator
+
+	// payment stores the cards of the checkouts, so that the sagas keep only their tokens
+	payment pbPayment.PaymentServiceClient
+}
+
+func NewCheckoutServer(repo domain.CheckoutServiceInterface, orchestrator *orchestrator.Orchestrator, payment pbPayment.PaymentServiceClient) *CheckoutServer {
+	return &CheckoutServer{repo: repo, orchestrator: orchestrator, payment: payment}
+}
+
+// Checkout starts the checkout of the cart of the user.
+// The saga runs in background, its result is polled with GetCheckoutStatus.
+func (s *CheckoutServer) Checkout(ctx context.Context, req *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
+
+	if req.Username == "" {
+		return &pb.CheckoutResponse{
+			ErrorMessage: "Username must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Username must be provided and not empty")
+	}
+
+	if req.Amount < 0 {
+		return &pb.CheckoutResponse{
+			ErrorMessage: "Amount cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Amount cannot be negative")
+	}
+
+	if req.CardNumber == "" {
+		return &pb.CheckoutResponse{
+			ErrorMessage: "Card number must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Card number must be provided and not empty")
+	}
+
+	if d := req.Destination; d != nil && (d.Latitude < -90 || d.Latitude > 90 || d.Longitude < -180 || d.Longitude > 180) {
+		return &pb.CheckoutResponse{
+			ErrorMessage: "Destination is not a valid location",
+		}, status.Error(codes.InvalidArgument, "Destination is not a valid location")
+	}
+
+	// The card is stored by the payment provider, the saga keeps only the token charging it
+	tokenRes, err := s.payment.TokenizeCard(ctx, &pbPayment.TokenizeCardRequest{CardNumber: req.CardNumber})
+	if status.Code(err) == codes.InvalidArgument {
+		return &pb.CheckoutResponse{ErrorMessage: "Card number is not valid"}, status.Error(codes.InvalidArgument, "Card number is not valid")
+	}
+	if err != nil {
+		return &pb.CheckoutResponse{ErrorMessage: err.Error()}, err
+	}
+
+	// A double click or a second tab doesn't buy the cart twice
+	saga, err := s.repo.CreateSaga(req.Username, req.Amount, tokenRes.GetCardToken(), req.Destination)
+	if errors.Is(err, repository.ErrCheckoutRunning) {
+		return &pb.CheckoutResponse{ErrorMessage: err.Error()}, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return &pb.CheckoutResponse{ErrorMessage: err.Error()}, err
+	}
+
+	// The saga must outlive the RPC
+	go func() {
+		if err := s.orchestrator.Run(context.Background(), saga.CheckoutID); err != nil {
+			log.Printf("Checkout %s interrupted: %v", saga.CheckoutID, err)
+		}
+	}()
+
+	return &pb.CheckoutResponse{CheckoutId: saga.CheckoutID}, nil
+}
+
+// GetCheckoutStatus retrieves the status of a checkout.
+func (s *CheckoutServer) GetCheckoutStatus(ctx context.Context, req *pb.GetCheckoutStatusRequest) (*pb.GetCheckoutStatusResponse, error) {
+
+	if req.CheckoutId == "" {
+		return &pb.GetCheckoutStatusResponse{
+			ErrorMessage: "Checkout ID must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Checkout ID must be provided and not empty")
+	}
+
+	saga, err := s.repo.GetSaga(req.CheckoutId)
+	if err != nil {
+		return &pb.GetCheckoutStatusResponse{ErrorMessage: err.Error()}, err
+	}
+
+	// Only the user who started the checkout can see it
+	if err := interceptor.CheckOwner(ctx, saga.Username); err != nil {
+		return &pb.GetCheckoutStatusResponse{ErrorMessage: err.Error()}, err
+	}
+
+	res, err := domain.DomainSagaToProtoStatus(saga)
+	if err != nil {
+		return &pb.GetCheckoutStatusResponse{ErrorMessage: err.Error()}, err
+	}
+	return res, nil
+}
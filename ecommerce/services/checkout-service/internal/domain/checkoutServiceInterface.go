@@ -0,0 +1,27 @@
+package domain
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+)
+
+type CheckoutServiceInterface interface {
+
+	// CreateSaga stores a new checkout of the cart of the user, in status RUNNING.
+	// It fails if another checkout of the user is RUNNING.
+	CreateSaga(username string, amount float64, cardToken string, destination *pb.Destination) (*Saga, error)
+
+	// GetSaga retrieves a checkout with its items.
+	GetSaga(checkoutID string) (*Saga, error)
+
+	// SaveSaga stores the current state of a checkout and its items.
+	SaveSaga(saga *Saga) error
+
+	// AppendLog appends an entry to the durable log of a checkout.
+	AppendLog(checkoutID string, step Step, action Action, outcome Outcome, stepErr error) error
+
+	// GetLog retrieves the durable log of a checkout, in order of writing.
+	GetLog(checkoutID string) ([]SagaLogEntry, error)
+
+	// ListUnfinishedSagas retrieves the checkouts interrupted before reaching a final status.
+	ListUnfinishedSagas() ([]*Saga, error)
+}
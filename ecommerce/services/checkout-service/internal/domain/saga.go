@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+)
+
+type SagaStatus string
+
+const (
+	// Running indicates that the steps of the checkout are being executed.
+	Running SagaStatus = "RUNNING"
+
+	// Compensating indicates that a step failed and the completed steps are being undone.
+	Compensating SagaStatus = "COMPENSATING"
+
+	// Completed indicates that the checkout ended successfully.
+	Completed SagaStatus = "COMPLETED"
+
+	// Failed indicates that the checkout failed and all the completed steps were undone.
+	Failed SagaStatus = "FAILED"
+)
+
+type Step string
+
+// Steps of the checkout, in order of execution
+const (
+	ValidateCart   Step = "VALIDATE_CART"
+	ReserveStock   Step = "RESERVE_STOCK"
+	CreateOrder    Step = "CREATE_ORDER"
+	CreatePayment  Step = "CREATE_PAYMENT"
+	ProcessPayment Step = "PROCESS_PAYMENT"
+	ConfirmOrder   Step = "CONFIRM_ORDER"
+	CommitStock    Step = "COMMIT_STOCK"
+	ClearCart      Step = "CLEAR_CART"
+)
+
+type Action string
+
+const (
+	Execute    Action = "EXECUTE"
+	Compensate Action = "COMPENSATE"
+)
+
+type Outcome string
+
+const (
+	Started    Outcome = "STARTED"
+	Succeeded  Outcome = "SUCCEEDED"
+	StepFailed Outcome = "FAILED"
+)
+
+// Failure reasons returned to the client, the web server maps them to the error messages of the cart page
+const (
+	ReasonCatalogChanged = "catalog_changed"
+	ReasonPaymentFailed  = "payment_failed"
+	ReasonCheckoutFailed = "checkout_failed"
+)
+
+type Saga struct {
+
+	// CheckoutID is the unique identifier of the checkout.
+	CheckoutID string `gorm:"primaryKey; not null; check:checkout_id <> ''"`
+
+	// Username of the user whose cart is bought.
+	// A user has at most one RUNNING checkout, so that the same cart is never bought twice at the same time.
+	Username string `gorm:"not null; index; uniqueIndex:idx_sagas_running_username,where:status = 'RUNNING'; check:username <> ''"`
+
+	// Amount paid by the user.
+	Amount float64 `gorm:"not null; check:amount >= 0"`
+
+	// CardToken charged for the payment, returned by the payment provider when the checkout starts.
+	// The card number is never stored, the token is kept only until the checkout is finished.
+	CardToken string
+
+	// DestinationLatitude and DestinationLongitude of the shipment, in degrees, nil if the user gave none.
+	// The stock is taken from the warehouses nearest to the destination, without it from the fewest warehouses.
+	DestinationLatitude  *float64
+	DestinationLongitude *float64
+
+	// Items bought, copied from the cart when it is validated.
+	Items []SagaItem `gorm:"foreignKey:CheckoutID;references:CheckoutID;constraint:OnDelete:CASCADE"`
+
+	// Status of the checkout.
+	Status SagaStatus `gorm:"not null; index; check:status in ('RUNNING', 'COMPENSATING', 'COMPLETED', 'FAILED')"`
+
+	// CurrentStep is the last step started (or being compensated).
+	CurrentStep Step
+
+	// ReservationID of the stock reserved in the catalog, empty until the stock is reserved.
+	ReservationID string
+
+	// OrderID of the order created by the checkout, empty until the order exists.
+	OrderID string
+
+	// FailureReason is one of the Reason constants when the checkout fails.
+	FailureReason string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type SagaItem struct {
+
+	// ID is the unique identifier of the row.
+	ID uint `gorm:"primaryKey; autoIncrement"`
+
+	// CheckoutID of the saga the item belongs to.
+	CheckoutID string `gorm:"not null; index"`
+
+	// ItemID of the catalog item.
+	ItemID string `gorm:"not null; check:item_id <> ''"`
+
+	// Quantity bought.
+	Quantity uint32 `gorm:"not null; check:quantity > 0"`
+
+	// Price of a single unit.
+	Price float64 `gorm:"not null; check:price >= 0"`
+
+	// SKU of the catalog item when the cart is validated, the variant bought for a product with variants.
+	SKU string `gorm:"column:sku; not null; default:''"`
+}
+
+// SagaLogEntry is a record of the durable log of a saga, written before and after every step.
+// After a crash the log tells which steps completed and must be compensated.
+type SagaLogEntry struct {
+
+	// ID is the unique identifier of the entry, increasing with time.
+	ID uint `gorm:"primaryKey; autoIncrement"`
+
+	// CheckoutID of the saga.
+	CheckoutID string `gorm:"not null; index"`
+
+	// Step executed or compensated.
+	Step Step `gorm:"not null"`
+
+	// Action performed on the step.
+	Action Action `gorm:"not null; check:action in ('EXECUTE', 'COMPENSATE')"`
+
+	// Outcome of the action.
+	Outcome Outcome `gorm:"not null; check:outcome in ('STARTED', 'SUCCEEDED', 'FAILED')"`
+
+	// Error returned by the step, if any.
+	Error string
+
+	CreatedAt time.Time
+}
+
+// DomainSagaToProtoStatus converts a model.Saga into a pb.GetCheckoutStatusResponse
+func DomainSagaToProtoStatus(saga *Saga) (*pb.GetCheckoutStatusResponse, error) {
+	if saga == nil {
+		return nil, fmt.Errorf("Input argument is nil")
+	}
+
+	status, ok := pb.CheckoutStatus_value[string(saga.Status)]
+	if !ok {
+		return nil, fmt.Errorf("invalid domain saga status: %v", saga.Status)
+	}
+
+	return &pb.GetCheckoutStatusResponse{
+		CheckoutId:    saga.CheckoutID,
+		Status:        pb.CheckoutStatus(status),
+		CurrentStep:   string(saga.CurrentStep),
+		OrderId:       saga.OrderID,
+		FailureReason: saga.FailureReason,
+	}, nil
+}
@@ -0,0 +1,329 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+)
+
+const (
+	// stepTimeout bounds the calls to the other services made by a single step
+	stepTimeout = 10 * time.Second
+
+	// maxAttempts is the number of times a step that cannot be undone is tried
+	maxAttempts = 3
+
+	// retryDelay is the pause between two attempts
+	retryDelay = 500 * time.Millisecond
+)
+
+// Clients groups the gRPC clients of the services taking part in the checkout.
+type Clients struct {
+	Cart    pbCart.CartServiceClient
+	Catalog pbCatalog.CatalogServiceClient
+	Order   pbOrder.OrderServiceClient
+	Payment pbPayment.PaymentServiceClient
+}
+
+// step is a local transaction of the saga with the action that undoes it.
+type step struct {
+	name domain.Step
+
+	// execute performs the step, it returns a *StepError to give the reason of the failure to the user
+	execute func(ctx context.Context, saga *domain.Saga) error
+
+	// compensate undoes the step, nil if there is nothing to undo.
+	// It must be safe to call even if execute was interrupted halfway.
+	compensate func(ctx context.Context, saga *domain.Saga) error
+
+	// retriable steps are idempotent: they are retried on transient failures and resumed after a crash,
+	// instead of compensating the checkout. From the payment on, the checkout can only move forward
+	retriable bool
+
+	// optional steps don't fail the checkout
+	optional bool
+}
+
+// StepError is the failure of a step with the reason shown to the user.
+type StepError struct {
+	Reason string
+	Err    error
+}
+
+func (e *StepError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Orchestrator runs the checkout sagas, writing every step to the durable log.
+type Orchestrator struct {
+	repo    domain.CheckoutServiceInterface
+	clients *Clients
+	steps   []step
+
+	// running holds the checkouts currently executed, a saga is never run twice at the same time
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func NewOrchestrator(repo domain.CheckoutServiceInterface, clients *Clients) *Orchestrator {
+	o := &Orchestrator{repo: repo, clients: clients, running: make(map[string]bool)}
+	o.steps = []step{
+		{name: domain.ValidateCart, execute: o.validateCart},
+		{name: domain.ReserveStock, execute: o.reserveStock, compensate: o.releaseStock},
+		{name: domain.CreateOrder, execute: o.createOrder, compensate: o.cancelOrder},
+		{name: domain.CreatePayment, execute: o.createPayment},
+		{name: domain.ProcessPayment, execute: o.processPayment, compensate: o.refundPayment, retriable: true},
+		// The order is confirmed before committing the stock: once PROCESSING, a cancellation gives the items back
+		{name: domain.ConfirmOrder, execute: o.confirmOrder, retriable: true},
+		{name: domain.CommitStock, execute: o.commitStock, retriable: true},
+		{name: domain.ClearCart, execute: o.clearCart, retriable: true, optional: true},
+	}
+	return o
+}
+
+// Run executes (or resumes) a checkout until it reaches a final status.
+func (o *Orchestrator) Run(ctx context.Context, checkoutID string) error {
+
+	if !o.acquire(checkoutID) {
+		return nil
+	}
+	defer o.release(checkoutID)
+
+	saga, err := o.repo.GetSaga(checkoutID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := o.repo.GetLog(checkoutID)
+	if err != nil {
+		return err
+	}
+	executed, compensated := replayLog(entries)
+
+	switch saga.Status {
+	case domain.Completed, domain.Failed:
+		return nil
+	case domain.Compensating:
+		return o.compensate(ctx, saga, executed, compensated)
+	}
+
+	// Forward execution, resuming after the last step completed
+	for _, s := range o.steps {
+		if executed[s.name] {
+			continue
+		}
+
+		// A step interrupted by a crash may be partially applied, if it can't be resumed it is undone
+		if s.name == saga.CurrentStep && startedBefore(entries, s.name) && !s.retriable {
+			saga.FailureReason = domain.ReasonCheckoutFailed
+			executed[s.name] = true
+			return o.compensate(ctx, saga, executed, compensated)
+		}
+
+		saga.CurrentStep = s.name
+		if err := o.repo.SaveSaga(saga); err != nil {
+			return err
+		}
+
+		err := o.execute(ctx, saga, s)
+		if err == nil {
+			executed[s.name] = true
+			continue
+		}
+
+		if s.optional {
+			log.Printf("Checkout %s: optional step %s failed: %v", saga.CheckoutID, s.name, err)
+			continue
+		}
+
+		var stepErr *StepError
+		isStepErr := errors.As(err, &stepErr)
+
+		if s.retriable && !isStepErr {
+			// The saga stays RUNNING and is resumed by the recovery
+			log.Printf("Checkout %s: step %s failed, will be retried: %v", saga.CheckoutID, s.name, err)
+			return err
+		}
+
+		saga.FailureReason = domain.ReasonCheckoutFailed
+		if isStepErr {
+			saga.FailureReason = stepErr.Reason
+		}
+
+		// The failed step may be partially applied, it is compensated too
+		executed[s.name] = true
+		return o.compensate(ctx, saga, executed, compensated)
+	}
+
+	saga.Status = domain.Completed
+	saga.CardToken = ""
+	if err := o.repo.SaveSaga(saga); err != nil {
+		return err
+	}
+	log.Printf("Checkout %s completed, order %s", saga.CheckoutID, saga.OrderID)
+	return nil
+}
+
+// Recover resumes the checkouts interrupted by a crash or left to be retried.
+func (o *Orchestrator) Recover(ctx context.Context) {
+	sagas, err := o.repo.ListUnfinishedSagas()
+	if err != nil {
+		log.Printf("Failed to list unfinished checkouts: %v", err)
+		return
+	}
+
+	for _, saga := range sagas {
+		if err := o.Run(ctx, saga.CheckoutID); err != nil {
+			log.Printf("Failed to resume checkout %s: %v", saga.CheckoutID, err)
+		}
+	}
+}
+
+// PRIVATE FUNCTIONS
+
+// execute runs a step, logging its start and outcome.
+// Steps that cannot be undone are tried more than once.
+func (o *Orchestrator) execute(ctx context.Context, saga *domain.Saga, s step) error {
+
+	attempts := 1
+	if s.retriable {
+		attempts = maxAttempts
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryDelay)
+		}
+
+		if err = o.repo.AppendLog(saga.CheckoutID, s.name, domain.Execute, domain.Started, nil); err != nil {
+			return err
+		}
+
+		stepCtx, cancel := context.WithTimeout(ctx, stepTimeout)
+		err = s.execute(stepCtx, saga)
+		cancel()
+
+		if err == nil {
+			return o.repo.AppendLog(saga.CheckoutID, s.name, domain.Execute, domain.Succeeded, nil)
+		}
+
+		if logErr := o.repo.AppendLog(saga.CheckoutID, s.name, domain.Execute, domain.StepFailed, err); logErr != nil {
+			return logErr
+		}
+
+		// Business failures are not retried
+		var stepErr *StepError
+		if errors.As(err, &stepErr) {
+			return err
+		}
+	}
+	return err
+}
+
+// compensate undoes the executed steps in reverse order.
+// If a compensation keeps failing the saga stays COMPENSATING and is retried by the recovery.
+func (o *Orchestrator) compensate(ctx context.Context, saga *domain.Saga, executed, compensated map[domain.Step]bool) error {
+
+	saga.Status = domain.Compensating
+	if err := o.repo.SaveSaga(saga); err != nil {
+		return err
+	}
+	log.Printf("Checkout %s failed at step %s (%s), compensating", saga.CheckoutID, saga.CurrentStep, saga.FailureReason)
+
+	for _, s := range slices.Backward(o.steps) {
+		if !executed[s.name] || compensated[s.name] || s.compensate == nil {
+			continue
+		}
+
+		var err error
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			if attempt > 0 {
+				time.Sleep(retryDelay)
+			}
+
+			stepCtx, cancel := context.WithTimeout(ctx, stepTimeout)
+			err = s.compensate(stepCtx, saga)
+			cancel()
+
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			if logErr := o.repo.AppendLog(saga.CheckoutID, s.name, domain.Compensate, domain.StepFailed, err); logErr != nil {
+				return logErr
+			}
+			return err
+		}
+
+		if err := o.repo.AppendLog(saga.CheckoutID, s.name, domain.Compensate, domain.Succeeded, nil); err != nil {
+			return err
+		}
+		compensated[s.name] = true
+	}
+
+	saga.Status = domain.Failed
+	saga.CardToken = ""
+	return o.repo.SaveSaga(saga)
+}
+
+// acquire marks a checkout as running, it returns false if it is already running.
+func (o *Orchestrator) acquire(checkoutID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.running[checkoutID] {
+		return false
+	}
+	o.running[checkoutID] = true
+	return true
+}
+
+func (o *Orchestrator) release(checkoutID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.running, checkoutID)
+}
+
+// replayLog finds the steps executed and the ones already compensated.
+func replayLog(entries []domain.SagaLogEntry) (executed, compensated map[domain.Step]bool) {
+	executed = make(map[domain.Step]bool)
+	compensated = make(map[domain.Step]bool)
+
+	for _, entry := range entries {
+		switch {
+		case entry.Outcome != domain.Succeeded:
+		case entry.Action == domain.Execute:
+			executed[entry.Step] = true
+		case entry.Action == domain.Compensate:
+			compensated[entry.Step] = true
+		}
+	}
+	return executed, compensated
+}
+
+// startedBefore tells if the log contains a previous attempt of the step.
+func startedBefore(entries []domain.SagaLogEntry, name domain.Step) bool {
+	for _, entry := range entries {
+		if entry.Step == name && entry.Action == domain.Execute {
+			return true
+		}
+	}
+	return false
+}
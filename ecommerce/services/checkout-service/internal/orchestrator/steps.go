@@ -0,0 +1,263 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+)
+
+// reservationTTL is how long the stock is held for the checkout before the payment
+const reservationTTL = 10 * time.Minute
+
+// validateCart copies the cart into the saga, checking that every item is still in the catalog
+// with the same price and enough quantity. Items changed in the catalog are removed from the cart.
+func (o *Orchestrator) validateCart(ctx context.Context, saga *domain.Saga) error {
+
+	cartRes, err := o.clients.Cart.GetCart(ctx, &pbCart.GetCartRequest{Username: saga.Username})
+	if err != nil {
+		return err
+	}
+
+	cartItems := cartRes.GetCart().GetItems()
+	if len(cartItems) == 0 {
+		return &StepError{Reason: domain.ReasonCheckoutFailed, Err: errors.New("Cart is empty")}
+	}
+
+	items := make([]domain.SagaItem, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+
+		itemID := cartItem.GetItemId()
+		getRes, err := o.clients.Catalog.GetCatalogItem(ctx, &pbCatalog.GetCatalogItemRequest{ItemId: itemID})
+
+		// Catalog item removed, price changed, not enough quantity or variants added -> item removed from cart
+		changed := err != nil || getRes.GetErrorMessage() != "" ||
+			cartItem.GetQuantity() > getRes.GetItem().GetQuantityAvailable() ||
+			cartItem.GetPrice() != getRes.GetItem().GetPrice() ||
+			len(getRes.GetItem().GetVariants()) > 0
+
+		if changed {
+			if _, err := o.clients.Cart.RemoveItemFromCart(ctx, &pbCart.RemoveItemFromCartRequest{
+				Username: saga.Username,
+				ItemId:   itemID,
+			}); err != nil {
+				return err
+			}
+			return &StepError{Reason: domain.ReasonCatalogChanged, Err: fmt.Errorf("Catalog item %s has changed", itemID)}
+		}
+
+		items = append(items, domain.SagaItem{
+			CheckoutID: saga.CheckoutID,
+			ItemID:     itemID,
+			Quantity:   cartItem.GetQuantity(),
+			Price:      cartItem.GetPrice(),
+			SKU:        getRes.GetItem().GetSku(),
+		})
+	}
+
+	saga.Items = items
+	return o.repo.SaveSaga(saga)
+}
+
+// reserveStock reserves the quantity of all the items in the catalog at once.
+// The reservation expires if the checkout is interrupted and never recovered.
+func (o *Orchestrator) reserveStock(ctx context.Context, saga *domain.Saga) error {
+
+	stockItems := make([]*pbCatalog.StockItem, len(saga.Items))
+	for i, item := range saga.Items {
+		stockItems[i] = &pbCatalog.StockItem{ItemId: item.ItemID, Quantity: item.Quantity}
+	}
+
+	reserveReq := &pbCatalog.ReserveStockRequest{
+		Items:      stockItems,
+		TtlSeconds: int64(reservationTTL.Seconds()),
+		Strategy:   pbCatalog.AllocationStrategy_ALLOCATE_FEWEST_SPLITS,
+	}
+
+	// With a destination the stock is shipped from the nearest warehouses
+	if saga.DestinationLatitude != nil && saga.DestinationLongitude != nil {
+		reserveReq.Strategy = pbCatalog.AllocationStrategy_ALLOCATE_NEAREST
+		reserveReq.Destination = &pbCatalog.Location{Latitude: *saga.DestinationLatitude, Longitude: *saga.DestinationLongitude}
+	}
+
+	reserveRes, err := o.clients.Catalog.ReserveStock(ctx, reserveReq)
+	if status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.NotFound {
+		return &StepError{Reason: domain.ReasonCatalogChanged, Err: err}
+	}
+	if err != nil {
+		return err
+	}
+
+	saga.ReservationID = reserveRes.GetReservationId()
+	return o.repo.SaveSaga(saga)
+}
+
+// releaseStock gives back the reserved stock to the catalog.
+func (o *Orchestrator) releaseStock(ctx context.Context, saga *domain.Saga) error {
+	if saga.ReservationID == "" {
+		return nil
+	}
+
+	_, err := o.clients.Catalog.ReleaseReservation(ctx, &pbCatalog.ReleaseReservationRequest{ReservationId: saga.ReservationID})
+	return err
+}
+
+// commitStock makes the reservation definitive once the order is paid.
+// The order is sent too, so that the stock sold is recorded with it in the inventory ledger.
+// If the reservation expired in the meantime the checkout fails and the payment is refunded.
+func (o *Orchestrator) commitStock(ctx context.Context, saga *domain.Saga) error {
+
+	_, err := o.clients.Catalog.CommitReservation(ctx, &pbCatalog.CommitReservationRequest{
+		ReservationId: saga.ReservationID,
+		OrderId:       saga.OrderID,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return &StepError{Reason: domain.ReasonCheckoutFailed, Err: err}
+	}
+	return err
+}
+
+// createOrder creates the order of the items, in status PENDING.
+func (o *Orchestrator) createOrder(ctx context.Context, saga *domain.Saga) error {
+
+	orderItems := make([]*pbOrder.OrderItem, len(saga.Items))
+	for i, item := range saga.Items {
+		orderItems[i] = &pbOrder.OrderItem{
+			ItemId:   item.ItemID,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			Sku:      item.SKU,
+		}
+	}
+
+	orderRes, err := o.clients.Order.CreateOrder(ctx, &pbOrder.CreateOrderRequest{
+		UserId:         saga.Username,
+		OrderItems:     orderItems,
+		IdempotencyKey: idempotencyKey(saga, domain.CreateOrder),
+	})
+	if err != nil {
+		return err
+	}
+
+	saga.OrderID = orderRes.GetOrderId()
+	return o.repo.SaveSaga(saga)
+}
+
+// cancelOrder cancels the order created by the checkout, if any.
+func (o *Orchestrator) cancelOrder(ctx context.Context, saga *domain.Saga) error {
+	if saga.OrderID == "" {
+		return nil
+	}
+
+	_, err := o.clients.Order.UpdateOrderStatus(ctx, &pbOrder.UpdateOrderStatusRequest{
+		OrderId: saga.OrderID,
+		Status:  pbOrder.OrderStatus_CANCELED,
+	})
+	return err
+}
+
+// createPayment creates the payment of the total price of the order.
+func (o *Orchestrator) createPayment(ctx context.Context, saga *domain.Saga) error {
+
+	priceRes, err := o.clients.Order.GetOrderPrice(ctx, &pbOrder.GetOrderPriceRequest{OrderId: saga.OrderID})
+	if err != nil {
+		return err
+	}
+
+	_, err = o.clients.Payment.CreatePayment(ctx, &pbPayment.CreatePaymentRequest{
+		OrderId:        saga.OrderID,
+		Amount:         truncate(priceRes.GetTotalPrice()),
+		IdempotencyKey: idempotencyKey(saga, domain.CreatePayment),
+	})
+	return err
+}
+
+// processPayment pays the order with the amount given by the user.
+// A payment already PAID by a previous attempt is not processed again.
+func (o *Orchestrator) processPayment(ctx context.Context, saga *domain.Saga) error {
+
+	statusRes, err := o.clients.Payment.GetPaymentStatus(ctx, &pbPayment.GetPaymentStatusRequest{OrderId: saga.OrderID})
+	if err != nil {
+		return err
+	}
+	if statusRes.GetStatus() == pbPayment.PaymentStatus_PAID {
+		return nil
+	}
+
+	if _, err := o.clients.Payment.ProcessPayment(ctx, &pbPayment.ProcessPaymentRequest{
+		OrderId:        saga.OrderID,
+		Amount:         truncate(saga.Amount),
+		CardToken:      saga.CardToken,
+		IdempotencyKey: idempotencyKey(saga, domain.ProcessPayment),
+	}); err != nil {
+		return err
+	}
+
+	// Verify payment status
+	statusRes, err = o.clients.Payment.GetPaymentStatus(ctx, &pbPayment.GetPaymentStatusRequest{OrderId: saga.OrderID})
+	if err != nil {
+		return err
+	}
+	if statusRes.GetStatus() != pbPayment.PaymentStatus_PAID {
+		return &StepError{Reason: domain.ReasonPaymentFailed, Err: errors.New("Payment has been refused")}
+	}
+	return nil
+}
+
+// refundPayment refunds what is left of the payment of the order, if it has been paid.
+func (o *Orchestrator) refundPayment(ctx context.Context, saga *domain.Saga) error {
+
+	statusRes, err := o.clients.Payment.GetPaymentStatus(ctx, &pbPayment.GetPaymentStatusRequest{OrderId: saga.OrderID})
+	if err != nil {
+		return err
+	}
+	if paymentStatus := statusRes.GetStatus(); paymentStatus != pbPayment.PaymentStatus_PAID && paymentStatus != pbPayment.PaymentStatus_PARTIALLY_REFUNDED {
+		return nil
+	}
+
+	_, err = o.clients.Payment.RefundPayment(ctx, &pbPayment.RefundPaymentRequest{
+		OrderId:        saga.OrderID,
+		IdempotencyKey: idempotencyKey(saga, domain.ProcessPayment) + "/refund",
+	})
+	return err
+}
+
+// confirmOrder moves the paid order to PROCESSING.
+func (o *Orchestrator) confirmOrder(ctx context.Context, saga *domain.Saga) error {
+	_, err := o.clients.Order.UpdateOrderStatus(ctx, &pbOrder.UpdateOrderStatusRequest{
+		OrderId: saga.OrderID,
+		Status:  pbOrder.OrderStatus_PROCESSING,
+	})
+
+	// The user canceled the order while it was still PENDING
+	if status.Code(err) == codes.FailedPrecondition {
+		return &StepError{Reason: domain.ReasonCheckoutFailed, Err: err}
+	}
+	return err
+}
+
+// clearCart empties the cart of the user after the purchase.
+func (o *Orchestrator) clearCart(ctx context.Context, saga *domain.Saga) error {
+	_, err := o.clients.Cart.ClearCart(ctx, &pbCart.ClearCartRequest{Username: saga.Username})
+	return err
+}
+
+// idempotencyKey identifies the calls of a step of the checkout, so that the services execute them once
+func idempotencyKey(saga *domain.Saga, step domain.Step) string {
+	return saga.CheckoutID + "/" + string(step)
+}
+
+// truncate keeps two decimal digits of an amount of money
+func truncate(amount float64) float64 {
+	return math.Trunc(amount*100) / 100
+}
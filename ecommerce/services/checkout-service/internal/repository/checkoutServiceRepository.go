@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"errors"
+
+	ulid "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+)
+
+// ErrCheckoutRunning is returned when the user already has a checkout running.
+var ErrCheckoutRunning = errors.New("A checkout of the cart is already running")
+
+type CheckoutServiceRepository struct {
+	db *gorm.DB
+}
+
+func NewCheckoutServiceRepository(db *gorm.DB) *CheckoutServiceRepository {
+	return &CheckoutServiceRepository{db: db}
+}
+
+// CreateSaga stores a new checkout of the cart of the user, in status RUNNING.
+// It fails with ErrCheckoutRunning if another checkout of the user is RUNNING, the unique index on the
+// running sagas rejects the concurrent ones.
+func (r *CheckoutServiceRepository) CreateSaga(username string, amount float64, cardToken string, destination *pb.Destination) (*domain.Saga, error) {
+
+	// Validate inputs
+	if err := checkValidID(username); err != nil {
+		return nil, err
+	}
+	if amount < 0 {
+		return nil, errors.New("Invalid amount: cannot be negative")
+	}
+
+	saga := &domain.Saga{
+		CheckoutID:  ulid.Make().String(),
+		Username:    username,
+		Amount:      amount,
+		CardToken:   cardToken,
+		Status:      domain.Running,
+		CurrentStep: domain.ValidateCart,
+	}
+	if destination != nil {
+		saga.DestinationLatitude = &destination.Latitude
+		saga.DestinationLongitude = &destination.Longitude
+	}
+	if err := r.db.Create(saga).Error; err != nil {
+		if r.hasRunningSaga(username) {
+			return nil, ErrCheckoutRunning
+		}
+		return nil, err
+	}
+	return saga, nil
+}
+
+// GetSaga retrieves a checkout with its items.
+func (r *CheckoutServiceRepository) GetSaga(checkoutID string) (*domain.Saga, error) {
+
+	// Validate inputs
+	if err := checkValidID(checkoutID); err != nil {
+		return nil, err
+	}
+
+	var saga domain.Saga
+	if err := r.db.Preload("Items").Where("checkout_id = ?", checkoutID).First(&saga).Error; err != nil {
+		return nil, err
+	}
+	return &saga, nil
+}
+
+// SaveSaga stores the current state of a checkout and its items.
+func (r *CheckoutServiceRepository) SaveSaga(saga *domain.Saga) error {
+	if saga == nil {
+		return errors.New("Saga cannot be nil")
+	}
+	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(saga).Error
+}
+
+// AppendLog appends an entry to the durable log of a checkout.
+func (r *CheckoutServiceRepository) AppendLog(checkoutID string, step domain.Step, action domain.Action, outcome domain.Outcome, stepErr error) error {
+
+	// Validate inputs
+	if err := checkValidID(checkoutID); err != nil {
+		return err
+	}
+
+	entry := &domain.SagaLogEntry{
+		CheckoutID: checkoutID,
+		Step:       step,
+		Action:     action,
+		Outcome:    outcome,
+	}
+	if stepErr != nil {
+		entry.Error = stepErr.Error()
+	}
+	return r.db.Create(entry).Error
+}
+
+// GetLog retrieves the durable log of a checkout, in order of writing.
+func (r *CheckoutServiceRepository) GetLog(checkoutID string) ([]domain.SagaLogEntry, error) {
+
+	// Validate inputs
+	if err := checkValidID(checkoutID); err != nil {
+		return nil, err
+	}
+
+	var entries []domain.SagaLogEntry
+	if err := r.db.Where("checkout_id = ?", checkoutID).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListUnfinishedSagas retrieves the checkouts interrupted before reaching a final status.
+func (r *CheckoutServiceRepository) ListUnfinishedSagas() ([]*domain.Saga, error) {
+	var sagas []*domain.Saga
+	if err := r.db.Preload("Items").Where("status IN ?", []domain.SagaStatus{domain.Running, domain.Compensating}).
+		Order("created_at").Find(&sagas).Error; err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
+
+// PRIVATE FUNCTIONS TO CHECK ON THE VALIDITY OF INPUTS
+
+// hasRunningSaga tells if the user has a checkout RUNNING.
+func (r *CheckoutServiceRepository) hasRunningSaga(username string) bool {
+	var count int64
+	r.db.Model(&domain.Saga{}).Where("username = ? AND status = ?", username, domain.Running).Count(&count)
+	return count > 0
+}
+
+// checkValidID checks if the provided ID is valid (non-empty).
+func checkValidID(id string) error {
+	if id == "" {
+		return errors.New("Invalid ID: cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/repository"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect database: %v", err)
+	}
+
+	if err = db.AutoMigrate(&domain.Saga{}, &domain.SagaItem{}, &domain.SagaLogEntry{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+func setupTest(t *testing.T) (*gorm.DB, *repository.CheckoutServiceRepository) {
+	db := setupTestDB(t)
+	return db, repository.NewCheckoutServiceRepository(db)
+}
+
+func TestCreateSaga(t *testing.T) {
+	_, repo := setupTest(t)
+
+	saga, err := repo.CreateSaga("user1", 99.99, "card_token", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saga.CheckoutID == "" {
+		t.Fatalf("Expected a checkout ID")
+	}
+	if saga.Status != domain.Running {
+		t.Fatalf("Expected status RUNNING, got %v", saga.Status)
+	}
+}
+
+func TestCreateSagaInvalidInputs(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.CreateSaga("", 10, "card_token", nil); err == nil {
+		t.Fatalf("Expected error for empty username, got nil")
+	}
+	if _, err := repo.CreateSaga("user1", -1, "card_token", nil); err == nil {
+		t.Fatalf("Expected error for negative amount, got nil")
+	}
+}
+
+func TestCreateSagaWhileAnotherIsRunning(t *testing.T) {
+	db, repo := setupTest(t)
+
+	running, err := repo.CreateSaga("user1", 10, "card_token", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.CreateSaga("user1", 10, "card_token", nil); !errors.Is(err, repository.ErrCheckoutRunning) {
+		t.Fatalf("Expected ErrCheckoutRunning, got %v", err)
+	}
+	if _, err := repo.CreateSaga("user2", 10, "card_token", nil); err != nil {
+		t.Fatalf("Expected the checkout of another user to start, got %v", err)
+	}
+
+	// Once the checkout is finished a new one can start
+	running.Status = domain.Completed
+	if err := repo.SaveSaga(running); err != nil {
+		t.Fatalf("SaveSaga failed: %v", err)
+	}
+	if _, err := repo.CreateSaga("user1", 10, "card_token", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Concurrent checkouts of the same cart, a single one starts
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	started := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.CreateSaga("user3", 10, "card_token", nil); err == nil {
+				mu.Lock()
+				started++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if started != 1 {
+		t.Fatalf("Expected a single checkout to start, got %d", started)
+	}
+}
+
+func TestSaveAndGetSaga(t *testing.T) {
+	_, repo := setupTest(t)
+
+	saga, err := repo.CreateSaga("user1", 10, "card_token", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	saga.Items = []domain.SagaItem{{CheckoutID: saga.CheckoutID, ItemID: "item1", Quantity: 2, Price: 5}}
+	saga.OrderID = "order1"
+	if err := repo.SaveSaga(saga); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Items are updated, not duplicated
+	saga.Items[0].Quantity = 3
+	if err := repo.SaveSaga(saga); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stored, err := repo.GetSaga(saga.CheckoutID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stored.OrderID != "order1" {
+		t.Fatalf("Expected order ID order1, got %s", stored.OrderID)
+	}
+	if len(stored.Items) != 1 || stored.Items[0].Quantity != 3 {
+		t.Fatalf("Expected one item with quantity 3, got %+v", stored.Items)
+	}
+}
+
+func TestGetSagaNonExistent(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.GetSaga("nonexistent"); err == nil {
+		t.Fatalf("Expected error for nonexistent checkout, got nil")
+	}
+}
+
+func TestAppendAndGetLog(t *testing.T) {
+	_, repo := setupTest(t)
+
+	saga, err := repo.CreateSaga("user1", 10, "card_token", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := repo.AppendLog(saga.CheckoutID, domain.ValidateCart, domain.Execute, domain.Started, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.AppendLog(saga.CheckoutID, domain.ValidateCart, domain.Execute, domain.StepFailed, errors.New("Cart is empty")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entries, err := repo.GetLog(saga.CheckoutID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Outcome != domain.Started || entries[1].Outcome != domain.StepFailed {
+		t.Fatalf("Entries are not in order of writing: %+v", entries)
+	}
+	if entries[1].Error != "Cart is empty" {
+		t.Fatalf("Expected the error of the step, got %q", entries[1].Error)
+	}
+}
+
+func TestListUnfinishedSagas(t *testing.T) {
+	_, repo := setupTest(t)
+
+	running, _ := repo.CreateSaga("user1", 10, "card_token", nil)
+	completed, _ := repo.CreateSaga("user2", 10, "card_token", nil)
+	completed.Status = domain.Completed
+	if err := repo.SaveSaga(completed); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sagas, err := repo.ListUnfinishedSagas()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sagas) != 1 || sagas[0].CheckoutID != running.CheckoutID {
+		t.Fatalf("Expected only the running checkout, got %+v", sagas)
+	}
+}
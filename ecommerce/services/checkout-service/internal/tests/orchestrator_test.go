@@ -0,0 +1,454 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbCheckout "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/orchestrator"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/repository"
+)
+
+// In-memory services taking part in the checkout
+
+type fakeCart struct {
+	pbCart.CartServiceClient
+	items []*pbCart.CartItem
+}
+
+func (c *fakeCart) GetCart(ctx context.Context, req *pbCart.GetCartRequest, opts ...grpc.CallOption) (*pbCart.GetCartResponse, error) {
+	return &pbCart.GetCartResponse{Cart: &pbCart.Cart{Username: req.Username, Items: c.items}}, nil
+}
+
+func (c *fakeCart) RemoveItemFromCart(ctx context.Context, req *pbCart.RemoveItemFromCartRequest, opts ...grpc.CallOption) (*pbCart.RemoveItemFromCartResponse, error) {
+	for i, item := range c.items {
+		if item.ItemId == req.ItemId {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			break
+		}
+	}
+	return &pbCart.RemoveItemFromCartResponse{}, nil
+}
+
+func (c *fakeCart) ClearCart(ctx context.Context, req *pbCart.ClearCartRequest, opts ...grpc.CallOption) (*pbCart.ClearCartResponse, error) {
+	c.items = nil
+	return &pbCart.ClearCartResponse{}, nil
+}
+
+type fakeCatalog struct {
+	pbCatalog.CatalogServiceClient
+	items        map[string]*pbCatalog.CatalogItem
+	reservations map[string][]*pbCatalog.StockItem
+	committed    map[string]bool
+	strategy     pbCatalog.AllocationStrategy
+	destination  *pbCatalog.Location
+}
+
+func (c *fakeCatalog) GetCatalogItem(ctx context.Context, req *pbCatalog.GetCatalogItemRequest, opts ...grpc.CallOption) (*pbCatalog.GetCatalogItemResponse, error) {
+	item, ok := c.items[req.ItemId]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return &pbCatalog.GetCatalogItemResponse{Item: item}, nil
+}
+
+func (c *fakeCatalog) ReserveStock(ctx context.Context, req *pbCatalog.ReserveStockRequest, opts ...grpc.CallOption) (*pbCatalog.ReserveStockResponse, error) {
+	for _, item := range req.Items {
+		if c.items[item.ItemId].QuantityAvailable < item.Quantity {
+			return nil, status.Error(codes.FailedPrecondition, "Not enough quantity available")
+		}
+	}
+	for _, item := range req.Items {
+		c.items[item.ItemId].QuantityAvailable -= item.Quantity
+	}
+	c.reservations["reservation1"] = req.Items
+	c.strategy, c.destination = req.Strategy, req.Destination
+	return &pbCatalog.ReserveStockResponse{ReservationId: "reservation1"}, nil
+}
+
+func (c *fakeCatalog) CommitReservation(ctx context.Context, req *pbCatalog.CommitReservationRequest, opts ...grpc.CallOption) (*pbCatalog.CommitReservationResponse, error) {
+	if _, ok := c.reservations[req.ReservationId]; !ok {
+		return nil, status.Error(codes.FailedPrecondition, "Reservation has been released or has expired")
+	}
+	c.committed[req.ReservationId] = true
+	return &pbCatalog.CommitReservationResponse{}, nil
+}
+
+func (c *fakeCatalog) ReleaseReservation(ctx context.Context, req *pbCatalog.ReleaseReservationRequest, opts ...grpc.CallOption) (*pbCatalog.ReleaseReservationResponse, error) {
+	for _, item := range c.reservations[req.ReservationId] {
+		c.items[item.ItemId].QuantityAvailable += item.Quantity
+	}
+	delete(c.reservations, req.ReservationId)
+	return &pbCatalog.ReleaseReservationResponse{}, nil
+}
+
+// expire simulates the expiration of a reservation in the catalog
+func (c *fakeCatalog) expire(reservationID string) {
+	c.ReleaseReservation(context.Background(), &pbCatalog.ReleaseReservationRequest{ReservationId: reservationID})
+}
+
+type fakeOrder struct {
+	pbOrder.OrderServiceClient
+	orders map[string]*pbOrder.Order
+
+	// failStatus makes UpdateOrderStatus fail for the given status
+	failStatus *pbOrder.OrderStatus
+}
+
+func (o *fakeOrder) CreateOrder(ctx context.Context, req *pbOrder.CreateOrderRequest, opts ...grpc.CallOption) (*pbOrder.CreateOrderResponse, error) {
+	orderID := "order1"
+	o.orders[orderID] = &pbOrder.Order{OrderId: orderID, UserId: req.UserId, Items: req.OrderItems, Status: pbOrder.OrderStatus_PENDING}
+	return &pbOrder.CreateOrderResponse{OrderId: orderID}, nil
+}
+
+func (o *fakeOrder) UpdateOrderStatus(ctx context.Context, req *pbOrder.UpdateOrderStatusRequest, opts ...grpc.CallOption) (*pbOrder.UpdateOrderStatusResponse, error) {
+	if o.failStatus != nil && *o.failStatus == req.Status {
+		return nil, errors.New("order service unavailable")
+	}
+	if current := o.orders[req.OrderId].Status; current == pbOrder.OrderStatus_CANCELED && req.Status != current {
+		return nil, status.Error(codes.FailedPrecondition, "Illegal order status transition")
+	}
+	o.orders[req.OrderId].Status = req.Status
+	return &pbOrder.UpdateOrderStatusResponse{}, nil
+}
+
+func (o *fakeOrder) GetOrderPrice(ctx context.Context, req *pbOrder.GetOrderPriceRequest, opts ...grpc.CallOption) (*pbOrder.GetOrderPriceResponse, error) {
+	var total float64
+	for _, item := range o.orders[req.OrderId].Items {
+		total += float64(item.Quantity) * item.Price
+	}
+	return &pbOrder.GetOrderPriceResponse{TotalPrice: total}, nil
+}
+
+type fakePayment struct {
+	pbPayment.PaymentServiceClient
+	amounts  map[string]float64
+	statuses map[string]pbPayment.PaymentStatus
+
+	// onProcess is called when a payment is processed
+	onProcess func()
+}
+
+func (p *fakePayment) CreatePayment(ctx context.Context, req *pbPayment.CreatePaymentRequest, opts ...grpc.CallOption) (*pbPayment.CreatePaymentResponse, error) {
+	p.amounts[req.OrderId] = req.Amount
+	p.statuses[req.OrderId] = pbPayment.PaymentStatus_PENDING_PAYMENT
+	return &pbPayment.CreatePaymentResponse{}, nil
+}
+
+func (p *fakePayment) ProcessPayment(ctx context.Context, req *pbPayment.ProcessPaymentRequest, opts ...grpc.CallOption) (*pbPayment.ProcessPaymentResponse, error) {
+	if req.CardToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "Card token must be provided and not empty")
+	}
+	if p.onProcess != nil {
+		p.onProcess()
+	}
+	if req.Amount >= p.amounts[req.OrderId] {
+		p.statuses[req.OrderId] = pbPayment.PaymentStatus_PAID
+	} else {
+		p.statuses[req.OrderId] = pbPayment.PaymentStatus_PAYMENT_FAILED
+	}
+	return &pbPayment.ProcessPaymentResponse{}, nil
+}
+
+func (p *fakePayment) GetPaymentStatus(ctx context.Context, req *pbPayment.GetPaymentStatusRequest, opts ...grpc.CallOption) (*pbPayment.GetPaymentStatusResponse, error) {
+	return &pbPayment.GetPaymentStatusResponse{Status: p.statuses[req.OrderId]}, nil
+}
+
+func (p *fakePayment) RefundPayment(ctx context.Context, req *pbPayment.RefundPaymentRequest, opts ...grpc.CallOption) (*pbPayment.RefundPaymentResponse, error) {
+	p.statuses[req.OrderId] = pbPayment.PaymentStatus_REFUNDED
+	return &pbPayment.RefundPaymentResponse{}, nil
+}
+
+type fakeServices struct {
+	cart    *fakeCart
+	catalog *fakeCatalog
+	order   *fakeOrder
+	payment *fakePayment
+}
+
+// setupOrchestrator creates a cart with two items of the catalog
+func setupOrchestrator(t *testing.T) (*repository.CheckoutServiceRepository, *orchestrator.Orchestrator, *fakeServices) {
+	_, repo := setupTest(t)
+
+	services := &fakeServices{
+		cart: &fakeCart{items: []*pbCart.CartItem{
+			{ItemId: "item1", Quantity: 2, Price: 10},
+			{ItemId: "item2", Quantity: 1, Price: 5},
+		}},
+		catalog: &fakeCatalog{items: map[string]*pbCatalog.CatalogItem{
+			"item1": {ItemId: "item1", Price: 10, QuantityAvailable: 5},
+			"item2": {ItemId: "item2", Price: 5, QuantityAvailable: 1},
+		}, reservations: make(map[string][]*pbCatalog.StockItem), committed: make(map[string]bool)},
+		order:   &fakeOrder{orders: make(map[string]*pbOrder.Order)},
+		payment: &fakePayment{amounts: make(map[string]float64), statuses: make(map[string]pbPayment.PaymentStatus)},
+	}
+
+	o := orchestrator.NewOrchestrator(repo, &orchestrator.Clients{
+		Cart:    services.cart,
+		Catalog: services.catalog,
+		Order:   services.order,
+		Payment: services.payment,
+	})
+	return repo, o, services
+}
+
+func runCheckout(t *testing.T, repo *repository.CheckoutServiceRepository, o *orchestrator.Orchestrator, amount float64) *domain.Saga {
+	saga, err := repo.CreateSaga("user1", amount, "card_token", nil)
+	if err != nil {
+		t.Fatalf("CreateSaga failed: %v", err)
+	}
+
+	o.Run(context.Background(), saga.CheckoutID)
+
+	saga, err = repo.GetSaga(saga.CheckoutID)
+	if err != nil {
+		t.Fatalf("GetSaga failed: %v", err)
+	}
+	return saga
+}
+
+func checkStock(t *testing.T, services *fakeServices, item1, item2 uint32) {
+	if got := services.catalog.items["item1"].QuantityAvailable; got != item1 {
+		t.Fatalf("Expected %d units of item1, got %d", item1, got)
+	}
+	if got := services.catalog.items["item2"].QuantityAvailable; got != item2 {
+		t.Fatalf("Expected %d units of item2, got %d", item2, got)
+	}
+}
+
+func TestCheckoutCompleted(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Completed {
+		t.Fatalf("Expected status COMPLETED, got %v (%s)", saga.Status, saga.FailureReason)
+	}
+	checkStock(t, services, 3, 0)
+	if !services.catalog.committed[saga.ReservationID] {
+		t.Fatalf("Expected reservation to be committed")
+	}
+	if status := services.order.orders[saga.OrderID].Status; status != pbOrder.OrderStatus_PROCESSING {
+		t.Fatalf("Expected order PROCESSING, got %v", status)
+	}
+	if status := services.payment.statuses[saga.OrderID]; status != pbPayment.PaymentStatus_PAID {
+		t.Fatalf("Expected payment PAID, got %v", status)
+	}
+	if len(services.cart.items) != 0 {
+		t.Fatalf("Expected cart to be cleared")
+	}
+	if saga.CardToken != "" {
+		t.Fatalf("Expected card token to be forgotten")
+	}
+}
+
+func TestCheckoutFromNearestWarehouses(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	saga, err := repo.CreateSaga("user1", 25, "card_token", &pbCheckout.Destination{Latitude: 45.07, Longitude: 7.69})
+	if err != nil {
+		t.Fatalf("CreateSaga failed: %v", err)
+	}
+	o.Run(context.Background(), saga.CheckoutID)
+
+	if services.catalog.strategy != pbCatalog.AllocationStrategy_ALLOCATE_NEAREST ||
+		services.catalog.destination.GetLatitude() != 45.07 || services.catalog.destination.GetLongitude() != 7.69 {
+		t.Fatalf("Expected the stock reserved nearest to the destination, got %v %v", services.catalog.strategy, services.catalog.destination)
+	}
+
+	// Without a destination the fewest warehouses are used
+	repo, o, services = setupOrchestrator(t)
+	runCheckout(t, repo, o, 25)
+	if services.catalog.strategy != pbCatalog.AllocationStrategy_ALLOCATE_FEWEST_SPLITS || services.catalog.destination != nil {
+		t.Fatalf("Expected the stock reserved from the fewest warehouses, got %v %v", services.catalog.strategy, services.catalog.destination)
+	}
+}
+
+func TestCheckoutPaymentFailed(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// Amount lower than the total of the order
+	saga := runCheckout(t, repo, o, 10)
+
+	if saga.Status != domain.Failed {
+		t.Fatalf("Expected status FAILED, got %v", saga.Status)
+	}
+	if saga.FailureReason != domain.ReasonPaymentFailed {
+		t.Fatalf("Expected reason %s, got %s", domain.ReasonPaymentFailed, saga.FailureReason)
+	}
+
+	// Stock released and order canceled, the cart is kept
+	checkStock(t, services, 5, 1)
+	if status := services.order.orders[saga.OrderID].Status; status != pbOrder.OrderStatus_CANCELED {
+		t.Fatalf("Expected order CANCELED, got %v", status)
+	}
+	if len(services.cart.items) != 2 {
+		t.Fatalf("Expected cart to be kept")
+	}
+}
+
+func TestCheckoutCatalogChanged(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	services.catalog.items["item2"].Price = 6
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Failed {
+		t.Fatalf("Expected status FAILED, got %v", saga.Status)
+	}
+	if saga.FailureReason != domain.ReasonCatalogChanged {
+		t.Fatalf("Expected reason %s, got %s", domain.ReasonCatalogChanged, saga.FailureReason)
+	}
+	if len(services.order.orders) != 0 {
+		t.Fatalf("Expected no order to be created")
+	}
+	if len(services.cart.items) != 1 || services.cart.items[0].ItemId != "item1" {
+		t.Fatalf("Expected changed item to be removed from the cart, got %v", services.cart.items)
+	}
+	checkStock(t, services, 5, 1)
+}
+
+func TestCheckoutOrderItemsSKU(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	services.catalog.items["item1"].Sku = "ABC-HC"
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Completed {
+		t.Fatalf("Expected status COMPLETED, got %v (%s)", saga.Status, saga.FailureReason)
+	}
+	items := services.order.orders[saga.OrderID].Items
+	if len(items) != 2 || items[0].Sku != "ABC-HC" || items[1].Sku != "" {
+		t.Fatalf("Expected the SKUs of the catalog items in the order, got %v", items)
+	}
+}
+
+func TestCheckoutProductWithVariants(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// item2 got variants after it was added to the cart: one of them must be chosen
+	services.catalog.items["item2"].Variants = []*pbCatalog.CatalogItem{{ItemId: "item3", ProductId: "item2", Price: 5}}
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.FailureReason != domain.ReasonCatalogChanged {
+		t.Fatalf("Expected reason %s, got %s", domain.ReasonCatalogChanged, saga.FailureReason)
+	}
+	if len(services.cart.items) != 1 || services.cart.items[0].ItemId != "item1" {
+		t.Fatalf("Expected the product to be removed from the cart, got %v", services.cart.items)
+	}
+}
+
+func TestCheckoutRetriedAfterPayment(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// The order can't be confirmed: the payment is kept and the checkout waits for a retry
+	processing := pbOrder.OrderStatus_PROCESSING
+	services.order.failStatus = &processing
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Running {
+		t.Fatalf("Expected status RUNNING, got %v", saga.Status)
+	}
+	if status := services.payment.statuses[saga.OrderID]; status != pbPayment.PaymentStatus_PAID {
+		t.Fatalf("Expected payment PAID, got %v", status)
+	}
+
+	// The order service is back
+	services.order.failStatus = nil
+	o.Recover(context.Background())
+
+	saga, _ = repo.GetSaga(saga.CheckoutID)
+	if saga.Status != domain.Completed {
+		t.Fatalf("Expected status COMPLETED, got %v", saga.Status)
+	}
+	if status := services.order.orders[saga.OrderID].Status; status != pbOrder.OrderStatus_PROCESSING {
+		t.Fatalf("Expected order PROCESSING, got %v", status)
+	}
+	checkStock(t, services, 3, 0)
+}
+
+func TestRecoverInterruptedCheckout(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// Simulate a crash while the order was being created, after the stock was reserved
+	saga, _ := repo.CreateSaga("user1", 25, "card_token", nil)
+	saga.Items = []domain.SagaItem{
+		{CheckoutID: saga.CheckoutID, ItemID: "item1", Quantity: 2, Price: 10},
+		{CheckoutID: saga.CheckoutID, ItemID: "item2", Quantity: 1, Price: 5},
+	}
+	services.catalog.ReserveStock(context.Background(), &pbCatalog.ReserveStockRequest{Items: []*pbCatalog.StockItem{
+		{ItemId: "item1", Quantity: 2},
+		{ItemId: "item2", Quantity: 1},
+	}})
+	saga.ReservationID = "reservation1"
+	saga.CurrentStep = domain.CreateOrder
+	repo.SaveSaga(saga)
+
+	for _, step := range []domain.Step{domain.ValidateCart, domain.ReserveStock} {
+		repo.AppendLog(saga.CheckoutID, step, domain.Execute, domain.Started, nil)
+		repo.AppendLog(saga.CheckoutID, step, domain.Execute, domain.Succeeded, nil)
+	}
+	repo.AppendLog(saga.CheckoutID, domain.CreateOrder, domain.Execute, domain.Started, nil)
+
+	o.Recover(context.Background())
+
+	saga, _ = repo.GetSaga(saga.CheckoutID)
+	if saga.Status != domain.Failed {
+		t.Fatalf("Expected status FAILED, got %v", saga.Status)
+	}
+	checkStock(t, services, 5, 1)
+}
+
+func TestCheckoutReservationExpired(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// The reservation expires while the payment is processed
+	services.payment.onProcess = func() { services.catalog.expire("reservation1") }
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Failed {
+		t.Fatalf("Expected status FAILED, got %v", saga.Status)
+	}
+	if status := services.payment.statuses[saga.OrderID]; status != pbPayment.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected payment REFUNDED, got %v", status)
+	}
+	if status := services.order.orders[saga.OrderID].Status; status != pbOrder.OrderStatus_CANCELED {
+		t.Fatalf("Expected order CANCELED, got %v", status)
+	}
+	checkStock(t, services, 5, 1)
+}
+
+func TestCheckoutOrderCanceledByUser(t *testing.T) {
+	repo, o, services := setupOrchestrator(t)
+
+	// The user cancels the order while the payment is processed
+	services.payment.onProcess = func() { services.order.orders["order1"].Status = pbOrder.OrderStatus_CANCELED }
+
+	saga := runCheckout(t, repo, o, 25)
+
+	if saga.Status != domain.Failed {
+		t.Fatalf("Expected status FAILED, got %v", saga.Status)
+	}
+	if status := services.payment.statuses[saga.OrderID]; status != pbPayment.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected payment REFUNDED, got %v", status)
+	}
+	if services.catalog.committed[saga.ReservationID] {
+		t.Fatalf("Expected reservation not to be committed")
+	}
+	checkStock(t, services, 5, 1)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/orchestrator"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/checkout-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/idempotency"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+var port = "8086"
+
+// purgeInterval is how often the expired idempotency keys are deleted
+const purgeInterval = time.Hour
+
+// serviceName is the identity of the checkout service when it calls the other services
+const serviceName = "checkout-service"
+
+// recoveryInterval is how often the unfinished checkouts are resumed
+const recoveryInterval = time.Minute
+
+func main() {
+
+	// Initialize database connection with GORM
+	db, err := gorm.Open(sqlite.Open("checkout.db"), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Failed to connect database: %v", err)
+	}
+
+	// Migrate the schema
+	if err := db.AutoMigrate(&domain.Saga{}, &domain.SagaItem{}, &domain.SagaLogEntry{}, &idempotency.Record{}); err != nil {
+		log.Fatalf("Failed to migrate database schema: %v", err)
+	}
+
+	// The card numbers stored by the first sagas are dropped, the sagas keep only the tokens of the cards
+	if db.Migrator().HasColumn(&domain.Saga{}, "card_number") {
+		if err := db.Migrator().DropColumn(&domain.Saga{}, "card_number"); err != nil {
+			log.Fatalf("Failed to drop the card numbers: %v", err)
+		}
+	}
+
+	// Connections to the services taking part in the checkout, authenticated as a service
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(interceptor.NewServiceCredentials(tokens, serviceName)),
+	}
+
+	cartConn, err := grpc.NewClient("localhost:8082", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to cart service: %v", err)
+	}
+	defer cartConn.Close()
+
+	catalogConn, err := grpc.NewClient("localhost:8083", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to catalog service: %v", err)
+	}
+	defer catalogConn.Close()
+
+	orderConn, err := grpc.NewClient("localhost:8084", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer orderConn.Close()
+
+	paymentConn, err := grpc.NewClient("localhost:8085", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to payment service: %v", err)
+	}
+	defer paymentConn.Close()
+
+	// Start gRPC server
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", port, err)
+	}
+
+	// Initialize repository
+	checkoutRepo := repository.NewCheckoutServiceRepository(db)
+
+	// Initialize Orchestrator
+	paymentClient := pbPayment.NewPaymentServiceClient(paymentConn)
+	sagaOrchestrator := orchestrator.NewOrchestrator(checkoutRepo, &orchestrator.Clients{
+		Cart:    pbCart.NewCartServiceClient(cartConn),
+		Catalog: pbCatalog.NewCatalogServiceClient(catalogConn),
+		Order:   pbOrder.NewOrderServiceClient(orderConn),
+		Payment: paymentClient,
+	})
+
+	// Resume the checkouts interrupted by a crash, then periodically the ones waiting for a retry
+	go func() {
+		for {
+			sagaOrchestrator.Recover(context.Background())
+			time.Sleep(recoveryInterval)
+		}
+	}()
+
+	// Initialize CheckoutServer
+	checkoutServer := internal.NewCheckoutServer(checkoutRepo, sagaOrchestrator, paymentClient)
+
+	// Responses to requests with an idempotency key are replayed, expired keys are purged periodically
+	idempotencyStore := idempotency.NewStore(db, idempotency.DefaultTTL)
+	go func() {
+		for {
+			if _, err := idempotencyStore.PurgeExpired(); err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+			}
+			time.Sleep(purgeInterval)
+		}
+	}()
+
+	// Register gRPC server, every call is checked against the authorization policy before the idempotency keys
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(append(authorizer.ServerOptions(), grpc.ChainUnaryInterceptor(idempotencyStore.Unary()))...)
+	pb.RegisterCheckoutServiceServer(grpcServer, checkoutServer)
+
+	log.Printf("Checkout service listening on port %s", port)
+
+	// Start serving
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("gRPC checkout service failed: %v", err)
+	}
+}
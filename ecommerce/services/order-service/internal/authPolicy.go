@@ -0,0 +1,29 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// orderOwner returns the user targeted by a request.
+func orderOwner(req any) string {
+	if r, ok := req.(interface{ GetUserId() string }); ok {
+		return r.GetUserId()
+	}
+	return ""
+}
+
+// AuthPolicy defines who can call each RPC of the order service.
+// GetOrder, GetOrderPrice, GetOrderHistory and CancelOrder check the owner after reading the order,
+// GetRecommendations checks it only for the recommendations of a user.
+var AuthPolicy = interceptor.Policy{
+	pb.OrderService_CreateOrder_FullMethodName:        interceptor.OwnerOnly(orderOwner),
+	pb.OrderService_UpdateOrderStatus_FullMethodName:  interceptor.AdminOnly(),
+	pb.OrderService_GetOrder_FullMethodName:           interceptor.Authenticated(),
+	pb.OrderService_GetOrderPrice_FullMethodName:      interceptor.Authenticated(),
+	pb.OrderService_ListOrdersByUser_FullMethodName:   interceptor.OwnerOnly(orderOwner),
+	pb.OrderService_GetOrderHistory_FullMethodName:    interceptor.Authenticated(),
+	pb.OrderService_CancelOrder_FullMethodName:        interceptor.Authenticated(),
+	pb.OrderService_GetItemSales_FullMethodName:       interceptor.AdminOnly(),
+	pb.OrderService_GetRecommendations_FullMethodName: interceptor.Public(),
+}
@@ -0,0 +1,111 @@
+package cancellation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/domain"
+)
+
+// callTimeout bounds the calls to the other services
+const callTimeout = 10 * time.Second
+
+// Processor completes the cancellations: it gives back the stock of the items and refunds the payment.
+// Both calls are idempotent, a cancellation can be processed again until it succeeds.
+type Processor struct {
+	repo    domain.OrderServiceInterface
+	catalog pbCatalog.CatalogServiceClient
+	payment pbPayment.PaymentServiceClient
+}
+
+func NewProcessor(repo domain.OrderServiceInterface, catalog pbCatalog.CatalogServiceClient, payment pbPayment.PaymentServiceClient) *Processor {
+	return &Processor{repo: repo, catalog: catalog, payment: payment}
+}
+
+// Process completes a cancellation, recording its progress.
+func (p *Processor) Process(ctx context.Context, cancellation *domain.Cancellation) error {
+	if cancellation.Completed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+
+	err := p.process(ctx, cancellation)
+	if err != nil {
+		cancellation.LastError = err.Error()
+	} else {
+		cancellation.Completed = true
+		cancellation.LastError = ""
+	}
+
+	if saveErr := p.repo.SaveCancellation(cancellation); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// Retry processes again the cancellations not yet completed.
+func (p *Processor) Retry(ctx context.Context) {
+	cancellations, err := p.repo.ListPendingCancellations()
+	if err != nil {
+		log.Printf("Failed to list pending cancellations: %v", err)
+		return
+	}
+
+	for _, cancellation := range cancellations {
+		if err := p.Process(ctx, cancellation); err != nil {
+			log.Printf("Failed to complete cancellation of order %s: %v", cancellation.OrderID, err)
+		}
+	}
+}
+
+// PRIVATE FUNCTIONS
+
+func (p *Processor) process(ctx context.Context, cancellation *domain.Cancellation) error {
+
+	order, err := p.repo.GetOrder(cancellation.OrderID)
+	if err != nil {
+		return err
+	}
+
+	// Give back the items, the order ID makes the restock happen only once
+	if cancellation.Restock {
+		items := make([]*pbCatalog.StockItem, len(order.Items))
+		for i, item := range order.Items {
+			items[i] = &pbCatalog.StockItem{ItemId: item.ItemId, Quantity: item.Quantity}
+		}
+
+		if _, err := p.catalog.RestockItems(ctx, &pbCatalog.RestockItemsRequest{
+			RestockId: "cancel:" + order.OrderId,
+			OrderId:   order.OrderId,
+			Items:     items,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Refund what is left of the payment, if the order was paid
+	statusRes, err := p.payment.GetPaymentStatus(ctx, &pbPayment.GetPaymentStatusRequest{OrderId: order.OrderId})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if paymentStatus := statusRes.GetStatus(); paymentStatus != pbPayment.PaymentStatus_PAID && paymentStatus != pbPayment.PaymentStatus_PARTIALLY_REFUNDED {
+		return nil
+	}
+
+	_, err = p.payment.RefundPayment(ctx, &pbPayment.RefundPaymentRequest{
+		OrderId:        order.OrderId,
+		IdempotencyKey: "cancel:" + order.OrderId,
+	})
+	return err
+}
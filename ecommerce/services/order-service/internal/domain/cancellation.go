@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Cancellation tracks the work left after an order is canceled: giving back the stock and refunding the payment.
+// It is stored with the status change, so that the work is retried until completed even after a crash.
+type Cancellation struct {
+
+	// OrderID of the canceled order.
+	OrderID string `gorm:"primaryKey; not null; check:order_id <> ''"`
+
+	// Restock tells if the items must be given back to the catalog.
+	// Stock of orders still PENDING is held by the checkout, which releases it by itself.
+	Restock bool `gorm:"not null"`
+
+	// Completed tells if stock and payment have been handled.
+	Completed bool `gorm:"not null; index"`
+
+	// LastError is the error of the last attempt, if any.
+	LastError string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,36 @@
+package domain
+
+import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+
+// ItemPair counts the orders, canceled ones excluded, in which two items were bought together.
+// Every pair is stored in both directions, so that the items bought with an item are read by its ID.
+type ItemPair struct {
+
+	// ItemID of the item the recommendations are for.
+	ItemID string `gorm:"primaryKey; not null; check:item_id <> ''"`
+
+	// OtherItemID of the item bought together with it.
+	OtherItemID string `gorm:"primaryKey; not null; check:other_item_id <> ''"`
+
+	// Orders is the number of orders containing both items.
+	Orders int64 `gorm:"not null"`
+}
+
+// RecommendationProgress records how far the item pairs are counted, they are refreshed from the next status change.
+// There is a single progress, the pairs are rebuilt from scratch when it is deleted.
+type RecommendationProgress struct {
+
+	// ID is always 1.
+	ID uint `gorm:"primaryKey"`
+
+	// LastChangeID is the ID of the last status change counted in the item pairs.
+	LastChangeID uint `gorm:"not null"`
+}
+
+// DomainItemPairToProtoRecommendation converts an ItemPair into the recommendation of its other item
+func DomainItemPairToProtoRecommendation(pair *ItemPair) *pb.Recommendation {
+	return &pb.Recommendation{
+		ItemId: pair.OtherItemID,
+		Orders: uint32(pair.Orders),
+	}
+}
@@ -25,6 +25,24 @@ const (
 	Canceled Status = "CANCELED"
 )
 
+// transitions lists the statuses an order can move to from each status.
+// Delivered and canceled orders are final.
+var transitions = map[Status][]Status{
+	Pending:    {Processing, Canceled},
+	Processing: {Shipped, Canceled},
+	Shipped:    {Delivered},
+}
+
+// CanTransition tells if an order can move from one status to another.
+func CanTransition(from, to Status) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 type Order struct {
 
 	// OrderID is the unique identifier for the order.
@@ -53,6 +71,7 @@ func DomainOrderToProtoOrder(order *Order) (*pb.Order, error) {
 			ItemId:   item.ItemID,
 			Quantity: item.Quantity,
 			Price:    item.Price,
+			Sku:      item.SKU,
 		})
 	}
 
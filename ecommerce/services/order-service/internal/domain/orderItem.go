@@ -19,6 +19,9 @@ type OrderItem struct {
 
 	// Price represents the price of a single unit of the item at the time of the order.
 	Price float64 `gorm:"not null; check:price >= 0"`
+
+	// SKU is the SKU of the catalog item at the time of the order, the variant bought for a product with variants.
+	SKU string `gorm:"column:sku; not null; default:''"`
 }
 
 // DomainOrderItemToProtoOrderItem converts a model.OrderItem into a pb.OrderItem
@@ -30,5 +33,6 @@ func DomainOrderItemToProtoOrderItem(item *OrderItem) (*pb.OrderItem, error) {
 		ItemId:   item.ItemID,
 		Quantity: item.Quantity,
 		Price:    item.Price,
+		Sku:      item.SKU,
 	}, nil
 }
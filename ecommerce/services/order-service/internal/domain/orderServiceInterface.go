@@ -1,14 +1,18 @@
 package domain
 
-import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+import (
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+)
 
 type OrderServiceInterface interface {
 
 	// CreateOrder creates a new order with the provided details.
 	CreateOrder(userID string, items []*pb.OrderItem) (string, error)
 
-	// UpdateOrderStatus updates the status of an order by its unique identifier.
-	UpdateOrderStatus(orderID string, status pb.OrderStatus) error
+	// UpdateOrderStatus moves an order to a new status, if the transition is legal, on behalf of actor.
+	UpdateOrderStatus(orderID string, status pb.OrderStatus, actor string) error
 
 	// GetOrder retrieves an order by its unique identifier.
 	GetOrder(orderID string) (*pb.Order, error)
@@ -18,4 +22,35 @@ type OrderServiceInterface interface {
 
 	// ListOrdersByUser retrieves all orders associated with a specific user.
 	ListOrdersByUser(userID string) ([]*pb.Order, error)
+
+	// GetOrderHistory retrieves the status changes of an order, oldest first.
+	GetOrderHistory(orderID string) ([]*pb.OrderStatusChange, error)
+
+	// CancelOrder moves an order not yet shipped to CANCELED on behalf of actor.
+	// It returns the work left to complete the cancellation.
+	CancelOrder(orderID string, actor string) (*Cancellation, error)
+
+	// ListPendingCancellations retrieves the cancellations not yet completed.
+	ListPendingCancellations() ([]*Cancellation, error)
+
+	// SaveCancellation stores the progress of a cancellation.
+	SaveCancellation(cancellation *Cancellation) error
+
+	// GetItemSales retrieves the units of each item sold by the orders created since a time, canceled orders excluded.
+	GetItemSales(since time.Time) ([]*pb.ItemSales, error)
+
+	// ListItemIDs retrieves the distinct IDs of the items in the orders.
+	ListItemIDs() ([]string, error)
+
+	// RewriteItemIDs replaces the IDs of the items in the orders with the new ones.
+	RewriteItemIDs(itemIDs map[string]string) (int64, error)
+
+	// RefreshItemPairs counts the orders created and canceled since the last refresh in the items bought together.
+	RefreshItemPairs() (int, error)
+
+	// GetRecommendations retrieves the items most often bought together with an item.
+	GetRecommendations(itemID string, limit int) ([]*pb.Recommendation, error)
+
+	// GetRecommendationsForUser retrieves the items most often bought together with the ones bought by a user.
+	GetRecommendationsForUser(userID string, limit int) ([]*pb.Recommendation, error)
 }
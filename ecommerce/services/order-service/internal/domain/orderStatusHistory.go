@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+)
+
+type OrderStatusHistory struct {
+
+	// ID is the unique identifier of the change, increasing with time.
+	ID uint `gorm:"primaryKey; autoIncrement"`
+
+	// OrderID of the order whose status changed.
+	OrderID string `gorm:"not null; index; check:order_id <> ''"`
+
+	// FromStatus is the previous status, empty when the order is created.
+	FromStatus Status
+
+	// ToStatus is the status reached.
+	ToStatus Status `gorm:"not null; check:to_status in ('PENDING', 'PROCESSING', 'SHIPPED', 'DELIVERED', 'CANCELED')"`
+
+	// Actor is the user or service that changed the status.
+	Actor string `gorm:"not null"`
+
+	// ChangedAt is the time of the change.
+	ChangedAt time.Time `gorm:"not null"`
+}
+
+// TableName overrides the default table name of GORM.
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
+
+// DomainStatusHistoryToProtoStatusChange converts a model.OrderStatusHistory into a pb.OrderStatusChange
+func DomainStatusHistoryToProtoStatusChange(change *OrderStatusHistory) (*pb.OrderStatusChange, error) {
+	if change == nil {
+		return nil, fmt.Errorf("Input argument is nil")
+	}
+
+	return &pb.OrderStatusChange{
+		Status:    pb.OrderStatus(pb.OrderStatus_value[string(change.ToStatus)]),
+		ChangedAt: change.ChangedAt.Unix(),
+		Actor:     change.Actor,
+	}, nil
+}
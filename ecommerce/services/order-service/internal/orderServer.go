@@ -1,23 +1,37 @@
 package internal
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"log"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/cancellation"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// Number of recommendations returned when no limit is given, and the greatest limit
+const (
+	defaultRecommendations = 10
+	maxRecommendations     = 50
 )
 
 // OrderServer implements the order service gRPC server.
 type OrderServer struct {
 	pb.OrderServiceServer
-	repo domain.OrderServiceInterface
+	repo          domain.OrderServiceInterface
+	cancellations *cancellation.Processor
 }
 
-func NewOrderServer(repo domain.OrderServiceInterface) *OrderServer {
-	return &OrderServer{repo: repo}
+func NewOrderServer(repo domain.OrderServiceInterface, cancellations *cancellation.Processor) *OrderServer {
+	return &OrderServer{repo: repo, cancellations: cancellations}
 }
 
 // CreateOrder creates a new order in the database.
@@ -75,7 +89,19 @@ func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrder
 		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
 	}
 
-	if err := s.repo.UpdateOrderStatus(req.OrderId, req.Status); err != nil {
+	// Only the checkout cancels an order by its status, since it gives back the stock and the payment itself.
+	// The orders canceled by the others are restocked and refunded as by CancelOrder
+	if req.Status == pb.OrderStatus_CANCELED && !calledByService(ctx) {
+		if err := s.cancelOrder(ctx, req.OrderId); err != nil {
+			return &pb.UpdateOrderStatusResponse{ErrorMessage: err.Error()}, err
+		}
+		return &pb.UpdateOrderStatusResponse{}, nil
+	}
+
+	if err := s.repo.UpdateOrderStatus(req.OrderId, req.Status, actorFromContext(ctx)); err != nil {
+		if errors.Is(err, repository.ErrIllegalTransition) {
+			return &pb.UpdateOrderStatusResponse{ErrorMessage: err.Error()}, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return &pb.UpdateOrderStatusResponse{ErrorMessage: err.Error()}, err
 	}
 	return &pb.UpdateOrderStatusResponse{}, nil
@@ -94,6 +120,11 @@ func (s *OrderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*p
 	if err != nil {
 		return &pb.GetOrderResponse{ErrorMessage: err.Error()}, err
 	}
+
+	// Only the owner of the order can read it
+	if err := interceptor.CheckOwner(ctx, order.UserId); err != nil {
+		return &pb.GetOrderResponse{ErrorMessage: err.Error()}, err
+	}
 	return &pb.GetOrderResponse{Order: order}, nil
 }
 
@@ -106,6 +137,15 @@ func (s *OrderServer) GetOrderPrice(ctx context.Context, req *pb.GetOrderPriceRe
 		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
 	}
 
+	// Only the owner of the order can read its price
+	order, err := s.repo.GetOrder(req.OrderId)
+	if err != nil {
+		return &pb.GetOrderPriceResponse{ErrorMessage: err.Error()}, err
+	}
+	if err := interceptor.CheckOwner(ctx, order.UserId); err != nil {
+		return &pb.GetOrderPriceResponse{ErrorMessage: err.Error()}, err
+	}
+
 	totalPrice, err := s.repo.GetOrderPrice(req.OrderId)
 	if err != nil {
 		return &pb.GetOrderPriceResponse{ErrorMessage: err.Error()}, err
@@ -128,3 +168,134 @@ func (s *OrderServer) ListOrdersByUser(ctx context.Context, req *pb.ListOrdersBy
 	}
 	return &pb.ListOrdersByUserResponse{Orders: orders}, nil
 }
+
+// GetOrderHistory retrieves the status changes of an order.
+func (s *OrderServer) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+
+	if req.OrderId == "" {
+		return &pb.GetOrderHistoryResponse{
+			ErrorMessage: "Order ID must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
+	}
+
+	// Only the owner of the order can read its history
+	order, err := s.repo.GetOrder(req.OrderId)
+	if err != nil {
+		return &pb.GetOrderHistoryResponse{ErrorMessage: err.Error()}, err
+	}
+	if err := interceptor.CheckOwner(ctx, order.UserId); err != nil {
+		return &pb.GetOrderHistoryResponse{ErrorMessage: err.Error()}, err
+	}
+
+	history, err := s.repo.GetOrderHistory(req.OrderId)
+	if err != nil {
+		return &pb.GetOrderHistoryResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.GetOrderHistoryResponse{History: history}, nil
+}
+
+// CancelOrder cancels an order not yet shipped, giving back its items and refunding its payment.
+func (s *OrderServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+
+	if req.OrderId == "" {
+		return &pb.CancelOrderResponse{
+			ErrorMessage: "Order ID must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
+	}
+
+	// Only the owner of the order can cancel it
+	order, err := s.repo.GetOrder(req.OrderId)
+	if err != nil {
+		return &pb.CancelOrderResponse{ErrorMessage: err.Error()}, err
+	}
+	if err := interceptor.CheckOwner(ctx, order.UserId); err != nil {
+		return &pb.CancelOrderResponse{ErrorMessage: err.Error()}, err
+	}
+
+	if err := s.cancelOrder(ctx, req.OrderId); err != nil {
+		return &pb.CancelOrderResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.CancelOrderResponse{}, nil
+}
+
+// GetItemSales retrieves the units of each item sold by the orders created since a time, canceled orders excluded.
+func (s *OrderServer) GetItemSales(ctx context.Context, req *pb.GetItemSalesRequest) (*pb.GetItemSalesResponse, error) {
+
+	if req.Since < 0 || req.Since > time.Now().Unix() {
+		return &pb.GetItemSalesResponse{
+			ErrorMessage: "Since must be a time in the past",
+		}, status.Error(codes.InvalidArgument, "Since must be a time in the past")
+	}
+
+	sales, err := s.repo.GetItemSales(time.Unix(req.Since, 0))
+	if err != nil {
+		return &pb.GetItemSalesResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.GetItemSalesResponse{Sales: sales}, nil
+}
+
+// GetRecommendations retrieves the items bought together with an item, or with the items bought by a user.
+// Everyone can see the recommendations for an item, only the user and the admins the ones for a user.
+func (s *OrderServer) GetRecommendations(ctx context.Context, req *pb.GetRecommendationsRequest) (*pb.GetRecommendationsResponse, error) {
+
+	if (req.ItemId == "") == (req.UserId == "") {
+		return &pb.GetRecommendationsResponse{
+			ErrorMessage: "Either Item ID or User ID must be provided",
+		}, status.Error(codes.InvalidArgument, "Either Item ID or User ID must be provided")
+	}
+	if req.Limit > maxRecommendations {
+		return &pb.GetRecommendationsResponse{
+			ErrorMessage: "Limit cannot be greater than 50",
+		}, status.Error(codes.InvalidArgument, "Limit cannot be greater than 50")
+	}
+	limit := int(cmp.Or(req.Limit, defaultRecommendations))
+
+	if req.ItemId != "" {
+		recommendations, err := s.repo.GetRecommendations(req.ItemId, limit)
+		if err != nil {
+			return &pb.GetRecommendationsResponse{ErrorMessage: err.Error()}, err
+		}
+		return &pb.GetRecommendationsResponse{Recommendations: recommendations}, nil
+	}
+
+	// The purchases of a user are read only by the user
+	if err := interceptor.CheckOwner(ctx, req.UserId); err != nil {
+		return &pb.GetRecommendationsResponse{ErrorMessage: err.Error()}, err
+	}
+	recommendations, err := s.repo.GetRecommendationsForUser(req.UserId, limit)
+	if err != nil {
+		return &pb.GetRecommendationsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.GetRecommendationsResponse{Recommendations: recommendations}, nil
+}
+
+// cancelOrder cancels an order, then gives back its items and refunds its payment.
+func (s *OrderServer) cancelOrder(ctx context.Context, orderID string) error {
+	pending, err := s.repo.CancelOrder(orderID, actorFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, repository.ErrIllegalTransition) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return err
+	}
+
+	// The order is canceled anyway, restock and refund failing now are retried in background
+	if err := s.cancellations.Process(context.WithoutCancel(ctx), pending); err != nil {
+		log.Printf("Cancellation of order %s will be retried: %v", orderID, err)
+	}
+	return nil
+}
+
+// calledByService tells if the RPC is called by another service rather than by a user or an admin.
+func calledByService(ctx context.Context) bool {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	return ok && claims.Role == interceptor.RoleService
+}
+
+// actorFromContext returns the user or service calling the RPC, recorded in the history of orders.
+func actorFromContext(ctx context.Context) string {
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		return claims.Subject
+	}
+	return "unknown"
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	ulid "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
@@ -10,6 +12,9 @@ import (
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/domain"
 )
 
+// ErrIllegalTransition is returned when an order can't move to the requested status
+var ErrIllegalTransition = errors.New("Illegal order status transition")
+
 type OrderServiceRepository struct {
 	db *gorm.DB
 }
@@ -58,6 +63,7 @@ func (r *OrderServiceRepository) CreateOrder(userID string, items []*pb.OrderIte
 			ItemID:   item.ItemId,
 			Quantity: item.Quantity,
 			Price:    item.Price,
+			SKU:      item.Sku,
 		}
 	}
 	order := &domain.Order{
@@ -67,15 +73,23 @@ func (r *OrderServiceRepository) CreateOrder(userID string, items []*pb.OrderIte
 		Status:  domain.Pending,
 	}
 
-	// Save Order to Database
-	if err := r.db.Create(order).Error; err != nil {
+	// Save Order to Database, with the first entry of its history
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		return recordStatusChange(tx, orderID, "", domain.Pending, userID)
+	})
+	if err != nil {
 		return "", err
 	}
 	return orderID, nil
 }
 
-// UpdateOrderStatus updates the status of an existing order.
-func (r *OrderServiceRepository) UpdateOrderStatus(orderID string, status pb.OrderStatus) error {
+// UpdateOrderStatus moves an existing order to a new status, if the transition is legal.
+// Setting the current status again has no effect, so that retried calls don't fail.
+// An order canceled this way is recorded with a cancellation already completed: stock and payment are left to the caller.
+func (r *OrderServiceRepository) UpdateOrderStatus(orderID string, status pb.OrderStatus, actor string) error {
 
 	// Validate OrderID
 	if err := checkValidID(orderID); err != nil {
@@ -87,15 +101,37 @@ func (r *OrderServiceRepository) UpdateOrderStatus(orderID string, status pb.Ord
 		return err
 	}
 
-	// Update Status in Database
-	result := r.db.Model(&domain.Order{}).Where("order_id = ?", orderID).Update("status", domainStatus)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("order not found")
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := tx.Where("order_id = ?", orderID).First(&order).Error; err != nil {
+			return errors.New("order not found")
+		}
+
+		if order.Status == domainStatus {
+			return nil
+		}
+		if !domain.CanTransition(order.Status, domainStatus) {
+			return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, order.Status, domainStatus)
+		}
+
+		// Update Status in Database, only if nobody changed it in the meantime
+		result := tx.Model(&domain.Order{}).Where("order_id = ? AND status = ?", orderID, order.Status).Update("status", domainStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: status of order %s changed concurrently", ErrIllegalTransition, orderID)
+		}
+		if err := recordStatusChange(tx, orderID, order.Status, domainStatus, actor); err != nil {
+			return err
+		}
+
+		// The caller gives back the stock and the payment by itself, the cancellation has nothing left to do
+		if domainStatus == domain.Canceled {
+			return tx.Create(&domain.Cancellation{OrderID: orderID, Completed: true}).Error
+		}
+		return nil
+	})
 }
 
 // GetOrder retrieves an order by its unique identifier.
@@ -164,6 +200,158 @@ func (r *OrderServiceRepository) ListOrdersByUser(userID string) ([]*pb.Order, e
 	return orders, nil
 }
 
+// GetOrderHistory retrieves the status changes of an order, oldest first.
+func (r *OrderServiceRepository) GetOrderHistory(orderID string) ([]*pb.OrderStatusChange, error) {
+
+	// Validate OrderID
+	if err := checkValidID(orderID); err != nil {
+		return nil, err
+	}
+
+	// Retrieve History from Database
+	var changes []*domain.OrderStatusHistory
+	if err := r.db.Where("order_id = ?", orderID).Order("id").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	history := make([]*pb.OrderStatusChange, len(changes))
+	for i, change := range changes {
+		protoChange, err := domain.DomainStatusHistoryToProtoStatusChange(change)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = protoChange
+	}
+	return history, nil
+}
+
+// CancelOrder moves an order not yet shipped to CANCELED and records the work left to complete the cancellation.
+// Canceling an order twice returns the existing cancellation.
+func (r *OrderServiceRepository) CancelOrder(orderID string, actor string) (*domain.Cancellation, error) {
+
+	// Validate OrderID
+	if err := checkValidID(orderID); err != nil {
+		return nil, err
+	}
+
+	var cancellation domain.Cancellation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := tx.Where("order_id = ?", orderID).First(&order).Error; err != nil {
+			return errors.New("order not found")
+		}
+
+		// Orders canceled by their status before the cancellations were recorded have nothing left to do
+		if order.Status == domain.Canceled {
+			err := tx.Where("order_id = ?", orderID).First(&cancellation).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				cancellation = domain.Cancellation{OrderID: orderID, Completed: true}
+				return nil
+			}
+			return err
+		}
+		if !domain.CanTransition(order.Status, domain.Canceled) {
+			return fmt.Errorf("%w: %s orders cannot be canceled", ErrIllegalTransition, order.Status)
+		}
+
+		result := tx.Model(&domain.Order{}).Where("order_id = ? AND status = ?", orderID, order.Status).Update("status", domain.Canceled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: status of order %s changed concurrently", ErrIllegalTransition, orderID)
+		}
+		if err := recordStatusChange(tx, orderID, order.Status, domain.Canceled, actor); err != nil {
+			return err
+		}
+
+		cancellation = domain.Cancellation{
+			OrderID: orderID,
+			Restock: order.Status == domain.Processing,
+		}
+		return tx.Create(&cancellation).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cancellation, nil
+}
+
+// ListPendingCancellations retrieves the cancellations not yet completed, oldest first.
+func (r *OrderServiceRepository) ListPendingCancellations() ([]*domain.Cancellation, error) {
+	var cancellations []*domain.Cancellation
+	if err := r.db.Where("completed = ?", false).Order("created_at").Find(&cancellations).Error; err != nil {
+		return nil, err
+	}
+	return cancellations, nil
+}
+
+// SaveCancellation stores the progress of a cancellation.
+func (r *OrderServiceRepository) SaveCancellation(cancellation *domain.Cancellation) error {
+	if cancellation == nil {
+		return errors.New("cancellation cannot be nil")
+	}
+	return r.db.Save(cancellation).Error
+}
+
+// GetItemSales retrieves the units of each item sold by the orders created since a time, canceled orders excluded.
+// An order is created at its first status change, the items are sorted by their ID.
+func (r *OrderServiceRepository) GetItemSales(since time.Time) ([]*pb.ItemSales, error) {
+	var sales []*pb.ItemSales
+	err := r.db.Model(&domain.OrderItem{}).
+		Select("order_items.item_id AS item_id, SUM(order_items.quantity) AS quantity").
+		Joins("JOIN orders ON orders.order_id = order_items.order_id").
+		Joins("JOIN order_status_history h ON h.order_id = order_items.order_id AND h.from_status = ''").
+		Where("orders.status <> ? AND h.changed_at >= ?", domain.Canceled, since).
+		Group("order_items.item_id").
+		Order("order_items.item_id").
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+// ListItemIDs retrieves the distinct IDs of the items in the orders.
+func (r *OrderServiceRepository) ListItemIDs() ([]string, error) {
+	var itemIDs []string
+	err := r.db.Model(&domain.OrderItem{}).Distinct().Pluck("item_id", &itemIDs).Error
+	return itemIDs, err
+}
+
+// RewriteItemIDs replaces the IDs of the items in the orders with the new ones, it returns the number of rows changed.
+// The item pairs counted with the old IDs are counted again at the next refresh.
+func (r *OrderServiceRepository) RewriteItemIDs(itemIDs map[string]string) (int64, error) {
+	var rewritten int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for legacyID, itemID := range itemIDs {
+			result := tx.Model(&domain.OrderItem{}).Where("item_id = ?", legacyID).Update("item_id", itemID)
+			if result.Error != nil {
+				return result.Error
+			}
+			rewritten += result.RowsAffected
+		}
+		if rewritten == 0 {
+			return nil
+		}
+		return resetItemPairs(tx)
+	})
+	return rewritten, err
+}
+
+// PRIVATE FUNCTIONS TO RECORD THE HISTORY OF ORDERS
+
+// recordStatusChange appends a status change to the history of an order.
+func recordStatusChange(tx *gorm.DB, orderID string, from, to domain.Status, actor string) error {
+	return tx.Create(&domain.OrderStatusHistory{
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      actor,
+		ChangedAt:  time.Now(),
+	}).Error
+}
+
 // PRIVATE FUNCTIONS TO CHECK ON THE VALIDITY OF INPUTS
 
 // checkValidID checks if the provided ID is valid (non-empty).
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/domain"
+)
+
+// refreshBatchSize bounds the status changes counted in the item pairs by each transaction
+const refreshBatchSize = 500
+
+// RefreshItemPairs counts in the item pairs the orders created and canceled since the last refresh.
+// A new order adds its pairs, a canceled one takes them back; it returns the number of status changes counted.
+func (r *OrderServiceRepository) RefreshItemPairs() (int, error) {
+	refreshed := 0
+	for {
+		counted, err := r.refreshItemPairsBatch()
+		refreshed += counted
+		if err != nil || counted < refreshBatchSize {
+			return refreshed, err
+		}
+	}
+}
+
+// GetRecommendations retrieves the items most often bought together with an item, at most limit of them.
+func (r *OrderServiceRepository) GetRecommendations(itemID string, limit int) ([]*pb.Recommendation, error) {
+
+	// Validate ItemID
+	if err := checkValidID(itemID); err != nil {
+		return nil, err
+	}
+
+	var pairs []*domain.ItemPair
+	if err := r.db.Where("item_id = ?", itemID).
+		Order("orders DESC, other_item_id").
+		Limit(limit).
+		Find(&pairs).Error; err != nil {
+		return nil, err
+	}
+	return toProtoRecommendations(pairs), nil
+}
+
+// GetRecommendationsForUser retrieves the items most often bought together with the ones bought by a user,
+// at most limit of them. The items the user already bought are not recommended.
+func (r *OrderServiceRepository) GetRecommendationsForUser(userID string, limit int) ([]*pb.Recommendation, error) {
+
+	// Validate UserID
+	if err := checkValidID(userID); err != nil {
+		return nil, err
+	}
+
+	bought := r.db.Model(&domain.OrderItem{}).
+		Select("order_items.item_id").
+		Joins("JOIN orders ON orders.order_id = order_items.order_id").
+		Where("orders.user_id = ? AND orders.status <> ?", userID, domain.Canceled)
+
+	var pairs []*domain.ItemPair
+	if err := r.db.Model(&domain.ItemPair{}).
+		Select("other_item_id, SUM(orders) AS orders").
+		Where("item_id IN (?) AND other_item_id NOT IN (?)", bought, bought).
+		Group("other_item_id").
+		Order("orders DESC, other_item_id").
+		Limit(limit).
+		Scan(&pairs).Error; err != nil {
+		return nil, err
+	}
+	return toProtoRecommendations(pairs), nil
+}
+
+// PRIVATE FUNCTIONS TO COUNT THE ITEMS BOUGHT TOGETHER
+
+// refreshItemPairsBatch counts the next status changes creating or canceling an order, moving the progress after them
+func (r *OrderServiceRepository) refreshItemPairsBatch() (int, error) {
+	counted := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var progress domain.RecommendationProgress
+		if err := tx.FirstOrCreate(&progress, domain.RecommendationProgress{ID: 1}).Error; err != nil {
+			return err
+		}
+
+		var changes []*domain.OrderStatusHistory
+		if err := tx.Where("id > ? AND (from_status = '' OR to_status = ?)", progress.LastChangeID, domain.Canceled).
+			Order("id").
+			Limit(refreshBatchSize).
+			Find(&changes).Error; err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		// The distinct items of each order, an item bought in several lines is counted once
+		orderIDs := make([]string, len(changes))
+		for i, change := range changes {
+			orderIDs[i] = change.OrderID
+		}
+		var items []*domain.OrderItem
+		if err := tx.Where("order_id IN ?", orderIDs).Find(&items).Error; err != nil {
+			return err
+		}
+		orderItems := make(map[string][]string)
+		for _, item := range items {
+			if !slices.Contains(orderItems[item.OrderID], item.ItemID) {
+				orderItems[item.OrderID] = append(orderItems[item.OrderID], item.ItemID)
+			}
+		}
+
+		// A created order adds its pairs, a canceled one takes them back
+		deltas := make(map[domain.ItemPair]int64)
+		for _, change := range changes {
+			delta := int64(1)
+			if change.ToStatus == domain.Canceled {
+				delta = -1
+			}
+			for _, itemID := range orderItems[change.OrderID] {
+				for _, otherItemID := range orderItems[change.OrderID] {
+					if itemID != otherItemID {
+						deltas[domain.ItemPair{ItemID: itemID, OtherItemID: otherItemID}] += delta
+					}
+				}
+			}
+		}
+
+		for pair, delta := range deltas {
+			if delta == 0 {
+				continue
+			}
+			pair.Orders = delta
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "item_id"}, {Name: "other_item_id"}},
+				DoUpdates: clause.Assignments(map[string]any{"orders": gorm.Expr("item_pairs.orders + ?", delta)}),
+			}).Create(&pair).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("orders <= 0").Delete(&domain.ItemPair{}).Error; err != nil {
+			return err
+		}
+
+		progress.LastChangeID = changes[len(changes)-1].ID
+		counted = len(changes)
+		return tx.Save(&progress).Error
+	})
+	return counted, err
+}
+
+// resetItemPairs deletes the item pairs and their progress, they are counted again from the first order
+func resetItemPairs(tx *gorm.DB) error {
+	if err := tx.Where("1 = 1").Delete(&domain.ItemPair{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("1 = 1").Delete(&domain.RecommendationProgress{}).Error
+}
+
+func toProtoRecommendations(pairs []*domain.ItemPair) []*pb.Recommendation {
+	recommendations := make([]*pb.Recommendation, len(pairs))
+	for i, pair := range pairs {
+		recommendations[i] = domain.DomainItemPairToProtoRecommendation(pair)
+	}
+	return recommendations
+}
@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/cancellation"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+// fakeCatalog records the restocks, applying each restock ID once
+type fakeCatalog struct {
+	pbCatalog.CatalogServiceClient
+	restocked map[string]uint32
+	applied   map[string]bool
+	fail      bool
+}
+
+func (c *fakeCatalog) RestockItems(ctx context.Context, req *pbCatalog.RestockItemsRequest, opts ...grpc.CallOption) (*pbCatalog.RestockItemsResponse, error) {
+	if c.fail {
+		return nil, status.Error(codes.Unavailable, "catalog down")
+	}
+	if c.applied[req.RestockId] {
+		return &pbCatalog.RestockItemsResponse{}, nil
+	}
+	c.applied[req.RestockId] = true
+	for _, item := range req.Items {
+		c.restocked[item.ItemId] += item.Quantity
+	}
+	return &pbCatalog.RestockItemsResponse{}, nil
+}
+
+// fakePayment keeps the status of the payment of each order
+type fakePayment struct {
+	pbPayment.PaymentServiceClient
+	payments map[string]pbPayment.PaymentStatus
+}
+
+func (p *fakePayment) GetPaymentStatus(ctx context.Context, req *pbPayment.GetPaymentStatusRequest, opts ...grpc.CallOption) (*pbPayment.GetPaymentStatusResponse, error) {
+	paymentStatus, ok := p.payments[req.OrderId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "No payment for this order ID")
+	}
+	return &pbPayment.GetPaymentStatusResponse{Status: paymentStatus}, nil
+}
+
+func (p *fakePayment) RefundPayment(ctx context.Context, req *pbPayment.RefundPaymentRequest, opts ...grpc.CallOption) (*pbPayment.RefundPaymentResponse, error) {
+	if p.payments[req.OrderId] != pbPayment.PaymentStatus_PAID {
+		return nil, errors.New("Only PAID payments can be refunded")
+	}
+	p.payments[req.OrderId] = pbPayment.PaymentStatus_REFUNDED
+	return &pbPayment.RefundPaymentResponse{}, nil
+}
+
+func setupCancellationTest(t *testing.T) (*repository.OrderServiceRepository, *fakeCatalog, *fakePayment, *cancellation.Processor) {
+	_, repo := setupTest(t)
+	catalog := &fakeCatalog{restocked: map[string]uint32{}, applied: map[string]bool{}}
+	payment := &fakePayment{payments: map[string]pbPayment.PaymentStatus{}}
+	return repo, catalog, payment, cancellation.NewProcessor(repo, catalog, payment)
+}
+
+func TestCancellationRestocksAndRefunds(t *testing.T) {
+	repo, catalog, payment, processor := setupCancellationTest(t)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{
+		{ItemId: "item123", Quantity: 2, Price: 10},
+		{ItemId: "item456", Quantity: 1, Price: 5},
+	})
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+	payment.payments[orderID] = pbPayment.PaymentStatus_PAID
+
+	pending, err := repo.CancelOrder(orderID, "user789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := processor.Process(context.Background(), pending); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if catalog.restocked["item123"] != 2 || catalog.restocked["item456"] != 1 {
+		t.Fatalf("Unexpected restock: %v", catalog.restocked)
+	}
+	if payment.payments[orderID] != pbPayment.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected payment REFUNDED, got %v", payment.payments[orderID])
+	}
+	if !pending.Completed {
+		t.Fatalf("Expected cancellation to be completed")
+	}
+}
+
+func TestCancellationOfPendingOrderWithoutPayment(t *testing.T) {
+	repo, catalog, _, processor := setupCancellationTest(t)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}})
+
+	pending, _ := repo.CancelOrder(orderID, "user789")
+	if err := processor.Process(context.Background(), pending); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The stock of pending orders is released by the checkout
+	if len(catalog.restocked) != 0 {
+		t.Fatalf("Expected no restock, got %v", catalog.restocked)
+	}
+	if !pending.Completed {
+		t.Fatalf("Expected cancellation to be completed")
+	}
+}
+
+func TestCancellationRetriedAfterFailure(t *testing.T) {
+	repo, catalog, payment, processor := setupCancellationTest(t)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}})
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+	payment.payments[orderID] = pbPayment.PaymentStatus_PAID
+
+	// The catalog is down, the cancellation stays pending
+	catalog.fail = true
+	pending, _ := repo.CancelOrder(orderID, "user789")
+	if err := processor.Process(context.Background(), pending); err == nil {
+		t.Fatalf("Expected error with catalog down, got nil")
+	}
+
+	stored, _ := repo.ListPendingCancellations()
+	if len(stored) != 1 || stored[0].LastError == "" {
+		t.Fatalf("Expected pending cancellation with last error, got %v", stored)
+	}
+
+	// Retrying twice restocks once
+	catalog.fail = false
+	processor.Retry(context.Background())
+	processor.Retry(context.Background())
+
+	if catalog.restocked["item123"] != 2 {
+		t.Fatalf("Expected 2 items restocked, got %d", catalog.restocked["item123"])
+	}
+	if payment.payments[orderID] != pbPayment.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected payment REFUNDED, got %v", payment.payments[orderID])
+	}
+	if stored, _ := repo.ListPendingCancellations(); len(stored) != 0 {
+		t.Fatalf("Expected no pending cancellation, got %v", stored)
+	}
+}
+
+// updateStatusAs calls UpdateOrderStatus through the authorizer, as a caller with the given role
+func updateStatusAs(t *testing.T, server *internal.OrderServer, subject, role string, req *pb.UpdateOrderStatusRequest) error {
+	tokens := token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+	raw, _, err := tokens.Issue(subject, role, token.Access)
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+raw))
+	info := &grpc.UnaryServerInfo{FullMethod: pb.OrderService_UpdateOrderStatus_FullMethodName}
+	_, err = interceptor.NewAuthorizer(tokens, internal.AuthPolicy).Unary()(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return server.UpdateOrderStatus(ctx, req.(*pb.UpdateOrderStatusRequest))
+	})
+	return err
+}
+
+func TestAdminCancellationByStatusRestocksAndRefunds(t *testing.T) {
+	repo, catalog, payment, processor := setupCancellationTest(t)
+	server := internal.NewOrderServer(repo, processor)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}})
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+	payment.payments[orderID] = pbPayment.PaymentStatus_PAID
+
+	err := updateStatusAs(t, server, "admin", interceptor.RoleAdmin, &pb.UpdateOrderStatusRequest{OrderId: orderID, Status: pb.OrderStatus_CANCELED})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	order, _ := repo.GetOrder(orderID)
+	if order.Status != pb.OrderStatus_CANCELED {
+		t.Fatalf("Expected order CANCELED, got %v", order.Status)
+	}
+	if catalog.restocked["item123"] != 2 {
+		t.Fatalf("Expected 2 items restocked, got %d", catalog.restocked["item123"])
+	}
+	if payment.payments[orderID] != pbPayment.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected payment REFUNDED, got %v", payment.payments[orderID])
+	}
+	if stored, _ := repo.ListPendingCancellations(); len(stored) != 0 {
+		t.Fatalf("Expected no pending cancellation, got %v", stored)
+	}
+
+	// Canceling again restocks nothing more
+	err = updateStatusAs(t, server, "admin", interceptor.RoleAdmin, &pb.UpdateOrderStatusRequest{OrderId: orderID, Status: pb.OrderStatus_CANCELED})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if catalog.restocked["item123"] != 2 {
+		t.Fatalf("Expected 2 items restocked, got %d", catalog.restocked["item123"])
+	}
+}
+
+func TestServiceCancellationByStatusChangesOnlyStatus(t *testing.T) {
+	repo, catalog, payment, processor := setupCancellationTest(t)
+	server := internal.NewOrderServer(repo, processor)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}})
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+	payment.payments[orderID] = pbPayment.PaymentStatus_PAID
+
+	// The checkout gives back the stock and the payment itself
+	err := updateStatusAs(t, server, "checkout-service", interceptor.RoleService, &pb.UpdateOrderStatusRequest{OrderId: orderID, Status: pb.OrderStatus_CANCELED})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	order, _ := repo.GetOrder(orderID)
+	if order.Status != pb.OrderStatus_CANCELED {
+		t.Fatalf("Expected order CANCELED, got %v", order.Status)
+	}
+	if len(catalog.restocked) != 0 {
+		t.Fatalf("Expected no restock, got %v", catalog.restocked)
+	}
+	if payment.payments[orderID] != pbPayment.PaymentStatus_PAID {
+		t.Fatalf("Expected payment PAID, got %v", payment.payments[orderID])
+	}
+
+	// Canceled again by the customer, the order is already canceled and nothing is given back
+	pending, err := repo.CancelOrder(orderID, "user789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := processor.Process(context.Background(), pending); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(catalog.restocked) != 0 || payment.payments[orderID] != pbPayment.PaymentStatus_PAID {
+		t.Fatalf("Expected nothing given back, got %v and payment %v", catalog.restocked, payment.payments[orderID])
+	}
+	if stored, _ := repo.ListPendingCancellations(); len(stored) != 0 {
+		t.Fatalf("Expected no pending cancellation, got %v", stored)
+	}
+}
@@ -1,7 +1,9 @@
 package tests
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	ulid "github.com/oklog/ulid/v2"
 	"gorm.io/driver/sqlite"
@@ -18,7 +20,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect database: %v", err)
 	}
 
-	err = db.AutoMigrate(&domain.Order{}, &domain.OrderItem{})
+	err = db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderStatusHistory{}, &domain.Cancellation{}, &domain.ItemPair{}, &domain.RecommendationProgress{})
 	if err != nil {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
@@ -130,6 +132,26 @@ func TestCreateNewOrderToExistingUser(t *testing.T) {
 	}
 }
 
+func TestCreateOrderWithSKU(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// Test the SKU of the chosen variant is kept in the order
+	orderID, err := repo.CreateOrder("user789", []*pb.OrderItem{
+		{ItemId: "item111", Quantity: 1, Price: 29.99, Sku: "ABC-HC"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	order, err := repo.GetOrder(orderID)
+	if err != nil {
+		t.Fatalf("Failed to get order: %v", err)
+	}
+	if len(order.Items) != 1 || order.Items[0].Sku != "ABC-HC" {
+		t.Fatalf("Expected SKU ABC-HC in the order, got %v", order.Items)
+	}
+}
+
 func TestCreateOrderWithInvalidUserID(t *testing.T) {
 	db, repo := setupTest(t)
 
@@ -248,7 +270,7 @@ func TestUpdateOrderStatusValid(t *testing.T) {
 	}
 
 	// Update order status
-	if err = repo.UpdateOrderStatus(order.OrderId, pb.OrderStatus_SHIPPED); err != nil {
+	if err = repo.UpdateOrderStatus(order.OrderId, pb.OrderStatus_SHIPPED, "admin"); err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
@@ -266,7 +288,7 @@ func TestUpdateOrderStatusInvalidID(t *testing.T) {
 	db, repo := setupTest(t)
 
 	// Attempt to update status with invalid orderID
-	err := repo.UpdateOrderStatus("", pb.OrderStatus_DELIVERED)
+	err := repo.UpdateOrderStatus("", pb.OrderStatus_DELIVERED, "admin")
 	if err == nil {
 		t.Fatalf("Expected error for invalid orderID, got nil")
 	}
@@ -287,7 +309,7 @@ func TestUpdateOrderStatusNonExistentID(t *testing.T) {
 	db, repo := setupTest(t)
 
 	// Attempt to update status with non-existent orderID
-	err := repo.UpdateOrderStatus("nonexistentid", pb.OrderStatus_CANCELED)
+	err := repo.UpdateOrderStatus("nonexistentid", pb.OrderStatus_CANCELED, "admin")
 	if err == nil {
 		t.Fatalf("Expected error for non-existent orderID, got nil")
 	}
@@ -459,3 +481,214 @@ func TestListOrdersByUserInvalidID(t *testing.T) {
 		t.Fatalf("Expected 0 orders for empty userID, got %d", len(orders))
 	}
 }
+
+func TestUpdateOrderStatusFollowsStateMachine(t *testing.T) {
+	_, repo := setupTest(t)
+
+	orderID, err := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// PENDING -> SHIPPED skips the processing
+	if err := repo.UpdateOrderStatus(orderID, pb.OrderStatus_SHIPPED, "admin"); !errors.Is(err, repository.ErrIllegalTransition) {
+		t.Fatalf("Expected ErrIllegalTransition, got %v", err)
+	}
+
+	for _, status := range []pb.OrderStatus{pb.OrderStatus_PROCESSING, pb.OrderStatus_SHIPPED, pb.OrderStatus_DELIVERED} {
+		if err := repo.UpdateOrderStatus(orderID, status, "admin"); err != nil {
+			t.Fatalf("Expected no error moving to %v, got %v", status, err)
+		}
+	}
+
+	// Delivered orders are final
+	if err := repo.UpdateOrderStatus(orderID, pb.OrderStatus_PENDING, "admin"); !errors.Is(err, repository.ErrIllegalTransition) {
+		t.Fatalf("Expected ErrIllegalTransition, got %v", err)
+	}
+	if err := repo.UpdateOrderStatus(orderID, pb.OrderStatus_CANCELED, "admin"); !errors.Is(err, repository.ErrIllegalTransition) {
+		t.Fatalf("Expected ErrIllegalTransition, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatusCanceledIsFinal(t *testing.T) {
+	_, repo := setupTest(t)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+
+	if err := repo.UpdateOrderStatus(orderID, pb.OrderStatus_CANCELED, "user789"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := repo.UpdateOrderStatus(orderID, pb.OrderStatus_SHIPPED, "admin"); !errors.Is(err, repository.ErrIllegalTransition) {
+		t.Fatalf("Expected ErrIllegalTransition, got %v", err)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	_, repo := setupTest(t)
+
+	orderID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+
+	// Setting the same status again is not recorded
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_PROCESSING, "checkout-service")
+
+	// Illegal transitions are not recorded
+	repo.UpdateOrderStatus(orderID, pb.OrderStatus_DELIVERED, "admin")
+
+	history, err := repo.GetOrderHistory(orderID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 status changes, got %d", len(history))
+	}
+	if history[0].Status != pb.OrderStatus_PENDING || history[0].Actor != "user789" {
+		t.Fatalf("Unexpected first change: %v", history[0])
+	}
+	if history[1].Status != pb.OrderStatus_PROCESSING || history[1].Actor != "checkout-service" {
+		t.Fatalf("Unexpected second change: %v", history[1])
+	}
+	if history[1].ChangedAt < history[0].ChangedAt {
+		t.Fatalf("Expected changes in chronological order")
+	}
+}
+
+func TestGetOrderHistoryInvalidID(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.GetOrderHistory(""); err == nil {
+		t.Fatalf("Expected error for invalid orderID, got nil")
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	_, repo := setupTest(t)
+
+	pendingID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+	processingID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}})
+	repo.UpdateOrderStatus(processingID, pb.OrderStatus_PROCESSING, "checkout-service")
+
+	// The stock of pending orders is still held by the checkout
+	cancellation, err := repo.CancelOrder(pendingID, "user789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cancellation.Restock || cancellation.Completed {
+		t.Fatalf("Unexpected cancellation of pending order: %+v", cancellation)
+	}
+
+	cancellation, err = repo.CancelOrder(processingID, "user789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !cancellation.Restock {
+		t.Fatalf("Expected restock for processing order")
+	}
+
+	order, _ := repo.GetOrder(processingID)
+	if order.Status != pb.OrderStatus_CANCELED {
+		t.Fatalf("Expected status CANCELED, got %v", order.Status)
+	}
+
+	history, _ := repo.GetOrderHistory(processingID)
+	if last := history[len(history)-1]; last.Status != pb.OrderStatus_CANCELED || last.Actor != "user789" {
+		t.Fatalf("Unexpected last change: %v", last)
+	}
+
+	// Canceling again returns the same cancellation
+	again, err := repo.CancelOrder(processingID, "user789")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if again.OrderID != processingID || !again.Restock {
+		t.Fatalf("Unexpected cancellation: %+v", again)
+	}
+}
+
+func TestCancelOrderShipped(t *testing.T) {
+	db, repo := setupTest(t)
+
+	var shipped domain.Order
+	db.Where("status = ?", domain.Shipped).First(&shipped)
+
+	if _, err := repo.CancelOrder(shipped.OrderID, "user456"); !errors.Is(err, repository.ErrIllegalTransition) {
+		t.Fatalf("Expected ErrIllegalTransition, got %v", err)
+	}
+	if _, err := repo.CancelOrder("", "user456"); err == nil {
+		t.Fatalf("Expected error for invalid orderID, got nil")
+	}
+}
+
+func TestListPendingCancellations(t *testing.T) {
+	_, repo := setupTest(t)
+
+	firstID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+	secondID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 1, Price: 10}})
+
+	first, _ := repo.CancelOrder(firstID, "user789")
+	repo.CancelOrder(secondID, "user789")
+
+	first.Completed = true
+	if err := repo.SaveCancellation(first); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pending, err := repo.ListPendingCancellations()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pending) != 1 || pending[0].OrderID != secondID {
+		t.Fatalf("Expected only the second cancellation to be pending, got %v", pending)
+	}
+}
+
+func TestRewriteItemIDs(t *testing.T) {
+	db, repo := setupTest(t)
+
+	itemIDs, err := repo.ListItemIDs()
+	if err != nil || len(itemIDs) != 4 {
+		t.Fatalf("Expected 4 item IDs, got %v (%v)", itemIDs, err)
+	}
+
+	rewritten, err := repo.RewriteItemIDs(map[string]string{"item123": "01NEWITEM123", "unknown": "01NEWUNKNOWN"})
+	if err != nil || rewritten != 1 {
+		t.Fatalf("Expected 1 order item rewritten, got %v (%v)", rewritten, err)
+	}
+
+	var count int64
+	db.Model(&domain.OrderItem{}).Where("item_id = ?", "item123").Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no order item with the legacy ID, got %v", count)
+	}
+	db.Model(&domain.OrderItem{}).Where("item_id = ? AND quantity = ?", "01NEWITEM123", 10).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected the order item with the new ID, got %v", count)
+	}
+}
+
+func TestGetItemSales(t *testing.T) {
+	db, repo := setupTest(t)
+
+	firstID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 2, Price: 10}, {ItemId: "item456", Quantity: 1, Price: 5}})
+	repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item123", Quantity: 3, Price: 10}})
+	canceledID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item456", Quantity: 7, Price: 5}})
+	repo.CancelOrder(canceledID, "user789")
+
+	// An order created before the window is left out
+	oldID, _ := repo.CreateOrder("user789", []*pb.OrderItem{{ItemId: "item789", Quantity: 4, Price: 1}})
+	db.Model(&domain.OrderStatusHistory{}).Where("order_id = ?", oldID).Update("changed_at", time.Now().Add(-48*time.Hour))
+
+	sales, err := repo.GetItemSales(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sales) != 2 || sales[0].ItemId != "item123" || sales[0].Quantity != 5 || sales[1].ItemId != "item456" || sales[1].Quantity != 1 {
+		t.Fatalf("Expected 5 units of item123 and 1 of item456, got %v", sales)
+	}
+
+	// The status changes after the creation don't count it again
+	repo.UpdateOrderStatus(firstID, pb.OrderStatus_PROCESSING, "checkout-service")
+	if sales, _ := repo.GetItemSales(time.Now().Add(-24 * time.Hour)); len(sales) != 2 || sales[0].Quantity != 5 {
+		t.Fatalf("Expected the same sales, got %v", sales)
+	}
+}
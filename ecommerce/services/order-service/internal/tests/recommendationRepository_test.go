@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/repository"
+)
+
+// createOrderOf creates an order of one unit of each item
+func createOrderOf(t *testing.T, repo *repository.OrderServiceRepository, userID string, itemIDs ...string) string {
+	items := make([]*pb.OrderItem, len(itemIDs))
+	for i, itemID := range itemIDs {
+		items[i] = &pb.OrderItem{ItemId: itemID, Quantity: 1, Price: 10}
+	}
+	orderID, err := repo.CreateOrder(userID, items)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	return orderID
+}
+
+// recommendedOrders returns the number of orders of each recommended item, in their order
+func recommendedOrders(recommendations []*pb.Recommendation) []string {
+	var got []string
+	for _, recommendation := range recommendations {
+		got = append(got, fmt.Sprintf("%s:%d", recommendation.ItemId, recommendation.Orders))
+	}
+	return got
+}
+
+func refresh(t *testing.T, repo *repository.OrderServiceRepository, want int) {
+	refreshed, err := repo.RefreshItemPairs()
+	if err != nil || refreshed != want {
+		t.Fatalf("Expected %d status changes counted, got %d (%v)", want, refreshed, err)
+	}
+}
+
+func TestGetRecommendations(t *testing.T) {
+	_, repo := setupTest(t)
+
+	createOrderOf(t, repo, "alice", "book", "bookmark", "lamp")
+	createOrderOf(t, repo, "bob", "book", "bookmark")
+	createOrderOf(t, repo, "carol", "book", "pen", "book")
+	refresh(t, repo, 3)
+
+	recommendations, err := repo.GetRecommendations("book", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, want := recommendedOrders(recommendations), []string{"bookmark:2", "lamp:1", "pen:1"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+
+	// The pairs are counted in both directions
+	if recommendations, _ := repo.GetRecommendations("lamp", 10); len(recommendations) != 2 {
+		t.Fatalf("Expected book and bookmark for lamp, got %v", recommendedOrders(recommendations))
+	}
+	if recommendations, _ := repo.GetRecommendations("book", 1); len(recommendations) != 1 || recommendations[0].ItemId != "bookmark" {
+		t.Fatalf("Expected only bookmark, got %v", recommendedOrders(recommendations))
+	}
+	if _, err := repo.GetRecommendations("", 10); err == nil {
+		t.Fatalf("Expected error for an empty item ID, got nil")
+	}
+}
+
+func TestRefreshItemPairsIncrementally(t *testing.T) {
+	_, repo := setupTest(t)
+
+	createOrderOf(t, repo, "alice", "book", "bookmark")
+	refresh(t, repo, 1)
+
+	// Only the new orders are counted, the status changes after the creation are not
+	processingID := createOrderOf(t, repo, "bob", "book", "bookmark")
+	repo.UpdateOrderStatus(processingID, pb.OrderStatus_PROCESSING, "checkout-service")
+	refresh(t, repo, 1)
+	refresh(t, repo, 0)
+	if recommendations, _ := repo.GetRecommendations("book", 10); len(recommendations) != 1 || recommendations[0].Orders != 2 {
+		t.Fatalf("Expected bookmark in 2 orders, got %v", recommendedOrders(recommendations))
+	}
+
+	// A canceled order takes its pairs back, the pairs in no order are gone
+	canceledID := createOrderOf(t, repo, "carol", "book", "pen")
+	refresh(t, repo, 1)
+	if _, err := repo.CancelOrder(canceledID, "carol"); err != nil {
+		t.Fatalf("Failed to cancel order: %v", err)
+	}
+	if _, err := repo.CancelOrder(processingID, "bob"); err != nil {
+		t.Fatalf("Failed to cancel order: %v", err)
+	}
+	refresh(t, repo, 2)
+	if recommendations, _ := repo.GetRecommendations("book", 10); len(recommendations) != 1 || recommendations[0].ItemId != "bookmark" ||
+		recommendations[0].Orders != 1 {
+		t.Fatalf("Expected bookmark in 1 order, got %v", recommendedOrders(recommendations))
+	}
+
+	// An order created and canceled between two refreshes is never counted
+	lostID := createOrderOf(t, repo, "dave", "lamp", "pen")
+	repo.CancelOrder(lostID, "dave")
+	refresh(t, repo, 2)
+	if recommendations, _ := repo.GetRecommendations("lamp", 10); len(recommendations) != 0 {
+		t.Fatalf("Expected no recommendations, got %v", recommendedOrders(recommendations))
+	}
+}
+
+func TestGetRecommendationsForUser(t *testing.T) {
+	_, repo := setupTest(t)
+
+	createOrderOf(t, repo, "alice", "book", "bookmark", "lamp")
+	createOrderOf(t, repo, "bob", "book", "bookmark")
+	createOrderOf(t, repo, "bob", "lamp", "bookmark")
+	createOrderOf(t, repo, "carol", "lamp", "pen")
+	canceledID := createOrderOf(t, repo, "dave", "pen", "ink")
+	repo.CancelOrder(canceledID, "dave")
+	createOrderOf(t, repo, "dave", "book")
+	refresh(t, repo, 7)
+
+	// The items bought with book and lamp, without the ones already bought
+	recommendations, err := repo.GetRecommendationsForUser("alice", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := recommendedOrders(recommendations); len(got) != 1 || got[0] != "pen:1" {
+		t.Fatalf("Expected pen in 1 order, got %v", got)
+	}
+
+	// The items of the canceled orders of the user are not among the ones bought
+	recommendations, _ = repo.GetRecommendationsForUser("dave", 10)
+	if got := recommendedOrders(recommendations); !slices.Equal(got, []string{"bookmark:2", "lamp:1"}) {
+		t.Fatalf("Expected bookmark and lamp, got %v", got)
+	}
+
+	if recommendations, _ := repo.GetRecommendationsForUser("nobody", 10); len(recommendations) != 0 {
+		t.Fatalf("Expected no recommendations, got %v", recommendedOrders(recommendations))
+	}
+}
+
+func TestRewriteItemIDsRecountsItemPairs(t *testing.T) {
+	_, repo := setupTest(t)
+
+	createOrderOf(t, repo, "alice", "Legacy Book", "bookmark")
+	refresh(t, repo, 1)
+
+	if _, err := repo.RewriteItemIDs(map[string]string{"Legacy Book": "01BOOK"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	refresh(t, repo, 1)
+	if recommendations, _ := repo.GetRecommendations("bookmark", 10); len(recommendations) != 1 || recommendations[0].ItemId != "01BOOK" {
+		t.Fatalf("Expected the new ID recommended, got %v", recommendedOrders(recommendations))
+	}
+}
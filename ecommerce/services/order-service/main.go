@@ -1,21 +1,45 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/cancellation"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/domain"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/order-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/idempotency"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 )
 
 var port = "8084"
 
+// purgeInterval is how often the expired idempotency keys are deleted
+const purgeInterval = time.Hour
+
+// serviceName is the identity of the order service when it calls the other services
+const serviceName = "order-service"
+
+// retryInterval is how often the incomplete cancellations are retried
+const retryInterval = time.Minute
+
+// recommendationInterval is how often the items bought together are counted again from the new orders
+const recommendationInterval = time.Minute
+
+// migrationRetryInterval is how often the migration of the item IDs is retried while the catalog is unreachable
+const migrationRetryInterval = 30 * time.Second
+
 func main() {
 
 	// Initialize database connection with GORM
@@ -25,10 +49,32 @@ func main() {
 	}
 
 	// Migrate the schema
-	if err := db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}); err != nil {
+	if err := db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderStatusHistory{}, &domain.Cancellation{}, &domain.ItemPair{}, &domain.RecommendationProgress{}, &idempotency.Record{}); err != nil {
 		log.Fatalf("Failed to migrate database schema: %v", err)
 	}
 
+	// Connections to the services involved in cancellations, authenticated as a service
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(interceptor.NewServiceCredentials(tokens, serviceName)),
+	}
+
+	catalogConn, err := grpc.NewClient("localhost:8083", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to catalog service: %v", err)
+	}
+	defer catalogConn.Close()
+
+	paymentConn, err := grpc.NewClient("localhost:8085", opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to payment service: %v", err)
+	}
+	defer paymentConn.Close()
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -38,11 +84,61 @@ func main() {
 	// Initialize repository
 	orderRepo := repository.NewOrderServiceRepository(db)
 
+	// Initialize cancellation Processor
+	cancellations := cancellation.NewProcessor(orderRepo,
+		pbCatalog.NewCatalogServiceClient(catalogConn),
+		pbPayment.NewPaymentServiceClient(paymentConn))
+
+	// Items of the orders may still be identified by their title, their generated IDs are asked to the catalog
+	go func() {
+		catalog := pbCatalog.NewCatalogServiceClient(catalogConn)
+		for {
+			err := migrateItemIDs(catalog, orderRepo)
+			if err == nil {
+				return
+			}
+			log.Printf("Failed to migrate the item IDs, retrying: %v", err)
+			time.Sleep(migrationRetryInterval)
+		}
+	}()
+
+	// Periodically complete the cancellations whose restock or refund failed
+	go func() {
+		for {
+			cancellations.Retry(context.Background())
+			time.Sleep(retryInterval)
+		}
+	}()
+
+	// Periodically count the orders created and canceled in the meantime in the items bought together
+	go func() {
+		for {
+			if refreshed, err := orderRepo.RefreshItemPairs(); err != nil {
+				log.Printf("Failed to refresh the recommendations: %v", err)
+			} else if refreshed > 0 {
+				log.Printf("Counted %d order changes in the recommendations", refreshed)
+			}
+			time.Sleep(recommendationInterval)
+		}
+	}()
+
 	// Initialize OrderServer
-	orderServer := internal.NewOrderServer(orderRepo)
+	orderServer := internal.NewOrderServer(orderRepo, cancellations)
 
-	// Register gRPC server
-	grpcServer := grpc.NewServer()
+	// Responses to requests with an idempotency key are replayed, expired keys are purged periodically
+	idempotencyStore := idempotency.NewStore(db, idempotency.DefaultTTL)
+	go func() {
+		for {
+			if _, err := idempotencyStore.PurgeExpired(); err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+			}
+			time.Sleep(purgeInterval)
+		}
+	}()
+
+	// Register gRPC server, every call is checked against the authorization policy before the idempotency keys
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(append(authorizer.ServerOptions(), grpc.ChainUnaryInterceptor(idempotencyStore.Unary()))...)
 	pb.RegisterOrderServiceServer(grpcServer, orderServer)
 
 	log.Printf("Order service listening on port %s", port)
@@ -52,3 +148,28 @@ func main() {
 		log.Fatalf("gRPC order service failed: %v", err)
 	}
 }
+
+// migrateItemIDs rewrites the items of the orders identified by their title with the IDs generated by the catalog
+func migrateItemIDs(catalog pbCatalog.CatalogServiceClient, orderRepo *repository.OrderServiceRepository) error {
+	itemIDs, err := orderRepo.ListItemIDs()
+	if err != nil || len(itemIDs) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := catalog.ResolveLegacyItemIDs(ctx, &pbCatalog.ResolveLegacyItemIDsRequest{LegacyIds: itemIDs})
+	if err != nil {
+		return err
+	}
+	if len(res.GetItemIds()) == 0 {
+		return nil
+	}
+
+	rewritten, err := orderRepo.RewriteItemIDs(res.GetItemIds())
+	if err == nil {
+		log.Printf("Rewrote %d order items with the generated item IDs", rewritten)
+	}
+	return err
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
+)
+
+var port = "8090"
+
+// The stub serves the fake gateway over HTTP, to run the payment service with PAYMENT_PROVIDER_URL=http://localhost:8090
+func main() {
+
+	gateway := provider.NewFakeGateway(provider.FakeGatewayConfig{})
+
+	log.Printf("Payment gateway stub listening on port %s", port)
+
+	if err := http.ListenAndServe(":"+port, provider.NewStubHandler(gateway)); err != nil {
+		log.Fatalf("Payment gateway stub failed: %v", err)
+	}
+}
@@ -4,7 +4,13 @@ go 1.25.1
 
 replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto => ../../proto
 
-require github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0-00010101000000-000000000000
+replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared => ../../shared
+
+require (
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0-00010101000000-000000000000
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared v0.0.0-00010101000000-000000000000
+	github.com/oklog/ulid/v2 v2.1.1
+)
 
 require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
@@ -0,0 +1,18 @@
+package internal
+
+import (
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// AuthPolicy defines who can call each RPC of the payment service.
+// Payments are created, charged and refunded by the checkout on behalf of the user, only services and admins can do it.
+// Cards are tokenized and charged only by the checkout, never directly by a user.
+var AuthPolicy = interceptor.Policy{
+	pb.PaymentService_CreatePayment_FullMethodName:           interceptor.AdminOnly(),
+	pb.PaymentService_TokenizeCard_FullMethodName:            interceptor.ServiceOnly(),
+	pb.PaymentService_ProcessPayment_FullMethodName:          interceptor.ServiceOnly(),
+	pb.PaymentService_GetPaymentStatus_FullMethodName:        interceptor.AdminOnly(),
+	pb.PaymentService_RefundPayment_FullMethodName:           interceptor.AdminOnly(),
+	pb.PaymentService_ListPaymentTransactions_FullMethodName: interceptor.AdminOnly(),
+}
@@ -12,6 +12,9 @@ const (
 	PendingPayment PaymentStatus = "PENDING_PAYMENT"
 	Paid           PaymentStatus = "PAID"
 	PaymentFailed  PaymentStatus = "PAYMENT_FAILED"
+	Refunded       PaymentStatus = "REFUNDED"
+
+	PartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
 )
 
 type Payment struct {
@@ -22,8 +25,14 @@ type Payment struct {
 	// Amount paid
 	Amount float64 `gorm:"not null; check:amount >= 0"`
 
+	// AuthorizationID is the reference of the captured money at the payment provider
+	AuthorizationID string
+
+	// Amount given back to the user
+	RefundedAmount float64 `gorm:"not null; default:0; check:refunded_amount >= 0"`
+
 	// Current status of the payment
-	Status PaymentStatus `gorm:"not null; check:status in ('PENDING_PAYMENT', 'PAID', 'PAYMENT_FAILED')"`
+	Status PaymentStatus `gorm:"not null; check:status in ('PENDING_PAYMENT', 'PAID', 'PAYMENT_FAILED', 'REFUNDED', 'PARTIALLY_REFUNDED')"`
 }
 
 // DomainPaymentStatusToProtoPaymentStatus converts a model.Payment.Status into a pb.PaymentStatus
@@ -35,6 +44,10 @@ func DomainPaymentStatusToProtoPaymentStatus(status PaymentStatus) (pb.PaymentSt
 		return pb.PaymentStatus_PAID, nil
 	case PaymentFailed:
 		return pb.PaymentStatus_PAYMENT_FAILED, nil
+	case Refunded:
+		return pb.PaymentStatus_REFUNDED, nil
+	case PartiallyRefunded:
+		return pb.PaymentStatus_PARTIALLY_REFUNDED, nil
 	default:
 		return pb.PaymentStatus(0), fmt.Errorf("invalid domain payment status: %v", status)
 	}
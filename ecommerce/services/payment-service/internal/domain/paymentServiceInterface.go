@@ -1,15 +1,29 @@
 package domain
 
-import pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+import (
+	"context"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+)
 
 type PaymentServiceInterface interface {
 
 	// Creates a new payment
 	CreatePayment(orderID string, amount float64) error
 
-	// Processes a payment for a given order ID and amount
-	ProcessPayment(orderID string, amount float64) error
+	// Stores a card at the payment provider, returning the token that charges it
+	TokenizeCard(ctx context.Context, cardNumber string) (string, error)
+
+	// Processes a payment for a given order ID and amount, charging the card of the token through the payment provider
+	ProcessPayment(ctx context.Context, orderID string, amount float64, cardToken string) error
 
 	// Retrieves the payment status for a given order ID
 	GetPaymentStatus(orderID string) (pb.PaymentStatus, error)
+
+	// Refunds amount of a paid payment for a given order ID, 0 refunds everything left.
+	// It returns the resulting status of the payment. A refund whose outcome is unknown is resumed by the next call.
+	RefundPayment(ctx context.Context, orderID string, amount float64) (pb.PaymentStatus, error)
+
+	// Retrieves the transactions of the payment for a given order ID, oldest first
+	ListPaymentTransactions(orderID string) ([]*pb.PaymentTransaction, error)
 }
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+)
+
+type TransactionType string
+
+const (
+	Attempt TransactionType = "ATTEMPT"
+	Capture TransactionType = "CAPTURE"
+	Refund  TransactionType = "REFUND"
+)
+
+// PaymentTransaction is a movement of money of a payment: every processing attempt, the capture and the refunds.
+// Transactions are only appended, the Payment row keeps the resulting status.
+type PaymentTransaction struct {
+
+	// TransactionID is the unique identifier (ULID) of the transaction
+	TransactionID string `gorm:"primaryKey; not null"`
+
+	// OrderID of the payment
+	OrderID string `gorm:"not null; index; check:order_id <> ''"`
+
+	// Type of the transaction
+	Type TransactionType `gorm:"not null; check:type in ('ATTEMPT', 'CAPTURE', 'REFUND')"`
+
+	// Amount of money moved (or offered, for attempts)
+	Amount float64 `gorm:"not null; check:amount >= 0"`
+
+	// Succeeded tells if the transaction went through
+	Succeeded bool `gorm:"not null"`
+
+	// FailureReason explains why the transaction failed
+	FailureReason string
+
+	// Pending tells the refund has been asked to the payment provider but its outcome is not recorded yet
+	Pending bool `gorm:"not null; default:false"`
+
+	CreatedAt time.Time
+}
+
+// DomainTransactionToProtoTransaction converts a model.PaymentTransaction into a pb.PaymentTransaction
+func DomainTransactionToProtoTransaction(transaction *PaymentTransaction) (*pb.PaymentTransaction, error) {
+	var protoType pb.TransactionType
+	switch transaction.Type {
+	case Attempt:
+		protoType = pb.TransactionType_ATTEMPT
+	case Capture:
+		protoType = pb.TransactionType_CAPTURE
+	case Refund:
+		protoType = pb.TransactionType_REFUND
+	default:
+		return nil, fmt.Errorf("invalid domain transaction type: %v", transaction.Type)
+	}
+
+	return &pb.PaymentTransaction{
+		TransactionId: transaction.TransactionID,
+		OrderId:       transaction.OrderID,
+		Type:          protoType,
+		Amount:        transaction.Amount,
+		Succeeded:     transaction.Succeeded,
+		FailureReason: transaction.FailureReason,
+		Pending:       transaction.Pending,
+		CreatedAt:     transaction.CreatedAt.Unix(),
+	}, nil
+}
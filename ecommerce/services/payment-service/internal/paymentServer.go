@@ -2,12 +2,16 @@ package internal
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/repository"
 )
 
 // PaymentServer implements the payment service gRPC server.
@@ -41,6 +45,29 @@ func (s *PaymentServer) CreatePayment(ctx context.Context, req *pb.CreatePayment
 	return &pb.CreatePaymentResponse{}, nil
 }
 
+// TokenizeCard stores a card at the payment provider, returning the token that charges it.
+// A card number not valid is refused with InvalidArgument.
+func (s *PaymentServer) TokenizeCard(ctx context.Context, req *pb.TokenizeCardRequest) (*pb.TokenizeCardResponse, error) {
+
+	if req.CardNumber == "" {
+		return &pb.TokenizeCardResponse{
+			ErrorMessage: "Card number must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Card number must be provided and not empty")
+	}
+
+	cardToken, err := s.repo.TokenizeCard(ctx, req.CardNumber)
+	if errors.Is(err, provider.ErrInvalidCard) {
+		return &pb.TokenizeCardResponse{ErrorMessage: err.Error()}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, provider.ErrTimeout) {
+		return &pb.TokenizeCardResponse{ErrorMessage: err.Error()}, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return &pb.TokenizeCardResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.TokenizeCardResponse{CardToken: cardToken}, nil
+}
+
 // ProcessPayment processes a payment for a given order ID and amount.
 func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPaymentRequest) (*pb.ProcessPaymentResponse, error) {
 
@@ -56,7 +83,17 @@ func (s *PaymentServer) ProcessPayment(ctx context.Context, req *pb.ProcessPayme
 		}, status.Error(codes.InvalidArgument, "Amount cannot be negative")
 	}
 
-	if err := s.repo.ProcessPayment(req.OrderId, req.Amount); err != nil {
+	if req.CardToken == "" {
+		return &pb.ProcessPaymentResponse{
+			ErrorMessage: "Card token must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Card token must be provided and not empty")
+	}
+
+	err := s.repo.ProcessPayment(ctx, req.OrderId, req.Amount, req.CardToken)
+	if errors.Is(err, provider.ErrTimeout) {
+		return &pb.ProcessPaymentResponse{ErrorMessage: err.Error()}, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
 		return &pb.ProcessPaymentResponse{ErrorMessage: err.Error()}, err
 	}
 	return &pb.ProcessPaymentResponse{}, nil
@@ -71,9 +108,62 @@ func (s *PaymentServer) GetPaymentStatus(ctx context.Context, req *pb.GetPayment
 		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
 	}
 
-	status, err := s.repo.GetPaymentStatus(req.OrderId)
+	paymentStatus, err := s.repo.GetPaymentStatus(req.OrderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.GetPaymentStatusResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "No payment for this order ID")
+	}
 	if err != nil {
 		return &pb.GetPaymentStatusResponse{ErrorMessage: err.Error()}, err
 	}
-	return &pb.GetPaymentStatusResponse{Status: status}, nil
+	return &pb.GetPaymentStatusResponse{Status: paymentStatus}, nil
+}
+
+// RefundPayment refunds the payment of a given order ID, fully or partially.
+func (s *PaymentServer) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+
+	if req.OrderId == "" {
+		return &pb.RefundPaymentResponse{
+			ErrorMessage: "Order ID must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
+	}
+
+	if req.Amount < 0 {
+		return &pb.RefundPaymentResponse{
+			ErrorMessage: "Amount cannot be negative",
+		}, status.Error(codes.InvalidArgument, "Amount cannot be negative")
+	}
+
+	paymentStatus, err := s.repo.RefundPayment(ctx, req.OrderId, req.Amount)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.RefundPaymentResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "No payment for this order ID")
+	}
+	if errors.Is(err, provider.ErrTimeout) {
+		return &pb.RefundPaymentResponse{ErrorMessage: err.Error()}, status.Error(codes.Unavailable, err.Error())
+	}
+	if errors.Is(err, repository.ErrRefundRefused) {
+		return &pb.RefundPaymentResponse{ErrorMessage: err.Error()}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return &pb.RefundPaymentResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.RefundPaymentResponse{Status: paymentStatus}, nil
+}
+
+// ListPaymentTransactions retrieves the attempts, captures and refunds of the payment of a given order ID.
+func (s *PaymentServer) ListPaymentTransactions(ctx context.Context, req *pb.ListPaymentTransactionsRequest) (*pb.ListPaymentTransactionsResponse, error) {
+
+	if req.OrderId == "" {
+		return &pb.ListPaymentTransactionsResponse{
+			ErrorMessage: "Order ID must be provided and not empty",
+		}, status.Error(codes.InvalidArgument, "Order ID must be provided and not empty")
+	}
+
+	transactions, err := s.repo.ListPaymentTransactions(req.OrderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.ListPaymentTransactionsResponse{ErrorMessage: err.Error()}, status.Error(codes.NotFound, "No payment for this order ID")
+	}
+	if err != nil {
+		return &pb.ListPaymentTransactionsResponse{ErrorMessage: err.Error()}, err
+	}
+	return &pb.ListPaymentTransactionsResponse{Transactions: transactions}, nil
 }
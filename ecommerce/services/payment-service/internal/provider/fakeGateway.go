@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// CardRule is the outcome the fake gateway simulates for a card.
+type CardRule int
+
+const (
+	Approve CardRule = iota
+	Decline
+	InsufficientFunds
+	Timeout
+)
+
+// DefaultCardRules are the test cards recognized by the fake gateway, any other valid number is approved.
+var DefaultCardRules = map[string]CardRule{
+	"4000000000000002": Decline,
+	"4000000000009995": InsufficientFunds,
+	"4000000000000119": Timeout,
+}
+
+// FakeGatewayConfig configures the failures simulated by the fake gateway.
+type FakeGatewayConfig struct {
+
+	// Rules maps card numbers to their outcome, DefaultCardRules if nil
+	Rules map[string]CardRule
+
+	// Balances limits the funds of some cards, the others have unlimited funds
+	Balances map[string]float64
+
+	// Latency is added to every call
+	Latency time.Duration
+
+	// TimeoutAfter is how long cards with the Timeout rule hang before failing
+	TimeoutAfter time.Duration
+}
+
+type authorizationState int
+
+const (
+	authorized authorizationState = iota
+	captured
+	voided
+)
+
+// fakeAuthorization is the money held on a card
+type fakeAuthorization struct {
+	card     string
+	amount   float64
+	refunded float64
+	state    authorizationState
+}
+
+// FakeGateway is an in-process PaymentProvider for development and tests.
+// It checks the card numbers with the Luhn algorithm and simulates declines, timeouts and insufficient funds.
+type FakeGateway struct {
+	config FakeGatewayConfig
+
+	mu             sync.Mutex
+	balances       map[string]float64
+	cards          map[string]string
+	authorizations map[string]*fakeAuthorization
+	refunds        map[string]bool
+}
+
+func NewFakeGateway(config FakeGatewayConfig) *FakeGateway {
+	if config.Rules == nil {
+		config.Rules = DefaultCardRules
+	}
+	if config.TimeoutAfter == 0 {
+		config.TimeoutAfter = 5 * time.Second
+	}
+
+	balances := make(map[string]float64, len(config.Balances))
+	for card, balance := range config.Balances {
+		balances[card] = balance
+	}
+
+	return &FakeGateway{
+		config:         config,
+		balances:       balances,
+		cards:          make(map[string]string),
+		authorizations: make(map[string]*fakeAuthorization),
+		refunds:        make(map[string]bool),
+	}
+}
+
+// Tokenize stores the card, returning the token that charges it.
+func (g *FakeGateway) Tokenize(ctx context.Context, cardNumber string) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+
+	if !validCardNumber(cardNumber) {
+		return "", ErrInvalidCard
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cardToken := "card_" + ulid.Make().String()
+	g.cards[cardToken] = cardNumber
+	return cardToken, nil
+}
+
+// Authorize holds the amount on the card.
+func (g *FakeGateway) Authorize(ctx context.Context, req AuthorizeRequest) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	card, ok := g.cards[req.CardToken]
+	g.mu.Unlock()
+	if !ok {
+		return "", ErrUnknownCard
+	}
+
+	switch g.config.Rules[card] {
+	case Decline:
+		return "", ErrDeclined
+	case InsufficientFunds:
+		return "", ErrInsufficientFunds
+	case Timeout:
+		return "", g.hang(ctx)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if balance, limited := g.balances[card]; limited {
+		if balance < req.Amount {
+			return "", ErrInsufficientFunds
+		}
+		g.balances[card] = roundCents(balance - req.Amount)
+	}
+
+	id := ulid.Make().String()
+	g.authorizations[id] = &fakeAuthorization{card: card, amount: req.Amount, state: authorized}
+	return id, nil
+}
+
+// Capture takes the money held by the authorization.
+func (g *FakeGateway) Capture(ctx context.Context, authorizationID string) error {
+	if err := g.wait(ctx); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authorization, err := g.retrieve(authorizationID)
+	if err != nil {
+		return err
+	}
+	switch authorization.state {
+	case captured:
+		return nil
+	case voided:
+		return fmt.Errorf("%w: authorization is voided", ErrInvalidOperation)
+	}
+
+	authorization.state = captured
+	return nil
+}
+
+// Void releases the money held by an authorization not captured.
+func (g *FakeGateway) Void(ctx context.Context, authorizationID string) error {
+	if err := g.wait(ctx); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	authorization, err := g.retrieve(authorizationID)
+	if err != nil {
+		return err
+	}
+	switch authorization.state {
+	case voided:
+		return nil
+	case captured:
+		return fmt.Errorf("%w: authorization is captured", ErrInvalidOperation)
+	}
+
+	authorization.state = voided
+	g.credit(authorization.card, authorization.amount)
+	return nil
+}
+
+// Refund gives back part of the captured money, once for each refund ID.
+func (g *FakeGateway) Refund(ctx context.Context, authorizationID string, amount float64, refundID string) error {
+	if err := g.wait(ctx); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.refunds[refundID] {
+		return nil
+	}
+
+	authorization, err := g.retrieve(authorizationID)
+	if err != nil {
+		return err
+	}
+	if authorization.state != captured {
+		return fmt.Errorf("%w: authorization is not captured", ErrInvalidOperation)
+	}
+	if roundCents(authorization.refunded+amount) > authorization.amount {
+		return fmt.Errorf("%w: refund exceeds the captured amount", ErrInvalidOperation)
+	}
+
+	authorization.refunded = roundCents(authorization.refunded + amount)
+	g.refunds[refundID] = true
+	g.credit(authorization.card, amount)
+	return nil
+}
+
+// Balance returns the funds left on a card with limited funds.
+func (g *FakeGateway) Balance(card string) (float64, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	balance, limited := g.balances[card]
+	return balance, limited
+}
+
+// PRIVATE FUNCTIONS
+
+// wait simulates the latency of the network
+func (g *FakeGateway) wait(ctx context.Context) error {
+	if g.config.Latency == 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(g.config.Latency):
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", ErrTimeout, ctx.Err())
+	}
+}
+
+// hang simulates a gateway not answering
+func (g *FakeGateway) hang(ctx context.Context) error {
+	select {
+	case <-time.After(g.config.TimeoutAfter):
+	case <-ctx.Done():
+	}
+	return ErrTimeout
+}
+
+func (g *FakeGateway) retrieve(authorizationID string) (*fakeAuthorization, error) {
+	authorization, ok := g.authorizations[authorizationID]
+	if !ok {
+		return nil, ErrUnknownAuthorization
+	}
+	return authorization, nil
+}
+
+// credit gives money back to a card with limited funds
+func (g *FakeGateway) credit(card string, amount float64) {
+	if balance, limited := g.balances[card]; limited {
+		g.balances[card] = roundCents(balance + amount)
+	}
+}
+
+// validCardNumber checks the length and the Luhn checksum of a card number
+func validCardNumber(number string) bool {
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// roundCents rounds an amount of money to two decimal digits
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// errorCodes are the codes carried by the error responses of the gateway API
+var errorCodes = map[string]error{
+	"declined":              ErrDeclined,
+	"insufficient_funds":    ErrInsufficientFunds,
+	"invalid_card":          ErrInvalidCard,
+	"unknown_card":          ErrUnknownCard,
+	"timeout":               ErrTimeout,
+	"unknown_authorization": ErrUnknownAuthorization,
+	"invalid_operation":     ErrInvalidOperation,
+}
+
+// cardBody is the request body of POST /cards
+type cardBody struct {
+	CardNumber string `json:"card_number"`
+}
+
+// cardTokenBody is the response body of POST /cards
+type cardTokenBody struct {
+	Token string `json:"token"`
+}
+
+// authorizeBody is the request body of POST /authorizations
+type authorizeBody struct {
+	Reference string  `json:"reference"`
+	CardToken string  `json:"card_token"`
+	Amount    float64 `json:"amount"`
+}
+
+// authorizationBody is the response body of POST /authorizations
+type authorizationBody struct {
+	ID string `json:"id"`
+}
+
+// refundBody is the request body of POST /authorizations/{id}/refunds
+type refundBody struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+// errorBody is the body of the error responses
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// HTTPProvider is a PaymentProvider calling a gateway over a JSON API, such as the one served by NewStubHandler.
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPProvider(baseURL string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{baseURL: baseURL, client: &http.Client{Timeout: timeout}}
+}
+
+// Tokenize stores the card, returning the token that charges it.
+func (p *HTTPProvider) Tokenize(ctx context.Context, cardNumber string) (string, error) {
+	var res cardTokenBody
+	if err := p.post(ctx, "/cards", cardBody{CardNumber: cardNumber}, &res); err != nil {
+		return "", err
+	}
+	return res.Token, nil
+}
+
+// Authorize holds the amount on the card.
+func (p *HTTPProvider) Authorize(ctx context.Context, req AuthorizeRequest) (string, error) {
+	var res authorizationBody
+	err := p.post(ctx, "/authorizations", authorizeBody{Reference: req.Reference, CardToken: req.CardToken, Amount: req.Amount}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+// Capture takes the money held by the authorization.
+func (p *HTTPProvider) Capture(ctx context.Context, authorizationID string) error {
+	return p.post(ctx, "/authorizations/"+url.PathEscape(authorizationID)+"/capture", nil, nil)
+}
+
+// Void releases the money held by an authorization not captured.
+func (p *HTTPProvider) Void(ctx context.Context, authorizationID string) error {
+	return p.post(ctx, "/authorizations/"+url.PathEscape(authorizationID)+"/void", nil, nil)
+}
+
+// Refund gives back part of the captured money, once for each refund ID.
+func (p *HTTPProvider) Refund(ctx context.Context, authorizationID string, amount float64, refundID string) error {
+	return p.post(ctx, "/authorizations/"+url.PathEscape(authorizationID)+"/refunds", refundBody{ID: refundID, Amount: amount}, nil)
+}
+
+// post sends a JSON request to the gateway and decodes the response into out, if not nil.
+// Network failures and server errors are reported as ErrTimeout, since the outcome of the call is unknown.
+func (p *HTTPProvider) post(ctx context.Context, path string, in any, out any) error {
+
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		var errRes errorBody
+		json.NewDecoder(res.Body).Decode(&errRes)
+		if known, ok := errorCodes[errRes.Error]; ok {
+			return known
+		}
+		if res.StatusCode >= 500 {
+			return fmt.Errorf("%w: gateway answered %s", ErrTimeout, res.Status)
+		}
+		return fmt.Errorf("Payment gateway answered %s", res.Status)
+	}
+
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+	return nil
+}
+
+// NewStubHandler serves the gateway API backed by a provider, usually a FakeGateway.
+// It lets the HTTPProvider be exercised against a local server.
+func NewStubHandler(provider PaymentProvider) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /cards", func(w http.ResponseWriter, r *http.Request) {
+		var req cardBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		cardToken, err := provider.Tokenize(r.Context(), req.CardNumber)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(cardTokenBody{Token: cardToken})
+	})
+
+	mux.HandleFunc("POST /authorizations", func(w http.ResponseWriter, r *http.Request) {
+		var req authorizeBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id, err := provider.Authorize(r.Context(), AuthorizeRequest{Reference: req.Reference, CardToken: req.CardToken, Amount: req.Amount})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(authorizationBody{ID: id})
+	})
+
+	mux.HandleFunc("POST /authorizations/{id}/capture", func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, provider.Capture(r.Context(), r.PathValue("id")))
+	})
+
+	mux.HandleFunc("POST /authorizations/{id}/void", func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, provider.Void(r.Context(), r.PathValue("id")))
+	})
+
+	mux.HandleFunc("POST /authorizations/{id}/refunds", func(w http.ResponseWriter, r *http.Request) {
+		var req refundBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeResult(w, provider.Refund(r.Context(), r.PathValue("id"), req.Amount, req.ID))
+	})
+
+	return mux
+}
+
+// writeResult answers 204 on success, or the error response
+func writeResult(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError answers with the code of a provider error
+func writeError(w http.ResponseWriter, err error) {
+	code, status := "", http.StatusInternalServerError
+	for c, known := range errorCodes {
+		if errors.Is(err, known) {
+			code = c
+			break
+		}
+	}
+
+	switch code {
+	case "declined", "insufficient_funds", "invalid_card", "unknown_card":
+		status = http.StatusPaymentRequired
+	case "timeout":
+		status = http.StatusGatewayTimeout
+	case "unknown_authorization":
+		status = http.StatusNotFound
+	case "invalid_operation":
+		status = http.StatusConflict
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorBody{Error: code})
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// Errors returned by the providers.
+// Declines are final for the attempt, ErrTimeout means the outcome is unknown and the call can be retried.
+var (
+	ErrDeclined          = errors.New("Card declined")
+	ErrInsufficientFunds = errors.New("Insufficient funds")
+	ErrInvalidCard       = errors.New("Invalid card number")
+	ErrUnknownCard       = errors.New("Unknown card token")
+	ErrTimeout           = errors.New("Payment provider timed out")
+
+	ErrUnknownAuthorization = errors.New("Unknown authorization")
+	ErrInvalidOperation     = errors.New("Operation not allowed on this authorization")
+)
+
+// AuthorizeRequest asks to hold an amount of money on a card.
+type AuthorizeRequest struct {
+
+	// Reference identifies the payment on our side (the order ID)
+	Reference string
+
+	// CardToken of the card to charge, returned by Tokenize
+	CardToken string
+
+	// Amount to hold
+	Amount float64
+}
+
+// PaymentProvider moves the money of the payments.
+// The card is stored by the provider, which returns a token to charge it: card numbers are never kept on our side.
+// The authorization holds the money, which is then captured or voided; captured money can be refunded.
+type PaymentProvider interface {
+
+	// Tokenize stores the card, returning the token that charges it
+	Tokenize(ctx context.Context, cardNumber string) (string, error)
+
+	// Authorize holds the amount on the card, returning the ID of the authorization
+	Authorize(ctx context.Context, req AuthorizeRequest) (string, error)
+
+	// Capture takes the money held by the authorization
+	Capture(ctx context.Context, authorizationID string) error
+
+	// Void releases the money held by an authorization not captured
+	Void(ctx context.Context, authorizationID string) error
+
+	// Refund gives back part of the captured money.
+	// The refund ID is applied once, so a refund whose outcome is unknown can be asked again.
+	Refund(ctx context.Context, authorizationID string, amount float64, refundID string) error
+}
+
+// IsDecline tells if the provider refused the payment, as opposed to failing to answer.
+func IsDecline(err error) bool {
+	return errors.Is(err, ErrDeclined) || errors.Is(err, ErrInsufficientFunds) || errors.Is(err, ErrInvalidCard) ||
+		errors.Is(err, ErrUnknownCard)
+}
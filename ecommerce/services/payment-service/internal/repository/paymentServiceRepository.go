@@ -1,20 +1,31 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
 
+	ulid "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
 )
 
+// ErrRefundRefused is returned when the payment provider refuses a refund, which is recorded as failed
+var ErrRefundRefused = errors.New("Refund refused by the payment provider")
+
 type PaymentServiceRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	provider provider.PaymentProvider
 }
 
-func NewPaymentServiceRepository(db *gorm.DB) *PaymentServiceRepository {
-	return &PaymentServiceRepository{db: db}
+func NewPaymentServiceRepository(db *gorm.DB, paymentProvider provider.PaymentProvider) *PaymentServiceRepository {
+	return &PaymentServiceRepository{db: db, provider: paymentProvider}
 }
 
 // CreatePayment creates a new payment for a given order ID and amount.
@@ -46,8 +57,19 @@ func (r *PaymentServiceRepository) CreatePayment(orderID string, amount float64)
 	return nil
 }
 
-// ProcessPayment processes a payment for a given order ID.
-func (r *PaymentServiceRepository) ProcessPayment(orderID string, amount float64) error {
+// TokenizeCard stores a card at the payment provider, returning the token that charges it.
+// The card number is not stored by the payment service.
+func (r *PaymentServiceRepository) TokenizeCard(ctx context.Context, cardNumber string) (string, error) {
+	if cardNumber == "" {
+		return "", errors.New("Card number cannot be empty")
+	}
+	return r.provider.Tokenize(ctx, cardNumber)
+}
+
+// ProcessPayment processes a payment for a given order ID, charging the card of the token through the payment provider.
+// Every attempt is recorded, a successful one also records the capture of the money.
+// Declined cards make the payment fail, while the other errors of the provider are returned and the payment can be retried.
+func (r *PaymentServiceRepository) ProcessPayment(ctx context.Context, orderID string, amount float64, cardToken string) error {
 
 	// Validate inputs
 	if err := checkValidID(orderID); err != nil {
@@ -64,23 +86,67 @@ func (r *PaymentServiceRepository) ProcessPayment(orderID string, amount float64
 		return err
 	}
 
-	// Check if payment is already PAID
-	if payment.Status == domain.Paid {
-		return errors.New("Payment has already been processed and is marked as PAID")
+	// Check if payment has already been processed
+	if !isProcessable(payment.Status) {
+		return fmt.Errorf("Payment has already been processed and is marked as %s", payment.Status)
 	}
 
-	// Simulate payment processing logic
-	if amount >= payment.Amount {
-		payment.Status = domain.Paid
-	} else {
-		payment.Status = domain.PaymentFailed
+	// The payer must agree to pay the whole amount
+	if amount < payment.Amount {
+		return r.recordFailedAttempt(orderID, amount, "Insufficient amount", true)
 	}
 
-	// Update the payment status in the database
-	if err := r.db.Save(&payment).Error; err != nil {
+	// Hold the money on the card and take it, the money held is released if the capture fails
+	authorizationID, err := r.provider.Authorize(ctx, provider.AuthorizeRequest{
+		Reference: orderID,
+		CardToken: cardToken,
+		Amount:    payment.Amount,
+	})
+	if err == nil {
+		if err = r.provider.Capture(ctx, authorizationID); err != nil {
+			if voidErr := r.provider.Void(context.WithoutCancel(ctx), authorizationID); voidErr != nil {
+				log.Printf("Failed to void authorization %s of order %s: %v", authorizationID, orderID, voidErr)
+			}
+		}
+	}
+	if err != nil {
+		declined := provider.IsDecline(err)
+		if recordErr := r.recordFailedAttempt(orderID, amount, err.Error(), declined); recordErr != nil {
+			return recordErr
+		}
+		if declined {
+			return nil
+		}
 		return err
 	}
-	return nil
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+
+		// Update the payment status in the database, unless another attempt got there first
+		result := tx.Model(&domain.Payment{}).
+			Where("order_id = ? AND status IN ?", orderID, processableStatuses).
+			Updates(map[string]any{"status": domain.Paid, "authorization_id": authorizationID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("Payment has been processed concurrently")
+		}
+
+		transactions := []*domain.PaymentTransaction{
+			{TransactionID: ulid.Make().String(), OrderID: orderID, Type: domain.Attempt, Amount: amount, Succeeded: true},
+			{TransactionID: ulid.Make().String(), OrderID: orderID, Type: domain.Capture, Amount: payment.Amount, Succeeded: true},
+		}
+		return tx.Create(transactions).Error
+	})
+
+	// The money has been taken but the payment was not recorded, give it back
+	if err != nil {
+		if refundErr := r.provider.Refund(context.WithoutCancel(ctx), authorizationID, payment.Amount, "unrecorded:"+authorizationID); refundErr != nil {
+			log.Printf("Failed to refund authorization %s of order %s: %v", authorizationID, orderID, refundErr)
+		}
+	}
+	return err
 }
 
 // GetPaymentStatus retrieves the payment status for a given order ID.
@@ -105,8 +171,237 @@ func (r *PaymentServiceRepository) GetPaymentStatus(orderID string) (pb.PaymentS
 	return protoStatus, nil
 }
 
+// RefundPayment gives back amount of the payment of a given order ID, 0 refunds everything left.
+// The payment must be PAID or PARTIALLY_REFUNDED, refunding a REFUNDED payment with amount 0 has no effect.
+// The refund is recorded as pending before asking it to the payment provider, then its outcome is recorded.
+// A refund left pending by an unknown outcome is resumed by the next call, the provider applying each refund once.
+// A refund refused by the provider is recorded as failed and ErrRefundRefused is returned.
+func (r *PaymentServiceRepository) RefundPayment(ctx context.Context, orderID string, amount float64) (pb.PaymentStatus, error) {
+
+	// Validate inputs
+	if err := checkValidID(orderID); err != nil {
+		return pb.PaymentStatus(0), err
+	}
+	if amount < 0 {
+		return pb.PaymentStatus(0), errors.New("Invalid amount: cannot be negative")
+	}
+
+	for {
+		refund, resumed, err := r.startRefund(orderID, amount)
+		if err != nil {
+			return pb.PaymentStatus(0), err
+		}
+
+		// Everything has already been refunded
+		if refund == nil {
+			return pb.PaymentStatus_REFUNDED, nil
+		}
+
+		paymentStatus, err := r.completeRefund(ctx, refund)
+		if err != nil || !resumed {
+			return paymentStatus, err
+		}
+
+		// The refund resumed was an earlier one, the one asked is still to do
+	}
+}
+
+// ListPaymentTransactions retrieves the transactions of the payment of a given order ID, oldest first.
+func (r *PaymentServiceRepository) ListPaymentTransactions(orderID string) ([]*pb.PaymentTransaction, error) {
+
+	// Validate inputs
+	if err := checkValidID(orderID); err != nil {
+		return nil, err
+	}
+
+	// The payment must exist
+	var payment domain.Payment
+	if err := r.db.Where("order_id = ?", orderID).First(&payment).Error; err != nil {
+		return nil, err
+	}
+
+	var transactions []*domain.PaymentTransaction
+	if err := r.db.Where("order_id = ?", orderID).Order("created_at, transaction_id").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	protoTransactions := make([]*pb.PaymentTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		protoTransaction, err := domain.DomainTransactionToProtoTransaction(transaction)
+		if err != nil {
+			return nil, err
+		}
+		protoTransactions = append(protoTransactions, protoTransaction)
+	}
+	return protoTransactions, nil
+}
+
 // PRIVATE FUNCTIONS TO CHECK ON THE VALIDITY OF INPUTS
 
+// startRefund records a pending refund of amount, reserving it on the payment so concurrent refunds cannot exceed it.
+// If a refund is already pending it is returned to be resumed instead, resumed telling it is not the refund asked.
+// It returns no refund if amount is 0 and everything has already been refunded.
+func (r *PaymentServiceRepository) startRefund(orderID string, amount float64) (refund *domain.PaymentTransaction, resumed bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+
+		// Retrieve the payment
+		var payment domain.Payment
+		if err := tx.Where("order_id = ?", orderID).First(&payment).Error; err != nil {
+			return err
+		}
+
+		// The pending refund is resumed, it is the refund asked when retried with the same amount
+		var pending domain.PaymentTransaction
+		err := tx.Where("order_id = ? AND type = ? AND pending", orderID, domain.Refund).First(&pending).Error
+		if err == nil {
+			refund, resumed = &pending, amount == 0 || roundCents(amount) != pending.Amount
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// Refunding everything twice has no effect
+		if payment.Status == domain.Refunded {
+			if amount == 0 {
+				return nil
+			}
+			return errors.New("Payment has already been fully refunded")
+		}
+
+		if payment.Status != domain.Paid && payment.Status != domain.PartiallyRefunded {
+			return errors.New("Only PAID payments can be refunded")
+		}
+
+		remaining := roundCents(payment.Amount - payment.RefundedAmount)
+		if amount == 0 {
+			amount = remaining
+		}
+		amount = roundCents(amount)
+		if amount > remaining {
+			return fmt.Errorf("Refund of %.2f exceeds the %.2f left to refund", amount, remaining)
+		}
+
+		// Reserve the amount, unless another refund got there first
+		result := tx.Model(&domain.Payment{}).
+			Where("order_id = ? AND refunded_amount = ?", orderID, payment.RefundedAmount).
+			Update("refunded_amount", roundCents(payment.RefundedAmount+amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("Payment has been refunded concurrently")
+		}
+
+		refund = &domain.PaymentTransaction{
+			TransactionID: ulid.Make().String(),
+			OrderID:       orderID,
+			Type:          domain.Refund,
+			Amount:        amount,
+			Pending:       true,
+		}
+		return tx.Create(refund).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return refund, resumed, nil
+}
+
+// completeRefund asks a pending refund to the payment provider, its transaction ID as refund ID, then records the outcome.
+// If the outcome is unknown the refund is left pending, if the provider refuses it the reserved amount is released.
+func (r *PaymentServiceRepository) completeRefund(ctx context.Context, refund *domain.PaymentTransaction) (pb.PaymentStatus, error) {
+
+	var payment domain.Payment
+	if err := r.db.Where("order_id = ?", refund.OrderID).First(&payment).Error; err != nil {
+		return pb.PaymentStatus(0), err
+	}
+
+	// Payments without authorization have not been charged through the provider
+	var err error
+	if payment.AuthorizationID != "" {
+		err = r.provider.Refund(ctx, payment.AuthorizationID, refund.Amount, refund.TransactionID)
+	}
+	refused := errors.Is(err, provider.ErrInvalidOperation) || errors.Is(err, provider.ErrUnknownAuthorization)
+	if err != nil && !refused {
+		return pb.PaymentStatus(0), err
+	}
+
+	var reason string
+	if refused {
+		reason = err.Error()
+	}
+	recordErr := r.db.Transaction(func(tx *gorm.DB) error {
+
+		// The outcome may have been recorded by a concurrent call resuming the same refund
+		result := tx.Model(&domain.PaymentTransaction{}).
+			Where("transaction_id = ? AND pending", refund.TransactionID).
+			Updates(map[string]any{"pending": false, "succeeded": !refused, "failure_reason": reason})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if refused {
+			return tx.Model(&domain.Payment{}).
+				Where("order_id = ?", refund.OrderID).
+				Update("refunded_amount", gorm.Expr("ROUND(refunded_amount - ?, 2)", refund.Amount)).Error
+		}
+
+		if err := tx.Where("order_id = ?", refund.OrderID).First(&payment).Error; err != nil {
+			return err
+		}
+		paymentStatus := domain.PartiallyRefunded
+		if payment.RefundedAmount >= roundCents(payment.Amount) {
+			paymentStatus = domain.Refunded
+		}
+		return tx.Model(&domain.Payment{}).Where("order_id = ?", refund.OrderID).Update("status", paymentStatus).Error
+	})
+	if recordErr != nil {
+		return pb.PaymentStatus(0), recordErr
+	}
+	if refused {
+		return pb.PaymentStatus(0), fmt.Errorf("%w: %w", ErrRefundRefused, err)
+	}
+	return r.GetPaymentStatus(refund.OrderID)
+}
+
+// recordFailedAttempt records an attempt that didn't take the money.
+// If failed is set, the payment is marked as PAYMENT_FAILED, otherwise its status is left unchanged.
+func (r *PaymentServiceRepository) recordFailedAttempt(orderID string, amount float64, reason string, failed bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		attempt := &domain.PaymentTransaction{
+			TransactionID: ulid.Make().String(),
+			OrderID:       orderID,
+			Type:          domain.Attempt,
+			Amount:        amount,
+			FailureReason: reason,
+		}
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		if !failed {
+			return nil
+		}
+		return tx.Model(&domain.Payment{}).
+			Where("order_id = ? AND status IN ?", orderID, processableStatuses).
+			Update("status", domain.PaymentFailed).Error
+	})
+}
+
+// processableStatuses are the statuses of the payments not processed yet
+var processableStatuses = []domain.PaymentStatus{domain.PendingPayment, domain.PaymentFailed}
+
+// isProcessable tells if a payment in the given status can be processed.
+func isProcessable(status domain.PaymentStatus) bool {
+	return slices.Contains(processableStatuses, status)
+}
+
+// roundCents rounds an amount of money to two decimal digits.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
 // checkValidID checks if the provided ID is valid (non-empty).
 func checkValidID(id string) error {
 	if id == "" {
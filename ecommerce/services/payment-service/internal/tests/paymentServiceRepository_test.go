@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"testing"
 
 	"gorm.io/driver/sqlite"
@@ -8,16 +9,20 @@ import (
 
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/repository"
 )
 
+// testCard is approved by the fake gateway
+const testCard = "4242424242424242"
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to connect database: %v", err)
 	}
 
-	if err = db.AutoMigrate(&domain.Payment{}); err != nil {
+	if err = db.AutoMigrate(&domain.Payment{}, &domain.PaymentTransaction{}); err != nil {
 		t.Fatalf("Failed to migrate database: %v", err)
 	}
 	return db
@@ -53,9 +58,18 @@ func setupDefaultPayments(t *testing.T, db *gorm.DB) {
 	}
 }
 
+// cardToken stores a card at the payment provider of the repository
+func cardToken(t *testing.T, repo *repository.PaymentServiceRepository, card string) string {
+	token, err := repo.TokenizeCard(context.Background(), card)
+	if err != nil {
+		t.Fatalf("Failed to tokenize card %s: %v", card, err)
+	}
+	return token
+}
+
 func setupTest(t *testing.T) (*gorm.DB, *repository.PaymentServiceRepository) {
 	db := setupTestDB(t)
-	repo := repository.NewPaymentServiceRepository(db)
+	repo := repository.NewPaymentServiceRepository(db, provider.NewFakeGateway(provider.FakeGatewayConfig{}))
 
 	setupDefaultPayments(t, db)
 
@@ -113,7 +127,7 @@ func TestCreatePaymentInvalidAmount(t *testing.T) {
 func TestProcessPayment(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("order123", 199.99); err != nil {
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, testCard)); err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
@@ -129,7 +143,7 @@ func TestProcessPayment(t *testing.T) {
 func TestProcessPaymentInvalidID(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("", 50.00); err == nil {
+	if err := repo.ProcessPayment(context.Background(), "", 50.00, cardToken(t, repo, testCard)); err == nil {
 		t.Fatalf("Expected error for invalid order ID, got nil")
 	}
 }
@@ -137,7 +151,7 @@ func TestProcessPaymentInvalidID(t *testing.T) {
 func TestProcessPaymentNegativeAmount(t *testing.T) {
 	_, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("order123", -20.00); err == nil {
+	if err := repo.ProcessPayment(context.Background(), "order123", -20.00, cardToken(t, repo, testCard)); err == nil {
 		t.Fatalf("Expected error for negative amount, got nil")
 	}
 }
@@ -145,7 +159,7 @@ func TestProcessPaymentNegativeAmount(t *testing.T) {
 func TestProcessPaymentInsufficientAmount(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("order123", 100.00); err != nil {
+	if err := repo.ProcessPayment(context.Background(), "order123", 100.00, cardToken(t, repo, testCard)); err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
@@ -161,7 +175,7 @@ func TestProcessPaymentInsufficientAmount(t *testing.T) {
 func TestProcessPaymentAlreadyPaid(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("order456", 49.99); err == nil {
+	if err := repo.ProcessPayment(context.Background(), "order456", 49.99, cardToken(t, repo, testCard)); err == nil {
 		t.Fatalf("Expected error for already PAID payment, got nil")
 	}
 
@@ -177,7 +191,7 @@ func TestProcessPaymentAlreadyPaid(t *testing.T) {
 func TestProcessFailedPayment(t *testing.T) {
 	db, repo := setupTest(t)
 
-	if err := repo.ProcessPayment("order789", 40.00); err != nil {
+	if err := repo.ProcessPayment(context.Background(), "order789", 40.00, cardToken(t, repo, testCard)); err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
@@ -238,3 +252,124 @@ func TestGetPaymentStatusInvalidIDFormat(t *testing.T) {
 		t.Fatalf("Expected error for invalid order ID format, got nil")
 	}
 }
+
+func TestRefundPayment(t *testing.T) {
+	db, repo := setupTest(t)
+
+	status, err := repo.RefundPayment(context.Background(), "order456", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status != pb.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected status REFUNDED, got %v", status)
+	}
+
+	var payment domain.Payment
+	if err := db.Where("order_id = ?", "order456").First(&payment).Error; err != nil {
+		t.Fatalf("Failed to retrieve payment: %v", err)
+	}
+	if payment.Status != domain.Refunded {
+		t.Fatalf("Expected payment status to be REFUNDED, got %v", payment.Status)
+	}
+
+	// Refunding again is a no-op
+	if _, err := repo.RefundPayment(context.Background(), "order456", 0); err != nil {
+		t.Fatalf("Expected no error on second refund, got %v", err)
+	}
+}
+
+func TestRefundPaymentNotPaid(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.RefundPayment(context.Background(), "order123", 0); err == nil {
+		t.Fatalf("Expected error when refunding a pending payment, got nil")
+	}
+}
+
+func TestPartialRefunds(t *testing.T) {
+	db, repo := setupTest(t)
+
+	status, err := repo.RefundPayment(context.Background(), "order456", 20)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status != pb.PaymentStatus_PARTIALLY_REFUNDED {
+		t.Fatalf("Expected status PARTIALLY_REFUNDED, got %v", status)
+	}
+
+	// More than what is left can't be refunded
+	if _, err := repo.RefundPayment(context.Background(), "order456", 30); err == nil {
+		t.Fatalf("Expected error when refunding more than paid, got nil")
+	}
+
+	// Amount 0 refunds everything left
+	status, err = repo.RefundPayment(context.Background(), "order456", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status != pb.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected status REFUNDED, got %v", status)
+	}
+
+	var payment domain.Payment
+	db.Where("order_id = ?", "order456").First(&payment)
+	if payment.RefundedAmount != 49.99 {
+		t.Fatalf("Expected 49.99 refunded, got %v", payment.RefundedAmount)
+	}
+
+	if _, err := repo.RefundPayment(context.Background(), "order456", 1); err == nil {
+		t.Fatalf("Expected error when refunding a refunded payment, got nil")
+	}
+}
+
+func TestRefundPaymentNegativeAmount(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.RefundPayment(context.Background(), "order456", -1); err == nil {
+		t.Fatalf("Expected error for negative amount, got nil")
+	}
+}
+
+func TestListPaymentTransactions(t *testing.T) {
+	_, repo := setupTest(t)
+
+	// A failed attempt, then a successful one, then a partial refund
+	repo.ProcessPayment(context.Background(), "order123", 100.00, cardToken(t, repo, testCard))
+	repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, testCard))
+	repo.RefundPayment(context.Background(), "order123", 50)
+
+	transactions, err := repo.ListPaymentTransactions("order123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		kind      pb.TransactionType
+		amount    float64
+		succeeded bool
+	}{
+		{pb.TransactionType_ATTEMPT, 100.00, false},
+		{pb.TransactionType_ATTEMPT, 199.99, true},
+		{pb.TransactionType_CAPTURE, 199.99, true},
+		{pb.TransactionType_REFUND, 50, true},
+	}
+	if len(transactions) != len(expected) {
+		t.Fatalf("Expected %d transactions, got %d", len(expected), len(transactions))
+	}
+	for i, e := range expected {
+		if transactions[i].Type != e.kind || transactions[i].Amount != e.amount || transactions[i].Succeeded != e.succeeded {
+			t.Fatalf("Unexpected transaction %d: %v", i, transactions[i])
+		}
+	}
+	if transactions[0].FailureReason == "" {
+		t.Fatalf("Expected failure reason for the failed attempt")
+	}
+}
+
+func TestListPaymentTransactionsNonExistentOrder(t *testing.T) {
+	_, repo := setupTest(t)
+
+	if _, err := repo.ListPaymentTransactions("nonexistent"); err == nil {
+		t.Fatalf("Expected error for non-existent order, got nil")
+	}
+}
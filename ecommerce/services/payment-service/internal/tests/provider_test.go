@@ -0,0 +1,373 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+const (
+	declinedCard     = "4000000000000002"
+	noFundsCard      = "4000000000009995"
+	timeoutCard      = "4000000000000119"
+	limitedFundsCard = "5555555555554444"
+)
+
+func setupGateway() *provider.FakeGateway {
+	return provider.NewFakeGateway(provider.FakeGatewayConfig{
+		Balances:     map[string]float64{limitedFundsCard: 250},
+		TimeoutAfter: 10 * time.Millisecond,
+	})
+}
+
+func setupProviderTest(t *testing.T, paymentProvider provider.PaymentProvider) (*repository.PaymentServiceRepository, func() domain.Payment) {
+	db := setupTestDB(t)
+	repo := repository.NewPaymentServiceRepository(db, paymentProvider)
+	setupDefaultPayments(t, db)
+
+	// retrieve reads the payment of order123, due 199.99
+	retrieve := func() domain.Payment {
+		var payment domain.Payment
+		if err := db.Where("order_id = ?", "order123").First(&payment).Error; err != nil {
+			t.Fatalf("Failed to retrieve payment: %v", err)
+		}
+		return payment
+	}
+	return repo, retrieve
+}
+
+func TestProcessPaymentDeclinedCards(t *testing.T) {
+	for _, card := range []string{declinedCard, noFundsCard, ""} {
+		repo, retrieve := setupProviderTest(t, setupGateway())
+
+		// The empty card stands for a token unknown to the provider
+		token := "card_unknown"
+		if card != "" {
+			token = cardToken(t, repo, card)
+		}
+		if err := repo.ProcessPayment(context.Background(), "order123", 199.99, token); err != nil {
+			t.Fatalf("Expected no error for card %s, got %v", card, err)
+		}
+		if payment := retrieve(); payment.Status != domain.PaymentFailed {
+			t.Fatalf("Expected payment status PAYMENT_FAILED for card %s, got %v", card, payment.Status)
+		}
+
+		transactions, _ := repo.ListPaymentTransactions("order123")
+		if len(transactions) != 1 || transactions[0].Succeeded || transactions[0].FailureReason == "" {
+			t.Fatalf("Expected one failed attempt for card %s, got %v", card, transactions)
+		}
+	}
+}
+
+func TestProcessPaymentTimeout(t *testing.T) {
+	repo, retrieve := setupProviderTest(t, setupGateway())
+
+	// The outcome is unknown: the error is returned and the payment can be retried
+	err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, timeoutCard))
+	if !errors.Is(err, provider.ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.PendingPayment {
+		t.Fatalf("Expected payment status to remain PENDING_PAYMENT, got %v", payment.Status)
+	}
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, testCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.Paid {
+		t.Fatalf("Expected payment status PAID, got %v", payment.Status)
+	}
+}
+
+func TestProcessPaymentLimitedFunds(t *testing.T) {
+	gateway := setupGateway()
+	repo, retrieve := setupProviderTest(t, gateway)
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.Paid || payment.AuthorizationID == "" {
+		t.Fatalf("Expected PAID payment with authorization, got %+v", payment)
+	}
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 50.01 {
+		t.Fatalf("Expected balance 50.01, got %v", balance)
+	}
+
+	// The refund gives the money back to the card
+	if _, err := repo.RefundPayment(context.Background(), "order123", 100); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 150.01 {
+		t.Fatalf("Expected balance 150.01, got %v", balance)
+	}
+}
+
+func TestHTTPProviderAgainstStub(t *testing.T) {
+	gateway := setupGateway()
+	stub := httptest.NewServer(provider.NewStubHandler(gateway))
+	defer stub.Close()
+
+	repo, retrieve := setupProviderTest(t, provider.NewHTTPProvider(stub.URL, time.Second))
+
+	// Declines cross the HTTP API
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, declinedCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.PaymentFailed {
+		t.Fatalf("Expected payment status PAYMENT_FAILED, got %v", payment.Status)
+	}
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.RefundPayment(context.Background(), "order123", 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 250 {
+		t.Fatalf("Expected balance 250 after the refund, got %v", balance)
+	}
+}
+
+func TestHTTPProviderUnreachable(t *testing.T) {
+	stub := httptest.NewServer(provider.NewStubHandler(setupGateway()))
+	stub.Close()
+
+	repo, _ := setupProviderTest(t, provider.NewHTTPProvider(stub.URL, time.Second))
+
+	if _, err := repo.TokenizeCard(context.Background(), testCard); !errors.Is(err, provider.ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	err := repo.ProcessPayment(context.Background(), "order123", 199.99, "card_unknown")
+	if !errors.Is(err, provider.ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestFakeGatewayVoidAndRefund(t *testing.T) {
+	gateway := setupGateway()
+	ctx := context.Background()
+
+	cardToken, err := gateway.Tokenize(ctx, limitedFundsCard)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	id, err := gateway.Authorize(ctx, provider.AuthorizeRequest{Reference: "order1", CardToken: cardToken, Amount: 100})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Money is held until the authorization is voided
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 150 {
+		t.Fatalf("Expected balance 150, got %v", balance)
+	}
+	if err := gateway.Refund(ctx, id, 10, "refund1"); !errors.Is(err, provider.ErrInvalidOperation) {
+		t.Fatalf("Expected ErrInvalidOperation refunding an authorization not captured, got %v", err)
+	}
+	if err := gateway.Void(ctx, id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 250 {
+		t.Fatalf("Expected balance 250, got %v", balance)
+	}
+	if err := gateway.Capture(ctx, id); !errors.Is(err, provider.ErrInvalidOperation) {
+		t.Fatalf("Expected ErrInvalidOperation capturing a voided authorization, got %v", err)
+	}
+	if err := gateway.Capture(ctx, "unknown"); !errors.Is(err, provider.ErrUnknownAuthorization) {
+		t.Fatalf("Expected ErrUnknownAuthorization, got %v", err)
+	}
+}
+
+func TestTokenizeCard(t *testing.T) {
+	gateway := setupGateway()
+	stub := httptest.NewServer(provider.NewStubHandler(gateway))
+	defer stub.Close()
+
+	// Card numbers are checked when they are stored, over the HTTP API too
+	for _, paymentProvider := range []provider.PaymentProvider{gateway, provider.NewHTTPProvider(stub.URL, time.Second)} {
+		repo, _ := setupProviderTest(t, paymentProvider)
+
+		token, err := repo.TokenizeCard(context.Background(), testCard)
+		if err != nil || token == "" || token == testCard {
+			t.Fatalf("Expected a token for the card, got %q (%v)", token, err)
+		}
+		if _, err := repo.TokenizeCard(context.Background(), "4242424242424241"); !errors.Is(err, provider.ErrInvalidCard) {
+			t.Fatalf("Expected ErrInvalidCard, got %v", err)
+		}
+		if _, err := repo.TokenizeCard(context.Background(), ""); err == nil {
+			t.Fatalf("Expected error for an empty card number, got nil")
+		}
+	}
+}
+
+// lostRefundResponses is a gateway whose refunds go through, but whose answers are lost while lost is set
+type lostRefundResponses struct {
+	*provider.FakeGateway
+	lost bool
+}
+
+func (g *lostRefundResponses) Refund(ctx context.Context, authorizationID string, amount float64, refundID string) error {
+	if err := g.FakeGateway.Refund(ctx, authorizationID, amount, refundID); err != nil {
+		return err
+	}
+	if g.lost {
+		return provider.ErrTimeout
+	}
+	return nil
+}
+
+func TestRefundWithUnknownOutcomeIsResumed(t *testing.T) {
+	gateway := &lostRefundResponses{FakeGateway: setupGateway()}
+	repo, retrieve := setupProviderTest(t, gateway)
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The money is given back but the answer is lost, the refund stays pending
+	gateway.lost = true
+	if _, err := repo.RefundPayment(context.Background(), "order123", 0); !errors.Is(err, provider.ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.Paid {
+		t.Fatalf("Expected payment status to remain PAID, got %v", payment.Status)
+	}
+	transactions, _ := repo.ListPaymentTransactions("order123")
+	if last := transactions[len(transactions)-1]; !last.Pending || last.Succeeded {
+		t.Fatalf("Expected a pending refund, got %v", last)
+	}
+
+	// No other refund can be asked meanwhile
+	if _, err := repo.RefundPayment(context.Background(), "order123", 10); err == nil {
+		t.Fatalf("Expected error refunding more than what is left, got nil")
+	}
+
+	// Retrying resumes the same refund, the money is given back once
+	gateway.lost = false
+	status, err := repo.RefundPayment(context.Background(), "order123", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status != pb.PaymentStatus_REFUNDED {
+		t.Fatalf("Expected status REFUNDED, got %v", status)
+	}
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 250 {
+		t.Fatalf("Expected balance 250 after the refund, got %v", balance)
+	}
+	transactions, _ = repo.ListPaymentTransactions("order123")
+	if last := transactions[len(transactions)-1]; last.Pending || !last.Succeeded || last.Amount != 199.99 {
+		t.Fatalf("Expected the refund to succeed, got %v", last)
+	}
+}
+
+func TestRefundRefusedReleasesAmount(t *testing.T) {
+	gateway := setupGateway()
+	repo, retrieve := setupProviderTest(t, gateway)
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The provider refuses a refund of money it no longer holds
+	payment := retrieve()
+	if err := gateway.Refund(context.Background(), payment.AuthorizationID, 199.99, "elsewhere"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err := repo.RefundPayment(context.Background(), "order123", 0)
+	if !errors.Is(err, repository.ErrRefundRefused) || !errors.Is(err, provider.ErrInvalidOperation) {
+		t.Fatalf("Expected ErrRefundRefused for ErrInvalidOperation, got %v", err)
+	}
+	if payment := retrieve(); payment.Status != domain.Paid || payment.RefundedAmount != 0 {
+		t.Fatalf("Expected PAID payment with nothing refunded, got %+v", payment)
+	}
+	transactions, _ := repo.ListPaymentTransactions("order123")
+	if last := transactions[len(transactions)-1]; last.Pending || last.Succeeded || last.FailureReason == "" {
+		t.Fatalf("Expected a failed refund, got %v", last)
+	}
+}
+
+func TestRefundRefusedFailsRPC(t *testing.T) {
+	db := setupTestDB(t)
+	setupDefaultPayments(t, db)
+	repo := repository.NewPaymentServiceRepository(db, setupGateway())
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The in-memory gateway restarted, its authorizations are lost
+	server := internal.NewPaymentServer(repository.NewPaymentServiceRepository(db, setupGateway()))
+
+	tokens := token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+	raw, _, err := tokens.Issue("order-service", interceptor.RoleService, token.Access)
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+raw))
+	info := &grpc.UnaryServerInfo{FullMethod: pb.PaymentService_RefundPayment_FullMethodName}
+	_, err = interceptor.NewAuthorizer(tokens, internal.AuthPolicy).Unary()(ctx, &pb.RefundPaymentRequest{OrderId: "order123"}, info,
+		func(ctx context.Context, req any) (any, error) {
+			return server.RefundPayment(ctx, req.(*pb.RefundPaymentRequest))
+		})
+
+	// The refund is not done, the caller keeps retrying it
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition, got %v", err)
+	}
+	if paymentStatus, _ := repo.GetPaymentStatus("order123"); paymentStatus != pb.PaymentStatus_PAID {
+		t.Fatalf("Expected payment status to remain PAID, got %v", paymentStatus)
+	}
+}
+
+func TestConcurrentRefundsRefundOnce(t *testing.T) {
+	gateway := setupGateway()
+	db := setupTestDB(t)
+	repo := repository.NewPaymentServiceRepository(db, gateway)
+	setupDefaultPayments(t, db)
+
+	if err := repo.ProcessPayment(context.Background(), "order123", 199.99, cardToken(t, repo, limitedFundsCard)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The saga compensation and the cancellation of the order refund at the same time
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.RefundPayment(context.Background(), "order123", 0)
+		}()
+	}
+	wg.Wait()
+
+	if balance, _ := gateway.Balance(limitedFundsCard); balance != 250 {
+		t.Fatalf("Expected balance 250 after the refund, got %v", balance)
+	}
+	refunds := 0
+	transactions, _ := repo.ListPaymentTransactions("order123")
+	for _, transaction := range transactions {
+		if transaction.Type == pb.TransactionType_REFUND && transaction.Succeeded {
+			refunds++
+		}
+	}
+	if refunds != 1 {
+		t.Fatalf("Expected 1 refund recorded, got %d", refunds)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"log"
 	"net"
+	"os"
+	"time"
 
 	"google.golang.org/grpc"
 	"gorm.io/driver/sqlite"
@@ -11,11 +13,24 @@ import (
 	pb "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/domain"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/provider"
 	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/services/payment-service/internal/repository"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/idempotency"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
 )
 
 var port = "8085"
 
+// purgeInterval is how often the expired idempotency keys are deleted
+const purgeInterval = time.Hour
+
+// providerURLEnv is the variable with the URL of the payment gateway, the fake gateway is used if it is not set
+const providerURLEnv = "PAYMENT_PROVIDER_URL"
+
+// providerTimeout bounds every call to the payment gateway
+const providerTimeout = 5 * time.Second
+
 func main() {
 
 	// Initialize database connection with GORM
@@ -25,7 +40,7 @@ func main() {
 	}
 
 	// Migrate the schema
-	if err := db.AutoMigrate(&domain.Payment{}); err != nil {
+	if err := db.AutoMigrate(&domain.Payment{}, &domain.PaymentTransaction{}, &idempotency.Record{}); err != nil {
 		log.Fatalf("Failed to migrate database schema: %v", err)
 	}
 
@@ -35,14 +50,40 @@ func main() {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 
+	// Initialize payment provider
+	var paymentProvider provider.PaymentProvider
+	if providerURL := os.Getenv(providerURLEnv); providerURL != "" {
+		paymentProvider = provider.NewHTTPProvider(providerURL, providerTimeout)
+		log.Printf("Payments are processed by the gateway at %s", providerURL)
+	} else {
+		paymentProvider = provider.NewFakeGateway(provider.FakeGatewayConfig{TimeoutAfter: providerTimeout})
+		log.Printf("Payments are processed by the fake gateway")
+	}
+
 	// Initialize repository
-	paymentRepo := repository.NewPaymentServiceRepository(db)
+	paymentRepo := repository.NewPaymentServiceRepository(db, paymentProvider)
 
 	// Initialize PaymentServer
 	paymentServer := internal.NewPaymentServer(paymentRepo)
 
-	// Register gRPC server
-	grpcServer := grpc.NewServer()
+	// Responses to requests with an idempotency key are replayed, expired keys are purged periodically
+	idempotencyStore := idempotency.NewStore(db, idempotency.DefaultTTL)
+	go func() {
+		for {
+			if _, err := idempotencyStore.PurgeExpired(); err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+			}
+			time.Sleep(purgeInterval)
+		}
+	}()
+
+	// Register gRPC server, every call is checked against the authorization policy before the idempotency keys
+	tokens, err := token.NewManagerFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load the token secret: %v", err)
+	}
+	authorizer := interceptor.NewAuthorizer(tokens, internal.AuthPolicy)
+	grpcServer := grpc.NewServer(append(authorizer.ServerOptions(), grpc.ChainUnaryInterceptor(idempotencyStore.Unary()))...)
 	pb.RegisterPaymentServiceServer(grpcServer, paymentServer)
 
 	log.Printf("Payment service listening on port %s", port)
@@ -0,0 +1,231 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// DefaultTTL is how long the responses are kept for replay.
+const DefaultTTL = 24 * time.Hour
+
+// inProgressLease is how long a request without response blocks the key,
+// after it the request is considered lost (e.g. the service crashed) and the key can be used again.
+const inProgressLease = time.Minute
+
+// Keyed is implemented by the requests carrying an idempotency key.
+type Keyed interface {
+	GetIdempotencyKey() string
+}
+
+// Record maps an idempotency key to the response of the first request that used it.
+// Keys are scoped by method and caller, so that different users can't replay each other's responses.
+type Record struct {
+
+	// Method is the full name of the RPC
+	Method string `gorm:"primaryKey"`
+
+	// Caller is the subject of the token of the caller
+	Caller string `gorm:"primaryKey"`
+
+	// Key chosen by the client
+	Key string `gorm:"primaryKey; column:idempotency_key"`
+
+	// RequestHash detects a key reused with a different request
+	RequestHash string `gorm:"not null"`
+
+	// Response is the serialized response, empty while the request is in progress
+	Response []byte
+
+	// ExpiresAt is when the record can be deleted
+	ExpiresAt time.Time `gorm:"not null; index"`
+
+	CreatedAt time.Time
+}
+
+func (Record) TableName() string {
+	return "idempotency_records"
+}
+
+// Store persists the responses of the requests with an idempotency key, in the database of the service.
+type Store struct {
+	db  *gorm.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewStore(db *gorm.DB, ttl time.Duration) *Store {
+	return &Store{db: db, ttl: ttl, now: time.Now}
+}
+
+// SetClock replaces the clock used to compute and check expirations.
+func (s *Store) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// Unary returns the interceptor replaying the responses of the requests with an idempotency key.
+// It must run after the authorization, to know the caller.
+// Only successful responses are stored: after an error the request can be retried with the same key.
+func (s *Store) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+
+		keyed, ok := req.(Keyed)
+		if !ok || keyed.GetIdempotencyKey() == "" {
+			return handler(ctx, req)
+		}
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		hash, err := requestHash(message)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		record := &Record{
+			Method:      info.FullMethod,
+			Caller:      callerFromContext(ctx),
+			Key:         keyed.GetIdempotencyKey(),
+			RequestHash: hash,
+		}
+
+		replay, err := s.begin(record)
+		if err != nil {
+			return nil, err
+		}
+		if replay != nil {
+			return replay, nil
+		}
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			s.abort(record)
+			return res, err
+		}
+
+		if err := s.complete(record, res); err != nil {
+			log.Printf("Failed to store response for idempotency key %s: %v", record.Key, err)
+		}
+		return res, nil
+	}
+}
+
+// PurgeExpired deletes the expired records, returning how many were deleted.
+func (s *Store) PurgeExpired() (int64, error) {
+	result := s.db.Where("expires_at <= ?", s.now()).Delete(&Record{})
+	return result.RowsAffected, result.Error
+}
+
+// PRIVATE FUNCTIONS
+
+// begin claims the key for the request, or returns the response to replay.
+func (s *Store) begin(record *Record) (proto.Message, error) {
+
+	now := s.now()
+	record.CreatedAt = now
+	record.ExpiresAt = now.Add(s.ttl)
+
+	if err := s.db.Create(record).Error; err == nil {
+		return nil, nil
+	}
+
+	var existing Record
+	err := s.db.Where("method = ? AND caller = ? AND idempotency_key = ?", record.Method, record.Caller, record.Key).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.Aborted, "A request with this idempotency key is in progress")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Expired keys and requests lost before answering can be used again
+	expired := !existing.ExpiresAt.After(now)
+	lost := existing.Response == nil && now.Sub(existing.CreatedAt) > inProgressLease
+	if expired || lost {
+		if err := s.db.Delete(&existing).Error; err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if err := s.db.Create(record).Error; err != nil {
+			return nil, status.Error(codes.Aborted, "A request with this idempotency key is in progress")
+		}
+		return nil, nil
+	}
+
+	if existing.RequestHash != record.RequestHash {
+		return nil, status.Error(codes.InvalidArgument, "Idempotency key already used with a different request")
+	}
+	if existing.Response == nil {
+		return nil, status.Error(codes.Aborted, "A request with this idempotency key is in progress")
+	}
+
+	var stored anypb.Any
+	if err := proto.Unmarshal(existing.Response, &stored); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := stored.UnmarshalNew()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+// complete stores the response of the request.
+func (s *Store) complete(record *Record, res any) error {
+	message, ok := res.(proto.Message)
+	if !ok {
+		return errors.New("response is not a protobuf message")
+	}
+
+	stored, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	raw, err := proto.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Model(&Record{}).
+		Where("method = ? AND caller = ? AND idempotency_key = ?", record.Method, record.Caller, record.Key).
+		Update("response", raw).Error
+}
+
+// abort releases the key after a failed request.
+func (s *Store) abort(record *Record) {
+	err := s.db.Where("method = ? AND caller = ? AND idempotency_key = ? AND response IS NULL", record.Method, record.Caller, record.Key).
+		Delete(&Record{}).Error
+	if err != nil {
+		log.Printf("Failed to release idempotency key %s: %v", record.Key, err)
+	}
+}
+
+// requestHash hashes the serialized request.
+func requestHash(req proto.Message) (string, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// callerFromContext returns the subject of the token of the caller, empty for anonymous callers.
+func callerFromContext(ctx context.Context) string {
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok {
+		return claims.Subject
+	}
+	return ""
+}
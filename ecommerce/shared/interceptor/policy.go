@@ -0,0 +1,69 @@
+package interceptor
+
+// Roles carried by the tokens
+const (
+	RoleUser    = "USER"
+	RoleAdmin   = "ADMIN"
+	RoleService = "SERVICE"
+)
+
+// OwnerFunc extracts from a request the username of the resource owner.
+type OwnerFunc func(req any) string
+
+// Rule describes who is allowed to call an RPC.
+type Rule struct {
+
+	// Public RPCs can be called without a token.
+	Public bool
+
+	// Roles allowed to call the RPC, if empty any authenticated caller is allowed.
+	Roles []string
+
+	// Owner, if set, restricts the RPC to the owner of the resource.
+	// Admins and services are always allowed to act on behalf of the owner.
+	Owner OwnerFunc
+}
+
+// Policy maps the full name of each RPC (e.g. "/cart.CartService/GetCart") to its rule.
+// RPCs missing from the policy are denied.
+type Policy map[string]Rule
+
+// Public allows anyone to call the RPC.
+func Public() Rule {
+	return Rule{Public: true}
+}
+
+// Authenticated allows any caller with a valid token.
+func Authenticated() Rule {
+	return Rule{}
+}
+
+// AdminOnly allows only admins (and other services) to call the RPC.
+func AdminOnly() Rule {
+	return Rule{Roles: []string{RoleAdmin, RoleService}}
+}
+
+// ServiceOnly allows only other services to call the RPC.
+func ServiceOnly() Rule {
+	return Rule{Roles: []string{RoleService}}
+}
+
+// OwnerOnly allows the owner of the resource, admins and services to call the RPC.
+func OwnerOnly(owner OwnerFunc) Rule {
+	return Rule{Owner: owner}
+}
+
+// isPrivileged tells if a role can act on resources owned by other users.
+func isPrivileged(role string) bool {
+	return role == RoleAdmin || role == RoleService
+}
+
+// hasRole checks if role is in the list of allowed roles.
+func hasRole(role string, allowed []string) bool {
+	for _, r := range allowed {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
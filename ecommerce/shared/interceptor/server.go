@@ -0,0 +1,187 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+// authorizationKey is the metadata key carrying the access token ("Bearer <token>").
+const authorizationKey = "authorization"
+
+type claimsKey struct{}
+
+// Authorizer verifies the caller identity and enforces a policy on every incoming RPC.
+type Authorizer struct {
+	tokens *token.Manager
+	policy Policy
+}
+
+func NewAuthorizer(tokens *token.Manager, policy Policy) *Authorizer {
+	return &Authorizer{tokens: tokens, policy: policy}
+}
+
+// ServerOptions returns the options to install the interceptors with grpc.NewServer.
+func (a *Authorizer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(a.Unary()),
+		grpc.StreamInterceptor(a.Stream()),
+	}
+}
+
+// Unary returns the interceptor for unary RPCs.
+func (a *Authorizer) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+
+		ctx, rule, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, rule, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the interceptor for streaming RPCs.
+// The owner of the resource is checked on every message received from the client.
+func (a *Authorizer) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		ctx, rule, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// Without an owner rule the request content doesn't matter
+		if rule.Owner == nil {
+			if err := authorize(ctx, rule, nil); err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx, rule: rule})
+	}
+}
+
+// ClaimsFromContext returns the claims of the caller, set by the interceptors.
+// Public RPCs called without a token have no claims.
+func ClaimsFromContext(ctx context.Context) (*token.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*token.Claims)
+	return claims, ok && claims != nil
+}
+
+// CheckOwner verifies that the caller is the given owner, an admin or a service.
+// It is used by RPCs whose owner is known only after reading the resource.
+func CheckOwner(ctx context.Context, owner string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "Missing access token")
+	}
+	if isPrivileged(claims.Role) || claims.Subject == owner {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "Caller is not the owner of the resource")
+}
+
+// PRIVATE FUNCTIONS
+
+// authenticate finds the rule of the method and verifies the token (if any) of the caller.
+// The returned context carries the claims of the caller.
+// Access tokens are not checked against the revocation table of the auth service: they are short-lived,
+// a logged out access token stays valid until its expiration.
+func (a *Authorizer) authenticate(ctx context.Context, method string) (context.Context, Rule, error) {
+
+	rule, ok := a.policy[method]
+	if !ok {
+		return ctx, rule, status.Errorf(codes.PermissionDenied, "No policy defined for %s", method)
+	}
+
+	raw, found := tokenFromMetadata(ctx)
+	if !found {
+		if rule.Public {
+			return ctx, rule, nil
+		}
+		return ctx, rule, status.Error(codes.Unauthenticated, "Missing access token")
+	}
+
+	// A stale token doesn't lock the caller out of public RPCs, they are called as anonymous
+	claims, err := a.tokens.Verify(raw, token.Access)
+	if err != nil {
+		if rule.Public {
+			return ctx, rule, nil
+		}
+		return ctx, rule, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return context.WithValue(ctx, claimsKey{}, claims), rule, nil
+}
+
+// authorize checks roles and ownership of the caller against the rule.
+func authorize(ctx context.Context, rule Rule, req any) error {
+
+	if rule.Public {
+		return nil
+	}
+
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "Missing access token")
+	}
+
+	if len(rule.Roles) > 0 && !hasRole(claims.Role, rule.Roles) {
+		return status.Errorf(codes.PermissionDenied, "Role %s is not allowed to call this method", claims.Role)
+	}
+
+	if rule.Owner != nil && req != nil && !isPrivileged(claims.Role) && rule.Owner(req) != claims.Subject {
+		return status.Error(codes.PermissionDenied, "Caller is not the owner of the resource")
+	}
+
+	return nil
+}
+
+// tokenFromMetadata extracts the bearer token from the incoming metadata.
+func tokenFromMetadata(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	raw, found := strings.CutPrefix(values[0], "Bearer ")
+	return raw, found && raw != ""
+}
+
+// authorizedStream carries the claims of the caller and checks ownership on received messages.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	rule Rule
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.rule.Owner == nil {
+		return nil
+	}
+	return authorize(s.ctx, s.rule, m)
+}
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/idempotency"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+const createMethod = "/test.TestService/Create"
+
+// keyedRequest is a request carrying an idempotency key
+type keyedRequest struct {
+	*wrapperspb.StringValue
+	key string
+}
+
+func (r *keyedRequest) GetIdempotencyKey() string {
+	return r.key
+}
+
+// idempotencyHarness runs requests through the authorizer and the idempotency store
+type idempotencyHarness struct {
+	t       *testing.T
+	manager *token.Manager
+	store   *idempotency.Store
+	unary   grpc.UnaryServerInterceptor
+
+	// calls counts the executions of the handler
+	calls int
+
+	// fail makes the handler fail
+	fail bool
+}
+
+func setupIdempotency(t *testing.T) *idempotencyHarness {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&idempotency.Record{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	manager := token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+	store := idempotency.NewStore(db, time.Hour)
+	authorizer := interceptor.NewAuthorizer(manager, interceptor.Policy{createMethod: interceptor.Authenticated()})
+
+	return &idempotencyHarness{
+		t:       t,
+		manager: manager,
+		store:   store,
+		unary:   chain(authorizer.Unary(), store.Unary()),
+	}
+}
+
+// chain runs two interceptors in order
+func chain(first, second grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return first(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return second(ctx, req, info, handler)
+		})
+	}
+}
+
+// call sends a request as username, the handler answers with a new value at every execution
+func (h *idempotencyHarness) call(username, key, value string) (string, error) {
+	raw, _, err := h.manager.Issue(username, interceptor.RoleUser, token.Access)
+	if err != nil {
+		h.t.Fatalf("Issue failed: %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+raw))
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		h.calls++
+		if h.fail {
+			return nil, errors.New("handler failed")
+		}
+		return wrapperspb.String(req.(*keyedRequest).GetValue() + "-" + string(rune('0'+h.calls))), nil
+	}
+
+	req := &keyedRequest{StringValue: wrapperspb.String(value), key: key}
+	res, err := h.unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: createMethod}, handler)
+	if err != nil {
+		return "", err
+	}
+	return res.(*wrapperspb.StringValue).GetValue(), nil
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	h := setupIdempotency(t)
+
+	first, err := h.call("user1", "key1", "order")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := h.call("user1", "key1", "order")
+	if err != nil {
+		t.Fatalf("Expected no error on replay, got %v", err)
+	}
+
+	if first != second {
+		t.Fatalf("Expected the original response %q, got %q", first, second)
+	}
+	if h.calls != 1 {
+		t.Fatalf("Expected the handler to run once, got %d", h.calls)
+	}
+}
+
+func TestIdempotencyKeyReusedWithDifferentRequest(t *testing.T) {
+	h := setupIdempotency(t)
+
+	h.call("user1", "key1", "order")
+
+	if _, err := h.call("user1", "key1", "another order"); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestIdempotencyKeysAreScopedByCaller(t *testing.T) {
+	h := setupIdempotency(t)
+
+	h.call("user1", "key1", "order")
+	h.call("user2", "key1", "order")
+
+	if h.calls != 2 {
+		t.Fatalf("Expected the handler to run for each caller, got %d", h.calls)
+	}
+}
+
+func TestIdempotencyErrorsAreNotStored(t *testing.T) {
+	h := setupIdempotency(t)
+
+	h.fail = true
+	if _, err := h.call("user1", "key1", "order"); err == nil {
+		t.Fatalf("Expected error from the handler, got nil")
+	}
+
+	// The request can be retried with the same key
+	h.fail = false
+	if _, err := h.call("user1", "key1", "order"); err != nil {
+		t.Fatalf("Expected no error on retry, got %v", err)
+	}
+	if h.calls != 2 {
+		t.Fatalf("Expected the handler to run twice, got %d", h.calls)
+	}
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	h := setupIdempotency(t)
+
+	h.call("user1", "", "order")
+	h.call("user1", "", "order")
+
+	if h.calls != 2 {
+		t.Fatalf("Expected the handler to run for each request without key, got %d", h.calls)
+	}
+}
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	h := setupIdempotency(t)
+
+	h.call("user1", "key1", "order")
+
+	// Move the clock after the expiration of the record
+	h.store.SetClock(func() time.Time { return time.Now().Add(2 * time.Hour) })
+
+	purged, err := h.store.PurgeExpired()
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected 1 record purged, got %d (%v)", purged, err)
+	}
+
+	h.call("user1", "key1", "order")
+	if h.calls != 2 {
+		t.Fatalf("Expected the handler to run again after expiration, got %d", h.calls)
+	}
+}
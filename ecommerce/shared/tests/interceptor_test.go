@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+const (
+	publicMethod = "/test.TestService/Public"
+	adminMethod  = "/test.TestService/Admin"
+	ownerMethod  = "/test.TestService/Owner"
+)
+
+// ownedRequest is a request targeting the resources of a user
+type ownedRequest struct {
+	username string
+}
+
+func setupAuthorizer() (*token.Manager, grpc.UnaryServerInterceptor) {
+	manager := token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+
+	policy := interceptor.Policy{
+		publicMethod: interceptor.Public(),
+		adminMethod:  interceptor.AdminOnly(),
+		ownerMethod: interceptor.OwnerOnly(func(req any) string {
+			return req.(*ownedRequest).username
+		}),
+	}
+
+	return manager, interceptor.NewAuthorizer(manager, policy).Unary()
+}
+
+// callAs invokes the interceptor as if the RPC was called with the token of username
+func callAs(t *testing.T, manager *token.Manager, unary grpc.UnaryServerInterceptor, username, role, method string, req any) error {
+	ctx := context.Background()
+	if username != "" {
+		raw, _, err := manager.Issue(username, role, token.Access)
+		if err != nil {
+			t.Fatalf("Issue failed: %v", err)
+		}
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+raw))
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	}
+
+	_, err := unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+func TestPublicMethodWithoutToken(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	if err := callAs(t, manager, unary, "", "", publicMethod, nil); err != nil {
+		t.Fatalf("Public method should not require a token: %v", err)
+	}
+}
+
+func TestProtectedMethodWithoutToken(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	err := callAs(t, manager, unary, "", "", adminMethod, nil)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestAdminMethodAsUser(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	err := callAs(t, manager, unary, "user1", interceptor.RoleUser, adminMethod, nil)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestAdminMethodAsAdmin(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	if err := callAs(t, manager, unary, "admin1", interceptor.RoleAdmin, adminMethod, nil); err != nil {
+		t.Fatalf("Admin should be allowed: %v", err)
+	}
+}
+
+func TestOwnerMethod(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	// The owner is allowed
+	if err := callAs(t, manager, unary, "user1", interceptor.RoleUser, ownerMethod, &ownedRequest{username: "user1"}); err != nil {
+		t.Fatalf("Owner should be allowed: %v", err)
+	}
+
+	// Another user is not allowed
+	err := callAs(t, manager, unary, "user2", interceptor.RoleUser, ownerMethod, &ownedRequest{username: "user1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied, got %v", err)
+	}
+
+	// Services can act on behalf of the owner
+	if err := callAs(t, manager, unary, "checkout", interceptor.RoleService, ownerMethod, &ownedRequest{username: "user1"}); err != nil {
+		t.Fatalf("Service should be allowed: %v", err)
+	}
+}
+
+func TestMethodMissingFromPolicy(t *testing.T) {
+	manager, unary := setupAuthorizer()
+
+	err := callAs(t, manager, unary, "admin1", interceptor.RoleAdmin, "/test.TestService/Unknown", nil)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	_, unary := setupAuthorizer()
+
+	// Token signed with another secret
+	other := token.NewManager([]byte("AnotherSecret"), time.Minute, time.Hour)
+
+	err := callAs(t, other, unary, "admin1", interceptor.RoleAdmin, adminMethod, nil)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestPublicMethodWithInvalidToken(t *testing.T) {
+	_, unary := setupAuthorizer()
+
+	// An expired or forged token is ignored by public methods, the caller has no claims
+	other := token.NewManager([]byte("AnotherSecret"), time.Minute, time.Hour)
+	raw, _, _ := other.Issue("admin1", interceptor.RoleAdmin, token.Access)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+raw))
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		if _, ok := interceptor.ClaimsFromContext(ctx); ok {
+			t.Fatalf("Expected no claims for an invalid token")
+		}
+		return nil, nil
+	}
+	if _, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: publicMethod}, handler); err != nil {
+		t.Fatalf("Public method should ignore an invalid token: %v", err)
+	}
+}
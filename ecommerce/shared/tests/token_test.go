@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/token"
+)
+
+func setupManager() *token.Manager {
+	return token.NewManager([]byte("TestSecret"), time.Minute, time.Hour)
+}
+
+func TestIssueAndVerifyAccessToken(t *testing.T) {
+	manager := setupManager()
+
+	raw, issued, err := manager.Issue("user1", "USER", token.Access)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	claims, err := manager.Verify(raw, token.Access)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if claims.Subject != "user1" {
+		t.Fatalf("Expected subject user1, got %s", claims.Subject)
+	}
+	if claims.Role != "USER" {
+		t.Fatalf("Expected role USER, got %s", claims.Role)
+	}
+	if claims.ID != issued.ID {
+		t.Fatalf("Expected token ID %s, got %s", issued.ID, claims.ID)
+	}
+}
+
+func TestIssueWithEmptySubject(t *testing.T) {
+	manager := setupManager()
+
+	if _, _, err := manager.Issue("", "USER", token.Access); err == nil {
+		t.Fatalf("Expected Issue to fail with empty subject")
+	}
+}
+
+func TestVerifyWrongType(t *testing.T) {
+	manager := setupManager()
+
+	raw, _, err := manager.Issue("user1", "USER", token.Refresh)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if _, err := manager.Verify(raw, token.Access); err != token.ErrWrongType {
+		t.Fatalf("Expected ErrWrongType, got %v", err)
+	}
+}
+
+func TestVerifyExpiredToken(t *testing.T) {
+	manager := setupManager()
+
+	raw, _, err := manager.Issue("user1", "USER", token.Access)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	// Move the clock after the expiration of the access token
+	manager.SetClock(func() time.Time { return time.Now().Add(2 * time.Minute) })
+
+	if _, err := manager.Verify(raw, token.Access); err != token.ErrExpiredToken {
+		t.Fatalf("Expected ErrExpiredToken, got %v", err)
+	}
+}
+
+func TestVerifyTamperedToken(t *testing.T) {
+	manager := setupManager()
+
+	raw, _, err := manager.Issue("user1", "USER", token.Access)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	// Replace the payload with the one of an admin token
+	adminRaw, _, err := manager.Issue("user1", "ADMIN", token.Access)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	parts := strings.Split(raw, ".")
+	adminParts := strings.Split(adminRaw, ".")
+	tampered := parts[0] + "." + adminParts[1] + "." + parts[2]
+
+	if _, err := manager.Verify(tampered, token.Access); err != token.ErrInvalidToken {
+		t.Fatalf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestVerifyWithDifferentSecret(t *testing.T) {
+	manager := setupManager()
+	other := token.NewManager([]byte("AnotherSecret"), time.Minute, time.Hour)
+
+	raw, _, err := manager.Issue("user1", "USER", token.Access)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if _, err := other.Verify(raw, token.Access); err != token.ErrInvalidToken {
+		t.Fatalf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestNewManagerFromEnvWithoutSecret(t *testing.T) {
+	t.Setenv("AUTH_TOKEN_SECRET", "")
+
+	if _, err := token.NewManagerFromEnv(); err != token.ErrMissingSecret {
+		t.Fatalf("Expected ErrMissingSecret, got %v", err)
+	}
+
+	t.Setenv("AUTH_TOKEN_SECRET", "TestSecret")
+	if _, err := token.NewManagerFromEnv(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+// Type distinguishes short-lived access tokens from long-lived refresh tokens.
+type Type string
+
+const (
+	// Access tokens are attached to every gRPC call to prove the identity of the caller.
+	Access Type = "access"
+
+	// Refresh tokens can only be exchanged with the auth service for a new token pair.
+	Refresh Type = "refresh"
+)
+
+const (
+	// DefaultAccessTTL is the lifetime of an access token.
+	DefaultAccessTTL = 15 * time.Minute
+
+	// DefaultRefreshTTL is the lifetime of a refresh token (same as the web session cookie).
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+)
+
+// secretEnv is the environment variable shared by every service to sign and verify tokens.
+// There is no default: a secret known to anyone would let anyone forge admin and service tokens.
+const secretEnv = "AUTH_TOKEN_SECRET"
+
+var (
+	ErrInvalidToken  = errors.New("Invalid token")
+	ErrExpiredToken  = errors.New("Token has expired")
+	ErrWrongType     = errors.New("Unexpected token type")
+	ErrMissingSecret = errors.New(secretEnv + " is not set")
+)
+
+// header is the fixed JWT header, tokens are always signed with HMAC-SHA256.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are the information carried (and signed) inside a token.
+type Claims struct {
+	// ID uniquely identifies the token, it is the key used in the revocation table.
+	ID string `json:"jti"`
+
+	// Subject is the username of the token owner.
+	Subject string `json:"sub"`
+
+	// Role is the role of the token owner (USER, ADMIN or SERVICE).
+	Role string `json:"role"`
+
+	// Type tells if the token is an access or a refresh token.
+	Type Type `json:"typ"`
+
+	// IssuedAt and ExpiresAt are unix timestamps in seconds.
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+// Expiration returns the expiration time of the token.
+func (c *Claims) Expiration() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Manager issues and verifies signed tokens.
+type Manager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+
+	// now is replaceable so that expiration can be tested.
+	now func() time.Time
+}
+
+func NewManager(secret []byte, accessTTL, refreshTTL time.Duration) *Manager {
+	return &Manager{
+		secret:     secret,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+// NewManagerFromEnv creates a manager with the secret shared by all services and the default lifetimes.
+// It fails when AUTH_TOKEN_SECRET is not set.
+func NewManagerFromEnv() (*Manager, error) {
+	secret, err := SecretFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewManager(secret, DefaultAccessTTL, DefaultRefreshTTL), nil
+}
+
+// SecretFromEnv returns the signing secret from AUTH_TOKEN_SECRET, ErrMissingSecret if it is not set.
+func SecretFromEnv() ([]byte, error) {
+	secret := os.Getenv(secretEnv)
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	return []byte(secret), nil
+}
+
+// SetClock replaces the clock used to compute and check expirations.
+func (m *Manager) SetClock(now func() time.Time) {
+	m.now = now
+}
+
+// Issue creates a new signed token of the given type for the subject.
+func (m *Manager) Issue(subject, role string, tokenType Type) (string, *Claims, error) {
+
+	if subject == "" {
+		return "", nil, errors.New("Token subject cannot be empty")
+	}
+
+	ttl := m.accessTTL
+	if tokenType == Refresh {
+		ttl = m.refreshTTL
+	}
+
+	id, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	issuedAt := m.now()
+	claims := &Claims{
+		ID:        id,
+		Subject:   subject,
+		Role:      role,
+		Type:      tokenType,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: issuedAt.Add(ttl).Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + m.sign(unsigned), claims, nil
+}
+
+// Verify checks signature, expiration and type of a token and returns its claims.
+func (m *Manager) Verify(raw string, expected Type) (*Claims, error) {
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return nil, ErrInvalidToken
+	}
+
+	// Constant time comparison of the signature
+	if !hmac.Equal([]byte(parts[2]), []byte(m.sign(parts[0]+"."+parts[1]))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Type != expected {
+		return nil, ErrWrongType
+	}
+
+	if !m.now().Before(claims.Expiration()) {
+		return &claims, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+// PRIVATE FUNCTIONS
+
+// sign computes the base64url encoded HMAC-SHA256 of the input.
+func (m *Manager) sign(input string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newTokenID generates a random 128 bit identifier.
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
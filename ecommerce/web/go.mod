@@ -4,13 +4,16 @@ go 1.25.1
 
 replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto => ../proto
 
-require github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0-00010101000000-000000000000
+replace github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared => ../shared
 
 require (
-	github.com/gorilla/securecookie v1.1.2 // indirect
-	github.com/gorilla/sessions v1.4.0 // indirect
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto v0.0.0-00010101000000-000000000000
+	github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared v0.0.0-00010101000000-000000000000
+	github.com/gorilla/sessions v1.4.0
 )
 
+require github.com/gorilla/securecookie v1.1.2 // indirect
+
 require (
 	golang.org/x/net v0.49.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
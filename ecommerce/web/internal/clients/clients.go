@@ -4,10 +4,13 @@ import (
 	pbAuth "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
 	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
 	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbCheckout "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
 	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
 	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
 )
 
 // ServiceClients groups all gRPC clients
@@ -17,39 +20,47 @@ type ServiceClients struct {
 	Catalog     pbCatalog.CatalogServiceClient
 	Order       pbOrder.OrderServiceClient
 	Payment     pbPayment.PaymentServiceClient
+	Checkout    pbCheckout.CheckoutServiceClient
 	connections []*grpc.ClientConn
 }
 
 // InitClients initializes all gRPC connections
 func InitClients() (*ServiceClients, error) {
-	opts := grpc.WithTransportCredentials(insecure.NewCredentials())
+	// The access token of the user (see interceptor.WithToken) is forwarded on every call
+	opts := append(interceptor.ClientOptions(), grpc.WithTransportCredentials(insecure.NewCredentials()))
 
 	// Auth connection
-	authConn, err := grpc.NewClient("localhost:8081", opts)
+	authConn, err := grpc.NewClient("localhost:8081", opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Cart connection
-	cartConn, err := grpc.NewClient("localhost:8082", opts)
+	cartConn, err := grpc.NewClient("localhost:8082", opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Catalog connection
-	catalogConn, err := grpc.NewClient("localhost:8083", opts)
+	catalogConn, err := grpc.NewClient("localhost:8083", opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Order connection
-	orderConn, err := grpc.NewClient("localhost:8084", opts)
+	orderConn, err := grpc.NewClient("localhost:8084", opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Payment connection
-	paymentConn, err := grpc.NewClient("localhost:8085", opts)
+	paymentConn, err := grpc.NewClient("localhost:8085", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checkout connection
+	checkoutConn, err := grpc.NewClient("localhost:8086", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +71,8 @@ func InitClients() (*ServiceClients, error) {
 		Catalog:     pbCatalog.NewCatalogServiceClient(catalogConn),
 		Order:       pbOrder.NewOrderServiceClient(orderConn),
 		Payment:     pbPayment.NewPaymentServiceClient(paymentConn),
-		connections: []*grpc.ClientConn{authConn, cartConn, catalogConn, orderConn, paymentConn},
+		Checkout:    pbCheckout.NewCheckoutServiceClient(checkoutConn),
+		connections: []*grpc.ClientConn{authConn, cartConn, catalogConn, orderConn, paymentConn, checkoutConn},
 	}, nil
 }
 
@@ -129,6 +129,11 @@ func (s *ServerDependencies) LoginHandler(writer http.ResponseWriter, request *h
 		session.Values["role"] = authRes.GetUser().GetRole().String()
 		session.Values["logged_in"] = true
 
+		// Save signed tokens, used to prove the user identity to the services
+		session.Values["access_token"] = authRes.GetAccessToken()
+		session.Values["refresh_token"] = authRes.GetRefreshToken()
+		session.Values["access_token_expires_at"] = authRes.GetAccessTokenExpiresAt()
+
 		// Saving session
 		err = session.Save(request, writer)
 		if !checkerr(writer, err) {
@@ -149,6 +154,14 @@ func (s *ServerDependencies) LogoutHandler(writer http.ResponseWriter, request *
 		return
 	}
 
+	// Revoke the refresh token of the session, the access token is deleted with the cookie and expires on its own
+	if refreshToken, ok := session.Values["refresh_token"].(string); ok && refreshToken != "" {
+		_, err = s.Clients.Auth.Logout(request.Context(), &pbAuth.LogoutRequest{RefreshToken: refreshToken})
+		if err != nil {
+			log.Printf("Failed to revoke the refresh token of %v: %v", session.Values["username"], err)
+		}
+	}
+
 	// Set MaxAge=-1 to tell the browser to delete the cookie
 	session.Options.MaxAge = -1
 
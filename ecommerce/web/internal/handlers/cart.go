@@ -6,7 +6,11 @@ import (
 	"net/http"
 	"strconv"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 )
 
 func (s *ServerDependencies) CartHandler(writer http.ResponseWriter, request *http.Request) {
@@ -57,14 +61,35 @@ func (s *ServerDependencies) CartHandler(writer http.ResponseWriter, request *ht
 	if queryError == "payment_failed" {
 		errorMessage = "Failed payment: the amount provided was insufficient"
 	}
+	if queryError == "invalid_card" {
+		errorMessage = "Failed payment: the card number is not valid"
+	}
+	if queryError == "checkout_running" {
+		errorMessage = "A checkout of your cart is already in progress, please wait for it to finish"
+	}
+	if queryError == "checkout_failed" {
+		errorMessage = "Checkout failed: your order has been canceled, please try again"
+	}
 
 	// Mapping data for HTML file
+	// Items are shown by their name
+	itemIDs := make([]string, 0, len(cartRes.GetCart().GetItems()))
+	for _, item := range cartRes.GetCart().GetItems() {
+		itemIDs = append(itemIDs, item.GetItemId())
+	}
+
 	templateData := map[string]interface{}{
 		"Items":      cartRes.GetCart().GetItems(),
+		"ItemNames":  s.itemNames(request.Context(), itemIDs),
 		"TotalPrice": math.Trunc(totalPriceRes.GetTotalPrice()*100) / 100,
 		"Error":      errorMessage,
 	}
 
+	// Items bought together with the ones in the cart
+	if len(itemIDs) > 0 {
+		templateData["Recommendations"] = s.cartRecommendations(request.Context(), itemIDs)
+	}
+
 	checkerr(writer, s.Templates.ExecuteTemplate(writer, "cart.html", templateData))
 }
 
@@ -81,17 +106,27 @@ func (s *ServerDependencies) AddToCartHandler(writer http.ResponseWriter, reques
 		return
 	}
 
-	// Retrieve user and product data
+	// Retrieve user and product data, the variant chosen of a product is the item added
 	productId := request.FormValue("product_id")
-	priceStr := request.FormValue("price")
+	if variantId := request.FormValue("variant_id"); variantId != "" {
+		productId = variantId
+	}
 	username := session.Values["username"].(string)
 	quantityStr := request.FormValue("quantity")
 
-	// Price conversion
-	price, err := strconv.ParseFloat(priceStr, 64)
+	// Price and SKU are the ones of the catalog
+	itemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{ItemId: productId})
+	if status.Code(err) == codes.NotFound {
+		http.Error(writer, "Item not found", http.StatusNotFound)
+		return
+	}
 	if !checkerr(writer, err) {
 		return
 	}
+	if len(itemRes.GetItem().GetVariants()) > 0 {
+		http.Error(writer, "Choose a variant of the item", http.StatusBadRequest)
+		return
+	}
 
 	// Quantity Conversion
 	quantity, err := strconv.Atoi(quantityStr)
@@ -106,8 +141,9 @@ func (s *ServerDependencies) AddToCartHandler(writer http.ResponseWriter, reques
 		Username: username,
 		CartItem: &pbCart.CartItem{
 			ItemId:   productId,
-			Price:    float64(price),
-			Quantity: uint32(quantity)},
+			Price:    itemRes.GetItem().GetPrice(),
+			Quantity: uint32(quantity),
+			Sku:      itemRes.GetItem().GetSku()},
 	})
 
 	if err != nil {
@@ -117,6 +153,7 @@ func (s *ServerDependencies) AddToCartHandler(writer http.ResponseWriter, reques
 	}
 
 	log.Printf("Product added successfully to cart")
+	s.publishCartChange(username, productId, "added")
 
 	// Redirection to shopping cart page
 	http.Redirect(writer, request, "/cart", http.StatusSeeOther)
@@ -152,6 +189,7 @@ func (s *ServerDependencies) RemoveFromCartHandler(writer http.ResponseWriter, r
 	}
 
 	log.Printf("Product removed successfully from cart")
+	s.publishCartChange(username, productId, "removed")
 
 	// Redirection to shopping cart page
 	http.Redirect(writer, request, "/cart", http.StatusSeeOther)
@@ -191,6 +229,7 @@ func (s *ServerDependencies) UpdateQuantityCartHandler(writer http.ResponseWrite
 	}
 
 	log.Printf("Product quantity successfully updated")
+	s.publishCartChange(username, productId, "updated")
 
 	// Redirection to shopping cart page
 	http.Redirect(writer, request, "/cart", http.StatusSeeOther)
@@ -1,21 +1,117 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
 )
 
+// catalogPageSize is the number of products shown per catalog page, unless another size is chosen
+const catalogPageSize = 12
+
+// categoryKept is the value of the category select leaving the category of an item unchanged
+const categoryKept = "keep"
+
+// Bulk import and export of the catalog
+const (
+	// maxImportUploadSize bounds an upload, the catalog accepts files up to 16 MiB and the rest is left to the form
+	maxImportUploadSize = 17 << 20
+
+	// importChunkSize is the size of the chunks of an imported file
+	importChunkSize = 32 << 10
+)
+
+// catalogFileFormats are the formats of the imported and exported files, by name
+var catalogFileFormats = map[string]pbCatalog.CatalogFileFormat{
+	"csv":  pbCatalog.CatalogFileFormat_CSV,
+	"json": pbCatalog.CatalogFileFormat_JSON,
+}
+
+// catalogFileTypes are the content types of the exported files, by format name
+var catalogFileTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json",
+}
+
+// itemTabs are the tabs of the admin page editing an item loaded with its current version
+var itemTabs = []string{"price", "quantity", "details", "images"}
+
+// adminTabs are the tabs of the admin page that can be opened without an item
+var adminTabs = []string{"reviews", "alerts"}
+
+// itemFields are the fields of the admin forms editing an item
+var itemFields = []string{"item_id", "price", "quantity", "warehouse_id", "name", "description", "sku", "slug", "attributes"}
+
 func (s *ServerDependencies) CatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	// Retrieve filters, sort order and page from the query string
+	query := request.URL.Query()
+
+	// A search replaces the listing
+	if searchQuery := strings.TrimSpace(query.Get("q")); searchQuery != "" {
+		s.searchCatalog(writer, request, searchQuery)
+		return
+	}
+
+	listRequest, err := catalogListRequest(query)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Categories of the sidebar, the catalog is still shown without them
+	categories := s.listCategories(request.Context())
+
+	// The category is given by its slug
+	var selected *pbCatalog.Category
+	if slug := query.Get("category"); slug != "" {
+		for _, category := range categories {
+			if category.GetSlug() == slug {
+				selected = category
+			}
+		}
+		if selected == nil {
+			http.Error(writer, "Category not found", http.StatusNotFound)
+			return
+		}
+		listRequest.CategoryId = selected.GetCategoryId()
+	}
+
 	// Calling catalog service via gRPC
-	catalogRes, err := s.Clients.Catalog.ListCatalogItems(request.Context(), &pbCatalog.ListCatalogItemsRequest{})
+	catalogRes, err := s.Clients.Catalog.ListCatalogItems(request.Context(), listRequest)
+
+	// Invalid filters or a page token of another listing
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.NotFound {
+		http.Error(writer, status.Convert(err).Message(), http.StatusNotFound)
+		return
+	}
 	if !checkerr(writer, err) {
 		return
 	}
 
+	// Link to the next page, keeping the same filters and sort order
+	nextPage := ""
+	if catalogRes.GetNextPageToken() != "" {
+		query.Set("page_token", catalogRes.GetNextPageToken())
+		nextPage = "/catalog?" + query.Encode()
+	}
+	query.Del("page_token")
+
 	// Get current session
 	session, err := s.Store.Get(request, sessionName)
 	if !checkerr(writer, err) {
@@ -25,16 +121,278 @@ func (s *ServerDependencies) CatalogHandler(writer http.ResponseWriter, request
 	// Retrieving if user is logged in or not
 	isLoggedIn, _ := session.Values["logged_in"].(bool)
 
+	// Links of the sidebar keep the filters and sort order, starting from the first page
+	withoutCategory := queryWithout(query, "category")
+	withoutTag := queryWithout(query, "tag")
+
 	// Map with data to send to HTML file
+	templateData := map[string]interface{}{
+		"Title":         "Fanta Catalog",
+		"Products":      catalogRes.GetItems(), // List the products of the page from gRPC
+		"IsLoggedIn":    isLoggedIn,
+		"Categories":    categoryTree(categories, selected, withoutCategory),
+		"Breadcrumb":    categoryPath(categories, selected),
+		"AllCategories": "/catalog?" + withoutCategory.Encode(),
+		"Category":      query.Get("category"),
+		"Tag":           listRequest.Tag,
+		"WithoutTag":    "/catalog?" + withoutTag.Encode(),
+		"MinPrice":      query.Get("min_price"),
+		"MaxPrice":      query.Get("max_price"),
+		"InStock":       listRequest.InStockOnly,
+		"Sort":          listRequest.Sort.String(),
+		"PageSize":      listRequest.PageSize,
+		"Sorts":         []string{"NAME", "PRICE_ASC", "PRICE_DESC", "NEWEST", "RATING"},
+		"FirstPage":     "/catalog?" + query.Encode(),
+		"NextPage":      nextPage,
+		"PastFirst":     listRequest.PageToken != "",
+	}
+
+	// The first page recommends to the user the items bought together with the ones they bought
+	if username, ok := session.Values["username"].(string); ok && isLoggedIn && listRequest.PageToken == "" {
+		templateData["Recommendations"] = s.userRecommendations(request.Context(), username)
+	}
+
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "catalog.html", templateData))
+}
+
+// categoryNode is a category of the sidebar, with its subcategories
+type categoryNode struct {
+	Name     string
+	Count    uint32
+	URL      string
+	Selected bool
+	Children []*categoryNode
+}
+
+// listCategories returns all the categories of the catalog, none if the catalog cannot be reached
+func (s *ServerDependencies) listCategories(ctx context.Context) []*pbCatalog.Category {
+	categoriesRes, err := s.Clients.Catalog.ListCategories(ctx, &pbCatalog.ListCategoriesRequest{})
+	if err != nil {
+		log.Printf("Impossible to retrieve the categories: %v", err)
+		return nil
+	}
+	return categoriesRes.GetCategories()
+}
+
+// categoryTree builds the tree of the sidebar from the categories, keeping their order.
+// Every link selects its category on top of the given query.
+func categoryTree(categories []*pbCatalog.Category, selected *pbCatalog.Category, query url.Values) []*categoryNode {
+	nodes := make(map[string]*categoryNode, len(categories))
+	for _, category := range categories {
+		link := queryWithout(query, "page_token")
+		link.Set("category", category.GetSlug())
+
+		nodes[category.GetCategoryId()] = &categoryNode{
+			Name:     category.GetName(),
+			Count:    category.GetItemCount(),
+			URL:      "/catalog?" + link.Encode(),
+			Selected: selected != nil && selected.GetCategoryId() == category.GetCategoryId(),
+		}
+	}
+
+	var roots []*categoryNode
+	for _, category := range categories {
+		node := nodes[category.GetCategoryId()]
+		if parent, ok := nodes[category.GetParentId()]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// categoryOption is a category in the selects of the admin page
+type categoryOption struct {
+	ID   string
+	Path string
+}
+
+// queryWithout returns a copy of the query without the given keys
+func queryWithout(query url.Values, keys ...string) url.Values {
+	copied := url.Values{}
+	for key, values := range query {
+		if !slices.Contains(keys, key) {
+			copied[key] = values
+		}
+	}
+	return copied
+}
+
+// categoryPath returns the categories from the root down to the selected one
+func categoryPath(categories []*pbCatalog.Category, selected *pbCatalog.Category) []string {
+	byID := make(map[string]*pbCatalog.Category, len(categories))
+	for _, category := range categories {
+		byID[category.GetCategoryId()] = category
+	}
+
+	var path []string
+	for category := selected; category != nil && len(path) <= len(categories); category = byID[category.GetParentId()] {
+		path = append([]string{category.GetName()}, path...)
+	}
+	return path
+}
+
+// itemNames returns the names of the catalog items, an item not found keeps its ID as name
+func (s *ServerDependencies) itemNames(ctx context.Context, itemIDs []string) map[string]string {
+	names := make(map[string]string, len(itemIDs))
+	for _, itemID := range itemIDs {
+		names[itemID] = itemID
+	}
+	if len(itemIDs) == 0 {
+		return names
+	}
+
+	itemsRes, err := s.Clients.Catalog.GetCatalogItems(ctx, &pbCatalog.GetCatalogItemsRequest{ItemIds: itemIDs})
+	if err != nil {
+		log.Printf("Impossible to retrieve the names of the items: %v", err)
+		return names
+	}
+	for _, item := range itemsRes.GetItems() {
+		names[item.GetItemId()] = item.GetName()
+	}
+	return names
+}
+
+// resolveItemID finds the ID of a catalog item given its ID, slug or SKU
+func (s *ServerDependencies) resolveItemID(ctx context.Context, reference string) (string, error) {
+	for _, req := range []*pbCatalog.GetCatalogItemRequest{{ItemId: reference}, {Slug: reference}, {Sku: reference}} {
+		itemRes, err := s.Clients.Catalog.GetCatalogItem(ctx, req)
+		if err == nil {
+			return itemRes.GetItem().GetItemId(), nil
+		}
+		if status.Code(err) != codes.NotFound {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("No item with ID, slug or SKU %q", reference)
+}
+
+// parseAttributes parses the attributes of a variant written as "format=Hardcover, language=English"
+func parseAttributes(value string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, attributeValue, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("Attribute %q is not written as name=value", strings.TrimSpace(pair))
+		}
+		attributes[strings.TrimSpace(name)] = strings.TrimSpace(attributeValue)
+	}
+	return attributes, nil
+}
+
+// snippetPart is a piece of a search snippet, highlighted if it matched the search
+type snippetPart struct {
+	Text  string
+	Match bool
+}
+
+// searchCatalog shows the products matching a search, the most relevant first
+func (s *ServerDependencies) searchCatalog(writer http.ResponseWriter, request *http.Request, searchQuery string) {
+	// Calling catalog service via gRPC
+	searchRes, err := s.Clients.Catalog.SearchCatalog(request.Context(), &pbCatalog.SearchCatalogRequest{Query: searchQuery})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// Get current session
+	session, err := s.Store.Get(request, sessionName)
+	if !checkerr(writer, err) {
+		return
+	}
+	isLoggedIn, _ := session.Values["logged_in"].(bool)
+
+	// Products are shown with the snippet of their description
+	products := make([]*pbCatalog.CatalogItem, 0, len(searchRes.GetHits()))
+	snippets := make(map[string][]snippetPart)
+	for _, hit := range searchRes.GetHits() {
+		products = append(products, hit.GetItem())
+		snippets[hit.GetItem().GetItemId()] = snippetParts(hit.GetSnippet(), hit.GetHighlights())
+	}
+
 	templateData := map[string]interface{}{
 		"Title":      "Fanta Catalog",
-		"Products":   catalogRes.GetItems(), // List all the products from gRPC
+		"Products":   products,
+		"Snippets":   snippets,
+		"Search":     searchQuery,
 		"IsLoggedIn": isLoggedIn,
 	}
 
 	checkerr(writer, s.Templates.ExecuteTemplate(writer, "catalog.html", templateData))
 }
 
+// snippetParts splits a snippet into its highlighted and plain pieces
+func snippetParts(snippet string, highlights []*pbCatalog.Highlight) []snippetPart {
+	var parts []snippetPart
+	last := 0
+	for _, h := range highlights {
+		start, end := int(h.GetStart()), int(h.GetEnd())
+		if start < last || end > len(snippet) || start >= end {
+			continue
+		}
+		if start > last {
+			parts = append(parts, snippetPart{Text: snippet[last:start]})
+		}
+		parts = append(parts, snippetPart{Text: snippet[start:end], Match: true})
+		last = end
+	}
+	if last < len(snippet) {
+		parts = append(parts, snippetPart{Text: snippet[last:]})
+	}
+	return parts
+}
+
+// catalogListRequest builds the catalog listing request from the query string of the catalog page
+func catalogListRequest(query url.Values) (*pbCatalog.ListCatalogItemsRequest, error) {
+	listRequest := &pbCatalog.ListCatalogItemsRequest{
+		PageSize:    catalogPageSize,
+		PageToken:   query.Get("page_token"),
+		InStockOnly: query.Get("in_stock") == "on",
+		Tag:         strings.TrimSpace(query.Get("tag")),
+	}
+
+	if minPrice := query.Get("min_price"); minPrice != "" {
+		price, err := strconv.ParseFloat(minPrice, 64)
+		if err != nil {
+			return nil, errors.New("Invalid minimum price")
+		}
+		listRequest.MinPrice = price
+	}
+
+	if maxPrice := query.Get("max_price"); maxPrice != "" {
+		price, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil {
+			return nil, errors.New("Invalid maximum price")
+		}
+		listRequest.MaxPrice = price
+	}
+
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		size, err := strconv.Atoi(pageSize)
+		if err != nil {
+			return nil, errors.New("Invalid page size")
+		}
+		listRequest.PageSize = int32(size)
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		value, ok := pbCatalog.CatalogSort_value[sort]
+		if !ok {
+			return nil, errors.New("Invalid sort order")
+		}
+		listRequest.Sort = pbCatalog.CatalogSort(value)
+	}
+
+	return listRequest, nil
+}
+
 func (s *ServerDependencies) UpdateCatalogHandler(writer http.ResponseWriter, request *http.Request) {
 	// User must be logged
 	session, ok := checkIfUserIsLogged(s, request, writer)
@@ -43,20 +401,135 @@ func (s *ServerDependencies) UpdateCatalogHandler(writer http.ResponseWriter, re
 	}
 	role := session.Values["role"].(string)
 
-	templateData := map[string]interface{}{
-		"Role":  role,
-		"Admin": "ADMIN",
-	}
-
 	// Only GET requests are accepted
 	if request.Method != http.MethodGet {
 		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	templateData := s.updateCatalogData(request.Context(), role)
+	if tab := request.URL.Query().Get("tab"); role == "ADMIN" && slices.Contains(adminTabs, tab) {
+		templateData["Tab"] = tab
+	}
+
+	// An item loaded in the forms is updated only if nobody changes it in the meantime
+	if reference := request.URL.Query().Get("item"); reference != "" {
+		itemId, err := s.resolveItemID(request.Context(), reference)
+		if !checkerr(writer, err) {
+			return
+		}
+		itemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{ItemId: itemId})
+		if !checkerr(writer, err) {
+			return
+		}
+
+		templateData["Item"] = itemRes.GetItem()
+		templateData["Values"] = itemValues(itemRes.GetItem())
+		if role == "ADMIN" {
+			s.itemPrices(request.Context(), templateData, itemId)
+			s.itemMovements(request.Context(), templateData, itemId)
+			s.itemStock(request.Context(), templateData, itemId)
+		}
+		templateData["Tab"] = "details"
+		if tab := request.URL.Query().Get("tab"); slices.Contains(itemTabs, tab) {
+			templateData["Tab"] = tab
+		}
+	}
+
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
+}
+
+// renderCatalogConflict shows the admin page again when an item was changed by someone else since it was loaded:
+// the form keeps the values submitted and the fresh ones are shown, so that the admin can submit them again
+func (s *ServerDependencies) renderCatalogConflict(writer http.ResponseWriter, request *http.Request, role, itemID, tab string) {
+	itemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{ItemId: itemID})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	values := make(map[string]string)
+	for _, field := range itemFields {
+		values[field] = request.FormValue(field)
+	}
+
+	templateData := s.updateCatalogData(request.Context(), role)
+	templateData["Item"] = itemRes.GetItem()
+	templateData["Values"] = values
+	templateData["Tab"] = tab
+	templateData["Conflict"] = true
+	s.itemPrices(request.Context(), templateData, itemID)
+	s.itemMovements(request.Context(), templateData, itemID)
+	s.itemStock(request.Context(), templateData, itemID)
+
+	writer.WriteHeader(http.StatusConflict)
 	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
 }
 
+// itemValues are the current values of an item in the admin forms
+func itemValues(item *pbCatalog.CatalogItem) map[string]string {
+	names := make([]string, 0, len(item.GetAttributes()))
+	for name := range item.GetAttributes() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	attributes := make([]string, 0, len(names))
+	for _, name := range names {
+		attributes = append(attributes, name+"="+item.GetAttributes()[name])
+	}
+
+	// The quantity is left empty: the one of the item is the total of the warehouses, not the one of the warehouse chosen
+	return map[string]string{
+		"item_id":     item.GetItemId(),
+		"price":       strconv.FormatFloat(item.GetPrice(), 'f', -1, 64),
+		"name":        item.GetName(),
+		"description": item.GetDescription(),
+		"sku":         item.GetSku(),
+		"slug":        item.GetSlug(),
+		"attributes":  strings.Join(attributes, ", "),
+	}
+}
+
+// formVersion is the version of the item the admin form was loaded with, zero if it wasn't loaded
+func formVersion(request *http.Request) (uint64, error) {
+	value := request.FormValue("version")
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// updateCatalogData is the data of the admin page of the catalog
+func (s *ServerDependencies) updateCatalogData(ctx context.Context, role string) map[string]interface{} {
+
+	// Categories are chosen by their full path
+	categories := s.listCategories(ctx)
+	categoryOptions := make([]categoryOption, 0, len(categories))
+	for _, category := range categories {
+		categoryOptions = append(categoryOptions, categoryOption{
+			ID:   category.GetCategoryId(),
+			Path: strings.Join(categoryPath(categories, category), " > "),
+		})
+	}
+	slices.SortFunc(categoryOptions, func(a, b categoryOption) int { return strings.Compare(a.Path, b.Path) })
+
+	templateData := map[string]interface{}{
+		"Role":       role,
+		"Admin":      "ADMIN",
+		"Categories": categoryOptions,
+		"Tab":        "add",
+		"Values":     map[string]string{},
+	}
+
+	// The stock is kept by the admins in the warehouses, they are alerted of the items running low
+	// and they moderate the reviews of the customers
+	if role == "ADMIN" {
+		templateData["Warehouses"] = s.listWarehouses(ctx)
+		templateData["StockAlerts"] = s.stockAlerts(ctx)
+		templateData["PendingReviews"] = s.pendingReviews(ctx)
+	}
+	return templateData
+}
+
 func (s *ServerDependencies) AddToCatalogHandler(writer http.ResponseWriter, request *http.Request) {
 	// User must be logged
 	session, ok := checkIfUserIsLogged(s, request, writer)
@@ -78,8 +551,10 @@ func (s *ServerDependencies) AddToCatalogHandler(writer http.ResponseWriter, req
 		return
 	}
 
-	// Retrieve item data
-	itemId := request.FormValue("item_id")
+	// Retrieve item data, the ID is generated by the catalog
+	name := request.FormValue("name")
+	sku := request.FormValue("sku")
+	slug := request.FormValue("slug")
 	description := request.FormValue("description")
 	priceStr := request.FormValue("price")
 	quantityStr := request.FormValue("quantity")
@@ -94,12 +569,31 @@ func (s *ServerDependencies) AddToCatalogHandler(writer http.ResponseWriter, req
 		return
 	}
 
+	attributes, err := parseAttributes(request.FormValue("attributes"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// A variant is added to its product, given by its ID, slug or SKU
+	productId := ""
+	if product := strings.TrimSpace(request.FormValue("product")); product != "" {
+		productId, err = s.resolveItemID(request.Context(), product)
+		if !checkerr(writer, err) {
+			return
+		}
+	}
+
 	// Creating catalog item
 	item := pbCatalog.CatalogItem{
-		ItemId:            itemId,
+		Name:              name,
+		Sku:               sku,
+		Slug:              slug,
 		Description:       description,
 		Price:             price,
 		QuantityAvailable: uint32(quantity),
+		ProductId:         productId,
+		Attributes:        attributes,
 	}
 
 	// Calling catalog service via gRPC
@@ -110,9 +604,6 @@ func (s *ServerDependencies) AddToCatalogHandler(writer http.ResponseWriter, req
 		return
 	}
 
-	// Notification that the catalog has changed
-	s.Manager.NotifyCatalogUpdate()
-
 	log.Printf("New Item successfully added to catalog by %s", username)
 
 	// Redirection to catalog page
@@ -141,19 +632,20 @@ func (s *ServerDependencies) RemoveFromCatalogHandler(writer http.ResponseWriter
 	}
 
 	// Retrieve item data
-	itemId := request.FormValue("item_id")
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
 
 	// Calling catalog service via gRPC
-	_, err := s.Clients.Catalog.RemoveCatalogItem(request.Context(), &pbCatalog.RemoveCatalogItemRequest{
+	_, err = s.Clients.Catalog.RemoveCatalogItem(request.Context(), &pbCatalog.RemoveCatalogItemRequest{
 		ItemId: itemId,
 	})
 	if !checkerr(writer, err) {
 		return
 	}
 
-	// Notification that the catalog has changed
-	s.Manager.NotifyCatalogUpdate()
-
 	log.Printf("Item successfully removed from catalog by %s", username)
 
 	// Redirection to catalog page
@@ -182,26 +674,38 @@ func (s *ServerDependencies) UpdatePriceCatalogHandler(writer http.ResponseWrite
 	}
 
 	// Retrieve item data
-	itemId := request.FormValue("item_id")
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
 	priceStr := request.FormValue("price")
 
 	price, err := strconv.ParseFloat(priceStr, 64)
 	if !checkerr(writer, err) {
 		return
 	}
+	version, err := formVersion(request)
+	if !checkerr(writer, err) {
+		return
+	}
 
 	// Calling catalog service via gRPC
 	_, err = s.Clients.Catalog.UpdatePrice(request.Context(), &pbCatalog.UpdatePriceRequest{
-		ItemId: itemId,
-		Price:  price,
+		ItemId:          itemId,
+		Price:           price,
+		ExpectedVersion: version,
 	})
+
+	// The item was changed by someone else since the form was loaded
+	if status.Code(err) == codes.Aborted {
+		s.renderCatalogConflict(writer, request, role, itemId, "price")
+		return
+	}
 	if !checkerr(writer, err) {
 		return
 	}
 
-	// Notification that the catalog has changed
-	s.Manager.NotifyCatalogUpdate()
-
 	log.Printf("Item Price successfully updated by %s", username)
 
 	// Redirection to catalog page
@@ -230,28 +734,389 @@ func (s *ServerDependencies) UpdateQuantityCatalogHandler(writer http.ResponseWr
 	}
 
 	// Retrieve item data
-	itemId := request.FormValue("item_id")
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
 	quantityStr := request.FormValue("quantity")
 
 	quantity, err := strconv.Atoi(quantityStr)
 	if !checkerr(writer, err) {
 		return
 	}
+	version, err := formVersion(request)
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The change of the stock is recorded in the ledger with its reason
+	reason, ok := movementReasons[request.FormValue("reason")]
+	if !ok {
+		http.Error(writer, "Reason not valid", http.StatusBadRequest)
+		return
+	}
 
 	// Calling catalog service via gRPC
 	_, err = s.Clients.Catalog.UpdateQuantityAvailable(request.Context(), &pbCatalog.UpdateQuantityAvailableRequest{
-		ItemId:   itemId,
-		Quantity: uint32(quantity),
+		ItemId:          itemId,
+		Quantity:        uint32(quantity),
+		ExpectedVersion: version,
+		Reason:          reason,
+		WarehouseId:     request.FormValue("warehouse_id"),
 	})
+
+	// The item was changed by someone else since the form was loaded
+	if status.Code(err) == codes.Aborted {
+		s.renderCatalogConflict(writer, request, role, itemId, "quantity")
+		return
+	}
 	if !checkerr(writer, err) {
 		return
 	}
 
-	// Notification that the catalog has changed
-	s.Manager.NotifyCatalogUpdate()
-
 	log.Printf("Item Quantity successfully updated by %s", username)
 
 	// Redirection to catalog page
 	http.Redirect(writer, request, "/catalog", http.StatusSeeOther)
 }
+
+func (s *ServerDependencies) UpdateDetailsCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The attributes of a variant are replaced, if given
+	attributes, err := parseAttributes(request.FormValue("attributes"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	version, err := formVersion(request)
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// Calling catalog service via gRPC, empty fields are left unchanged
+	_, err = s.Clients.Catalog.UpdateCatalogItem(request.Context(), &pbCatalog.UpdateCatalogItemRequest{
+		ItemId:          itemId,
+		Name:            request.FormValue("name"),
+		Description:     request.FormValue("description"),
+		Sku:             request.FormValue("sku"),
+		Slug:            request.FormValue("slug"),
+		Attributes:      attributes,
+		ExpectedVersion: version,
+	})
+
+	// The item was changed by someone else since the form was loaded
+	if status.Code(err) == codes.Aborted {
+		s.renderCatalogConflict(writer, request, role, itemId, "details")
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Item details successfully updated by %s", username)
+
+	// Redirection to catalog page
+	http.Redirect(writer, request, "/catalog", http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) AddCategoryHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Calling catalog service via gRPC, an empty parent creates a root category
+	_, err := s.Clients.Catalog.CreateCategory(request.Context(), &pbCatalog.CreateCategoryRequest{
+		Name:     request.FormValue("name"),
+		Slug:     request.FormValue("slug"),
+		ParentId: request.FormValue("parent_id"),
+	})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("New category successfully created by %s", username)
+
+	// Redirection to catalog page
+	http.Redirect(writer, request, "/catalog", http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) RemoveCategoryHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Calling catalog service via gRPC
+	_, err := s.Clients.Catalog.DeleteCategory(request.Context(), &pbCatalog.DeleteCategoryRequest{
+		CategoryId: request.FormValue("category_id"),
+	})
+
+	// Categories with subcategories or items are kept
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Category successfully removed by %s", username)
+
+	// Redirection to catalog page
+	http.Redirect(writer, request, "/catalog", http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) ClassifyCatalogItemHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The category is kept unless another one, or none, is chosen
+	if categoryId := request.FormValue("category_id"); categoryId != categoryKept {
+		_, err = s.Clients.Catalog.SetItemCategory(request.Context(), &pbCatalog.SetItemCategoryRequest{
+			ItemId:     itemId,
+			CategoryId: categoryId,
+		})
+		if !checkerr(writer, err) {
+			return
+		}
+	}
+
+	// Tags are comma separated, they are kept unless given or cleared
+	var tags []string
+	for _, tag := range strings.Split(request.FormValue("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 || request.FormValue("clear_tags") == "on" {
+		_, err = s.Clients.Catalog.SetItemTags(request.Context(), &pbCatalog.SetItemTagsRequest{
+			ItemId: itemId,
+			Tags:   tags,
+		})
+		if !checkerr(writer, err) {
+			return
+		}
+	}
+
+	log.Printf("Item category and tags successfully updated by %s", username)
+
+	// Redirection to catalog page
+	http.Redirect(writer, request, "/catalog", http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) ImportCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve the uploaded file and the import options
+	request.Body = http.MaxBytesReader(writer, request.Body, maxImportUploadSize)
+	file, _, err := request.FormFile("file")
+	if err != nil {
+		http.Error(writer, "File not valid", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format, ok := catalogFileFormats[request.FormValue("format")]
+	if !ok {
+		http.Error(writer, "Format not valid", http.StatusBadRequest)
+		return
+	}
+	dryRun := request.FormValue("dry_run") == "on"
+
+	// Calling catalog service via gRPC, the file is streamed in chunks
+	stream, err := s.Clients.Catalog.ImportCatalogItems(request.Context())
+	if !checkerr(writer, err) {
+		return
+	}
+	buffer := make([]byte, importChunkSize)
+	for first := true; ; first = false {
+		n, readErr := file.Read(buffer)
+		if n > 0 || first {
+
+			// A failed send is reported by the catalog service when the stream is closed
+			req := &pbCatalog.ImportCatalogItemsRequest{Format: format, DryRun: dryRun, Chunk: buffer[:n]}
+			if err := stream.Send(req); err != nil {
+				break
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if !checkerr(writer, readErr) {
+			return
+		}
+	}
+	report, err := stream.CloseAndRecv()
+
+	// A file which cannot be read is rejected as a whole
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Catalog import by %s: %d created, %d updated, %d failed (dry run: %t)",
+		username, report.GetCreated(), report.GetUpdated(), report.GetFailed(), report.GetDryRun())
+
+	// The report is shown on the admin page
+	templateData := s.updateCatalogData(request.Context(), role)
+	templateData["ImportReport"] = report
+	templateData["Tab"] = "import"
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
+}
+
+func (s *ServerDependencies) ExportCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only GET requests are accepted
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	formatName := request.URL.Query().Get("format")
+	format, ok := catalogFileFormats[formatName]
+	if !ok {
+		http.Error(writer, "Format not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC, the file is received in chunks
+	stream, err := s.Clients.Catalog.ExportCatalogItems(request.Context(), &pbCatalog.ExportCatalogItemsRequest{Format: format})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The first chunk is received before sending the headers, so that an error can still be reported
+	chunk, err := stream.Recv()
+	if err != io.EOF && !checkerr(writer, err) {
+		return
+	}
+
+	writer.Header().Set("Content-Type", catalogFileTypes[formatName])
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"catalog.%s\"", formatName))
+	for err == nil {
+		if _, err = writer.Write(chunk.GetChunk()); err != nil {
+			break
+		}
+		chunk, err = stream.Recv()
+	}
+	if err != io.EOF {
+		log.Printf("Catalog export interrupted: %v", err)
+		return
+	}
+
+	log.Printf("Catalog exported by %s", username)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/web/internal/manager"
+)
+
+// cartChange is the change of an item in the cart of a user, sent to the other pages of the user
+type cartChange struct {
+	ItemID string `json:"item_id"`
+	Action string `json:"action"`
+}
+
+// orderStatusChange is the new status of an order, sent to its customer and to the admins
+type orderStatusChange struct {
+	OrderID    string `json:"order_id"`
+	Status     string `json:"status"`
+	StatusCode int32  `json:"status_code"`
+}
+
+// EventsHandler streams the events of the topics listed in the "topics" parameter,
+// by default all the ones the user can listen to
+func (s *ServerDependencies) EventsHandler(writer http.ResponseWriter, request *http.Request) {
+	// Only GET requests are accepted
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Users not logged listen to the catalog only
+	var subscriber manager.Subscriber
+	if session, err := s.Store.Get(request, sessionName); err == nil {
+		if loggedIn, _ := session.Values["logged_in"].(bool); loggedIn {
+			subscriber.Username, _ = session.Values["username"].(string)
+			subscriber.Role, _ = session.Values["role"].(string)
+		}
+	}
+
+	topics := subscriber.Topics()
+	if requested := request.URL.Query().Get("topics"); requested != "" {
+		topics = strings.Split(requested, ",")
+		for _, topic := range topics {
+			if !subscriber.Allowed(topic) {
+				http.Error(writer, "Topic not allowed: "+topic, http.StatusForbidden)
+				return
+			}
+		}
+	}
+
+	s.Manager.ServeEvents(writer, request, subscriber, topics)
+}
+
+// publishCartChange tells the other pages of a user that their cart has changed
+func (s *ServerDependencies) publishCartChange(username, itemID, action string) {
+	s.Manager.PublishToUser(username, manager.TopicMyCart, manager.EventCart, cartChange{
+		ItemID: itemID,
+		Action: action,
+	})
+}
+
+// publishOrderStatus sends the status of an order to its customer and to the admins
+func (s *ServerDependencies) publishOrderStatus(ctx context.Context, orderID string) {
+	orderRes, err := s.Clients.Order.GetOrder(ctx, &pbOrder.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("Failed to publish the status of order %s: %v", orderID, err)
+		return
+	}
+
+	order := orderRes.GetOrder()
+	change := orderStatusChange{
+		OrderID:    order.GetOrderId(),
+		Status:     order.GetStatus().String(),
+		StatusCode: int32(order.GetStatus()),
+	}
+	s.Manager.PublishToUser(order.GetUserId(), manager.TopicMyOrders, manager.EventOrderStatus, change)
+	s.Manager.Publish(manager.TopicAdmin, manager.EventOrderStatus, change)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// maxImageUploadSize bounds an upload, the catalog accepts images up to 3 MiB and the rest is left to the form
+const maxImageUploadSize = 4 << 20
+
+// itemImagesURL is the admin page with the images of an item
+func itemImagesURL(itemID string) string {
+	return "/update/catalog?" + url.Values{"item": {itemID}, "tab": {"images"}}.Encode()
+}
+
+// ImageHandler serves an image of an item, or its thumbnail with size=thumbnail, to everyone.
+// An image never changes once uploaded, so the browsers can keep it as long as they want.
+func (s *ServerDependencies) ImageHandler(writer http.ResponseWriter, request *http.Request) {
+	// Only GET requests are accepted
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := request.URL.Query()
+	if query.Get("id") == "" {
+		http.Error(writer, "Image not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	res, err := s.Clients.Catalog.GetImage(request.Context(), &pbCatalog.GetImageRequest{
+		ImageId:   query.Get("id"),
+		Thumbnail: query.Get("size") == "thumbnail",
+	})
+	if status.Code(err) == codes.NotFound {
+		http.NotFound(writer, request)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	writer.Header().Set("Content-Type", res.GetContentType())
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	writer.Write(res.GetData())
+}
+
+func (s *ServerDependencies) AddItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve the uploaded image
+	request.Body = http.MaxBytesReader(writer, request.Body, maxImageUploadSize)
+	file, _, err := request.FormFile("image")
+	if err != nil {
+		http.Error(writer, "Image not valid", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// Calling catalog service via gRPC, it checks the image and generates its thumbnail
+	res, err := s.Clients.Catalog.AddItemImage(request.Context(), &pbCatalog.AddItemImageRequest{
+		ItemId: itemId,
+		Data:   data,
+	})
+
+	// The file is not an image accepted, or the item has too many images
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Image %s added to item %s by %s", res.GetImage().GetImageId(), itemId, username)
+
+	// Redirection to the images of the item
+	http.Redirect(writer, request, itemImagesURL(itemId), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) RemoveItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	itemId, imageId := request.FormValue("item_id"), request.FormValue("image_id")
+
+	// Calling catalog service via gRPC
+	_, err := s.Clients.Catalog.RemoveItemImage(request.Context(), &pbCatalog.RemoveItemImageRequest{
+		ItemId:  itemId,
+		ImageId: imageId,
+	})
+	if status.Code(err) == codes.NotFound {
+		http.Error(writer, status.Convert(err).Message(), http.StatusNotFound)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Image %s of item %s removed by %s", imageId, itemId, username)
+
+	// Redirection to the images of the item
+	http.Redirect(writer, request, itemImagesURL(itemId), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) MoveItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	itemId, imageId := request.FormValue("item_id"), request.FormValue("image_id")
+
+	// The image is moved among the current images of the item
+	itemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{ItemId: itemId})
+	if !checkerr(writer, err) {
+		return
+	}
+	var imageIds []string
+	for _, image := range itemRes.GetItem().GetImages() {
+		imageIds = append(imageIds, image.GetImageId())
+	}
+	position := slices.Index(imageIds, imageId)
+	if position < 0 {
+		http.Error(writer, "Image not found", http.StatusNotFound)
+		return
+	}
+
+	imageIds = slices.Delete(imageIds, position, position+1)
+	switch request.FormValue("to") {
+	case "first":
+		position = 0
+	case "previous":
+		position = max(position-1, 0)
+	case "next":
+		position = min(position+1, len(imageIds))
+	default:
+		http.Error(writer, "Position not valid", http.StatusBadRequest)
+		return
+	}
+	imageIds = slices.Insert(imageIds, position, imageId)
+
+	// Calling catalog service via gRPC
+	_, err = s.Clients.Catalog.ReorderItemImages(request.Context(), &pbCatalog.ReorderItemImagesRequest{
+		ItemId:   itemId,
+		ImageIds: imageIds,
+	})
+
+	// The images changed in the meantime, the admin sees the current ones
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// Redirection to the images of the item
+	http.Redirect(writer, request, itemImagesURL(itemId), http.StatusSeeOther)
+}
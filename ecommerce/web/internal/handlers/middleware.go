@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	pbAuth "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/auth"
+	"github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/shared/interceptor"
+)
+
+// refreshMargin is how long before its expiration the access token is renewed
+const refreshMargin = time.Minute
+
+// refreshGrace is how long the tokens renewed with a refresh token are given to the requests still carrying it
+const refreshGrace = 30 * time.Second
+
+// tokenRefresh is the renewal of the tokens with a refresh token, shared by the requests carrying it
+type tokenRefresh struct {
+	done     chan struct{}
+	res      *pbAuth.RefreshResponse
+	err      error
+	finished time.Time
+}
+
+// tokenRefreshes renews the tokens once for each refresh token.
+// Refresh tokens are single use, while the requests of a page (the page, its images, the events) carry the same cookie:
+// the first one renews the tokens, the others wait for it and get the same tokens.
+type tokenRefreshes struct {
+	mu       sync.Mutex
+	inFlight map[string]*tokenRefresh
+}
+
+var refreshes = &tokenRefreshes{inFlight: make(map[string]*tokenRefresh)}
+
+// refresh renews the tokens with the refresh token, unless it has just been done.
+func (r *tokenRefreshes) refresh(ctx context.Context, auth pbAuth.AuthenticationServiceClient, refreshToken string) (*pbAuth.RefreshResponse, error) {
+	r.mu.Lock()
+	for key, done := range r.inFlight {
+		if !done.finished.IsZero() && time.Since(done.finished) > refreshGrace {
+			delete(r.inFlight, key)
+		}
+	}
+	current, ok := r.inFlight[refreshToken]
+	if !ok {
+		current = &tokenRefresh{done: make(chan struct{})}
+		r.inFlight[refreshToken] = current
+	}
+	r.mu.Unlock()
+
+	if ok {
+		select {
+		case <-current.done:
+			return current.res, current.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The refresh goes on for the other requests even if this one is canceled
+	current.res, current.err = auth.Refresh(context.WithoutCancel(ctx), &pbAuth.RefreshRequest{RefreshToken: refreshToken})
+
+	// Only the tokens renewed are kept, a failed refresh is tried again by the next request
+	r.mu.Lock()
+	current.finished = time.Now()
+	if current.err != nil {
+		delete(r.inFlight, refreshToken)
+	}
+	r.mu.Unlock()
+	close(current.done)
+	return current.res, current.err
+}
+
+// WithAccessToken attaches the access token of the logged user to the request context,
+// so that every gRPC call made by the handlers is authenticated as that user.
+// The token is renewed with the refresh token when it is about to expire.
+func (s *ServerDependencies) WithAccessToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+
+		session, err := s.Store.Get(request, sessionName)
+		if err != nil {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		accessToken, ok := session.Values["access_token"].(string)
+		if !ok || accessToken == "" {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		// Access token about to expire -> new pair of tokens from the auth service
+		expiresAt, _ := session.Values["access_token_expires_at"].(int64)
+		if time.Until(time.Unix(expiresAt, 0)) < refreshMargin {
+			refreshToken, _ := session.Values["refresh_token"].(string)
+
+			refreshRes, err := refreshes.refresh(request.Context(), s.Clients.Auth, refreshToken)
+
+			// The current access token is still valid -> used until the next request tries again
+			if err != nil && time.Now().Before(time.Unix(expiresAt, 0)) {
+				log.Printf("Failed to refresh tokens of %v, the current ones are kept: %v", session.Values["username"], err)
+				ctx := interceptor.WithToken(request.Context(), accessToken)
+				next.ServeHTTP(writer, request.WithContext(ctx))
+				return
+			}
+
+			// Refresh token expired or revoked -> the user must log in again
+			if err != nil {
+				log.Printf("Failed to refresh tokens of %v: %v", session.Values["username"], err)
+				delete(session.Values, "access_token")
+				delete(session.Values, "refresh_token")
+				delete(session.Values, "access_token_expires_at")
+				session.Values["logged_in"] = false
+				if err := session.Save(request, writer); err != nil {
+					log.Printf("Failed to save session: %v", err)
+				}
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			accessToken = refreshRes.GetAccessToken()
+			session.Values["access_token"] = accessToken
+			session.Values["refresh_token"] = refreshRes.GetRefreshToken()
+			session.Values["access_token_expires_at"] = refreshRes.GetAccessTokenExpiresAt()
+			if err := session.Save(request, writer); err != nil {
+				log.Printf("Failed to save session: %v", err)
+			}
+		}
+
+		ctx := interceptor.WithToken(request.Context(), accessToken)
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"strconv"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
 	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
 )
@@ -41,8 +44,15 @@ func (s *ServerDependencies) OrderHandler(writer http.ResponseWriter, request *h
 	}
 
 	// Mapping data for HTML file
+	// Items are shown by their name
+	itemIDs := make([]string, 0, len(cartRes.GetCart().GetItems()))
+	for _, item := range cartRes.GetCart().GetItems() {
+		itemIDs = append(itemIDs, item.GetItemId())
+	}
+
 	templateData := map[string]interface{}{
 		"Items":      cartRes.GetCart().GetItems(),
+		"ItemNames":  s.itemNames(request.Context(), itemIDs),
 		"TotalPrice": math.Trunc(totalPriceRes.GetTotalPrice()*100) / 100,
 	}
 
@@ -93,17 +103,66 @@ func (s *ServerDependencies) UserOrdersHandler(writer http.ResponseWriter, reque
 
 		orderId := request.FormValue("order_id")
 
-		// gRPC call at Order service to update the status of the order
-		_, err = s.Clients.Order.UpdateOrderStatus(request.Context(), &pbOrder.UpdateOrderStatusRequest{
-			OrderId: orderId,
-			Status:  pbOrder.OrderStatus(newStatusValue),
-		})
+		// Canceled orders give back their items and refund the payment, the other statuses are just set
+		if pbOrder.OrderStatus(newStatusValue) == pbOrder.OrderStatus_CANCELED {
+			_, err = s.Clients.Order.CancelOrder(request.Context(), &pbOrder.CancelOrderRequest{
+				OrderId: orderId,
+			})
+		} else {
+			// gRPC call at Order service to update the status of the order
+			_, err = s.Clients.Order.UpdateOrderStatus(request.Context(), &pbOrder.UpdateOrderStatusRequest{
+				OrderId: orderId,
+				Status:  pbOrder.OrderStatus(newStatusValue),
+			})
+		}
+
+		// The order can't move to the requested status (e.g. DELIVERED -> PENDING)
+		if status.Code(err) == codes.FailedPrecondition {
+			http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+			return
+		}
 		if !checkerr(writer, err) {
 			return
 		}
 
 		log.Printf("Status order successfully updated")
+		s.publishOrderStatus(request.Context(), orderId)
 
 		http.Redirect(writer, request, "/list/users", http.StatusSeeOther)
 	}
 }
+
+func (s *ServerDependencies) CancelOrderHandler(writer http.ResponseWriter, request *http.Request) {
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// User must be logged
+	_, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+
+	orderId := request.FormValue("order_id")
+
+	// gRPC call at Order service to cancel the order, its items and payment are given back
+	_, err := s.Clients.Order.CancelOrder(request.Context(), &pbOrder.CancelOrderRequest{
+		OrderId: orderId,
+	})
+
+	// The order has already been shipped
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Order %s successfully canceled", orderId)
+	s.publishOrderStatus(request.Context(), orderId)
+
+	http.Redirect(writer, request, "/account", http.StatusSeeOther)
+}
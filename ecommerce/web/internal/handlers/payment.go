@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"log"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pbCart "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/cart"
-	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
-	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
-	pbPayment "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/payment"
+	pbCheckout "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/checkout"
 )
 
 func (s *ServerDependencies) PaymentHandler(writer http.ResponseWriter, request *http.Request) {
@@ -26,103 +31,19 @@ func (s *ServerDependencies) PaymentHandler(writer http.ResponseWriter, request
 	}
 	username := session.Values["username"].(string)
 
-	// Retrieve cart to create the order
-	cartRes, err := s.Clients.Cart.GetCart(request.Context(), &pbCart.GetCartRequest{
+	// Retrieve total price of the cart, the items are checked against the catalog by the checkout
+	totalPriceRes, err := s.Clients.Cart.CalculateTotalPrice(request.Context(), &pbCart.CalculateTotalPriceRequest{
 		Username: username,
 	})
 	if !checkerr(writer, err) {
 		return
 	}
 
-	// OrderItems are created depending on CartItems
-	var orderItems []*pbOrder.OrderItem
-	for _, cartItem := range cartRes.GetCart().GetItems() {
-
-		// Before creating order, check if the items are still in the catalog
-
-		// Retrieve catalog item that was in cart
-		itemId := cartItem.GetItemId()
-		getRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{
-			ItemId: itemId,
-		})
-
-		// Catalog item removed -> item removed also from cart
-		if err != nil || getRes.GetErrorMessage() != "" {
-			_, err = s.Clients.Cart.RemoveItemFromCart(request.Context(), &pbCart.RemoveItemFromCartRequest{
-				Username: username,
-				ItemId:   itemId,
-			})
-			if !checkerr(writer, err) {
-				return
-			}
-
-			// Redirection to cart page
-			http.Redirect(writer, request, "/cart?error=catalog_changed", http.StatusSeeOther)
-			return
-		}
-
-		// Catalog item price or quantity updated -> Item removed from cart and error message
-		catalogItem := getRes.GetItem()
-		cartQuantity := cartItem.GetQuantity()
-		cartPrice := cartItem.GetPrice()
-
-		// Quantity of the item in the cart greater than the quantity in the catalog -> error
-		if cartQuantity > catalogItem.GetQuantityAvailable() || cartPrice != catalogItem.GetPrice() {
-
-			// In case of error item is removed from cart
-			_, err = s.Clients.Cart.RemoveItemFromCart(request.Context(), &pbCart.RemoveItemFromCartRequest{
-				Username: username,
-				ItemId:   itemId,
-			})
-			if !checkerr(writer, err) {
-				return
-			}
-
-			// Redirection to cart page
-			http.Redirect(writer, request, "/cart?error=catalog_changed", http.StatusSeeOther)
-			return
-		}
-
-		orderItems = append(orderItems, &pbOrder.OrderItem{
-			ItemId:   itemId,
-			Quantity: cartQuantity,
-			Price:    cartPrice,
-		})
-	}
-
-	// Creation of the Order
-	orderRes, err := s.Clients.Order.CreateOrder(request.Context(), &pbOrder.CreateOrderRequest{
-		UserId:     username,
-		OrderItems: orderItems,
-	})
-	if !checkerr(writer, err) {
-		return
-	}
-
-	log.Printf("Order successfully created for: %s", username)
-
-	// Retrieve OrderId
-	orderIdStr := orderRes.GetOrderId()
-
-	// Retrieve total price order
-	priceRes, err := s.Clients.Order.GetOrderPrice(request.Context(), &pbOrder.GetOrderPriceRequest{
-		OrderId: orderIdStr,
-	})
-	if !checkerr(writer, err) {
-		return
-	}
-
-	// Creation of the Payment
-	_, err = s.Clients.Payment.CreatePayment(request.Context(), &pbPayment.CreatePaymentRequest{
-		OrderId: orderIdStr,
-		Amount:  math.Trunc(priceRes.GetTotalPrice()*100) / 100,
-	})
-	log.Printf("Payment successfully created for: %s", username)
-
 	// Mapping data for HTML file
+	// The idempotency key makes a resubmitted form return the checkout already started
 	templateData := map[string]interface{}{
-		"OrderID": orderIdStr,
-		"Amount":  math.Trunc(priceRes.GetTotalPrice()*100) / 100,
+		"Amount":         math.Trunc(totalPriceRes.GetTotalPrice()*100) / 100,
+		"IdempotencyKey": newIdempotencyKey(),
 	}
 
 	checkerr(writer, s.Templates.ExecuteTemplate(writer, "payment.html", templateData))
@@ -142,7 +63,6 @@ func (s *ServerDependencies) ProcessPaymentHandler(writer http.ResponseWriter, r
 	}
 
 	username := session.Values["username"].(string)
-	orderId := request.FormValue("order_id")
 	amountStr := request.FormValue("amount")
 
 	amount, err := strconv.ParseFloat(amountStr, 64)
@@ -150,80 +70,91 @@ func (s *ServerDependencies) ProcessPaymentHandler(writer http.ResponseWriter, r
 		return
 	}
 
-	// gRPC call at Payment service
-	// Payment status is updated
-	_, err = s.Clients.Payment.ProcessPayment(request.Context(), &pbPayment.ProcessPaymentRequest{
-		OrderId: orderId,
-		Amount:  math.Trunc(amount*100) / 100,
+	// Card numbers are often typed in groups of four digits
+	cardNumber := strings.ReplaceAll(request.FormValue("card_number"), " ", "")
+
+	idempotencyKey := request.FormValue("idempotency_key")
+	if idempotencyKey == "" {
+		idempotencyKey = newIdempotencyKey()
+	}
+
+	// The position of the user, when the browser gave it, is where the order is shipped
+	var destination *pbCheckout.Destination
+	latitude, latErr := strconv.ParseFloat(request.FormValue("latitude"), 64)
+	longitude, lonErr := strconv.ParseFloat(request.FormValue("longitude"), 64)
+	if latErr == nil && lonErr == nil {
+		destination = &pbCheckout.Destination{Latitude: latitude, Longitude: longitude}
+	}
+
+	// gRPC call at Checkout service
+	// Stock, order, payment and cart are updated by the checkout saga
+	checkoutRes, err := s.Clients.Checkout.Checkout(request.Context(), &pbCheckout.CheckoutRequest{
+		Username:       username,
+		Amount:         math.Trunc(amount*100) / 100,
+		CardNumber:     cardNumber,
+		IdempotencyKey: idempotencyKey,
+		Destination:    destination,
 	})
+
+	// The card is refused or another checkout of the cart is running, the user goes back to the cart
+	if status.Code(err) == codes.InvalidArgument {
+		http.Redirect(writer, request, "/cart?error=invalid_card", http.StatusSeeOther)
+		return
+	}
+	if status.Code(err) == codes.AlreadyExists {
+		http.Redirect(writer, request, "/cart?error=checkout_running", http.StatusSeeOther)
+		return
+	}
 	if !checkerr(writer, err) {
 		return
 	}
 
-	// Verify payment status
-	statusRes, err := s.Clients.Payment.GetPaymentStatus(request.Context(), &pbPayment.GetPaymentStatusRequest{
-		OrderId: orderId,
-	})
-	if !checkerr(writer, err) || statusRes.GetStatus() != pbPayment.PaymentStatus_PAID {
-		http.Redirect(writer, request, "/cart?error=payment_failed", http.StatusSeeOther)
+	log.Printf("Checkout %s started for: %s", checkoutRes.GetCheckoutId(), username)
+
+	// Redirection to the page waiting for the result, reloading it doesn't start another checkout
+	http.Redirect(writer, request, "/checkout?id="+checkoutRes.GetCheckoutId(), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) CheckoutHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	if _, ok := checkIfUserIsLogged(s, request, writer); !ok {
 		return
 	}
 
-	log.Printf("Successfull payment for: %s", username)
-
-	// Update Order Status
-	s.Clients.Order.UpdateOrderStatus(request.Context(), &pbOrder.UpdateOrderStatusRequest{
-		OrderId: orderId,
-		Status:  pbOrder.OrderStatus_PROCESSING,
-	})
-	log.Printf("Processing order for: %s", username)
+	// Mapping data for HTML file
+	templateData := map[string]interface{}{
+		"CheckoutID": request.URL.Query().Get("id"),
+	}
 
-	// Update catalog changing the available quantity of acquired items
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "process_payment.html", templateData))
+}
 
-	// Retrieve order
-	orderRes, err := s.Clients.Order.GetOrder(request.Context(), &pbOrder.GetOrderRequest{OrderId: orderId})
-	if !checkerr(writer, err) {
+// CheckoutStatusHandler returns the status of a checkout as JSON, it is polled by the checkout page
+func (s *ServerDependencies) CheckoutStatusHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	if _, ok := checkIfUserIsLogged(s, request, writer); !ok {
 		return
 	}
 
-	for _, item := range orderRes.GetOrder().GetItems() {
-		// Retrieve catalog item that was in the cart
-		catalogItemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{
-			ItemId: item.GetItemId(),
-		})
-		if !checkerr(writer, err) {
-			return
-		}
-
-		// Find new available quantity for that catalog item
-		currentStock := catalogItemRes.GetItem().GetQuantityAvailable()
-		purchasedQty := item.GetQuantity()
-
-		var newStock uint32
-		if currentStock >= purchasedQty {
-			newStock = currentStock - purchasedQty
-		} else {
-			newStock = 0
-		}
-
-		// Update available quantity for that item in the catalog
-		_, err = s.Clients.Catalog.UpdateQuantityAvailable(request.Context(), &pbCatalog.UpdateQuantityAvailableRequest{
-			ItemId:   item.GetItemId(),
-			Quantity: newStock,
-		})
-		if !checkerr(writer, err) {
-			return
-		}
-	}
-	log.Printf("Catalog has been updated successfully after the purchase")
-
-	// Clear cart
-	_, err = s.Clients.Cart.ClearCart(request.Context(), &pbCart.ClearCartRequest{Username: username})
-	if !checkerr(writer, err) {
+	statusRes, err := s.Clients.Checkout.GetCheckoutStatus(request.Context(), &pbCheckout.GetCheckoutStatusRequest{
+		CheckoutId: request.URL.Query().Get("id"),
+	})
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
 		return
 	}
 
-	log.Printf("Cleared cart for: %s", username)
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(map[string]string{
+		"status": statusRes.GetStatus().String(),
+		"order":  statusRes.GetOrderId(),
+		"reason": statusRes.GetFailureReason(),
+	})
+}
 
-	checkerr(writer, s.Templates.ExecuteTemplate(writer, "process_payment.html", nil))
+// newIdempotencyKey generates a random key identifying a submission of a form.
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return hex.EncodeToString(key)
 }
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// Layouts of the times of the prices, shown in the page and sent by the datetime-local inputs
+const (
+	priceTimeLayout     = "2006-01-02 15:04"
+	scheduleInputLayout = "2006-01-02T15:04"
+)
+
+// priceChangeRow is a price of the history of an item, as shown in the admin page
+type priceChangeRow struct {
+	Price     float64
+	ChangedAt string
+	Reason    string
+}
+
+// scheduledPriceRow is a scheduled price of an item, as shown in the admin page
+type scheduledPriceRow struct {
+	ScheduleID string
+	Price      float64
+	StartsAt   string
+	EndsAt     string
+	Active     bool
+}
+
+// itemPrices adds the price history and the scheduled prices of the item loaded to the admin page,
+// they are left out if the catalog cannot be reached
+func (s *ServerDependencies) itemPrices(ctx context.Context, templateData map[string]interface{}, itemID string) {
+	historyRes, err := s.Clients.Catalog.GetPriceHistory(ctx, &pbCatalog.GetPriceHistoryRequest{ItemId: itemID})
+	if err != nil {
+		log.Printf("Impossible to retrieve the price history of item %s: %v", itemID, err)
+	} else {
+		history := make([]priceChangeRow, 0, len(historyRes.GetChanges()))
+		for _, change := range historyRes.GetChanges() {
+			history = append(history, priceChangeRow{
+				Price:     change.GetPrice(),
+				ChangedAt: time.Unix(change.GetChangedAt(), 0).Format(priceTimeLayout),
+				Reason:    strings.TrimPrefix(change.GetReason().String(), "PRICE_"),
+			})
+		}
+		templateData["PriceHistory"] = history
+	}
+
+	schedulesRes, err := s.Clients.Catalog.ListScheduledPrices(ctx, &pbCatalog.ListScheduledPricesRequest{ItemId: itemID})
+	if err != nil {
+		log.Printf("Impossible to retrieve the scheduled prices of item %s: %v", itemID, err)
+		return
+	}
+	schedules := make([]scheduledPriceRow, 0, len(schedulesRes.GetSchedules()))
+	for _, schedule := range schedulesRes.GetSchedules() {
+		endsAt := ""
+		if schedule.GetEndsAt() > 0 {
+			endsAt = time.Unix(schedule.GetEndsAt(), 0).Format(priceTimeLayout)
+		}
+		schedules = append(schedules, scheduledPriceRow{
+			ScheduleID: schedule.GetScheduleId(),
+			Price:      schedule.GetPrice(),
+			StartsAt:   time.Unix(schedule.GetStartsAt(), 0).Format(priceTimeLayout),
+			EndsAt:     endsAt,
+			Active:     schedule.GetStatus() == pbCatalog.ScheduledPriceStatus_SCHEDULE_ACTIVE,
+		})
+	}
+	templateData["ScheduledPrices"] = schedules
+}
+
+// itemPricesURL is the admin page with the prices of an item
+func itemPricesURL(itemID string) string {
+	return "/update/catalog?" + url.Values{"item": {itemID}, "tab": {"price"}}.Encode()
+}
+
+func (s *ServerDependencies) SchedulePriceHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve the scheduled price, times are in the time zone of the server
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+	price, err := strconv.ParseFloat(request.FormValue("price"), 64)
+	if err != nil {
+		http.Error(writer, "Price not valid", http.StatusBadRequest)
+		return
+	}
+	startsAt, err := time.ParseInLocation(scheduleInputLayout, request.FormValue("starts_at"), time.Local)
+	if err != nil {
+		http.Error(writer, "Start not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Without an end the price is changed for good
+	var endsAt int64
+	if value := request.FormValue("ends_at"); value != "" {
+		end, err := time.ParseInLocation(scheduleInputLayout, value, time.Local)
+		if err != nil {
+			http.Error(writer, "End not valid", http.StatusBadRequest)
+			return
+		}
+		endsAt = end.Unix()
+	}
+
+	// Calling catalog service via gRPC
+	_, err = s.Clients.Catalog.SchedulePriceChange(request.Context(), &pbCatalog.SchedulePriceChangeRequest{
+		ItemId:   itemId,
+		Price:    price,
+		StartsAt: startsAt.Unix(),
+		EndsAt:   endsAt,
+	})
+
+	// Periods in the past are not valid, the ones overlapping other scheduled prices of the item are in conflict
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Price of item %s scheduled by %s", itemId, username)
+
+	// Redirection to the prices of the item
+	http.Redirect(writer, request, itemPricesURL(itemId), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) CancelScheduledPriceHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Calling catalog service via gRPC
+	_, err := s.Clients.Catalog.CancelScheduledPrice(request.Context(), &pbCatalog.CancelScheduledPriceRequest{
+		ScheduleId: request.FormValue("schedule_id"),
+	})
+
+	// The scheduled price has ended in the meantime
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Scheduled price canceled by %s", username)
+
+	// Redirection to the prices of the item
+	http.Redirect(writer, request, itemPricesURL(request.FormValue("item_id")), http.StatusSeeOther)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"slices"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+	pbOrder "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/order"
+)
+
+// maxRecommendationsShown bounds the items recommended in a page
+const maxRecommendationsShown = 4
+
+// recommendationList is a row of recommended items with its title
+type recommendationList struct {
+	Title string
+	Items []*pbCatalog.CatalogItem
+}
+
+// itemRecommendations returns the items bought together with an item, nil if there are none or the services cannot be reached
+func (s *ServerDependencies) itemRecommendations(ctx context.Context, itemID string) *recommendationList {
+	return s.mergedRecommendations(ctx, "Customers also bought", []string{itemID})
+}
+
+// cartRecommendations returns the items bought together with the items of a cart, the items of the cart left out
+func (s *ServerDependencies) cartRecommendations(ctx context.Context, itemIDs []string) *recommendationList {
+	return s.mergedRecommendations(ctx, "Customers who bought these items also bought", itemIDs)
+}
+
+// userRecommendations returns the items bought together with the ones bought by a user
+func (s *ServerDependencies) userRecommendations(ctx context.Context, username string) *recommendationList {
+	res, err := s.Clients.Order.GetRecommendations(ctx, &pbOrder.GetRecommendationsRequest{
+		UserId: username,
+		Limit:  maxRecommendationsShown,
+	})
+	if err != nil {
+		log.Printf("Impossible to retrieve the recommendations for %s: %v", username, err)
+		return nil
+	}
+	return s.recommendedItems(ctx, "Recommended for you", res.GetRecommendations())
+}
+
+// mergedRecommendations sums the orders of the items recommended for each item, the items given are left out
+func (s *ServerDependencies) mergedRecommendations(ctx context.Context, title string, itemIDs []string) *recommendationList {
+	orders := make(map[string]uint32)
+	for _, itemID := range itemIDs {
+		res, err := s.Clients.Order.GetRecommendations(ctx, &pbOrder.GetRecommendationsRequest{
+			ItemId: itemID,
+			Limit:  uint32(maxRecommendationsShown + len(itemIDs)),
+		})
+		if err != nil {
+			log.Printf("Impossible to retrieve the recommendations for item %s: %v", itemID, err)
+			return nil
+		}
+		for _, recommendation := range res.GetRecommendations() {
+			if !slices.Contains(itemIDs, recommendation.GetItemId()) {
+				orders[recommendation.GetItemId()] += recommendation.GetOrders()
+			}
+		}
+	}
+
+	recommendations := make([]*pbOrder.Recommendation, 0, len(orders))
+	for itemID, count := range orders {
+		recommendations = append(recommendations, &pbOrder.Recommendation{ItemId: itemID, Orders: count})
+	}
+	slices.SortFunc(recommendations, func(a, b *pbOrder.Recommendation) int {
+		return cmp.Or(cmp.Compare(b.GetOrders(), a.GetOrders()), cmp.Compare(a.GetItemId(), b.GetItemId()))
+	})
+	return s.recommendedItems(ctx, title, recommendations[:min(len(recommendations), maxRecommendationsShown)])
+}
+
+// recommendedItems reads the recommended items from the catalog, in the order of the recommendations.
+// The items removed from the catalog in the meantime are left out.
+func (s *ServerDependencies) recommendedItems(ctx context.Context, title string, recommendations []*pbOrder.Recommendation) *recommendationList {
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]string, len(recommendations))
+	for i, recommendation := range recommendations {
+		itemIDs[i] = recommendation.GetItemId()
+	}
+	itemsRes, err := s.Clients.Catalog.GetCatalogItems(ctx, &pbCatalog.GetCatalogItemsRequest{ItemIds: itemIDs})
+	if err != nil {
+		log.Printf("Impossible to retrieve the recommended items: %v", err)
+		return nil
+	}
+
+	list := &recommendationList{Title: title}
+	for _, itemID := range itemIDs {
+		index := slices.IndexFunc(itemsRes.GetItems(), func(item *pbCatalog.CatalogItem) bool { return item.GetItemId() == itemID })
+		if index >= 0 {
+			list.Items = append(list.Items, itemsRes.GetItems()[index])
+		}
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+	return list
+}
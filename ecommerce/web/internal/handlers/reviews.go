@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// reviewRow is a review as shown in the pages, with its stars and the item reviewed
+type reviewRow struct {
+	ReviewID  string
+	Username  string
+	Stars     string
+	Text      string
+	CreatedAt string
+	ItemName  string
+	ItemURL   string
+}
+
+// newReviewRow shows a review, its rating as filled and empty stars
+func newReviewRow(review *pbCatalog.Review, itemName string) reviewRow {
+	rating := min(int(review.GetRating()), 5)
+	return reviewRow{
+		ReviewID:  review.GetReviewId(),
+		Username:  review.GetUsername(),
+		Stars:     strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating),
+		Text:      review.GetText(),
+		CreatedAt: time.Unix(review.GetCreatedAt(), 0).Format(priceTimeLayout),
+		ItemName:  itemName,
+		ItemURL:   itemReviewsURL(review.GetItemId()),
+	}
+}
+
+// itemReviewsURL is the page with the reviews of an item
+func itemReviewsURL(itemID string) string {
+	return "/catalog/reviews?" + url.Values{"item": {itemID}}.Encode()
+}
+
+// pendingReviews returns the reviews waiting for moderation, none if the catalog cannot be reached
+func (s *ServerDependencies) pendingReviews(ctx context.Context) []reviewRow {
+	res, err := s.Clients.Catalog.ListPendingReviews(ctx, &pbCatalog.ListPendingReviewsRequest{})
+	if err != nil {
+		log.Printf("Impossible to retrieve the pending reviews: %v", err)
+		return nil
+	}
+
+	var itemIDs []string
+	for _, review := range res.GetReviews() {
+		itemIDs = append(itemIDs, review.GetItemId())
+	}
+	names := s.itemNames(ctx, itemIDs)
+
+	reviews := make([]reviewRow, 0, len(res.GetReviews()))
+	for _, review := range res.GetReviews() {
+		reviews = append(reviews, newReviewRow(review, names[review.GetItemId()]))
+	}
+	return reviews
+}
+
+// ReviewsHandler shows the approved reviews of an item to everyone, and the form to review it to the customers logged.
+func (s *ServerDependencies) ReviewsHandler(writer http.ResponseWriter, request *http.Request) {
+	// Only GET requests are accepted
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.URL.Query().Get("item"))
+	if !checkerr(writer, err) {
+		return
+	}
+	itemRes, err := s.Clients.Catalog.GetCatalogItem(request.Context(), &pbCatalog.GetCatalogItemRequest{ItemId: itemId})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// The reviews of a variant are the ones of its product
+	item := itemRes.GetItem()
+	if item.GetProductId() != "" {
+		http.Redirect(writer, request, itemReviewsURL(item.GetProductId()), http.StatusSeeOther)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	reviewsRes, err := s.Clients.Catalog.ListReviews(request.Context(), &pbCatalog.ListReviewsRequest{ItemId: itemId})
+	if !checkerr(writer, err) {
+		return
+	}
+	reviews := make([]reviewRow, 0, len(reviewsRes.GetReviews()))
+	for _, review := range reviewsRes.GetReviews() {
+		reviews = append(reviews, newReviewRow(review, item.GetName()))
+	}
+
+	// Get current session
+	session, err := s.Store.Get(request, sessionName)
+	if !checkerr(writer, err) {
+		return
+	}
+
+	// Retrieving if user is logged in or not
+	isLoggedIn, _ := session.Values["logged_in"].(bool)
+
+	// Map with data to send to HTML file
+	templateData := map[string]interface{}{
+		"Title":      "Reviews of " + item.GetName(),
+		"IsLoggedIn": isLoggedIn,
+		"Item":       item,
+		"Reviews":    reviews,
+		"Ratings":    []int{5, 4, 3, 2, 1},
+		"Submitted":  request.URL.Query().Get("submitted") != "",
+
+		// Items bought together with this one
+		"Recommendations": s.itemRecommendations(request.Context(), itemId),
+	}
+
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "reviews.html", templateData))
+}
+
+func (s *ServerDependencies) SubmitReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	itemId := request.FormValue("item_id")
+	rating, err := strconv.ParseUint(request.FormValue("rating"), 10, 32)
+	if err != nil {
+		http.Error(writer, "Rating not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC, it checks the orders of the user
+	_, err = s.Clients.Catalog.SubmitReview(request.Context(), &pbCatalog.SubmitReviewRequest{
+		ItemId: itemId,
+		Rating: uint32(rating),
+		Text:   request.FormValue("text"),
+	})
+
+	// The review is not valid, or the user never bought the item
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	case codes.FailedPrecondition, codes.PermissionDenied:
+		http.Error(writer, status.Convert(err).Message(), http.StatusForbidden)
+		return
+	case codes.NotFound:
+		http.Error(writer, status.Convert(err).Message(), http.StatusNotFound)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Review of item %s submitted by %s", itemId, username)
+
+	// Redirection to the reviews of the item, the review is shown once approved
+	http.Redirect(writer, request, itemReviewsURL(itemId)+"&submitted=1", http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) ModerateReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	reviewId, decision := request.FormValue("review_id"), request.FormValue("decision")
+	if decision != "approve" && decision != "reject" {
+		http.Error(writer, "Decision not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	_, err := s.Clients.Catalog.ModerateReview(request.Context(), &pbCatalog.ModerateReviewRequest{
+		ReviewId: reviewId,
+		Approve:  decision == "approve",
+	})
+	if status.Code(err) == codes.NotFound {
+		http.Error(writer, status.Convert(err).Message(), http.StatusNotFound)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Review %s moderated by %s: %s", reviewId, username, decision)
+
+	// Redirection to the reviews left to moderate
+	http.Redirect(writer, request, "/update/catalog?tab=reviews", http.StatusSeeOther)
+}
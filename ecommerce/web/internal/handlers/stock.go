@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// stockMovementsShown is the number of the most recent movements of an item shown in the admin page
+const stockMovementsShown = 20
+
+// Periods the reorder suggestions are computed on when the admin leaves them empty, as in the catalog service
+const (
+	defaultSalesWindowDays = 30
+	defaultCoverDays       = 14
+)
+
+// Reasons an admin can give for a new quantity available
+var movementReasons = map[string]pbCatalog.StockMovementReason{
+	"adjustment": pbCatalog.StockMovementReason_MOVEMENT_ADJUSTMENT,
+	"restock":    pbCatalog.StockMovementReason_MOVEMENT_RESTOCK,
+}
+
+// stockMovementRow is a movement of the stock of an item, as shown in the admin page
+type stockMovementRow struct {
+	CreatedAt     string
+	Delta         int64
+	QuantityAfter uint32
+	Reason        string
+	Warehouse     string
+	OrderID       string
+	Actor         string
+}
+
+// itemMovements adds the most recent movements of the stock of the item loaded to the admin page,
+// they are left out if the catalog cannot be reached
+func (s *ServerDependencies) itemMovements(ctx context.Context, templateData map[string]interface{}, itemID string) {
+	res, err := s.Clients.Catalog.ListStockMovements(ctx, &pbCatalog.ListStockMovementsRequest{
+		ItemId:   itemID,
+		PageSize: stockMovementsShown,
+	})
+	if err != nil {
+		log.Printf("Impossible to retrieve the stock movements of item %s: %v", itemID, err)
+		return
+	}
+
+	// Movements show the name of their warehouse
+	warehouses := make(map[string]string)
+	if list, ok := templateData["Warehouses"].([]*pbCatalog.Warehouse); ok {
+		for _, warehouse := range list {
+			warehouses[warehouse.GetWarehouseId()] = warehouse.GetName()
+		}
+	}
+
+	movements := make([]stockMovementRow, 0, len(res.GetMovements()))
+	for _, movement := range res.GetMovements() {
+		movements = append(movements, stockMovementRow{
+			CreatedAt:     time.Unix(movement.GetCreatedAt(), 0).Format(priceTimeLayout),
+			Delta:         movement.GetDelta(),
+			QuantityAfter: movement.GetQuantityAfter(),
+			Reason:        strings.TrimPrefix(movement.GetReason().String(), "MOVEMENT_"),
+			Warehouse:     warehouses[movement.GetWarehouseId()],
+			OrderID:       movement.GetOrderId(),
+			Actor:         movement.GetActor(),
+		})
+	}
+	templateData["StockMovements"] = movements
+}
+
+// listWarehouses returns the warehouses the stock is kept in, none if the catalog cannot be reached
+func (s *ServerDependencies) listWarehouses(ctx context.Context) []*pbCatalog.Warehouse {
+	res, err := s.Clients.Catalog.ListWarehouses(ctx, &pbCatalog.ListWarehousesRequest{})
+	if err != nil {
+		log.Printf("Impossible to retrieve the warehouses: %v", err)
+		return nil
+	}
+	return res.GetWarehouses()
+}
+
+// itemStock adds the stock of the item loaded in each warehouse to the admin page,
+// it is left out if the catalog cannot be reached
+func (s *ServerDependencies) itemStock(ctx context.Context, templateData map[string]interface{}, itemID string) {
+	res, err := s.Clients.Catalog.GetItemStock(ctx, &pbCatalog.GetItemStockRequest{ItemId: itemID})
+	if err != nil {
+		log.Printf("Impossible to retrieve the stock of item %s: %v", itemID, err)
+		return
+	}
+	templateData["ItemStock"] = res.GetStock()
+}
+
+// stockAlertRow is an open low-stock alert, as shown in the admin page
+type stockAlertRow struct {
+	RaisedAt          string
+	Name              string
+	URL               string
+	QuantityAvailable uint32
+	Threshold         uint32
+}
+
+// reorderSuggestionRow is the quantity suggested to reorder of an item, as shown in the admin page
+type reorderSuggestionRow struct {
+	*pbCatalog.ReorderSuggestion
+	URL string
+}
+
+// stockAlerts returns the open low-stock alerts, none if the catalog cannot be reached
+func (s *ServerDependencies) stockAlerts(ctx context.Context) []stockAlertRow {
+	res, err := s.Clients.Catalog.ListStockAlerts(ctx, &pbCatalog.ListStockAlertsRequest{})
+	if err != nil {
+		log.Printf("Impossible to retrieve the stock alerts: %v", err)
+		return nil
+	}
+
+	alerts := make([]stockAlertRow, 0, len(res.GetAlerts()))
+	for _, alert := range res.GetAlerts() {
+		alerts = append(alerts, stockAlertRow{
+			RaisedAt:          time.Unix(alert.GetRaisedAt(), 0).Format(priceTimeLayout),
+			Name:              cmp.Or(alert.GetName(), alert.GetItemId()),
+			URL:               itemStockURL(alert.GetItemId()),
+			QuantityAvailable: alert.GetQuantityAvailable(),
+			Threshold:         alert.GetThreshold(),
+		})
+	}
+	return alerts
+}
+
+// itemStockURL is the admin page with the stock of an item
+func itemStockURL(itemID string) string {
+	return "/update/catalog?" + url.Values{"item": {itemID}, "tab": {"quantity"}}.Encode()
+}
+
+func (s *ServerDependencies) ReconcileStockHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Without apply the discrepancies are only reported
+	apply := request.FormValue("apply") == "on"
+
+	// Calling catalog service via gRPC
+	report, err := s.Clients.Catalog.ReconcileStock(request.Context(), &pbCatalog.ReconcileStockRequest{Apply: apply})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Stock reconciliation by %s: %d items checked, %d discrepancies (applied: %t)",
+		username, report.GetItemsChecked(), len(report.GetDiscrepancies()), apply)
+
+	// The report is shown on the admin page
+	templateData := s.updateCatalogData(request.Context(), role)
+	templateData["ReconcileReport"] = report
+	templateData["ReconcileApplied"] = apply
+	templateData["Tab"] = "quantity"
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
+}
+
+func (s *ServerDependencies) CreateWarehouseHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve warehouse data
+	name := strings.TrimSpace(request.FormValue("name"))
+	latitude, err := strconv.ParseFloat(request.FormValue("latitude"), 64)
+	if err != nil {
+		http.Error(writer, "Latitude not valid", http.StatusBadRequest)
+		return
+	}
+	longitude, err := strconv.ParseFloat(request.FormValue("longitude"), 64)
+	if err != nil {
+		http.Error(writer, "Longitude not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	res, err := s.Clients.Catalog.CreateWarehouse(request.Context(), &pbCatalog.CreateWarehouseRequest{
+		Warehouse: &pbCatalog.Warehouse{
+			Name:     name,
+			Location: &pbCatalog.Location{Latitude: latitude, Longitude: longitude},
+		},
+	})
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Warehouse %s created by %s", res.GetWarehouseId(), username)
+
+	// The warehouses are shown on the admin page
+	templateData := s.updateCatalogData(request.Context(), role)
+	templateData["Tab"] = "quantity"
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
+}
+
+func (s *ServerDependencies) TransferStockHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve transfer data
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+	quantity, err := strconv.ParseUint(request.FormValue("quantity"), 10, 32)
+	if err != nil {
+		http.Error(writer, "Quantity not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	_, err = s.Clients.Catalog.TransferStock(request.Context(), &pbCatalog.TransferStockRequest{
+		ItemId:          itemId,
+		FromWarehouseId: request.FormValue("from_warehouse_id"),
+		ToWarehouseId:   request.FormValue("to_warehouse_id"),
+		Quantity:        uint32(quantity),
+	})
+
+	// The warehouse has not enough of the item, or the item is a product with variants
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Stock of item %s transferred by %s", itemId, username)
+
+	// Redirection to the stock of the item
+	http.Redirect(writer, request, itemStockURL(itemId), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) SetReorderThresholdHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	username := session.Values["username"]
+	role := session.Values["role"].(string)
+
+	// Only POST requests are accepted
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve threshold data
+	// The item can be given by its ID, slug or SKU
+	itemId, err := s.resolveItemID(request.Context(), request.FormValue("item_id"))
+	if !checkerr(writer, err) {
+		return
+	}
+	threshold, err := strconv.ParseUint(request.FormValue("threshold"), 10, 32)
+	if err != nil {
+		http.Error(writer, "Threshold not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC
+	_, err = s.Clients.Catalog.SetReorderThreshold(request.Context(), &pbCatalog.SetReorderThresholdRequest{
+		ItemId:    itemId,
+		Threshold: uint32(threshold),
+	})
+
+	// The stock of a product with variants is the one of its variants
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(writer, status.Convert(err).Message(), http.StatusConflict)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	log.Printf("Reorder threshold of item %s set to %d by %s", itemId, threshold, username)
+
+	// Redirection to the stock of the item
+	http.Redirect(writer, request, itemStockURL(itemId), http.StatusSeeOther)
+}
+
+func (s *ServerDependencies) ReorderSuggestionsHandler(writer http.ResponseWriter, request *http.Request) {
+	// User must be logged
+	session, ok := checkIfUserIsLogged(s, request, writer)
+	if !ok {
+		return
+	}
+	role := session.Values["role"].(string)
+
+	// Check if user is an admin
+	if role != "ADMIN" {
+		checkerr(writer, errors.New("User Must be an admin to do this operation"))
+		return
+	}
+
+	// Retrieve the periods, the catalog service has a default for the ones left empty
+	windowDays, err := strconv.ParseUint(cmp.Or(request.FormValue("window_days"), "0"), 10, 32)
+	if err != nil {
+		http.Error(writer, "Sales window not valid", http.StatusBadRequest)
+		return
+	}
+	coverDays, err := strconv.ParseUint(cmp.Or(request.FormValue("cover_days"), "0"), 10, 32)
+	if err != nil {
+		http.Error(writer, "Days of cover not valid", http.StatusBadRequest)
+		return
+	}
+
+	// Calling catalog service via gRPC, it asks the order service for the recent sales
+	res, err := s.Clients.Catalog.GetReorderSuggestions(request.Context(), &pbCatalog.GetReorderSuggestionsRequest{
+		WindowDays: uint32(windowDays),
+		CoverDays:  uint32(coverDays),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(writer, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	if !checkerr(writer, err) {
+		return
+	}
+
+	suggestions := make([]reorderSuggestionRow, 0, len(res.GetSuggestions()))
+	for _, suggestion := range res.GetSuggestions() {
+		suggestions = append(suggestions, reorderSuggestionRow{
+			ReorderSuggestion: suggestion,
+			URL:               itemStockURL(suggestion.GetItemId()),
+		})
+	}
+
+	// The suggestions are shown on the admin page, with the periods they were computed on
+	templateData := s.updateCatalogData(request.Context(), role)
+	templateData["ReorderSuggestions"] = suggestions
+	templateData["WindowDays"] = cmp.Or(windowDays, defaultSalesWindowDays)
+	templateData["CoverDays"] = cmp.Or(coverDays, defaultCoverDays)
+	templateData["Tab"] = "alerts"
+	checkerr(writer, s.Templates.ExecuteTemplate(writer, "update_catalog.html", templateData))
+}
@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	pbCatalog "github.com/MatteoBollecchino/Distributed_Programming_Project/ecommerce/proto/catalog"
+)
+
+// Delays between the attempts to watch the catalog again
+const (
+	minWatchRetryDelay = time.Second
+	maxWatchRetryDelay = 30 * time.Second
+)
+
+// WatchCatalog forwards the changes of the catalog to the browsers until ctx is done.
+// When the stream breaks it watches again, and the browsers reload since they missed the changes in between.
+func (em *EventsManager) WatchCatalog(ctx context.Context, catalog pbCatalog.CatalogServiceClient) {
+	delay := minWatchRetryDelay
+	watched := false
+
+	for ctx.Err() == nil {
+		stream, err := catalog.WatchCatalog(ctx, &pbCatalog.WatchCatalogRequest{})
+
+		// The headers are sent as soon as the catalog service starts watching
+		if err == nil {
+			_, err = stream.Header()
+		}
+		if err == nil {
+			if watched {
+				em.NotifyCatalogUpdate()
+			}
+			watched = true
+			delay = minWatchRetryDelay
+			log.Printf("Watching the changes of the catalog")
+
+			var event *pbCatalog.CatalogEvent
+			for event, err = stream.Recv(); err == nil; event, err = stream.Recv() {
+				// The low-stock alerts are for the admins only
+				if event.GetType() == pbCatalog.CatalogEventType_LOW_STOCK {
+					em.Publish(TopicAdmin, EventStockAlert, newStockAlert(event.GetAlert()))
+					continue
+				}
+				em.Publish(TopicCatalog, EventCatalog, newCatalogChange(event))
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Watch of the catalog interrupted, retrying in %v: %v", delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay = min(2*delay, maxWatchRetryDelay)
+	}
+}
+
+// NotifyCatalogUpdate tells the browsers to reload the catalog
+func (em *EventsManager) NotifyCatalogUpdate() {
+	em.Publish(TopicCatalog, EventReload, nil)
+}
+
+// catalogChange is a change of the catalog as sent to the browsers
+type catalogChange struct {
+	Type   string       `json:"type"`
+	ItemID string       `json:"item_id,omitempty"`
+	Item   *changedItem `json:"item,omitempty"`
+}
+
+// changedItem is the state of a changed item, with the fields shown in the catalog page, its rating and the IDs of its images
+type changedItem struct {
+	ItemID            string            `json:"item_id"`
+	ProductID         string            `json:"product_id,omitempty"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	Price             float64           `json:"price"`
+	QuantityAvailable uint32            `json:"quantity_available"`
+	LocationsInStock  uint32            `json:"locations_in_stock"`
+	RatingAverage     float64           `json:"rating_average"`
+	RatingCount       uint32            `json:"rating_count"`
+	Images            []string          `json:"images,omitempty"`
+	Attributes        map[string]string `json:"attributes,omitempty"`
+	Variants          []*changedItem    `json:"variants,omitempty"`
+}
+
+func newCatalogChange(event *pbCatalog.CatalogEvent) *catalogChange {
+	return &catalogChange{
+		Type:   strings.ToLower(event.GetType().String()),
+		ItemID: event.GetItemId(),
+		Item:   newChangedItem(event.GetItem()),
+	}
+}
+
+func newChangedItem(item *pbCatalog.CatalogItem) *changedItem {
+	if item == nil {
+		return nil
+	}
+
+	changed := &changedItem{
+		ItemID:            item.GetItemId(),
+		ProductID:         item.GetProductId(),
+		Name:              item.GetName(),
+		Description:       item.GetDescription(),
+		Price:             item.GetPrice(),
+		QuantityAvailable: item.GetQuantityAvailable(),
+		LocationsInStock:  item.GetLocationsInStock(),
+		RatingAverage:     item.GetRatingAverage(),
+		RatingCount:       item.GetRatingCount(),
+		Attributes:        item.GetAttributes(),
+	}
+	for _, image := range item.GetImages() {
+		changed.Images = append(changed.Images, image.GetImageId())
+	}
+	for _, variant := range item.GetVariants() {
+		changed.Variants = append(changed.Variants, newChangedItem(variant))
+	}
+	return changed
+}
+
+// stockAlert is a low-stock alert as sent to the browsers of the admins
+type stockAlert struct {
+	ItemID            string `json:"item_id"`
+	Name              string `json:"name"`
+	QuantityAvailable uint32 `json:"quantity_available"`
+	Threshold         uint32 `json:"threshold"`
+}
+
+func newStockAlert(alert *pbCatalog.StockAlert) *stockAlert {
+	return &stockAlert{
+		ItemID:            alert.GetItemId(),
+		Name:              alert.GetName(),
+		QuantityAvailable: alert.GetQuantityAvailable(),
+		Threshold:         alert.GetThreshold(),
+	}
+}
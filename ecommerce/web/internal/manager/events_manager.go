@@ -1,27 +1,117 @@
 package manager
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
-// EventsManager manages synchronization between server and browser
+// Topics of the events, a browser listens to the ones allowed to its user
+const (
+	TopicCatalog  = "catalog"
+	TopicMyCart   = "my-cart"
+	TopicMyOrders = "my-orders"
+	TopicAdmin    = "admin"
+)
+
+// Types of the events, they are the names of the SSE events listened by the browser
+const (
+	EventReload      = "reload"
+	EventCatalog     = "catalog"
+	EventCart        = "cart"
+	EventOrderStatus = "order_status"
+	EventStockAlert  = "stock_alert"
+)
+
+// historySize is the number of past events kept for the browsers reconnecting or falling behind
+const historySize = 512
+
+// heartbeatInterval is how often a comment is sent to keep an idle connection open
+const heartbeatInterval = 15 * time.Second
+
+// event is an event sent to the browsers, with its payload encoded in JSON
+type event struct {
+	id        uint64
+	topic     string
+	username  string // The only user receiving the event, every browser listening to the topic if empty
+	eventType string
+	data      []byte
+}
+
+// Subscriber is the user of a browser listening to the events, with no username if not logged
+type Subscriber struct {
+	Username string
+	Role     string
+}
+
+// Allowed tells if the subscriber can listen to a topic
+func (s Subscriber) Allowed(topic string) bool {
+	switch topic {
+	case TopicCatalog:
+		return true
+	case TopicMyCart, TopicMyOrders:
+		return s.Username != ""
+	case TopicAdmin:
+		return s.Role == "ADMIN"
+	default:
+		return false
+	}
+}
+
+// Topics returns all the topics the subscriber can listen to
+func (s Subscriber) Topics() []string {
+	var topics []string
+	for _, topic := range []string{TopicCatalog, TopicMyCart, TopicMyOrders, TopicAdmin} {
+		if s.Allowed(topic) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// client is a browser connected to the events
+type client struct {
+	subscriber Subscriber
+	topics     []string
+
+	// wake is signaled when there are new events for the browser
+	wake chan struct{}
+}
+
+// receives tells if the browser listens to an event
+func (c *client) receives(e *event) bool {
+	return slices.Contains(c.topics, e.topic) && (e.username == "" || e.username == c.subscriber.Username)
+}
+
+// EventsManager manages synchronization between server and browser.
+// The last events are kept in a ring buffer, every browser reads them from the last one it received:
+// one reconnecting with Last-Event-ID or falling behind gets the events it missed, or reloads if they are gone.
 type EventsManager struct {
 	mu      sync.Mutex
-	clients map[chan struct{}]bool
+	clients map[*client]bool
+
+	// epoch identifies this run of the server in the event IDs, the IDs of a previous run can't be replayed
+	epoch   string
+	history [historySize]*event
+	lastID  uint64
 }
 
 // NewEventsManager creates a new manager
 func NewEventsManager() *EventsManager {
 	return &EventsManager{
-		clients: make(map[chan struct{}]bool),
+		clients: make(map[*client]bool),
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
 	}
 }
 
-// HandleEvents is the HTTP Handler for the events, that the browser will call
-func (em *EventsManager) HandleEvents(w http.ResponseWriter, r *http.Request) {
+// ServeEvents streams to a browser the events of the topics, the subscriber must be allowed to listen to them
+func (em *EventsManager) ServeEvents(w http.ResponseWriter, r *http.Request, subscriber Subscriber, topics []string) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
@@ -33,30 +123,62 @@ func (em *EventsManager) HandleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Creation of the channel for a specific client
-	messageChan := make(chan struct{}, 1)
+	// Creation of the client for a specific browser
+	c := &client{
+		subscriber: subscriber,
+		topics:     topics,
+		wake:       make(chan struct{}, 1),
+	}
 
-	// Register the client in mutual exclusion
+	// Register the client in mutual exclusion, it resumes from the last event it received
 	em.mu.Lock()
-	em.clients[messageChan] = true
+	em.clients[c] = true
+	cursor, resumed := em.resumeFrom(r.Header.Get("Last-Event-ID"))
 	em.mu.Unlock()
 
 	// Cleaning when client disconnects
 	defer func() {
 		em.mu.Lock()
-		delete(em.clients, messageChan)
+		delete(em.clients, c)
 		em.mu.Unlock()
-		close(messageChan)
 	}()
 
 	// Invia un commento di keep-alive iniziale per Firefox
 	fmt.Fprintf(w, ": ok\n\n")
+
+	// The events missed while disconnected are lost
+	if !resumed {
+		em.writeEvent(w, cursor, EventReload, []byte("null"))
+	}
 	flusher.Flush()
 
+	// The events missed while reconnecting are sent right away
+	c.wake <- struct{}{}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
 	for {
 		select {
-		case <-messageChan:
-			fmt.Fprintf(w, "data: reload\n\n")
+		case <-c.wake:
+			events, last, missed := em.since(c, cursor)
+			if missed {
+				em.writeEvent(w, last, EventReload, []byte("null"))
+				cursor = last
+			}
+			for _, e := range events {
+				em.writeEvent(w, e.id, e.eventType, e.data)
+				cursor = e.id
+			}
+
+			// An ID alone moves the Last-Event-ID of the browser past the events of the others
+			if cursor != last {
+				fmt.Fprintf(w, "id: %s\n\n", em.eventID(last))
+				cursor = last
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprintf(w, ": heartbeat\n\n")
 			flusher.Flush()
 		case <-r.Context().Done():
 			return
@@ -64,16 +186,86 @@ func (em *EventsManager) HandleEvents(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// NotifyCatalogUpdate tells the connected clients to refresh
-func (em *EventsManager) NotifyCatalogUpdate() {
+// Publish sends an event to every browser listening to the topic
+func (em *EventsManager) Publish(topic, eventType string, payload any) {
+	em.publish(&event{topic: topic, eventType: eventType}, payload)
+}
+
+// PublishToUser sends an event to the browsers of a user listening to the topic
+func (em *EventsManager) PublishToUser(username, topic, eventType string, payload any) {
+	em.publish(&event{topic: topic, username: username, eventType: eventType}, payload)
+}
+
+func (em *EventsManager) publish(e *event, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Failed to encode the %s event: %v", e.eventType, err)
+		return
+	}
+	e.data = data
+
 	em.mu.Lock()
 	defer em.mu.Unlock()
 
-	log.Printf("Notification of refreshing sent to %d connected clients", len(em.clients))
-	for messageChan := range em.clients {
-		select {
-		case messageChan <- struct{}{}:
-		default: // The channel is full -> do nothing and skip to next client
+	em.lastID++
+	e.id = em.lastID
+	em.history[e.id%historySize] = e
+
+	notified := 0
+	for c := range em.clients {
+		if c.receives(e) {
+			notified++
+
+			// A browser already woken up reads every event from the history
+			select {
+			case c.wake <- struct{}{}:
+			default:
+			}
 		}
 	}
+	log.Printf("Event %s of %s sent to %d connected clients", e.eventType, e.topic, notified)
+}
+
+// since returns the events for a browser after the cursor, and the ID of the last event.
+// missed is true if some of them are no longer in the history.
+func (em *EventsManager) since(c *client, cursor uint64) (events []*event, last uint64, missed bool) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if em.lastID > historySize && cursor < em.lastID-historySize {
+		return nil, em.lastID, true
+	}
+
+	for id := cursor + 1; id <= em.lastID; id++ {
+		if e := em.history[id%historySize]; c.receives(e) {
+			events = append(events, e)
+		}
+	}
+	return events, em.lastID, false
+}
+
+// resumeFrom returns the ID of the last event received by a browser from its Last-Event-ID,
+// resumed is false if the events it missed can't be found.
+func (em *EventsManager) resumeFrom(lastEventID string) (cursor uint64, resumed bool) {
+	if lastEventID == "" {
+		return em.lastID, true
+	}
+
+	epoch, id, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != em.epoch {
+		return em.lastID, false
+	}
+	cursor, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || cursor > em.lastID {
+		return em.lastID, false
+	}
+	return cursor, true
+}
+
+func (em *EventsManager) eventID(id uint64) string {
+	return em.epoch + "-" + strconv.FormatUint(id, 10)
+}
+
+func (em *EventsManager) writeEvent(w http.ResponseWriter, id uint64, eventType string, data []byte) {
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", em.eventID(id), eventType, data)
 }
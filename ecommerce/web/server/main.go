@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"html/template"
 	"log"
 	"net/http"
@@ -38,6 +39,12 @@ func (s *WebServer) welcomeHandler(writer http.ResponseWriter, request *http.Req
 	s.dep.WelcomeHandler(writer, request)
 }
 
+// EVENTS HANDLER ///////////////////////////////////////////////////////////////
+
+func (s *WebServer) eventsHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.EventsHandler(writer, request)
+}
+
 // CATALOG PAGE HANDLER ///////////////////////////////////////////////////////////////
 
 func (s *WebServer) catalogHandler(writer http.ResponseWriter, request *http.Request) {
@@ -64,6 +71,86 @@ func (s *WebServer) updateQuantityCatalogHandler(writer http.ResponseWriter, req
 	s.dep.UpdateQuantityCatalogHandler(writer, request)
 }
 
+func (s *WebServer) updateDetailsCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.UpdateDetailsCatalogHandler(writer, request)
+}
+
+func (s *WebServer) schedulePriceHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.SchedulePriceHandler(writer, request)
+}
+
+func (s *WebServer) cancelScheduledPriceHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.CancelScheduledPriceHandler(writer, request)
+}
+
+func (s *WebServer) reconcileStockHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ReconcileStockHandler(writer, request)
+}
+
+func (s *WebServer) setReorderThresholdHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.SetReorderThresholdHandler(writer, request)
+}
+
+func (s *WebServer) reorderSuggestionsHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ReorderSuggestionsHandler(writer, request)
+}
+
+func (s *WebServer) imageHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ImageHandler(writer, request)
+}
+
+func (s *WebServer) addItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.AddItemImageHandler(writer, request)
+}
+
+func (s *WebServer) removeItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.RemoveItemImageHandler(writer, request)
+}
+
+func (s *WebServer) moveItemImageHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.MoveItemImageHandler(writer, request)
+}
+
+func (s *WebServer) reviewsHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ReviewsHandler(writer, request)
+}
+
+func (s *WebServer) submitReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.SubmitReviewHandler(writer, request)
+}
+
+func (s *WebServer) moderateReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ModerateReviewHandler(writer, request)
+}
+
+func (s *WebServer) createWarehouseHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.CreateWarehouseHandler(writer, request)
+}
+
+func (s *WebServer) transferStockHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.TransferStockHandler(writer, request)
+}
+
+func (s *WebServer) classifyCatalogItemHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ClassifyCatalogItemHandler(writer, request)
+}
+
+func (s *WebServer) addCategoryHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.AddCategoryHandler(writer, request)
+}
+
+func (s *WebServer) removeCategoryHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.RemoveCategoryHandler(writer, request)
+}
+
+func (s *WebServer) importCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ImportCatalogHandler(writer, request)
+}
+
+func (s *WebServer) exportCatalogHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.ExportCatalogHandler(writer, request)
+}
+
 // CART PAGE HANDLERS ///////////////////////////////////////////////////////////////
 
 func (s *WebServer) cartHandler(writer http.ResponseWriter, request *http.Request) {
@@ -92,6 +179,10 @@ func (s *WebServer) userOrdersHandler(writer http.ResponseWriter, request *http.
 	s.dep.UserOrdersHandler(writer, request)
 }
 
+func (s *WebServer) cancelOrderHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.CancelOrderHandler(writer, request)
+}
+
 // PAYMENT PAGE HANDLER ///////////////////////////////////////////////////////////////
 
 func (s *WebServer) paymentHandler(writer http.ResponseWriter, request *http.Request) {
@@ -102,6 +193,14 @@ func (s *WebServer) processPaymentHandler(writer http.ResponseWriter, request *h
 	s.dep.ProcessPaymentHandler(writer, request)
 }
 
+func (s *WebServer) checkoutHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.CheckoutHandler(writer, request)
+}
+
+func (s *WebServer) checkoutStatusHandler(writer http.ResponseWriter, request *http.Request) {
+	s.dep.CheckoutStatusHandler(writer, request)
+}
+
 // AUTHETIFICATION PAGE HANDLERS ///////////////////////////////////////////////////////////////
 
 func (s *WebServer) accountHandler(writer http.ResponseWriter, request *http.Request) {
@@ -141,6 +240,9 @@ func main() {
 	// Manager of the synchronization between server and client/browser
 	eventsManager := manager.NewEventsManager()
 
+	// Changes of the catalog forwarded to the browsers
+	go eventsManager.WatchCatalog(context.Background(), clientsRegistry.Catalog)
+
 	// Cookies creation
 	cookieStore := sessions.NewCookieStore(authKey, encKey)
 
@@ -170,12 +272,32 @@ func main() {
 
 	// Association of paths to correspondent handlers
 	mux.HandleFunc("/welcome", server.welcomeHandler)
-	mux.HandleFunc("/events", server.dep.Manager.HandleEvents)
+	mux.HandleFunc("/events", server.eventsHandler)
 	mux.HandleFunc("/catalog", server.catalogHandler)
 	mux.HandleFunc("/catalog/add", server.addToCatalogHandler)
 	mux.HandleFunc("/catalog/remove", server.removeFromCatalogHandler)
 	mux.HandleFunc("/catalog/update/price", server.updatePriceCatalogHandler)
 	mux.HandleFunc("/catalog/update/quantity", server.updateQuantityCatalogHandler)
+	mux.HandleFunc("/catalog/update/details", server.updateDetailsCatalogHandler)
+	mux.HandleFunc("/catalog/update/classification", server.classifyCatalogItemHandler)
+	mux.HandleFunc("/catalog/prices/schedule", server.schedulePriceHandler)
+	mux.HandleFunc("/catalog/prices/cancel", server.cancelScheduledPriceHandler)
+	mux.HandleFunc("/catalog/stock/reconcile", server.reconcileStockHandler)
+	mux.HandleFunc("/catalog/stock/transfer", server.transferStockHandler)
+	mux.HandleFunc("/catalog/stock/threshold", server.setReorderThresholdHandler)
+	mux.HandleFunc("/catalog/stock/reorder", server.reorderSuggestionsHandler)
+	mux.HandleFunc("/catalog/warehouses/add", server.createWarehouseHandler)
+	mux.HandleFunc("/catalog/images/add", server.addItemImageHandler)
+	mux.HandleFunc("/catalog/images/remove", server.removeItemImageHandler)
+	mux.HandleFunc("/catalog/images/move", server.moveItemImageHandler)
+	mux.HandleFunc("/images", server.imageHandler)
+	mux.HandleFunc("/catalog/reviews", server.reviewsHandler)
+	mux.HandleFunc("/catalog/reviews/submit", server.submitReviewHandler)
+	mux.HandleFunc("/catalog/reviews/moderate", server.moderateReviewHandler)
+	mux.HandleFunc("/catalog/categories/add", server.addCategoryHandler)
+	mux.HandleFunc("/catalog/categories/remove", server.removeCategoryHandler)
+	mux.HandleFunc("/catalog/import", server.importCatalogHandler)
+	mux.HandleFunc("/catalog/export", server.exportCatalogHandler)
 	mux.HandleFunc("/update/catalog", server.updateCatalogHandler)
 	mux.HandleFunc("/cart", server.cartHandler)
 	mux.HandleFunc("/cart/add", server.addToCartHandler)
@@ -183,8 +305,11 @@ func main() {
 	mux.HandleFunc("/cart/update", server.updateQuantityCartHandler)
 	mux.HandleFunc("/order", server.orderHandler)
 	mux.HandleFunc("/user/orders", server.userOrdersHandler)
+	mux.HandleFunc("/order/cancel", server.cancelOrderHandler)
 	mux.HandleFunc("/payment", server.paymentHandler)
 	mux.HandleFunc("/payment/process", server.processPaymentHandler)
+	mux.HandleFunc("/checkout", server.checkoutHandler)
+	mux.HandleFunc("/checkout/status", server.checkoutStatusHandler)
 	mux.HandleFunc("/account", server.accountHandler)
 	mux.HandleFunc("/register", server.registerHandler)
 	mux.HandleFunc("/login", server.loginHandler)
@@ -193,5 +318,5 @@ func main() {
 	mux.HandleFunc("/list/users", server.listAllUsersHandler)
 
 	log.Printf("The Web Server listening on %s", port)
-	log.Fatal(http.ListenAndServe(port, mux))
+	log.Fatal(http.ListenAndServe(port, server.dep.WithAccessToken(mux)))
 }